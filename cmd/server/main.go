@@ -10,19 +10,53 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
 	cardhttp "flash2fy/internal/adapters/http/card"
+	cataloghttp "flash2fy/internal/adapters/http/catalog"
+	deckhttp "flash2fy/internal/adapters/http/deck"
+	filterhttp "flash2fy/internal/adapters/http/filter"
+	formulahttp "flash2fy/internal/adapters/http/formula"
+	notehttp "flash2fy/internal/adapters/http/note"
+	notetypehttp "flash2fy/internal/adapters/http/notetype"
+	studyhttp "flash2fy/internal/adapters/http/study"
+	userhttp "flash2fy/internal/adapters/http/user"
+	formularender "flash2fy/internal/adapters/render/formula"
 	cardstorage "flash2fy/internal/adapters/storage/card"
+	catalogstorage "flash2fy/internal/adapters/storage/catalog"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	filterstorage "flash2fy/internal/adapters/storage/filter"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	notestorage "flash2fy/internal/adapters/storage/note"
+	notetypestorage "flash2fy/internal/adapters/storage/notetype"
+	reviewstorage "flash2fy/internal/adapters/storage/review"
+	revisionstorage "flash2fy/internal/adapters/storage/revision"
+	studystorage "flash2fy/internal/adapters/storage/study"
 	telecardstorage "flash2fy/internal/adapters/storage/telegram/card"
+	telereminderstorage "flash2fy/internal/adapters/storage/telegram/reminder"
+	telereviewstorage "flash2fy/internal/adapters/storage/telegram/review"
 	teleuserstorage "flash2fy/internal/adapters/storage/telegram/user"
 	userstorage "flash2fy/internal/adapters/storage/user"
+	telegram "flash2fy/internal/adapters/telegram"
 	appcardapp "flash2fy/internal/app/application/card"
+	appcatalogapp "flash2fy/internal/app/application/catalog"
+	appdeckapp "flash2fy/internal/app/application/deck"
+	appfilterapp "flash2fy/internal/app/application/filter"
+	appformulaapp "flash2fy/internal/app/application/formula"
+	appnoteapp "flash2fy/internal/app/application/note"
+	appstudyapp "flash2fy/internal/app/application/study"
 	appuserapp "flash2fy/internal/app/application/user"
+	"flash2fy/internal/app/domain/study"
 	flashconfig "flash2fy/internal/config"
 	telegramcardapp "flash2fy/internal/telegram/application/card"
+	telegramcatalogapp "flash2fy/internal/telegram/application/catalog"
+	telegramdeckapp "flash2fy/internal/telegram/application/deck"
+	telegramformulaapp "flash2fy/internal/telegram/application/formula"
+	telegramreminderapp "flash2fy/internal/telegram/application/reminder"
+	telegramreviewapp "flash2fy/internal/telegram/application/review"
 	telegramuserapp "flash2fy/internal/telegram/application/user"
 )
 
@@ -47,29 +81,124 @@ func run() error {
 	}
 	defer db.Close()
 
-	appCardRepo := cardstorage.NewPostgresRepository(db)
-	appCardService := appcardapp.NewService(appCardRepo)
-
 	appUserRepo := userstorage.NewPostgresRepository(db)
 	appUserService := appuserapp.NewService(appUserRepo)
 
-	teleCardRepo := telecardstorage.NewMemoryRepository()
+	appCardRepo := cardstorage.NewPostgresRepository(db)
+	appDeckRepo := deckstorage.NewPostgresRepository(db)
+	deckMemberRepo := deckstorage.NewPostgresMemberRepository(db)
+	reviewLogRepo := reviewstorage.NewPostgresRepository(db)
+	mediaStorage, err := mediastorage.NewLocalStorage(cfg.Media.Dir)
+	if err != nil {
+		return err
+	}
+	teleCardRepo := telecardstorage.NewPostgresRepository(db)
+	appCardService := appcardapp.NewService(appCardRepo,
+		appcardapp.WithUsers(appUserRepo),
+		appcardapp.WithReviewLog(reviewLogRepo),
+		appcardapp.WithLeitnerCadence(cfg.Study.LeitnerCadence),
+		appcardapp.WithLeechThreshold(cfg.Study.LeechThreshold),
+		appcardapp.WithDecks(appDeckRepo),
+		appcardapp.WithDeckMembers(deckMemberRepo),
+		appcardapp.WithMedia(mediastorage.NewPostgresRepository(db), mediaStorage),
+		appcardapp.WithRevisions(revisionstorage.NewPostgresRepository(db)),
+		appcardapp.WithPurgeHook(telegramcardapp.NewProjectionPurger(teleCardRepo)),
+	)
+
+	appDeckService := appdeckapp.NewService(appDeckRepo, appCardRepo,
+		appdeckapp.WithSharing(deckMemberRepo, deckstorage.NewPostgresInviteRepository(db)),
+	)
+	appFilterService := appfilterapp.NewService(filterstorage.NewPostgresRepository(db), appCardRepo,
+		appfilterapp.WithDecks(appDeckRepo),
+	)
+	appCatalogService := appcatalogapp.NewService(catalogstorage.NewPostgresRepository(db), appDeckRepo, appCardRepo,
+		appcatalogapp.WithSubscriptions(catalogstorage.NewPostgresSubscriptionRepository(db), catalogstorage.NewPostgresLinkRepository(db)),
+		appcatalogapp.WithCardTrash(appCardService),
+	)
+	appNoteService := appnoteapp.NewService(notestorage.NewPostgresRepository(db), appCardRepo,
+		appnoteapp.WithDecks(appDeckRepo),
+		appnoteapp.WithDeckMembers(deckMemberRepo),
+		appnoteapp.WithNoteTypes(notetypestorage.NewPostgresRepository(db)),
+		appnoteapp.WithCardPurger(appCardService),
+	)
+
+	formulaRenderer, err := formularender.NewRenderer()
+	if err != nil {
+		return err
+	}
+	formulaCache, err := mediastorage.NewLocalStorage(cfg.Formulas.CacheDir)
+	if err != nil {
+		return err
+	}
+	if err := formulaCache.DeletePrefix(appformulaapp.CachePrefix); err != nil {
+		return err
+	}
+	appFormulaService := appformulaapp.NewService(formulaRenderer, formulaCache,
+		appformulaapp.WithCacheLimit(cfg.Formulas.CacheLimit),
+	)
+
+	sessionRepo := studystorage.NewMemoryRepository()
+	appStudyService := appstudyapp.NewService(appCardRepo, appCardService, sessionRepo, appUserRepo,
+		appstudyapp.WithDefaultLimits(study.Limits{
+			NewCards: cfg.Study.DailyNewLimit,
+			Reviews:  cfg.Study.DailyReviewLimit,
+		}),
+		appstudyapp.WithDecks(appDeckRepo),
+		appstudyapp.WithDeckMembers(deckMemberRepo),
+		appstudyapp.WithFilters(appFilterService),
+	)
+
 	teleUserRepo := teleuserstorage.NewMemoryRepository()
+	teleReviewRepo := telereviewstorage.NewMemoryRepository()
 	teleCardService := telegramcardapp.NewService(appCardService, teleCardRepo)
 	teleUserService := telegramuserapp.NewService(appUserService, teleUserRepo)
+	teleReviewService := telegramreviewapp.NewService(appStudyService, teleReviewRepo)
+	teleReminderRepo := telereminderstorage.NewPostgresRepository(db)
+	teleReminderService := telegramreminderapp.NewService(appCardService, teleReminderRepo, teleCardRepo,
+		telegramreminderapp.WithDefaultTimezone(cfg.Reminders.Timezone),
+	)
 
 	handler := cardhttp.NewHandler(appCardService)
+	catalogHandler := cataloghttp.NewHandler(appCatalogService)
+	deckHandler := deckhttp.NewHandler(appDeckService, deckhttp.WithTelegramBot(cfg.Telegram.BotUsername))
+	filterHandler := filterhttp.NewHandler(appFilterService)
+	formulaHandler := formulahttp.NewHandler(appFormulaService, formulahttp.WithRateLimit(cfg.Formulas.RateLimit, time.Minute))
+	noteHandler := notehttp.NewHandler(appNoteService)
+	noteTypeHandler := notetypehttp.NewHandler(appNoteService)
+	userHandler := userhttp.NewHandler(appUserService)
+	studyHandler := studyhttp.NewHandler(appStudyService)
 
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
 
-	if err := setupTelegramWebhook(ctx, r, cfg, teleCardService, teleUserService); err != nil {
+	bot, err := setupTelegramWebhook(ctx, r, cfg, telegram.Services{
+		Cards:     teleCardService,
+		Users:     teleUserService,
+		Reviews:   teleReviewService,
+		Reminders: teleReminderService,
+		Decks:     telegramdeckapp.NewService(appDeckService),
+		Catalog:   telegramcatalogapp.NewService(appCatalogService),
+		Formulas:  telegramformulaapp.NewService(appFormulaService),
+	})
+	if err != nil {
 		return fmt.Errorf("setup telegram webhook: %w", err)
 	}
+	if bot != nil {
+		go teleReminderService.Run(ctx, bot, cfg.Reminders.Interval)
+	}
+	go appCardService.RunTrashPurge(ctx, cfg.Trash.PurgeInterval)
 
 	r.Mount("/v1/cards", handler.Routes())
+	r.Mount("/v1/catalog", catalogHandler.Routes())
+	r.Mount("/v1/decks", deckHandler.Routes())
+	r.Mount("/v1/filters", filterHandler.Routes())
+	r.Mount("/v1/formulas", formulaHandler.Routes())
+	r.Mount("/v1/notes", noteHandler.Routes())
+	r.Mount("/v1/note-types", noteTypeHandler.Routes())
+	r.Mount("/v1/users", userHandler.Routes())
+	r.Mount("/v1/sessions", studyHandler.Routes())
 
 	srv := &http.Server{
 		Addr:    cfg.Server.Addr,
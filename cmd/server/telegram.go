@@ -15,18 +15,17 @@ import (
 
 	telegram "flash2fy/internal/adapters/telegram"
 	flashconfig "flash2fy/internal/config"
-	telegramcardapp "flash2fy/internal/telegram/application/card"
-	telegramuserapp "flash2fy/internal/telegram/application/user"
 )
 
-func setupTelegramWebhook(ctx context.Context, r *chi.Mux, cfg *flashconfig.Config, cardService *telegramcardapp.Service, userService *telegramuserapp.Service) error {
+// setupTelegramWebhook registers the bot webhook and returns the bot, or nil when Telegram is disabled.
+func setupTelegramWebhook(ctx context.Context, r *chi.Mux, cfg *flashconfig.Config, services telegram.Services) (*telegram.Bot, error) {
 	if cfg.Telegram.BotToken == "" {
 		log.Println("telegram bot disabled (TELEGRAM_BOT_TOKEN not set)")
-		return nil
+		return nil, nil
 	}
 	if cfg.Telegram.WebhookURL == "" {
 		log.Println("telegram bot disabled (TELEGRAM_WEBHOOK_URL not set)")
-		return nil
+		return nil, nil
 	}
 
 	options := []telegramapi.Option{}
@@ -34,14 +33,14 @@ func setupTelegramWebhook(ctx context.Context, r *chi.Mux, cfg *flashconfig.Conf
 		options = append(options, telegramapi.WithWebhookSecretToken(secret))
 	}
 
-	bot, err := telegram.New(cfg.Telegram.BotToken, cardService, userService, options...)
+	bot, err := telegram.New(cfg.Telegram.BotToken, services, options...)
 	if err != nil {
-		return fmt.Errorf("init telegram bot: %w", err)
+		return nil, fmt.Errorf("init telegram bot: %w", err)
 	}
 
 	webhookURL, webhookPath, err := resolveWebhookEndpoint(cfg.Telegram.WebhookURL, cfg.Telegram.WebhookPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	ctxSet, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -50,8 +49,9 @@ func setupTelegramWebhook(ctx context.Context, r *chi.Mux, cfg *flashconfig.Conf
 		URL:                webhookURL,
 		SecretToken:        cfg.Telegram.WebhookSecret,
 		DropPendingUpdates: true,
+		AllowedUpdates:     []string{"message", "callback_query"},
 	}); err != nil {
-		return err
+		return nil, err
 	}
 
 	r.Post(webhookPath, bot.WebhookHandler())
@@ -61,7 +61,7 @@ func setupTelegramWebhook(ctx context.Context, r *chi.Mux, cfg *flashconfig.Conf
 		bot.StartWebhook(ctx)
 	}()
 
-	return nil
+	return bot, nil
 }
 
 func resolveWebhookEndpoint(baseURL string, configuredPath string) (string, string, error) {
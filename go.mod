@@ -8,6 +8,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/jackc/pgx/v5 v5.7.6
 	github.com/subosito/gotenv v1.6.0
+	golang.org/x/image v0.25.0
 )
 
 require (
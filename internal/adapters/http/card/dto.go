@@ -2,17 +2,163 @@ package cardhttp
 
 // cardRequest transports card creation/update payloads from HTTP.
 type cardRequest struct {
-	Front   string `json:"front"`
-	Back    string `json:"back"`
+	Front   string   `json:"front"`
+	Back    string   `json:"back"`
+	OwnerID string   `json:"ownerId"`
+	DeckID  string   `json:"deckId"`
+	Tags    []string `json:"tags"`
+}
+
+// clozeRequest carries a cloze text from which one card per deletion index is created.
+type clozeRequest struct {
+	Text    string `json:"text"`
+	Extra   string `json:"extra"`
+	OwnerID string `json:"ownerId"`
+	DeckID  string `json:"deckId"`
+}
+
+// moveRequest names the deck a card moves to; an empty deckId takes the card out of its deck.
+type moveRequest struct {
+	DeckID string `json:"deckId"`
+}
+
+// tagsRequest replaces the tags of a card.
+type tagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// renameTagRequest renames one of the owner's tags on all of their cards.
+type renameTagRequest struct {
 	OwnerID string `json:"ownerId"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// mergeTagsRequest folds several of the owner's tags into one.
+type mergeTagsRequest struct {
+	OwnerID string   `json:"ownerId"`
+	Tags    []string `json:"tags"`
+	Into    string   `json:"into"`
+}
+
+// tagCountResponse reports how many cards carry a tag.
+type tagCountResponse struct {
+	Tag   string `json:"tag"`
+	Cards int    `json:"cards"`
+}
+
+// tagChangeResponse reports how many cards a tag rename or merge changed.
+type tagChangeResponse struct {
+	Updated int `json:"updated"`
+}
+
+// gradeRequest carries the rating given to a card during a review.
+type gradeRequest struct {
+	Rating       string `json:"rating"`
+	AnswerTimeMS int64  `json:"answerTimeMs"`
 }
 
 // cardResponse captures the serialized flashcard representation returned to clients.
 type cardResponse struct {
-	ID        string `json:"id"`
+	ID           string          `json:"id"`
+	Front        string          `json:"front"`
+	Back         string          `json:"back"`
+	OwnerID      string          `json:"ownerId"`
+	DeckID       string          `json:"deckId,omitempty"`
+	Tags         []string        `json:"tags"`
+	NoteID       string          `json:"noteId,omitempty"`
+	Type         string          `json:"type"`
+	Reversed     bool            `json:"reversed,omitempty"`
+	ClozeIndex   int             `json:"clozeIndex,omitempty"`
+	FrontHTML    string          `json:"frontHtml"`
+	BackHTML     string          `json:"backHtml"`
+	Question     string          `json:"question"`
+	Answer       string          `json:"answer"`
+	QuestionHTML string          `json:"questionHtml"`
+	AnswerHTML   string          `json:"answerHtml"`
+	CreatedAt    string          `json:"createdAt"`
+	UpdatedAt    string          `json:"updatedAt"`
+	Review       reviewResponse  `json:"review"`
+	Suspended    bool            `json:"suspended"`
+	Leech        bool            `json:"leech"`
+	BuriedUntil  string          `json:"buriedUntil,omitempty"`
+	DeletedAt    string          `json:"deletedAt,omitempty"`
+	PurgeAt      string          `json:"purgeAt,omitempty"`
+	Media        []mediaResponse `json:"media,omitempty"`
+}
+
+// mediaResponse references a media file attached to one side of a card.
+type mediaResponse struct {
+	ID          string `json:"id"`
+	CardID      string `json:"cardId"`
+	Side        string `json:"side"`
+	ContentType string `json:"contentType"`
+	Filename    string `json:"filename,omitempty"`
+	Size        int64  `json:"size"`
+	URL         string `json:"url"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// searchHitResponse is a card matching a search with its rank and highlighted snippet.
+type searchHitResponse struct {
+	Card        cardResponse `json:"card"`
+	Rank        float64      `json:"rank"`
+	Snippet     string       `json:"snippet"`
+	SnippetHTML string       `json:"snippetHtml"`
+}
+
+// reviewResponse exposes the spaced-repetition state of a card.
+type reviewResponse struct {
+	EaseFactor  float64 `json:"easeFactor"`
+	Stability   float64 `json:"stability"`
+	Difficulty  float64 `json:"difficulty"`
+	Box         int     `json:"box"`
+	Interval    int     `json:"interval"`
+	Repetitions int     `json:"repetitions"`
+	Lapses      int     `json:"lapses"`
+	DueAt       string  `json:"dueAt"`
+	ReviewedAt  string  `json:"reviewedAt,omitempty"`
+}
+
+// leitnerBoxResponse reports the number of cards in one Leitner box.
+type leitnerBoxResponse struct {
+	Box         int `json:"box"`
+	CadenceDays int `json:"cadenceDays"`
+	Cards       int `json:"cards"`
+}
+
+// reviewLogResponse is one entry of a card's review history.
+type reviewLogResponse struct {
+	ID               string `json:"id"`
+	UserID           string `json:"userId"`
+	Rating           string `json:"rating"`
+	PreviousInterval int    `json:"previousInterval"`
+	NextInterval     int    `json:"nextInterval"`
+	ElapsedDays      int    `json:"elapsedDays"`
+	AnswerTimeMS     int64  `json:"answerTimeMs"`
+	ReviewedAt       string `json:"reviewedAt"`
+}
+
+// reviewStatsResponse aggregates a card's review history.
+type reviewStatsResponse struct {
+	Reviews             int     `json:"reviews"`
+	Lapses              int     `json:"lapses"`
+	AverageAnswerTimeMS int64   `json:"averageAnswerTimeMs"`
+	Retention           float64 `json:"retention"`
+}
+
+// cardReviewsResponse bundles the review history of a card with its statistics.
+type cardReviewsResponse struct {
+	Reviews []reviewLogResponse `json:"reviews"`
+	Stats   reviewStatsResponse `json:"stats"`
+}
+
+// revisionResponse is one version of a card's content.
+type revisionResponse struct {
+	Number    int    `json:"number"`
 	Front     string `json:"front"`
 	Back      string `json:"back"`
-	OwnerID   string `json:"ownerId"`
+	EditorID  string `json:"editorId"`
+	Channel   string `json:"channel,omitempty"`
 	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
 }
@@ -3,12 +3,17 @@ package cardhttp
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	markuphttp "flash2fy/internal/adapters/http/markup"
 	cardapp "flash2fy/internal/app/application/card"
 	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/formula"
+	"flash2fy/internal/app/domain/revision"
 )
 
 // Handler exposes HTTP endpoints for card operations.
@@ -24,10 +29,32 @@ func (h *Handler) Routes() chi.Router {
 	r := chi.NewRouter()
 
 	r.Post("/", h.createCard)
+	r.Post("/cloze", h.createClozeCards)
 	r.Get("/", h.listCards)
+	r.Get("/boxes", h.listLeitnerBoxes)
+	r.Get("/search", h.searchCards)
+	r.Get("/tags", h.listTags)
+	r.Post("/tags/rename", h.renameTag)
+	r.Post("/tags/merge", h.mergeTags)
+	r.Get("/trash", h.listTrash)
+	r.Post("/trash/{id}/restore", h.restoreCard)
+	r.Delete("/trash/{id}", h.purgeCard)
 	r.Get("/{id}", h.getCard)
 	r.Put("/{id}", h.updateCard)
+	r.Put("/{id}/deck", h.moveCard)
+	r.Put("/{id}/tags", h.setTags)
 	r.Delete("/{id}", h.deleteCard)
+	r.Post("/{id}/grade", h.gradeCard)
+	r.Get("/{id}/reviews", h.listReviews)
+	r.Get("/{id}/revisions", h.listRevisions)
+	r.Post("/{id}/revisions/{rev}/revert", h.revertCard)
+	r.Post("/{id}/suspend", h.suspendCard)
+	r.Post("/{id}/unsuspend", h.unsuspendCard)
+	r.Post("/{id}/bury", h.buryCard)
+	r.Post("/{id}/media", h.uploadMedia)
+	r.Get("/{id}/media", h.listMedia)
+	r.Get("/{id}/media/{mediaId}", h.downloadMedia)
+	r.Delete("/{id}/media/{mediaId}", h.deleteMedia)
 
 	return r
 }
@@ -43,10 +70,13 @@ func (h *Handler) createCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c, err := h.service.CreateCard(req.Front, req.Back, req.OwnerID)
+	c, err := h.service.CreateCardInDeck(req.Front, req.Back, req.OwnerID, req.DeckID, req.Tags...)
 	if err != nil {
 		status := http.StatusInternalServerError
-		if err == card.ErrEmptyFront {
+		switch {
+		case err == deck.ErrForbidden:
+			status = http.StatusForbidden
+		case isValidationError(err):
 			status = http.StatusBadRequest
 		}
 		writeError(w, status, err.Error())
@@ -56,25 +86,133 @@ func (h *Handler) createCard(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, toResponse(c))
 }
 
+func (h *Handler) createClozeCards(w http.ResponseWriter, r *http.Request) {
+	var req clozeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	cards, err := h.service.CreateClozeCards(req.Text, req.Extra, req.OwnerID, req.DeckID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == deck.ErrForbidden:
+			status = http.StatusForbidden
+		case isValidationError(err):
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	result := make([]cardResponse, 0, len(cards))
+	for _, c := range cards {
+		result = append(result, toResponse(c))
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (h *Handler) searchCards(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	ownerID := query.Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	hits, err := h.service.Search(ownerID, query.Get("q"), limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == card.ErrEmptyQuery {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	result := make([]searchHitResponse, 0, len(hits))
+	for _, hit := range hits {
+		result = append(result, searchHitResponse{
+			Card:        toResponse(hit.Card),
+			Rank:        hit.Rank,
+			Snippet:     hit.Snippet,
+			SnippetHTML: markuphttp.HTML(hit.Snippet),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (h *Handler) getCard(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	c, err := h.service.GetCard(id)
+	c, err := h.service.GetCard(id, actorID(r))
 	if err != nil {
 		status := http.StatusInternalServerError
-		if err == card.ErrNotFound {
+		switch {
+		case err == card.ErrNotFound:
 			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
 		}
 		writeError(w, status, err.Error())
 		return
 	}
+	attachments, err := h.service.Media(id, actorID(r))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
 
-	writeJSON(w, http.StatusOK, toResponse(c))
+	resp := toResponse(c)
+	if len(attachments) > 0 {
+		resp.Media = toMediaResponses(attachments)
+	}
+	writeJSON(w, http.StatusOK, resp)
 }
 
 func (h *Handler) listCards(w http.ResponseWriter, r *http.Request) {
-	cards, err := h.service.ListCards()
+	filter, err := card.ParseTagFilter(r.URL.Query()["tag"])
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, err.Error())
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID := actorID(r)
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "userId query parameter is required")
+		return
+	}
+
+	var cards []card.Card
+	deckID := r.URL.Query().Get("deckId")
+	switch {
+	case !filter.Empty():
+		cards, err = h.service.ListCardsTagged(deckID, filter, userID)
+	case deckID != "":
+		cards, err = h.service.ListCardsInDeck(deckID, userID)
+	default:
+		cards, err = h.service.ListCards(userID)
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == deck.ErrNotFound:
+			status = http.StatusNotFound
+		case err == deck.ErrForeignOwner || isAccessError(err):
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
 		return
 	}
 
@@ -95,13 +233,46 @@ func (h *Handler) updateCard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c, err := h.service.UpdateCard(id, req.Front, req.Back)
+	c, err := h.service.EditCard(id, req.Front, req.Back, httpEditor(r))
 	if err != nil {
 		status := http.StatusInternalServerError
 		switch err {
 		case card.ErrNotFound:
 			status = http.StatusNotFound
-		case card.ErrEmptyFront:
+		case card.ErrManagedByNote:
+			status = http.StatusConflict
+		case card.ErrForeignOwner, deck.ErrForbidden:
+			status = http.StatusForbidden
+		default:
+			if isValidationError(err) {
+				status = http.StatusBadRequest
+			}
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(c))
+}
+
+func (h *Handler) moveCard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req moveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	c, err := h.service.MoveCard(id, req.DeckID, actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == card.ErrNotFound:
+			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
+		case isValidationError(err):
 			status = http.StatusBadRequest
 		}
 		writeError(w, status, err.Error())
@@ -111,12 +282,163 @@ func (h *Handler) updateCard(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, toResponse(c))
 }
 
+func (h *Handler) setTags(w http.ResponseWriter, r *http.Request) {
+	var req tagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	c, err := h.service.SetTags(chi.URLParam(r, "id"), req.Tags, actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == card.ErrNotFound:
+			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
+		case isValidationError(err):
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(c))
+}
+
+func (h *Handler) listTags(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	tags, err := h.service.Tags(ownerID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := make([]tagCountResponse, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, tagCountResponse{Tag: t.Tag, Cards: t.Cards})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) renameTag(w http.ResponseWriter, r *http.Request) {
+	var req renameTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	h.changeTags(w, req.OwnerID, func() (int, error) {
+		return h.service.RenameTag(req.OwnerID, req.From, req.To)
+	})
+}
+
+func (h *Handler) mergeTags(w http.ResponseWriter, r *http.Request) {
+	var req mergeTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	h.changeTags(w, req.OwnerID, func() (int, error) {
+		return h.service.MergeTags(req.OwnerID, req.Tags, req.Into)
+	})
+}
+
+func (h *Handler) changeTags(w http.ResponseWriter, ownerID string, change func() (int, error)) {
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId is required")
+		return
+	}
+
+	updated, err := change()
+	if err != nil {
+		status := http.StatusInternalServerError
+		if isValidationError(err) {
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tagChangeResponse{Updated: updated})
+}
+
 func (h *Handler) deleteCard(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	if err := h.service.DeleteCard(id); err != nil {
+	if err := h.service.DeleteCard(id, actorID(r)); err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case card.ErrNotFound:
+			status = http.StatusNotFound
+		case card.ErrManagedByNote:
+			status = http.StatusConflict
+		case card.ErrForeignOwner, deck.ErrForbidden:
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) listTrash(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	cards, err := h.service.Trash(ownerID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := make([]cardResponse, 0, len(cards))
+	for _, c := range cards {
+		result = append(result, toResponse(c))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) restoreCard(w http.ResponseWriter, r *http.Request) {
+	c, err := h.service.RestoreCard(chi.URLParam(r, "id"), actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case card.ErrNotFound:
+			status = http.StatusNotFound
+		case card.ErrTrashExpired:
+			status = http.StatusGone
+		case card.ErrForeignOwner, deck.ErrForbidden:
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(c))
+}
+
+// purgeCard empties one card out of the trash without waiting for the purge job.
+func (h *Handler) purgeCard(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.PurgeCard(chi.URLParam(r, "id"), actorID(r)); err != nil {
 		status := http.StatusInternalServerError
-		if err == card.ErrNotFound {
+		switch {
+		case err == card.ErrNotFound:
 			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
 		}
 		writeError(w, status, err.Error())
 		return
@@ -125,15 +447,262 @@ func (h *Handler) deleteCard(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) gradeCard(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req gradeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	rating, err := card.ParseRating(req.Rating)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c, err := h.service.GradeCard(id, rating, time.Duration(req.AnswerTimeMS)*time.Millisecond, actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == card.ErrNotFound:
+			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(c))
+}
+
+func (h *Handler) listReviews(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	logs, stats, err := h.service.CardReviews(id, actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == card.ErrNotFound:
+			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	result := cardReviewsResponse{
+		Reviews: make([]reviewLogResponse, 0, len(logs)),
+		Stats: reviewStatsResponse{
+			Reviews:             stats.Reviews,
+			Lapses:              stats.Lapses,
+			AverageAnswerTimeMS: stats.AverageAnswerTime.Milliseconds(),
+			Retention:           stats.Retention,
+		},
+	}
+	for _, l := range logs {
+		result.Reviews = append(result.Reviews, reviewLogResponse{
+			ID:               l.ID,
+			UserID:           l.UserID,
+			Rating:           l.Rating.String(),
+			PreviousInterval: l.PreviousInterval,
+			NextInterval:     l.NextInterval,
+			ElapsedDays:      l.ElapsedDays,
+			AnswerTimeMS:     l.AnswerTime.Milliseconds(),
+			ReviewedAt:       l.ReviewedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) listRevisions(w http.ResponseWriter, r *http.Request) {
+	revisions, err := h.service.Revisions(chi.URLParam(r, "id"), actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == card.ErrNotFound:
+			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	result := make([]revisionResponse, 0, len(revisions))
+	for _, rev := range revisions {
+		result = append(result, revisionResponse{
+			Number:    rev.Number,
+			Front:     rev.Front,
+			Back:      rev.Back,
+			EditorID:  rev.EditorID,
+			Channel:   string(rev.Channel),
+			CreatedAt: rev.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) revertCard(w http.ResponseWriter, r *http.Request) {
+	number, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil || number < 1 {
+		writeError(w, http.StatusBadRequest, revision.ErrInvalidNumber.Error())
+		return
+	}
+
+	c, err := h.service.RevertCard(chi.URLParam(r, "id"), number, httpEditor(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case card.ErrNotFound, revision.ErrNotFound:
+			status = http.StatusNotFound
+		case card.ErrManagedByNote:
+			status = http.StatusConflict
+		case card.ErrForeignOwner, deck.ErrForbidden:
+			status = http.StatusForbidden
+		default:
+			if isValidationError(err) {
+				status = http.StatusBadRequest
+			}
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(c))
+}
+
+// actorID names the user acting on a card through ?userId=.
+func actorID(r *http.Request) string {
+	return r.URL.Query().Get("userId")
+}
+
+// httpEditor names the user editing a card through ?userId=.
+func httpEditor(r *http.Request) revision.Editor {
+	return revision.Editor{UserID: actorID(r), Channel: revision.ChannelHTTP}
+}
+
+func (h *Handler) listLeitnerBoxes(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	boxes, err := h.service.LeitnerBoxes(ownerID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	result := make([]leitnerBoxResponse, 0, len(boxes))
+	for _, b := range boxes {
+		result = append(result, leitnerBoxResponse{Box: b.Box, CadenceDays: b.Cadence, Cards: b.Cards})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) suspendCard(w http.ResponseWriter, r *http.Request) {
+	h.changeAvailability(w, r, h.service.SuspendCard)
+}
+
+func (h *Handler) unsuspendCard(w http.ResponseWriter, r *http.Request) {
+	h.changeAvailability(w, r, h.service.UnsuspendCard)
+}
+
+func (h *Handler) buryCard(w http.ResponseWriter, r *http.Request) {
+	h.changeAvailability(w, r, h.service.BuryCard)
+}
+
+func (h *Handler) changeAvailability(w http.ResponseWriter, r *http.Request, change func(id, actorID string) (card.Card, error)) {
+	c, err := change(chi.URLParam(r, "id"), actorID(r))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case err == card.ErrNotFound:
+			status = http.StatusNotFound
+		case isAccessError(err):
+			status = http.StatusForbidden
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(c))
+}
+
+// isAccessError reports whether err denies the acting user the card: they have no role on it,
+// or too weak a role on its deck.
+func isAccessError(err error) bool {
+	return err == card.ErrForeignOwner || err == deck.ErrForbidden
+}
+
+// isValidationError reports whether err rejects the card content or deck supplied by the client.
+func isValidationError(err error) bool {
+	switch err {
+	case card.ErrEmptyFront, card.ErrUnknownType,
+		card.ErrClozeUnclosed, card.ErrClozeMalformed, card.ErrClozeEmpty,
+		card.ErrClozeMissing, card.ErrClozeIndexNotFound, card.ErrInvalidTag,
+		card.ErrUnclosedMarkup, card.ErrUnsafeLink, card.ErrUnclosedMath,
+		formula.ErrEmpty, formula.ErrTooLong, formula.ErrTooDeep, formula.ErrUnbalanced,
+		formula.ErrUnsupported, formula.ErrMissingArgument, formula.ErrMisplacedScript,
+		deck.ErrNotFound, deck.ErrForeignOwner:
+		return true
+	}
+	return false
+}
+
 func toResponse(c card.Card) cardResponse {
-	return cardResponse{
-		ID:        c.ID,
-		Front:     c.Front,
-		Back:      c.Back,
-		OwnerID:   c.OwnerID,
-		CreatedAt: c.CreatedAt.Format(time.RFC3339Nano),
-		UpdatedAt: c.UpdatedAt.Format(time.RFC3339Nano),
+	review := reviewResponse{
+		EaseFactor:  c.Review.EaseFactor,
+		Stability:   c.Review.Stability,
+		Difficulty:  c.Review.Difficulty,
+		Box:         c.Review.LeitnerBox(),
+		Interval:    c.Review.Interval,
+		Repetitions: c.Review.Repetitions,
+		Lapses:      c.Review.Lapses,
+		DueAt:       c.Review.DueAt.Format(time.RFC3339Nano),
+	}
+	if !c.Review.ReviewedAt.IsZero() {
+		review.ReviewedAt = c.Review.ReviewedAt.Format(time.RFC3339Nano)
+	}
+
+	resp := cardResponse{
+		ID:           c.ID,
+		Front:        c.Front,
+		Back:         c.Back,
+		OwnerID:      c.OwnerID,
+		DeckID:       c.DeckID,
+		Tags:         append([]string{}, c.Tags...),
+		NoteID:       c.NoteID,
+		Type:         string(c.Kind()),
+		Reversed:     c.Reversed,
+		ClozeIndex:   c.ClozeIndex,
+		FrontHTML:    markuphttp.HTML(c.Front),
+		BackHTML:     markuphttp.HTML(c.Back),
+		Question:     c.Question(),
+		Answer:       c.Answer(),
+		QuestionHTML: markuphttp.HTML(c.Question()),
+		AnswerHTML:   markuphttp.HTML(c.Answer()),
+		CreatedAt:    c.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:    c.UpdatedAt.Format(time.RFC3339Nano),
+		Review:       review,
+		Suspended:    c.Suspended,
+		Leech:        c.Leech,
+	}
+	if !c.BuriedUntil.IsZero() {
+		resp.BuriedUntil = c.BuriedUntil.Format(time.RFC3339Nano)
+	}
+	if c.Trashed() {
+		resp.DeletedAt = c.DeletedAt.Format(time.RFC3339Nano)
+		resp.PurgeAt = c.PurgeAt().Format(time.RFC3339Nano)
 	}
+	return resp
 }
 
 func writeJSON(w http.ResponseWriter, status int, payload any) {
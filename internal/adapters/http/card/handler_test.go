@@ -5,27 +5,42 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	reviewstorage "flash2fy/internal/adapters/storage/review"
+	revisionstorage "flash2fy/internal/adapters/storage/revision"
 	cardapp "flash2fy/internal/app/application/card"
 	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
 )
 
 type httpTestDeps struct {
 	service *cardapp.Service
+	decks   *deckstorage.MemoryRepository
 	handler http.Handler
 }
 
 func newHTTPTestDeps() httpTestDeps {
 	repo := cardstorage.NewMemoryRepository()
-	service := cardapp.NewService(repo)
+	decks := deckstorage.NewMemoryRepository()
+	service := cardapp.NewService(repo,
+		cardapp.WithReviewLog(reviewstorage.NewMemoryRepository()),
+		cardapp.WithDecks(decks),
+		cardapp.WithMedia(mediastorage.NewMemoryRepository(), mediastorage.NewMemoryStorage()),
+		cardapp.WithRevisions(revisionstorage.NewMemoryRepository()),
+	)
 	router := chi.NewRouter()
 	router.Mount("/v1/cards", NewHandler(service).Routes())
 	return httpTestDeps{
 		service: service,
+		decks:   decks,
 		handler: router,
 	}
 }
@@ -99,7 +114,7 @@ func TestGetCardEndpoint(t *testing.T) {
 		t.Fatalf("setup create failed: %v", err)
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/cards/"+created.ID, nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards/"+created.ID+"?userId=user-1", nil)
 	rec := httptest.NewRecorder()
 
 	deps.handler.ServeHTTP(rec, req)
@@ -146,7 +161,7 @@ func TestUpdateCardEndpoint(t *testing.T) {
 		"back":  "New Back",
 	}
 	body, _ := json.Marshal(payload)
-	req := httptest.NewRequest(http.MethodPut, "/v1/cards/"+created.ID, bytes.NewReader(body))
+	req := httptest.NewRequest(http.MethodPut, "/v1/cards/"+created.ID+"?userId=user-1", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
@@ -173,7 +188,7 @@ func TestDeleteCardEndpoint(t *testing.T) {
 		t.Fatalf("setup create failed: %v", err)
 	}
 
-	req := httptest.NewRequest(http.MethodDelete, "/v1/cards/"+created.ID, nil)
+	req := httptest.NewRequest(http.MethodDelete, "/v1/cards/"+created.ID+"?userId=user-1", nil)
 	rec := httptest.NewRecorder()
 
 	deps.handler.ServeHTTP(rec, req)
@@ -182,12 +197,57 @@ func TestDeleteCardEndpoint(t *testing.T) {
 		t.Fatalf("expected status 204, got %d", rec.Code)
 	}
 
-	_, err = deps.service.GetCard(created.ID)
+	_, err = deps.service.GetCard(created.ID, "user-1")
 	if err != card.ErrNotFound {
 		t.Fatalf("expected card to be deleted, got error %v", err)
 	}
 }
 
+func TestTrashEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+	created, _ := deps.service.CreateCard("der Hund", "dog", "user-1")
+	deps.service.DeleteCard(created.ID, "user-1")
+
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/cards/trash?ownerId=user-1", nil))
+	var trash []cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&trash); err != nil {
+		t.Fatalf("failed to decode trash: %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != created.ID || trash[0].DeletedAt == "" || trash[0].PurgeAt == "" {
+		t.Fatalf("expected the deleted card in the trash, got %+v", trash)
+	}
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/cards/trash", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without ownerId, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/cards/trash/"+created.ID+"/restore?userId=user-1", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if _, err := deps.service.GetCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("expected the card restored, got %v", err)
+	}
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/cards/trash/"+created.ID+"/restore?userId=user-1", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a card outside the trash, got %d", rec.Code)
+	}
+
+	deps.service.DeleteCard(created.ID, "user-1")
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1/cards/trash/"+created.ID+"?userId=user-1", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if trash, _ := deps.service.Trash("user-1"); len(trash) != 0 {
+		t.Fatalf("expected the card purged, got %+v", trash)
+	}
+}
+
 func TestListCardsEndpoint(t *testing.T) {
 	deps := newHTTPTestDeps()
 
@@ -200,7 +260,139 @@ func TestListCardsEndpoint(t *testing.T) {
 		t.Fatalf("setup create failed: %v", err)
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/v1/cards", nil)
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards?userId=user-1", nil)
+	rec := httptest.NewRecorder()
+
+	deps.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp []cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != 1 || resp[0].Front != "Front A" || resp[0].OwnerID != "user-1" {
+		t.Fatalf("expected only the user's own card, got %+v", resp)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/cards", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without userId, got %d", rec.Code)
+	}
+}
+
+func TestGradeCardEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"rating": "good"})
+	req := httptest.NewRequest(http.MethodPost, "/v1/cards/"+created.ID+"/grade?userId=user-1", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	deps.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Review.Repetitions != 1 || resp.Review.Interval != 1 || resp.Review.ReviewedAt == "" {
+		t.Fatalf("unexpected review state: %+v", resp.Review)
+	}
+}
+
+func TestGradeCardEndpointValidation(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		rating     string
+		wantStatus int
+	}{
+		{"unknown rating", created.ID, "perfect", http.StatusBadRequest},
+		{"missing card", "missing", "good", http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"rating": tc.rating})
+			req := httptest.NewRequest(http.MethodPost, "/v1/cards/"+tc.id+"/grade?userId=user-1", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			deps.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestListLeitnerBoxesEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	if _, err := deps.service.CreateCard("Front", "Back", "user-1"); err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards/boxes?ownerId=user-1", nil)
+	rec := httptest.NewRecorder()
+
+	deps.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp []leitnerBoxResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != len(cardapp.DefaultLeitnerCadence) {
+		t.Fatalf("expected %d boxes, got %d", len(cardapp.DefaultLeitnerCadence), len(resp))
+	}
+	if resp[0].Box != 1 || resp[0].Cards != 1 || resp[0].CadenceDays != cardapp.DefaultLeitnerCadence[0] {
+		t.Fatalf("unexpected first box: %+v", resp[0])
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/cards/boxes", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without ownerId, got %d", rec.Code)
+	}
+}
+
+func TestListReviewsEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+	if _, err := deps.service.GradeCard(created.ID, card.RatingHard, 1500*time.Millisecond, "user-1"); err != nil {
+		t.Fatalf("setup grade failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards/"+created.ID+"/reviews?userId=user-1", nil)
 	rec := httptest.NewRecorder()
 
 	deps.handler.ServeHTTP(rec, req)
@@ -209,18 +401,410 @@ func TestListCardsEndpoint(t *testing.T) {
 		t.Fatalf("expected status 200, got %d", rec.Code)
 	}
 
+	var resp cardReviewsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Reviews) != 1 || resp.Reviews[0].Rating != "hard" || resp.Reviews[0].AnswerTimeMS != 1500 {
+		t.Fatalf("unexpected reviews: %+v", resp.Reviews)
+	}
+	if resp.Stats.Reviews != 1 || resp.Stats.AverageAnswerTimeMS != 1500 {
+		t.Fatalf("unexpected stats: %+v", resp.Stats)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/cards/missing/reviews", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestRevisionEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateCard("Hund", "dog", "user-1")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"front": "der Hund", "back": "the dog"})
+	req := httptest.NewRequest(http.MethodPut, "/v1/cards/"+created.ID+"?userId=user-2", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 editing another user's card, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/v1/cards/"+created.ID+"?userId=user-1", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/cards/"+created.ID+"/revisions?userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var revisions []revisionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&revisions); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(revisions) != 2 || revisions[0].Front != "Hund" || revisions[1].Front != "der Hund" ||
+		revisions[1].EditorID != "user-1" || revisions[1].Channel != "http" {
+		t.Fatalf("unexpected revisions: %+v", revisions)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/cards/"+created.ID+"/revisions/1/revert?userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var reverted cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&reverted); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if reverted.Front != "Hund" || reverted.Back != "dog" {
+		t.Fatalf("expected the original content back, got %+v", reverted)
+	}
+
+	for target, want := range map[string]int{
+		"/v1/cards/" + created.ID + "/revisions/9/revert?userId=user-1": http.StatusNotFound,
+		"/v1/cards/" + created.ID + "/revisions/x/revert?userId=user-1": http.StatusBadRequest,
+		"/v1/cards/missing/revisions/1/revert?userId=user-1":            http.StatusNotFound,
+	} {
+		req = httptest.NewRequest(http.MethodPost, target, nil)
+		rec = httptest.NewRecorder()
+		deps.handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("%s: expected status %d, got %d", target, want, rec.Code)
+		}
+	}
+}
+
+func TestSuspendAndUnsuspendEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+
+	for _, tc := range []struct {
+		action        string
+		wantSuspended bool
+		wantBuried    bool
+	}{
+		{"suspend", true, false},
+		{"unsuspend", false, false},
+		{"bury", false, true},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/cards/"+created.ID+"/"+tc.action+"?userId=user-1", nil)
+		rec := httptest.NewRecorder()
+		deps.handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", tc.action, rec.Code)
+		}
+		var resp cardResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("%s: failed to decode response: %v", tc.action, err)
+		}
+		if resp.Suspended != tc.wantSuspended || (resp.BuriedUntil != "") != tc.wantBuried {
+			t.Fatalf("%s: unexpected availability %+v", tc.action, resp)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/cards/missing/suspend", nil)
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestCreateClozeCardsEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	body, _ := json.Marshal(map[string]string{
+		"text":    "The capital of {{c1::France}} is {{c2::Paris}}",
+		"ownerId": "user-1",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/v1/cards/cloze", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", rec.Code)
+	}
 	var resp []cardResponse
 	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
 		t.Fatalf("failed to decode response: %v", err)
 	}
-	if len(resp) != 2 {
-		t.Fatalf("expected 2 cards, got %d", len(resp))
+	if len(resp) != 2 || resp[0].Type != "cloze" || resp[1].ClozeIndex != 2 {
+		t.Fatalf("unexpected response payload: %+v", resp)
+	}
+	if resp[1].Question != "The capital of France is [...]" || resp[1].Answer != "The capital of France is [Paris]" {
+		t.Fatalf("unexpected rendering: %q / %q", resp[1].Question, resp[1].Answer)
+	}
+}
+
+func TestCreateClozeCardsEndpointValidation(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	for _, text := range []string{"no markers", "{{c1::open", "{{one::x}}", "{{c1::}}"} {
+		body, _ := json.Marshal(map[string]string{"text": text})
+		req := httptest.NewRequest(http.MethodPost, "/v1/cards/cloze", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		deps.handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%q: expected status 400, got %d", text, rec.Code)
+		}
+		var resp errorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil || resp.Message == "" {
+			t.Fatalf("%q: expected error message, got %+v (%v)", text, resp, err)
+		}
+	}
+}
+
+func TestDeckScopedCardEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+	if _, err := deps.decks.Save(deck.Deck{ID: "german", Name: "German", OwnerID: "user-1"}); err != nil {
+		t.Fatalf("save deck: %v", err)
+	}
+	created, err := deps.service.CreateCard("Hund", "dog", "user-1")
+	if err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+	if _, err := deps.service.CreateCard("Katze", "cat", "user-1"); err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{"deckId": "missing"})
+	req := httptest.NewRequest(http.MethodPut, "/v1/cards/"+created.ID+"/deck?userId=user-1", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown deck, got %d", rec.Code)
+	}
+
+	body, _ = json.Marshal(map[string]string{"deckId": "german"})
+	req = httptest.NewRequest(http.MethodPut, "/v1/cards/"+created.ID+"/deck?userId=user-1", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/cards?deckId=german&userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	var listed []cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID || listed[0].DeckID != "german" {
+		t.Fatalf("expected only the moved card in the deck, got %+v", listed)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/v1/cards?deckId=missing&userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 for unknown deck, got %d", rec.Code)
+	}
+}
+
+func TestTagEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+	grammar, err := deps.service.CreateCard("der, die, das", "", "user-1", "grammar")
+	if err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+	hard, err := deps.service.CreateCard("Konjunktiv", "", "user-1", "grammar", "hard")
+	if err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards?tag=grammar&tag=-hard&userId=user-1", nil)
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	var listed []cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != grammar.ID {
+		t.Fatalf("expected only the easy grammar card, got %+v", listed)
+	}
+
+	body, _ := json.Marshal(map[string]string{"ownerId": "user-1", "from": "hard", "to": "tricky"})
+	req = httptest.NewRequest(http.MethodPost, "/v1/cards/tags/rename", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	var changed tagChangeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&changed); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if rec.Code != http.StatusOK || changed.Updated != 1 {
+		t.Fatalf("expected one card renamed, got %d %+v", rec.Code, changed)
+	}
+
+	body, _ = json.Marshal(map[string]any{"ownerId": "user-1", "tags": []string{"grammar", "tricky"}, "into": "german"})
+	req = httptest.NewRequest(http.MethodPost, "/v1/cards/tags/merge", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if c, _ := deps.service.GetCard(hard.ID, "user-1"); len(c.Tags) != 1 || c.Tags[0] != "german" {
+		t.Fatalf("expected merged tag, got %v", c.Tags)
+	}
+
+	body, _ = json.Marshal(map[string]any{"tags": []string{"bad tag"}})
+	req = httptest.NewRequest(http.MethodPut, "/v1/cards/"+hard.ID+"/tags?userId=user-1", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an invalid tag, got %d", rec.Code)
+	}
+}
+
+func TestSearchEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+	created, err := deps.service.CreateCard("What is Go?", "A programming language", "user-1")
+	if err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+	if _, err := deps.service.CreateCard("What is Rust?", "Another language", "user-1"); err != nil {
+		t.Fatalf("failed to seed card: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards/search?q=programming&ownerId=user-1", nil)
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var hits []searchHitResponse
+	if err := json.NewDecoder(rec.Body).Decode(&hits); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(hits) != 1 || hits[0].Card.ID != created.ID || hits[0].Rank <= 0 || !strings.Contains(hits[0].Snippet, "**programming**") {
+		t.Fatalf("unexpected search hits: %+v", hits)
+	}
+
+	for _, target := range []string{"/v1/cards/search?q=&ownerId=user-1", "/v1/cards/search?q=go&ownerId=user-1&limit=zero", "/v1/cards/search?q=programming"} {
+		req = httptest.NewRequest(http.MethodGet, target, nil)
+		rec = httptest.NewRecorder()
+		deps.handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected status 400, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestMarkdownCardEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	payload := map[string]string{
+		"front":   "**der** Hund <3",
+		"back":    "the *dog*, see [Wikipedia](https://en.wikipedia.org/wiki/Dog)",
+		"ownerId": "user-1",
+	}
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest(http.MethodPost, "/v1/cards", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Front != payload["front"] || resp.FrontHTML != "<strong>der</strong> Hund &lt;3" {
+		t.Fatalf("expected raw and rendered front, got %q and %q", resp.Front, resp.FrontHTML)
+	}
+	wantBack := `the <em>dog</em>, see <a href="https://en.wikipedia.org/wiki/Dog" rel="nofollow noopener noreferrer">Wikipedia</a>`
+	if resp.BackHTML != wantBack || resp.AnswerHTML != wantBack {
+		t.Fatalf("unexpected rendered back: %q", resp.BackHTML)
+	}
+
+	for _, invalid := range []map[string]string{
+		{"front": "**unclosed", "ownerId": "user-1"},
+		{"front": "[click](javascript:alert(1))", "ownerId": "user-1"},
+		{"front": "$$x^2", "ownerId": "user-1"},
+		{"front": `$\unknown{x}$`, "ownerId": "user-1"},
+	} {
+		body, _ := json.Marshal(invalid)
+		req := httptest.NewRequest(http.MethodPost, "/v1/cards", bytes.NewReader(body))
+		rec := httptest.NewRecorder()
+		deps.handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %q, got %d", invalid["front"], rec.Code)
+		}
+	}
+
+	body, _ = json.Marshal(map[string]string{"front": "der Hund", "back": "`unclosed"})
+	req = httptest.NewRequest(http.MethodPut, "/v1/cards/"+resp.ID+"?userId=user-1", bytes.NewReader(body))
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 when updating with invalid markdown, got %d", rec.Code)
+	}
+}
+
+func TestCardEndpointsRejectOtherUsers(t *testing.T) {
+	deps := newHTTPTestDeps()
+	if _, err := deps.decks.Save(deck.Deck{ID: "german", Name: "German", OwnerID: "user-1"}); err != nil {
+		t.Fatalf("setup deck failed: %v", err)
 	}
-	ownersByFront := map[string]string{}
-	for _, c := range resp {
-		ownersByFront[c.Front] = c.OwnerID
+	created, err := deps.service.CreateCardInDeck("Hund", "dog", "user-1", "german")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
 	}
-	if ownersByFront["Front A"] != "user-1" || ownersByFront["Front B"] != "user-2" {
-		t.Fatalf("unexpected owners: %+v", ownersByFront)
+
+	grade, _ := json.Marshal(map[string]any{"rating": "good"})
+	tags, _ := json.Marshal(map[string]any{"tags": []string{"noun"}})
+	requests := []struct {
+		method string
+		target string
+		body   []byte
+	}{
+		{http.MethodGet, "/v1/cards/" + created.ID, nil},
+		{http.MethodGet, "/v1/cards?deckId=german", nil},
+		{http.MethodGet, "/v1/cards/" + created.ID + "/reviews", nil},
+		{http.MethodPost, "/v1/cards/" + created.ID + "/grade", grade},
+		{http.MethodPut, "/v1/cards/" + created.ID + "/tags", tags},
+		{http.MethodPost, "/v1/cards/" + created.ID + "/suspend", nil},
+		{http.MethodDelete, "/v1/cards/" + created.ID, nil},
+	}
+	for _, actor := range []string{"?userId=user-2", ""} {
+		for _, tc := range requests {
+			target := tc.target + actor
+			if actor != "" && strings.Contains(tc.target, "?") {
+				target = tc.target + "&" + strings.TrimPrefix(actor, "?")
+			}
+			// Listing without naming the user is rejected before any deck is looked at.
+			status := http.StatusForbidden
+			if actor == "" && strings.HasPrefix(tc.target, "/v1/cards?") {
+				status = http.StatusBadRequest
+			}
+			rec := httptest.NewRecorder()
+			deps.handler.ServeHTTP(rec, httptest.NewRequest(tc.method, target, bytes.NewReader(tc.body)))
+			if rec.Code != status {
+				t.Fatalf("%s %s: expected status %d, got %d", tc.method, target, status, rec.Code)
+			}
+		}
+	}
+
+	if c, _ := deps.service.GetCard(created.ID, "user-1"); !c.Review.IsNew() || c.Suspended || c.Trashed() {
+		t.Fatalf("expected the card untouched, got %+v", c)
 	}
 }
@@ -0,0 +1,150 @@
+package cardhttp
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/media"
+)
+
+// maxUploadSize bounds a whole multipart upload: the largest media file of any kind and room for the form around it.
+const maxUploadSize = media.MaxSize + 64<<10
+
+func (h *Handler) uploadMedia(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, media.ErrTooLarge.Error())
+			return
+		}
+		writeError(w, http.StatusBadRequest, "invalid multipart payload")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "file form field is required")
+		return
+	}
+	defer file.Close()
+
+	side := media.SideFront
+	if value := r.FormValue("side"); value != "" {
+		side = media.Side(value)
+	}
+
+	// The type is sniffed from the content rather than trusted from the client.
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "unreadable file")
+		return
+	}
+	head = head[:n]
+
+	attachment, err := h.service.AttachMedia(chi.URLParam(r, "id"), actorID(r), side, header.Filename,
+		detectContentType(head, header.Header.Get("Content-Type")), io.MultiReader(bytes.NewReader(head), file))
+	if err != nil {
+		writeError(w, mediaStatusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toMediaResponse(attachment))
+}
+
+func (h *Handler) listMedia(w http.ResponseWriter, r *http.Request) {
+	attachments, err := h.service.Media(chi.URLParam(r, "id"), actorID(r))
+	if err != nil {
+		writeError(w, mediaStatusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toMediaResponses(attachments))
+}
+
+// downloadMedia serves the file of an attachment. Range requests are answered with partial content,
+// so audio players can seek without loading the whole file.
+func (h *Handler) downloadMedia(w http.ResponseWriter, r *http.Request) {
+	attachment, content, err := h.service.OpenMedia(chi.URLParam(r, "id"), chi.URLParam(r, "mediaId"), actorID(r))
+	if err != nil {
+		writeError(w, mediaStatusFor(err), err.Error())
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if attachment.Filename != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.Filename}))
+	}
+	http.ServeContent(w, r, attachment.Filename, attachment.CreatedAt, content)
+}
+
+func (h *Handler) deleteMedia(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteMedia(chi.URLParam(r, "id"), chi.URLParam(r, "mediaId"), actorID(r)); err != nil {
+		writeError(w, mediaStatusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// detectContentType sniffs the type of an upload from its first bytes. MP4 containers hold audio
+// as well as video, so an MP4 the client declared as audio is taken for M4A.
+func detectContentType(head []byte, declared string) string {
+	detected := http.DetectContentType(head)
+	if detected == "video/mp4" && media.CanonicalType(declared) == "audio/mp4" {
+		return "audio/mp4"
+	}
+	return detected
+}
+
+func mediaStatusFor(err error) int {
+	if isAccessError(err) {
+		return http.StatusForbidden
+	}
+	switch err {
+	case card.ErrNotFound, media.ErrNotFound:
+		return http.StatusNotFound
+	case media.ErrInvalidSide, media.ErrEmpty:
+		return http.StatusBadRequest
+	case media.ErrTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case media.ErrUnsupportedType:
+		return http.StatusUnsupportedMediaType
+	case media.ErrUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toMediaResponses(attachments []media.Attachment) []mediaResponse {
+	result := make([]mediaResponse, 0, len(attachments))
+	for _, a := range attachments {
+		result = append(result, toMediaResponse(a))
+	}
+	return result
+}
+
+func toMediaResponse(a media.Attachment) mediaResponse {
+	return mediaResponse{
+		ID:          a.ID,
+		CardID:      a.CardID,
+		Side:        string(a.Side),
+		ContentType: a.ContentType,
+		Filename:    a.Filename,
+		Size:        a.Size,
+		URL:         "/v1/cards/" + a.CardID + "/media/" + a.ID,
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
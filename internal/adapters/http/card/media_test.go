@@ -0,0 +1,183 @@
+package cardhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+// pngHeader is enough of a PNG file for its type to be detected.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func upload(t *testing.T, handler http.Handler, cardID, userID, side, filename string, content []byte) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if side != "" {
+		_ = form.WriteField("side", side)
+	}
+	part, _ := form.CreateFormFile("file", filename)
+	_, _ = part.Write(content)
+	_ = form.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/cards/"+cardID+"/media?userId="+userID, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMediaEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+	created, _ := deps.service.CreateCard("Which bone?", "Femur", "user-1")
+
+	rec := upload(t, deps.handler, created.ID, "user-1", "", "leg.png", pngHeader)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var uploaded mediaResponse
+	if err := json.NewDecoder(rec.Body).Decode(&uploaded); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if uploaded.Side != "front" || uploaded.ContentType != "image/png" || uploaded.Filename != "leg.png" {
+		t.Fatalf("unexpected upload response: %+v", uploaded)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cards/"+created.ID+"?userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	var resp cardResponse
+	_ = json.NewDecoder(rec.Body).Decode(&resp)
+	if len(resp.Media) != 1 || resp.Media[0].URL != uploaded.URL {
+		t.Fatalf("expected the card to reference its media, got %+v", resp.Media)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, uploaded.URL+"?userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/png" || !bytes.Equal(rec.Body.Bytes(), pngHeader) {
+		t.Fatalf("unexpected download: %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, uploaded.URL+"?userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, uploaded.URL+"?userId=user-1", nil)
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestUploadMediaValidation(t *testing.T) {
+	deps := newHTTPTestDeps()
+	created, _ := deps.service.CreateCard("Which bone?", "Femur", "user-1")
+
+	cases := []struct {
+		name   string
+		cardID string
+		side   string
+		body   []byte
+		status int
+	}{
+		{"unknown card", "missing", "", pngHeader, http.StatusNotFound},
+		{"unknown side", created.ID, "left", pngHeader, http.StatusBadRequest},
+		{"not an image", created.ID, "back", []byte("just text"), http.StatusUnsupportedMediaType},
+		{"too large", created.ID, "back", append(pngHeader, make([]byte, media.MaxImageSize)...), http.StatusRequestEntityTooLarge},
+	}
+	for _, tc := range cases {
+		if rec := upload(t, deps.handler, tc.cardID, "user-1", tc.side, "file.png", tc.body); rec.Code != tc.status {
+			t.Fatalf("%s: expected status %d, got %d: %s", tc.name, tc.status, rec.Code, rec.Body.String())
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/cards/"+created.ID+"/media?userId=user-1", bytes.NewReader([]byte("{}")))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without a multipart payload, got %d", rec.Code)
+	}
+}
+
+func TestAudioMediaEndpointServesRanges(t *testing.T) {
+	deps := newHTTPTestDeps()
+	created, _ := deps.service.CreateCard("der Hund", "the dog", "user-1")
+
+	ogg := append([]byte("OggS\x00\x02"), bytes.Repeat([]byte{1}, 100)...)
+	rec := upload(t, deps.handler, created.ID, "user-1", "back", "hund.ogg", ogg)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var uploaded mediaResponse
+	_ = json.NewDecoder(rec.Body).Decode(&uploaded)
+	if uploaded.ContentType != "audio/ogg" || uploaded.Side != "back" {
+		t.Fatalf("unexpected upload response: %+v", uploaded)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, uploaded.URL+"?userId=user-1", nil)
+	req.Header.Set("Range", "bytes=0-3")
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != "OggS" {
+		t.Fatalf("expected the first four bytes, got %d %q", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Content-Range"); got != "bytes 0-3/106" {
+		t.Fatalf("unexpected Content-Range %q", got)
+	}
+	if rec.Header().Get("Content-Type") != "audio/ogg" {
+		t.Fatalf("unexpected Content-Type %q", rec.Header().Get("Content-Type"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, uploaded.URL+"?userId=user-1", nil)
+	req.Header.Set("Range", "bytes=200-")
+	rec = httptest.NewRecorder()
+	deps.handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestedRangeNotSatisfiable {
+		t.Fatalf("expected status 416, got %d", rec.Code)
+	}
+}
+
+func TestMediaEndpointsRejectOtherUsers(t *testing.T) {
+	deps := newHTTPTestDeps()
+	created, _ := deps.service.CreateCard("Which bone?", "Femur", "user-1")
+	rec := upload(t, deps.handler, created.ID, "user-1", "", "leg.png", pngHeader)
+	var uploaded mediaResponse
+	_ = json.NewDecoder(rec.Body).Decode(&uploaded)
+
+	if rec := upload(t, deps.handler, created.ID, "user-2", "", "leg.png", pngHeader); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 uploading to another user's card, got %d", rec.Code)
+	}
+	for _, tc := range []struct{ method, target string }{
+		{http.MethodGet, "/v1/cards/" + created.ID + "/media?userId=user-2"},
+		{http.MethodGet, uploaded.URL + "?userId=user-2"},
+		{http.MethodGet, uploaded.URL},
+		{http.MethodDelete, uploaded.URL + "?userId=user-2"},
+	} {
+		rec := httptest.NewRecorder()
+		deps.handler.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("%s %s: expected 403, got %d", tc.method, tc.target, rec.Code)
+		}
+	}
+}
+
+func TestDetectContentTypeTakesDeclaredAudioMP4(t *testing.T) {
+	m4a := []byte("\x00\x00\x00\x18ftypM4A \x00\x00\x00\x00mp42isom")
+	if got := detectContentType(m4a, "audio/x-m4a"); got != "audio/mp4" {
+		t.Fatalf("expected audio/mp4, got %q", got)
+	}
+	if got := detectContentType(m4a, "application/octet-stream"); got != "video/mp4" {
+		t.Fatalf("expected undeclared MP4 to stay video, got %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package cataloghttp
+
+// publishRequest transports a deck listing from HTTP.
+type publishRequest struct {
+	DeckID      string `json:"deckId"`
+	UserID      string `json:"userId"`
+	Title       string `json:"title"`
+	Language    string `json:"language"`
+	Description string `json:"description"`
+}
+
+// cloneRequest names the user who receives the cloned or subscribed deck.
+type cloneRequest struct {
+	UserID string `json:"userId"`
+}
+
+// listingResponse is one published deck in the catalog.
+type listingResponse struct {
+	DeckID      string `json:"deckId"`
+	OwnerID     string `json:"ownerId"`
+	Title       string `json:"title"`
+	Language    string `json:"language"`
+	Description string `json:"description"`
+	Deck        string `json:"deck,omitempty"`
+	Cards       int    `json:"cards"`
+	PublishedAt string `json:"publishedAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// cloneResponse is the deck created by cloning or subscribing to a listing and the number of cards copied into it.
+type cloneResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	OwnerID   string `json:"ownerId"`
+	SourceID  string `json:"sourceId"`
+	Cards     int    `json:"cards"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// resolveRequest picks the side of a conflict the subscriber keeps: "local" or "upstream".
+type resolveRequest struct {
+	UserID string `json:"userId"`
+	Keep   string `json:"keep"`
+}
+
+// subscriptionResponse is a subscribed copy and the source deck it follows.
+type subscriptionResponse struct {
+	DeckID    string `json:"deckId"`
+	SourceID  string `json:"sourceId"`
+	UserID    string `json:"userId"`
+	CreatedAt string `json:"createdAt"`
+	SyncedAt  string `json:"syncedAt"`
+}
+
+// syncResponse counts the changes a sync applied.
+type syncResponse struct {
+	Added     int `json:"added"`
+	Updated   int `json:"updated"`
+	Removed   int `json:"removed"`
+	Conflicts int `json:"conflicts"`
+}
+
+// conflictResponse shows both sides of a card edited locally and upstream.
+type conflictResponse struct {
+	CardID        string `json:"cardId"`
+	SourceCardID  string `json:"sourceCardId"`
+	Front         string `json:"front"`
+	Back          string `json:"back"`
+	UpstreamFront string `json:"upstreamFront"`
+	UpstreamBack  string `json:"upstreamBack"`
+}
+
+// resolvedResponse is the card content kept after resolving a conflict.
+type resolvedResponse struct {
+	ID    string `json:"id"`
+	Front string `json:"front"`
+	Back  string `json:"back"`
+}
@@ -0,0 +1,335 @@
+package cataloghttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	catalogapp "flash2fy/internal/app/application/catalog"
+	"flash2fy/internal/app/domain/catalog"
+	"flash2fy/internal/app/domain/deck"
+)
+
+// Handler exposes HTTP endpoints for the public deck catalog.
+type Handler struct {
+	service *catalogapp.Service
+}
+
+func NewHandler(service *catalogapp.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.publish)
+	r.Get("/", h.browse)
+	r.Get("/{deckId}", h.getListing)
+	r.Delete("/{deckId}", h.unpublish)
+	r.Post("/{deckId}/clone", h.clone)
+	r.Post("/{deckId}/subscribe", h.subscribe)
+	r.Get("/subscriptions", h.listSubscriptions)
+	r.Post("/subscriptions/sync", h.syncAll)
+	r.Delete("/subscriptions/{deckId}", h.unsubscribe)
+	r.Post("/subscriptions/{deckId}/sync", h.sync)
+	r.Get("/subscriptions/{deckId}/conflicts", h.listConflicts)
+	r.Post("/subscriptions/{deckId}/conflicts/{cardId}/resolve", h.resolveConflict)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) publish(w http.ResponseWriter, r *http.Request) {
+	var req publishRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "userId is required")
+		return
+	}
+
+	if _, err := h.service.Publish(req.DeckID, req.UserID, req.Title, req.Language, req.Description); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+	entry, err := h.service.Get(req.DeckID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toListingResponse(entry))
+}
+
+func (h *Handler) browse(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.service.Browse(r.URL.Query().Get("q"), r.URL.Query().Get("language"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]listingResponse, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, toListingResponse(e))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) getListing(w http.ResponseWriter, r *http.Request) {
+	entry, err := h.service.Get(chi.URLParam(r, "deckId"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toListingResponse(entry))
+}
+
+func (h *Handler) unpublish(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "userId query parameter is required")
+		return
+	}
+
+	if err := h.service.Unpublish(chi.URLParam(r, "deckId"), userID); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) clone(w http.ResponseWriter, r *http.Request) {
+	var req cloneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "userId is required")
+		return
+	}
+
+	d, cards, err := h.service.Clone(chi.URLParam(r, "deckId"), req.UserID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toCloneResponse(d, len(cards)))
+}
+
+func (h *Handler) subscribe(w http.ResponseWriter, r *http.Request) {
+	var req cloneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "userId is required")
+		return
+	}
+
+	d, cards, err := h.service.Subscribe(chi.URLParam(r, "deckId"), req.UserID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toCloneResponse(d, len(cards)))
+}
+
+func (h *Handler) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.service.Subscriptions(userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]subscriptionResponse, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		result = append(result, subscriptionResponse{
+			DeckID:    s.DeckID,
+			SourceID:  s.SourceID,
+			UserID:    s.UserID,
+			CreatedAt: s.CreatedAt.Format(time.RFC3339Nano),
+			SyncedAt:  s.SyncedAt.Format(time.RFC3339Nano),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unsubscribe(chi.URLParam(r, "deckId"), userID); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) sync(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.Sync(chi.URLParam(r, "deckId"), userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSyncResponse(report))
+}
+
+func (h *Handler) syncAll(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.SyncAll(userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSyncResponse(report))
+}
+
+func (h *Handler) listConflicts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	conflicts, err := h.service.Conflicts(chi.URLParam(r, "deckId"), userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]conflictResponse, 0, len(conflicts))
+	for _, c := range conflicts {
+		result = append(result, conflictResponse{
+			CardID:        c.Card.ID,
+			SourceCardID:  c.Link.SourceCardID,
+			Front:         c.Card.Front,
+			Back:          c.Card.Back,
+			UpstreamFront: c.Link.UpstreamFront,
+			UpstreamBack:  c.Link.UpstreamBack,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) resolveConflict(w http.ResponseWriter, r *http.Request) {
+	var req resolveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "userId is required")
+		return
+	}
+
+	c, err := h.service.ResolveConflict(chi.URLParam(r, "deckId"), req.UserID, chi.URLParam(r, "cardId"), catalog.Resolution(req.Keep))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resolvedResponse{ID: c.ID, Front: c.Front, Back: c.Back})
+}
+
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "userId query parameter is required")
+		return "", false
+	}
+	return userID, true
+}
+
+func statusFor(err error) int {
+	switch err {
+	case catalog.ErrNotFound, catalog.ErrSubscriptionNotFound, catalog.ErrLinkNotFound:
+		return http.StatusNotFound
+	case catalog.ErrEmptyDeck, catalog.ErrEmptyTitle, catalog.ErrInvalidLanguage, catalog.ErrInvalidResolution, deck.ErrNotFound:
+		return http.StatusBadRequest
+	case deck.ErrForeignOwner:
+		return http.StatusForbidden
+	case catalog.ErrNoConflict:
+		return http.StatusConflict
+	case catalog.ErrSourceGone:
+		return http.StatusGone
+	}
+	return http.StatusInternalServerError
+}
+
+func toCloneResponse(d deck.Deck, cards int) cloneResponse {
+	return cloneResponse{
+		ID:        d.ID,
+		Name:      d.Name,
+		OwnerID:   d.OwnerID,
+		SourceID:  d.SourceID,
+		Cards:     cards,
+		CreatedAt: d.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func toSyncResponse(report catalog.SyncReport) syncResponse {
+	return syncResponse{
+		Added:     report.Added,
+		Updated:   report.Updated,
+		Removed:   report.Removed,
+		Conflicts: report.Conflicts,
+	}
+}
+
+func toListingResponse(e catalogapp.Entry) listingResponse {
+	return listingResponse{
+		DeckID:      e.Listing.DeckID,
+		OwnerID:     e.Listing.OwnerID,
+		Title:       e.Listing.Title,
+		Language:    e.Listing.Language,
+		Description: e.Listing.Description,
+		Deck:        e.Deck.Name,
+		Cards:       e.Cards,
+		PublishedAt: e.Listing.PublishedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   e.Listing.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
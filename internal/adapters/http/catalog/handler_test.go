@@ -0,0 +1,192 @@
+package cataloghttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	catalogstorage "flash2fy/internal/adapters/storage/catalog"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	cardapp "flash2fy/internal/app/application/card"
+	catalogapp "flash2fy/internal/app/application/catalog"
+	deckapp "flash2fy/internal/app/application/deck"
+)
+
+type httpTestDeps struct {
+	cards   *cardapp.Service
+	decks   *deckapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	service := catalogapp.NewService(catalogstorage.NewMemoryRepository(), decks, cards,
+		catalogapp.WithSubscriptions(catalogstorage.NewMemorySubscriptionRepository(), catalogstorage.NewMemoryLinkRepository()),
+	)
+	router := chi.NewRouter()
+	router.Mount("/v1/catalog", NewHandler(service).Routes())
+	return httpTestDeps{
+		cards:   cardapp.NewService(cards, cardapp.WithDecks(decks)),
+		decks:   deckapp.NewService(decks, cards),
+		handler: router,
+	}
+}
+
+func (d httpTestDeps) do(t *testing.T, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	d.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCatalogPublishBrowseAndClone(t *testing.T) {
+	deps := newHTTPTestDeps()
+	german, err := deps.decks.CreateDeck("German", "", "user-1", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	if _, err := deps.cards.CreateCardInDeck("der Hund", "dog", "user-1", german.ID); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	publish := map[string]any{"deckId": german.ID, "userId": "user-2", "title": "German basics", "language": "de"}
+	if rec := deps.do(t, http.MethodPost, "/v1/catalog", publish); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a foreign deck, got %d", rec.Code)
+	}
+
+	publish["userId"] = "user-1"
+	publish["description"] = "Everyday animals"
+	rec := deps.do(t, http.MethodPost, "/v1/catalog", publish)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var published listingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&published); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if published.Deck != "German" || published.Cards != 1 {
+		t.Fatalf("unexpected listing: %+v", published)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/catalog?q=animals&language=de", nil)
+	var listings []listingResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listings); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(listings) != 1 || listings[0].DeckID != german.ID {
+		t.Fatalf("expected the published deck, got %+v", listings)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/catalog/"+german.ID+"/clone", map[string]any{"userId": "user-3"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var clone cloneResponse
+	if err := json.NewDecoder(rec.Body).Decode(&clone); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if clone.OwnerID != "user-3" || clone.SourceID != german.ID || clone.Cards != 1 {
+		t.Fatalf("unexpected clone: %+v", clone)
+	}
+
+	if rec := deps.do(t, http.MethodDelete, "/v1/catalog/"+german.ID+"?userId=user-1", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodGet, "/v1/catalog/"+german.ID, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after unpublishing, got %d", rec.Code)
+	}
+}
+
+func TestSubscriptionSyncAndConflicts(t *testing.T) {
+	deps := newHTTPTestDeps()
+	german, _ := deps.decks.CreateDeck("German", "", "author", "")
+	hund, err := deps.cards.CreateCardInDeck("der Hund", "dog", "author", german.ID)
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	publish := map[string]any{"deckId": german.ID, "userId": "author", "title": "German nouns", "language": "de"}
+	if rec := deps.do(t, http.MethodPost, "/v1/catalog", publish); rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec := deps.do(t, http.MethodPost, "/v1/catalog/"+german.ID+"/subscribe", map[string]any{"userId": "student"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var copied cloneResponse
+	if err := json.NewDecoder(rec.Body).Decode(&copied); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/catalog/subscriptions?userId=student", nil)
+	var subscriptions []subscriptionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&subscriptions); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(subscriptions) != 1 || subscriptions[0].DeckID != copied.ID || subscriptions[0].SourceID != german.ID {
+		t.Fatalf("unexpected subscriptions: %+v", subscriptions)
+	}
+
+	local, _ := deps.cards.ListCardsInDeck(copied.ID, "student")
+	deps.cards.UpdateCard(local[0].ID, "der Hund", "my dog", "student")
+	deps.cards.UpdateCard(hund.ID, "der Hund", "the dog", "author")
+
+	base := "/v1/catalog/subscriptions/" + copied.ID
+	if rec := deps.do(t, http.MethodPost, base+"/sync?userId=stranger", nil); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for another user, got %d", rec.Code)
+	}
+	rec = deps.do(t, http.MethodPost, base+"/sync?userId=student", nil)
+	var report syncResponse
+	if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if report.Conflicts != 1 {
+		t.Fatalf("expected one conflict, got %+v", report)
+	}
+
+	rec = deps.do(t, http.MethodGet, base+"/conflicts?userId=student", nil)
+	var conflicts []conflictResponse
+	if err := json.NewDecoder(rec.Body).Decode(&conflicts); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0].Back != "my dog" || conflicts[0].UpstreamBack != "the dog" {
+		t.Fatalf("unexpected conflicts: %+v", conflicts)
+	}
+
+	resolve := base + "/conflicts/" + conflicts[0].CardID + "/resolve"
+	if rec := deps.do(t, http.MethodPost, resolve, map[string]any{"userId": "student", "keep": "theirs"}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown side, got %d", rec.Code)
+	}
+	rec = deps.do(t, http.MethodPost, resolve, map[string]any{"userId": "student", "keep": "upstream"})
+	var resolved resolvedResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resolved); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resolved.Back != "the dog" {
+		t.Fatalf("expected the upstream content, got %+v", resolved)
+	}
+	if rec := deps.do(t, http.MethodPost, resolve, map[string]any{"userId": "student", "keep": "upstream"}); rec.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 once resolved, got %d", rec.Code)
+	}
+
+	if rec := deps.do(t, http.MethodDelete, base+"?userId=student", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodPost, base+"/sync?userId=student", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after unsubscribing, got %d", rec.Code)
+	}
+}
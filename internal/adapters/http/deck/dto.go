@@ -0,0 +1,101 @@
+package deckhttp
+
+// deckRequest transports deck creation/update payloads from HTTP.
+type deckRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OwnerID     string `json:"ownerId"`
+	ParentID    string `json:"parentId"`
+}
+
+// moveRequest names the new parent of a deck; an empty parentId makes the deck top-level.
+type moveRequest struct {
+	ParentID string `json:"parentId"`
+}
+
+// shareRequest grants a user a role on a deck.
+type shareRequest struct {
+	MemberID string `json:"memberId"`
+	Role     string `json:"role"`
+}
+
+// inviteRequest creates an invite for a role on a deck.
+type inviteRequest struct {
+	Role string `json:"role"`
+}
+
+// acceptRequest names the user accepting an invite.
+type acceptRequest struct {
+	UserID string `json:"userId"`
+}
+
+// deckResponse captures the serialized deck representation returned to clients.
+type deckResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	OwnerID     string `json:"ownerId"`
+	ParentID    string `json:"parentId,omitempty"`
+	SourceID    string `json:"sourceId,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// sharedDeckResponse is a deck shared with the requesting user and the role they hold on it.
+type sharedDeckResponse struct {
+	deckResponse
+	Role string `json:"role"`
+}
+
+// memberResponse is a user a deck is shared with.
+type memberResponse struct {
+	DeckID    string `json:"deckId"`
+	UserID    string `json:"userId"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// inviteResponse carries the token a recipient accepts, and the bot deep link when the bot is known.
+type inviteResponse struct {
+	Token        string `json:"token"`
+	DeckID       string `json:"deckId"`
+	Role         string `json:"role"`
+	ExpiresAt    string `json:"expiresAt"`
+	TelegramLink string `json:"telegramLink,omitempty"`
+}
+
+// countsResponse tallies the cards of a deck or of a deck subtree.
+type countsResponse struct {
+	Cards int `json:"cards"`
+	New   int `json:"new"`
+	Due   int `json:"due"`
+}
+
+// nodeResponse is a deck in its owner's hierarchy with its subdecks nested below it.
+type nodeResponse struct {
+	deckResponse
+	Path     string         `json:"path"`
+	Own      countsResponse `json:"own"`
+	Total    countsResponse `json:"total"`
+	Children []nodeResponse `json:"children"`
+}
+
+// exportResponse bundles a deck with the content of all of its cards.
+type exportResponse struct {
+	Deck  deckResponse         `json:"deck"`
+	Cards []exportCardResponse `json:"cards"`
+}
+
+// exportCardResponse is one card of an exported deck.
+type exportCardResponse struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Front      string `json:"front"`
+	Back       string `json:"back"`
+	NoteID     string `json:"noteId,omitempty"`
+	Reversed   bool   `json:"reversed,omitempty"`
+	ClozeIndex int    `json:"clozeIndex,omitempty"`
+	Suspended  bool   `json:"suspended"`
+	DueAt      string `json:"dueAt"`
+	CreatedAt  string `json:"createdAt"`
+}
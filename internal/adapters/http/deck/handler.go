@@ -0,0 +1,444 @@
+package deckhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	deckapp "flash2fy/internal/app/application/deck"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+)
+
+// Handler exposes HTTP endpoints for deck operations. Requests on an existing deck name the
+// acting user in the userId query parameter; the deck service checks that user's role.
+type Handler struct {
+	service *deckapp.Service
+	botName string
+}
+
+// Option customises optional Handler settings.
+type Option func(*Handler)
+
+// WithTelegramBot adds a t.me deep link for the bot to every created invite.
+func WithTelegramBot(username string) Option {
+	return func(h *Handler) {
+		h.botName = username
+	}
+}
+
+func NewHandler(service *deckapp.Service, opts ...Option) *Handler {
+	h := &Handler{service: service}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.createDeck)
+	r.Get("/", h.listDecks)
+	r.Get("/tree", h.deckTree)
+	r.Get("/shared", h.sharedDecks)
+	r.Post("/invites/{token}/accept", h.acceptInvite)
+	r.Get("/{id}", h.getDeck)
+	r.Put("/{id}", h.updateDeck)
+	r.Delete("/{id}", h.deleteDeck)
+	r.Put("/{id}/parent", h.moveDeck)
+	r.Get("/{id}/counts", h.deckCounts)
+	r.Get("/{id}/export", h.exportDeck)
+	r.Get("/{id}/members", h.listMembers)
+	r.Post("/{id}/members", h.shareDeck)
+	r.Delete("/{id}/members/{userId}", h.revokeMember)
+	r.Post("/{id}/invites", h.inviteToDeck)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) createDeck(w http.ResponseWriter, r *http.Request) {
+	var req deckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	d, err := h.service.CreateDeck(req.Name, req.Description, req.OwnerID, req.ParentID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toResponse(d))
+}
+
+func (h *Handler) listDecks(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	decks, err := h.service.ListDecks(ownerID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]deckResponse, 0, len(decks))
+	for _, d := range decks {
+		result = append(result, toResponse(d))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) deckTree(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	nodes, err := h.service.Hierarchy(ownerID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toNodeResponses(nodes))
+}
+
+func (h *Handler) getDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.service.GetDeck(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(d))
+}
+
+func (h *Handler) updateDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req deckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	d, err := h.service.UpdateDeck(chi.URLParam(r, "id"), userID, req.Name, req.Description)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(d))
+}
+
+func (h *Handler) moveDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req moveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	d, err := h.service.MoveDeck(chi.URLParam(r, "id"), userID, req.ParentID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(d))
+}
+
+func (h *Handler) deckCounts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	node, err := h.service.Counts(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toNodeResponse(node))
+}
+
+func (h *Handler) deleteDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteDeck(chi.URLParam(r, "id"), userID); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) exportDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	d, cards, err := h.service.Export(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := exportResponse{
+		Deck:  toResponse(d),
+		Cards: make([]exportCardResponse, 0, len(cards)),
+	}
+	for _, c := range cards {
+		result.Cards = append(result.Cards, toExportCard(c))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) sharedDecks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	shared, err := h.service.SharedDecks(userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]sharedDeckResponse, 0, len(shared))
+	for _, sd := range shared {
+		result = append(result, sharedDeckResponse{deckResponse: toResponse(sd.Deck), Role: string(sd.Role)})
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) listMembers(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.service.Members(chi.URLParam(r, "id"), userID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]memberResponse, 0, len(members))
+	for _, m := range members {
+		result = append(result, toMemberResponse(m))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) shareDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req shareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	m, err := h.service.Share(chi.URLParam(r, "id"), userID, req.MemberID, deck.Role(req.Role))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toMemberResponse(m))
+}
+
+func (h *Handler) revokeMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Revoke(chi.URLParam(r, "id"), userID, chi.URLParam(r, "userId")); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) inviteToDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req inviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	invite, err := h.service.Invite(chi.URLParam(r, "id"), userID, deck.Role(req.Role))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	resp := inviteResponse{
+		Token:     invite.Token,
+		DeckID:    invite.DeckID,
+		Role:      string(invite.Role),
+		ExpiresAt: invite.ExpiresAt.Format(time.RFC3339Nano),
+	}
+	if h.botName != "" {
+		resp.TelegramLink = "https://t.me/" + h.botName + "?start=" + invite.Token
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+func (h *Handler) acceptInvite(w http.ResponseWriter, r *http.Request) {
+	var req acceptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "userId is required")
+		return
+	}
+
+	_, m, err := h.service.AcceptInvite(chi.URLParam(r, "token"), req.UserID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toMemberResponse(m))
+}
+
+// requireUser reads the acting user from the userId query parameter and rejects requests without one.
+func requireUser(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "userId query parameter is required")
+		return "", false
+	}
+	return userID, true
+}
+
+func statusFor(err error) int {
+	switch err {
+	case deck.ErrNotFound, deck.ErrMemberNotFound, deck.ErrInviteNotFound:
+		return http.StatusNotFound
+	case deck.ErrEmptyName, deck.ErrNameSeparator, deck.ErrEmptyOwner, deck.ErrForeignOwner,
+		deck.ErrInvalidRole, deck.ErrEmptyMember, deck.ErrOwnerMember:
+		return http.StatusBadRequest
+	case deck.ErrForbidden:
+		return http.StatusForbidden
+	case deck.ErrCycle:
+		return http.StatusConflict
+	case deck.ErrInviteExpired:
+		return http.StatusGone
+	}
+	return http.StatusInternalServerError
+}
+
+func toResponse(d deck.Deck) deckResponse {
+	return deckResponse{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+		OwnerID:     d.OwnerID,
+		ParentID:    d.ParentID,
+		SourceID:    d.SourceID,
+		CreatedAt:   d.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:   d.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func toMemberResponse(m deck.Member) memberResponse {
+	return memberResponse{
+		DeckID:    m.DeckID,
+		UserID:    m.UserID,
+		Role:      string(m.Role),
+		CreatedAt: m.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func toNodeResponses(nodes []deckapp.Node) []nodeResponse {
+	result := make([]nodeResponse, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, toNodeResponse(n))
+	}
+	return result
+}
+
+func toNodeResponse(n deckapp.Node) nodeResponse {
+	return nodeResponse{
+		deckResponse: toResponse(n.Deck),
+		Path:         n.Path,
+		Own:          countsResponse{Cards: n.Own.Cards, New: n.Own.New, Due: n.Own.Due},
+		Total:        countsResponse{Cards: n.Total.Cards, New: n.Total.New, Due: n.Total.Due},
+		Children:     toNodeResponses(n.Children),
+	}
+}
+
+func toExportCard(c card.Card) exportCardResponse {
+	return exportCardResponse{
+		ID:         c.ID,
+		Type:       string(c.Kind()),
+		Front:      c.Front,
+		Back:       c.Back,
+		NoteID:     c.NoteID,
+		Reversed:   c.Reversed,
+		ClozeIndex: c.ClozeIndex,
+		Suspended:  c.Suspended,
+		DueAt:      c.Review.DueAt.Format(time.RFC3339Nano),
+		CreatedAt:  c.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
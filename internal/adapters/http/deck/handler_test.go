@@ -0,0 +1,237 @@
+package deckhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	cardapp "flash2fy/internal/app/application/card"
+	deckapp "flash2fy/internal/app/application/deck"
+)
+
+type httpTestDeps struct {
+	cards   *cardapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	members := deckstorage.NewMemoryMemberRepository()
+	service := deckapp.NewService(decks, cards, deckapp.WithSharing(members, deckstorage.NewMemoryInviteRepository()))
+	router := chi.NewRouter()
+	router.Mount("/v1/decks", NewHandler(service, WithTelegramBot("flash2fy_bot")).Routes())
+	return httpTestDeps{cards: cardapp.NewService(cards, cardapp.WithDecks(decks), cardapp.WithDeckMembers(members)), handler: router}
+}
+
+func (d httpTestDeps) do(t *testing.T, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	d.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDeckLifecycle(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	rec := deps.do(t, http.MethodPost, "/v1/decks", map[string]any{"name": "German", "ownerId": "user-1"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", rec.Code)
+	}
+	var created deckResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rec = deps.do(t, http.MethodPut, "/v1/decks/"+created.ID+"?userId=user-1", map[string]any{"name": "German A1", "description": "Basics"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on update, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/decks?ownerId=user-1", nil)
+	var listed []deckResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Name != "German A1" || listed[0].Description != "Basics" {
+		t.Fatalf("unexpected deck list: %+v", listed)
+	}
+
+	if rec := deps.do(t, http.MethodDelete, "/v1/decks/"+created.ID+"?userId=user-1", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 on delete, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodGet, "/v1/decks/"+created.ID+"?userId=user-1", nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestExportDeckEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	rec := deps.do(t, http.MethodPost, "/v1/decks", map[string]any{"name": "German", "ownerId": "user-1"})
+	var created deckResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if _, err := deps.cards.CreateCardInDeck("Hund", "dog", "user-1", created.ID); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := deps.cards.CreateCard("Katze", "cat", "user-1"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/decks/"+created.ID+"/export?userId=user-1", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var exported exportResponse
+	if err := json.NewDecoder(rec.Body).Decode(&exported); err != nil {
+		t.Fatalf("failed to decode export: %v", err)
+	}
+	if exported.Deck.ID != created.ID || len(exported.Cards) != 1 || exported.Cards[0].Front != "Hund" {
+		t.Fatalf("unexpected export: %+v", exported)
+	}
+}
+
+func TestCreateDeckValidation(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	if rec := deps.do(t, http.MethodPost, "/v1/decks", map[string]any{"name": "", "ownerId": "user-1"}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for empty name, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodGet, "/v1/decks", nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without ownerId, got %d", rec.Code)
+	}
+}
+
+func TestDeckTreeEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	create := func(name, parentID string) deckResponse {
+		t.Helper()
+		rec := deps.do(t, http.MethodPost, "/v1/decks", map[string]any{"name": name, "ownerId": "user-1", "parentId": parentID})
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status 201 creating %s, got %d", name, rec.Code)
+		}
+		var created deckResponse
+		if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return created
+	}
+	spanish := create("Spanish", "")
+	verbs := create("Verbs", spanish.ID)
+	if _, err := deps.cards.CreateCardInDeck("ser", "to be", "user-1", verbs.ID); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	if rec := deps.do(t, http.MethodPut, "/v1/decks/"+spanish.ID+"/parent?userId=user-1", map[string]any{"parentId": verbs.ID}); rec.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a cycle, got %d", rec.Code)
+	}
+
+	rec := deps.do(t, http.MethodGet, "/v1/decks/tree?ownerId=user-1", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var tree []nodeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&tree); err != nil {
+		t.Fatalf("failed to decode tree: %v", err)
+	}
+	if len(tree) != 1 || tree[0].Total.Cards != 1 || len(tree[0].Children) != 1 || tree[0].Children[0].Path != "Spanish::Verbs" {
+		t.Fatalf("unexpected tree: %+v", tree)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/decks/"+verbs.ID+"/counts?userId=user-1", nil)
+	var counts nodeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&counts); err != nil {
+		t.Fatalf("failed to decode counts: %v", err)
+	}
+	if counts.Own.Cards != 1 || counts.Own.New != 1 {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+}
+
+func TestShareDeckEndpoints(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	rec := deps.do(t, http.MethodPost, "/v1/decks", map[string]any{"name": "German", "ownerId": "teacher"})
+	var created deckResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	base := "/v1/decks/" + created.ID
+
+	if rec := deps.do(t, http.MethodGet, base, nil); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without userId, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodPost, base+"/members?userId=teacher", map[string]any{"memberId": "viewer", "role": "viewer"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 on share, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec := deps.do(t, http.MethodGet, base+"?userId=viewer", nil); rec.Code != http.StatusOK {
+		t.Fatalf("expected the viewer to read the deck, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodPut, base+"?userId=viewer", map[string]any{"name": "Mine"}); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a viewer rename, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodPost, base+"/invites?userId=teacher", map[string]any{"role": "editor"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201 on invite, got %d", rec.Code)
+	}
+	var invite inviteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&invite); err != nil {
+		t.Fatalf("failed to decode invite: %v", err)
+	}
+	if invite.TelegramLink != "https://t.me/flash2fy_bot?start="+invite.Token {
+		t.Fatalf("unexpected deep link: %q", invite.TelegramLink)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/decks/invites/"+invite.Token+"/accept", map[string]any{"userId": "editor"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on accept, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodPut, base+"?userId=editor", map[string]any{"name": "German A1"}); rec.Code != http.StatusOK {
+		t.Fatalf("expected the editor to rename the deck, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodGet, base+"/members?userId=teacher", nil)
+	var members []memberResponse
+	if err := json.NewDecoder(rec.Body).Decode(&members); err != nil {
+		t.Fatalf("failed to decode members: %v", err)
+	}
+	if len(members) != 2 {
+		t.Fatalf("expected two members, got %+v", members)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/decks/shared?userId=editor", nil)
+	var shared []sharedDeckResponse
+	if err := json.NewDecoder(rec.Body).Decode(&shared); err != nil {
+		t.Fatalf("failed to decode shared decks: %v", err)
+	}
+	if len(shared) != 1 || shared[0].Role != "editor" || shared[0].Name != "German A1" {
+		t.Fatalf("unexpected shared decks: %+v", shared)
+	}
+
+	if rec := deps.do(t, http.MethodDelete, base+"/members/editor?userId=teacher", nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 on revoke, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodPut, base+"?userId=editor", map[string]any{"name": "Again"}); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected a revoked editor to be rejected, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,30 @@
+package filterhttp
+
+// filterRequest transports filtered deck creation/update payloads from HTTP.
+type filterRequest struct {
+	Name    string `json:"name"`
+	Query   string `json:"query"`
+	OwnerID string `json:"ownerId"`
+}
+
+// filterResponse captures the serialized filtered deck returned to clients.
+type filterResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Query     string `json:"query"`
+	OwnerID   string `json:"ownerId"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// cardResponse is one card matching a filtered deck.
+type cardResponse struct {
+	ID        string   `json:"id"`
+	Front     string   `json:"front"`
+	Back      string   `json:"back"`
+	DeckID    string   `json:"deckId,omitempty"`
+	Tags      []string `json:"tags"`
+	Suspended bool     `json:"suspended"`
+	DueAt     string   `json:"dueAt"`
+	CreatedAt string   `json:"createdAt"`
+}
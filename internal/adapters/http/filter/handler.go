@@ -0,0 +1,197 @@
+package filterhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	filterapp "flash2fy/internal/app/application/filter"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/filter"
+)
+
+// Handler exposes HTTP endpoints for filtered deck operations.
+type Handler struct {
+	service *filterapp.Service
+}
+
+func NewHandler(service *filterapp.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.createFilter)
+	r.Get("/", h.listFilters)
+	r.Get("/preview", h.previewFilter)
+	r.Get("/{id}", h.getFilter)
+	r.Put("/{id}", h.updateFilter)
+	r.Delete("/{id}", h.deleteFilter)
+	r.Get("/{id}/cards", h.filterCards)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) createFilter(w http.ResponseWriter, r *http.Request) {
+	var req filterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	f, err := h.service.CreateFilter(req.Name, req.Query, req.OwnerID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toResponse(f))
+}
+
+func (h *Handler) listFilters(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	filters, err := h.service.ListFilters(ownerID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]filterResponse, 0, len(filters))
+	for _, f := range filters {
+		result = append(result, toResponse(f))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) previewFilter(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	cards, err := h.service.Preview(ownerID, r.URL.Query().Get("q"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCardResponses(cards))
+}
+
+func (h *Handler) getFilter(w http.ResponseWriter, r *http.Request) {
+	f, err := h.service.GetFilter(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(f))
+}
+
+func (h *Handler) updateFilter(w http.ResponseWriter, r *http.Request) {
+	var req filterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	f, err := h.service.UpdateFilter(chi.URLParam(r, "id"), req.Name, req.Query)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(f))
+}
+
+func (h *Handler) deleteFilter(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteFilter(chi.URLParam(r, "id")); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) filterCards(w http.ResponseWriter, r *http.Request) {
+	cards, err := h.service.Cards(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCardResponses(cards))
+}
+
+// statusFor uses errors.Is because query parse errors wrap the filter sentinels with the offending term.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, filter.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, filter.ErrEmptyName), errors.Is(err, filter.ErrEmptyOwner), errors.Is(err, filter.ErrEmptyQuery),
+		errors.Is(err, filter.ErrUnknownField), errors.Is(err, filter.ErrInvalidValue), errors.Is(err, filter.ErrUnclosedQuote),
+		errors.Is(err, deck.ErrNotFound):
+		return http.StatusBadRequest
+	case errors.Is(err, filter.ErrForeignOwner):
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+func toResponse(f filter.Filter) filterResponse {
+	return filterResponse{
+		ID:        f.ID,
+		Name:      f.Name,
+		Query:     f.Query,
+		OwnerID:   f.OwnerID,
+		CreatedAt: f.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt: f.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func toCardResponses(cards []card.Card) []cardResponse {
+	result := make([]cardResponse, 0, len(cards))
+	for _, c := range cards {
+		tags := c.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		result = append(result, cardResponse{
+			ID:        c.ID,
+			Front:     c.Front,
+			Back:      c.Back,
+			DeckID:    c.DeckID,
+			Tags:      tags,
+			Suspended: c.Suspended,
+			DueAt:     c.Review.DueAt.Format(time.RFC3339Nano),
+			CreatedAt: c.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
@@ -0,0 +1,120 @@
+package filterhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	filterstorage "flash2fy/internal/adapters/storage/filter"
+	cardapp "flash2fy/internal/app/application/card"
+	filterapp "flash2fy/internal/app/application/filter"
+)
+
+type httpTestDeps struct {
+	cards   *cardapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	service := filterapp.NewService(filterstorage.NewMemoryRepository(), cards, filterapp.WithDecks(decks))
+	router := chi.NewRouter()
+	router.Mount("/v1/filters", NewHandler(service).Routes())
+	return httpTestDeps{cards: cardapp.NewService(cards, cardapp.WithDecks(decks)), handler: router}
+}
+
+func (d httpTestDeps) do(t *testing.T, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	d.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestFilterLifecycle(t *testing.T) {
+	deps := newHTTPTestDeps()
+	noun, err := deps.cards.CreateCard("der Hund", "dog", "user-1", "noun")
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := deps.cards.CreateCard("laufen", "to run", "user-1", "verb"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	rec := deps.do(t, http.MethodPost, "/v1/filters", map[string]any{"name": "Nouns", "query": "tag:noun", "ownerId": "user-1"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created filterResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/filters/"+created.ID+"/cards", nil)
+	var cards []cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&cards); err != nil {
+		t.Fatalf("failed to decode cards: %v", err)
+	}
+	if len(cards) != 1 || cards[0].ID != noun.ID {
+		t.Fatalf("expected only the noun, got %+v", cards)
+	}
+
+	rec = deps.do(t, http.MethodPut, "/v1/filters/"+created.ID, map[string]any{"name": "Verbs", "query": "tag:verb"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200 on update, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/filters?ownerId=user-1", nil)
+	var listed []filterResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].Query != "tag:verb" {
+		t.Fatalf("unexpected filter list: %+v", listed)
+	}
+
+	if rec := deps.do(t, http.MethodDelete, "/v1/filters/"+created.ID, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 on delete, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodGet, "/v1/filters/"+created.ID, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestPreviewFilterEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+	if _, err := deps.cards.CreateCard("der Hund", "dog", "user-1"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	rec := deps.do(t, http.MethodGet, "/v1/filters/preview?ownerId=user-1&q="+url.QueryEscape("hund is:new"), nil)
+	var cards []cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&cards); err != nil {
+		t.Fatalf("failed to decode preview: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(cards) != 1 {
+		t.Fatalf("expected one matching card, got %d %+v", rec.Code, cards)
+	}
+
+	for _, query := range []string{"color:red", "is:forgotten", `deck:"German`, "deck:Missing"} {
+		rec := deps.do(t, http.MethodGet, "/v1/filters/preview?ownerId=user-1&q="+url.QueryEscape(query), nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400 for %q, got %d", query, rec.Code)
+		}
+	}
+}
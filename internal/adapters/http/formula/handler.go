@@ -0,0 +1,106 @@
+package formulahttp
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	formulaapp "flash2fy/internal/app/application/formula"
+	"flash2fy/internal/app/domain/formula"
+)
+
+// Handler exposes formula renderings to HTTP clients that cannot display the MathML of card
+// HTML.
+type Handler struct {
+	service *formulaapp.Service
+	limiter *limiter
+}
+
+// Option customises optional Handler settings.
+type Option func(*Handler)
+
+// WithRateLimit allows each client at most requests images per window; later requests are
+// answered with 429 until the window ends.
+func WithRateLimit(requests int, window time.Duration) Option {
+	return func(h *Handler) {
+		if requests > 0 && window > 0 {
+			h.limiter = newLimiter(requests, window, time.Now)
+		}
+	}
+}
+
+func NewHandler(service *formulaapp.Service, opts ...Option) *Handler {
+	h := &Handler{service: service}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/image", h.image)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+// image renders the LaTeX of the tex query parameter as a PNG image, set on its own line when
+// display is true.
+func (h *Handler) image(w http.ResponseWriter, r *http.Request) {
+	if h.limiter != nil {
+		if ok, retry := h.limiter.allow(clientOf(r)); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+			writeError(w, http.StatusTooManyRequests, "too many formula images requested, try again later")
+			return
+		}
+	}
+
+	f := formula.Formula{Source: r.URL.Query().Get("tex")}
+	if raw := r.URL.Query().Get("display"); raw != "" {
+		display, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "display must be true or false")
+			return
+		}
+		f.Display = display
+	}
+
+	image, err := h.service.Image(f)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(image)
+}
+
+func statusFor(err error) int {
+	switch err {
+	case formula.ErrEmpty, formula.ErrTooLong, formula.ErrTooDeep, formula.ErrUnbalanced,
+		formula.ErrUnsupported, formula.ErrMissingArgument, formula.ErrMisplacedScript:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
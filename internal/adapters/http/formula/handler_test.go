@@ -0,0 +1,108 @@
+package formulahttp
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	formularender "flash2fy/internal/adapters/render/formula"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	formulaapp "flash2fy/internal/app/application/formula"
+)
+
+func newHTTPTestHandler(t *testing.T, opts ...Option) http.Handler {
+	t.Helper()
+	renderer, err := formularender.NewRenderer()
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	router := chi.NewRouter()
+	router.Mount("/v1/formulas", NewHandler(formulaapp.NewService(renderer, mediastorage.NewMemoryStorage()), opts...).Routes())
+	return router
+}
+
+func get(handler http.Handler, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec
+}
+
+func TestFormulaImage(t *testing.T) {
+	handler := newHTTPTestHandler(t)
+
+	rec := get(handler, "/v1/formulas/image?display=true&tex="+url.QueryEscape(`\sqrt{x^2+1}`))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Content-Type") != "image/png" {
+		t.Fatalf("expected a PNG, got %q", rec.Header().Get("Content-Type"))
+	}
+	if _, err := png.Decode(bytes.NewReader(rec.Body.Bytes())); err != nil {
+		t.Fatalf("expected a valid PNG: %v", err)
+	}
+}
+
+func TestFormulaImageRejectsInvalidInput(t *testing.T) {
+	handler := newHTTPTestHandler(t)
+
+	for _, target := range []string{
+		"/v1/formulas/image",
+		"/v1/formulas/image?tex=" + url.QueryEscape(`\frac{1}`),
+		"/v1/formulas/image?tex=x&display=maybe",
+	} {
+		if rec := get(handler, target); rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 for %s, got %d", target, rec.Code)
+		}
+	}
+}
+
+func TestFormulaImageIsRateLimited(t *testing.T) {
+	handler := newHTTPTestHandler(t, WithRateLimit(2, time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if rec := get(handler, "/v1/formulas/image?tex=x"); rec.Code != http.StatusOK {
+			t.Fatalf("expected 200 within the limit, got %d", rec.Code)
+		}
+	}
+	rec := get(handler, "/v1/formulas/image?tex=y")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 over the limit, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "60" {
+		t.Fatalf("expected Retry-After of the window, got %q", rec.Header().Get("Retry-After"))
+	}
+
+	other := httptest.NewRequest(http.MethodGet, "/v1/formulas/image?tex=y", nil)
+	other.RemoteAddr = "198.51.100.7:4321"
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, other)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected other clients unaffected, got %d", rec.Code)
+	}
+}
+
+func TestLimiterResetsEachWindow(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	l := newLimiter(1, time.Minute, func() time.Time { return now })
+
+	if ok, _ := l.allow("client"); !ok {
+		t.Fatalf("expected the first request allowed")
+	}
+	now = now.Add(20 * time.Second)
+	if ok, retry := l.allow("client"); ok || retry != 40*time.Second {
+		t.Fatalf("expected the second request refused for 40s, got %v, %v", ok, retry)
+	}
+	now = now.Add(40 * time.Second)
+	if ok, _ := l.allow("client"); !ok {
+		t.Fatalf("expected a request allowed in the next window")
+	}
+	if len(l.clients) != 1 {
+		t.Fatalf("expected idle clients pruned, got %d", len(l.clients))
+	}
+}
@@ -0,0 +1,71 @@
+package formulahttp
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// limiter allows each client a fixed number of requests per window.
+type limiter struct {
+	requests int
+	window   time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*usage
+	pruned  time.Time
+}
+
+// usage counts the requests of one client in its current window.
+type usage struct {
+	start time.Time
+	count int
+}
+
+func newLimiter(requests int, window time.Duration, now func() time.Time) *limiter {
+	return &limiter{
+		requests: requests,
+		window:   window,
+		now:      now,
+		clients:  make(map[string]*usage),
+	}
+}
+
+// allow counts a request of the client and reports whether it is within the limit; when it is
+// not, it also returns how long until the client's window ends.
+func (l *limiter) allow(client string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.pruned) >= l.window {
+		for key, u := range l.clients {
+			if now.Sub(u.start) >= l.window {
+				delete(l.clients, key)
+			}
+		}
+		l.pruned = now
+	}
+
+	u, ok := l.clients[client]
+	if !ok || now.Sub(u.start) >= l.window {
+		u = &usage{start: now}
+		l.clients[client] = u
+	}
+	if u.count >= l.requests {
+		return false, u.start.Add(l.window).Sub(now)
+	}
+	u.count++
+	return true, 0
+}
+
+// clientOf identifies the client of r by its remote address without the port.
+func clientOf(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,56 @@
+package markuphttp
+
+import (
+	"html"
+	"strings"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/formula"
+)
+
+// HTML renders card Markdown as HTML that is safe to embed in a page: every piece of text is
+// escaped, only strong, em, del, code, br, MathML formulas and links to http(s) URLs are
+// produced, and links carry rel="nofollow noopener noreferrer". Content that is not valid Markdown, such as cards
+// written before Markdown was supported, is escaped as plain text.
+func HTML(text string) string {
+	spans, err := card.ParseMarkdown(text)
+	if err != nil {
+		return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+	}
+	var b strings.Builder
+	write(&b, spans)
+	return b.String()
+}
+
+func write(b *strings.Builder, spans []card.Span) {
+	for _, s := range spans {
+		switch s.Kind {
+		case card.SpanText:
+			b.WriteString(html.EscapeString(s.Text))
+		case card.SpanBreak:
+			b.WriteString("<br>")
+		case card.SpanCode:
+			b.WriteString("<code>" + html.EscapeString(s.Text) + "</code>")
+		case card.SpanMath:
+			b.WriteString(MathML(formula.Formula{Source: s.Text}))
+		case card.SpanDisplayMath:
+			b.WriteString(MathML(formula.Formula{Source: s.Text, Display: true}))
+		case card.SpanBold:
+			wrap(b, "strong", s.Children)
+		case card.SpanItalic:
+			wrap(b, "em", s.Children)
+		case card.SpanStrike:
+			wrap(b, "del", s.Children)
+		case card.SpanLink:
+			b.WriteString(`<a href="` + html.EscapeString(s.URL) + `" rel="nofollow noopener noreferrer">`)
+			write(b, s.Children)
+			b.WriteString("</a>")
+		}
+	}
+}
+
+func wrap(b *strings.Builder, tag string, children []card.Span) {
+	b.WriteString("<" + tag + ">")
+	write(b, children)
+	b.WriteString("</" + tag + ">")
+}
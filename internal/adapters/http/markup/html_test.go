@@ -0,0 +1,64 @@
+package markuphttp
+
+import (
+	"strings"
+	"testing"
+
+	"flash2fy/internal/app/domain/formula"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "der Hund", "der Hund"},
+		{"emphasis", "**der** *Hund* _dog_ ~~cat~~", "<strong>der</strong> <em>Hund</em> <em>dog</em> <del>cat</del>"},
+		{"nested", "**a *b* c**", "<strong>a <em>b</em> c</strong>"},
+		{"code keeps markers", "`a*b*c`", "<code>a*b*c</code>"},
+		{"escapes html", "<script>alert(1)</script> & **<b>**", "&lt;script&gt;alert(1)&lt;/script&gt; &amp; <strong>&lt;b&gt;</strong>"},
+		{"line breaks", "line one\nline two", "line one<br>line two"},
+		{"link", `[der **Hund**](https://de.wikipedia.org/wiki/Hund?a=1&b="2")`, `<a href="https://de.wikipedia.org/wiki/Hund?a=1&amp;b=&#34;2&#34;" rel="nofollow noopener noreferrer">der <strong>Hund</strong></a>`},
+		{"intraword markers", "snake_case_name and 2*3*4", "snake_case_name and 2*3*4"},
+		{"lone markers", "a * b _ c", "a * b _ c"},
+		{"escaped markers", `\*not italic\*`, "*not italic*"},
+		{"brackets", "Berlin is [...]", "Berlin is [...]"},
+		{"invalid falls back to text", "*unclosed <i>\nnext", "*unclosed &lt;i&gt;<br>next"},
+		{"unsafe link falls back to text", "[x](javascript:alert(1))", "[x](javascript:alert(1))"},
+		{"inline math", "area $\\pi r^2$ here", `area <math display="inline"><semantics><mrow><mi>π</mi><msup><mi>r</mi><mn>2</mn></msup></mrow><annotation encoding="application/x-tex">\pi r^2</annotation></semantics></math> here`},
+		{"dollars stay text", "costs $5 or $10", "costs $5 or $10"},
+		{"unclosed display math falls back to text", "$$x", "$$x"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HTML(tc.in); got != tc.want {
+				t.Fatalf("HTML(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMathML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   formula.Formula
+		want string
+	}{
+		{"fraction", formula.Formula{Source: `\frac{a}{b}`}, "<mfrac><mrow><mi>a</mi></mrow><mrow><mi>b</mi></mrow></mfrac>"},
+		{"root", formula.Formula{Source: `\sqrt[3]{x}`}, "<mroot><mrow><mi>x</mi></mrow><mrow><mn>3</mn></mrow></mroot>"},
+		{"sum limits in display", formula.Formula{Source: `\sum_{i=1}^n i`, Display: true}, `<munderover><mo largeop="true">∑</mo><mrow><mi>i</mi><mo>=</mo><mn>1</mn></mrow><mi>n</mi></munderover>`},
+		{"sum scripts inline", formula.Formula{Source: `\sum_{i=1}^n i`}, `<msubsup><mo largeop="true">∑</mo>`},
+		{"escapes text", formula.Formula{Source: `\text{a<b}`}, "<mtext>a&lt;b</mtext>"},
+		{"invalid", formula.Formula{Source: `\unknown`}, `<code>\unknown</code>`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MathML(tc.in); !strings.Contains(got, tc.want) {
+				t.Fatalf("MathML(%+v) = %q, want it to contain %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package markuphttp
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"flash2fy/internal/app/domain/formula"
+)
+
+// MathML renders a formula as a MathML math element that browsers display natively. The LaTeX
+// source is kept as an annotation so it can be copied. A formula that does not parse is
+// rendered as its escaped source.
+func MathML(f formula.Formula) string {
+	root, err := f.Parse()
+	if err != nil {
+		return "<code>" + html.EscapeString(f.Source) + "</code>"
+	}
+	display := "inline"
+	if f.Display {
+		display = "block"
+	}
+
+	var b strings.Builder
+	b.WriteString(`<math display="` + display + `"><semantics>`)
+	writeMath(&b, root, f.Display)
+	b.WriteString(`<annotation encoding="application/x-tex">` + html.EscapeString(f.Source) + `</annotation>`)
+	b.WriteString("</semantics></math>")
+	return b.String()
+}
+
+func writeMath(b *strings.Builder, n formula.Node, display bool) {
+	switch n.Kind {
+	case formula.NodeRow:
+		b.WriteString("<mrow>")
+		for _, child := range n.Children {
+			writeMath(b, child, display)
+		}
+		b.WriteString("</mrow>")
+	case formula.NodeIdent:
+		if utf8.RuneCountInString(n.Text) > 1 {
+			leaf(b, "mi", n.Text, ` mathvariant="normal"`)
+		} else {
+			leaf(b, "mi", n.Text, "")
+		}
+	case formula.NodeNumber:
+		leaf(b, "mn", n.Text, "")
+	case formula.NodeOperator:
+		leaf(b, "mo", n.Text, "")
+	case formula.NodeFunction:
+		leaf(b, "mi", n.Text, "")
+	case formula.NodeText:
+		leaf(b, "mtext", n.Text, "")
+	case formula.NodeSpace:
+		b.WriteString(`<mspace width="` + strconv.FormatFloat(n.Width, 'f', 3, 64) + `em"/>`)
+	case formula.NodeLargeOp:
+		leaf(b, "mo", n.Text, ` largeop="true"`)
+	case formula.NodeFraction:
+		b.WriteString("<mfrac>")
+		writeMath(b, n.Children[0], display)
+		writeMath(b, n.Children[1], display)
+		b.WriteString("</mfrac>")
+	case formula.NodeRoot:
+		if len(n.Children) > 1 {
+			b.WriteString("<mroot>")
+			writeMath(b, n.Children[0], display)
+			writeMath(b, n.Children[1], display)
+			b.WriteString("</mroot>")
+			return
+		}
+		b.WriteString("<msqrt>")
+		writeMath(b, n.Children[0], display)
+		b.WriteString("</msqrt>")
+	case formula.NodeAccent:
+		b.WriteString(`<mover accent="true">`)
+		writeMath(b, n.Children[0], display)
+		leaf(b, "mo", n.Text, ` stretchy="true"`)
+		b.WriteString("</mover>")
+	case formula.NodeFenced:
+		b.WriteString("<mrow>")
+		if n.Children[0].Text != "" {
+			leaf(b, "mo", n.Children[0].Text, ` fence="true"`)
+		}
+		writeMath(b, n.Children[1], display)
+		if n.Children[2].Text != "" {
+			leaf(b, "mo", n.Children[2].Text, ` fence="true"`)
+		}
+		b.WriteString("</mrow>")
+	case formula.NodeScripts:
+		writeScripts(b, n, display)
+	}
+}
+
+// writeScripts renders sub- and superscripts, which display formulas set as limits under and
+// over sums and lim.
+func writeScripts(b *strings.Builder, n formula.Node, display bool) {
+	base := n.Children[0]
+	limits := display && base.Limits()
+	var tag string
+	switch {
+	case n.Sub != nil && n.Sup != nil && limits:
+		tag = "munderover"
+	case n.Sub != nil && n.Sup != nil:
+		tag = "msubsup"
+	case n.Sub != nil && limits:
+		tag = "munder"
+	case n.Sub != nil:
+		tag = "msub"
+	case limits:
+		tag = "mover"
+	default:
+		tag = "msup"
+	}
+
+	b.WriteString("<" + tag + ">")
+	writeMath(b, base, display)
+	if n.Sub != nil {
+		writeMath(b, *n.Sub, display)
+	}
+	if n.Sup != nil {
+		writeMath(b, *n.Sup, display)
+	}
+	b.WriteString("</" + tag + ">")
+}
+
+func leaf(b *strings.Builder, tag, text, attrs string) {
+	b.WriteString("<" + tag + attrs + ">" + html.EscapeString(text) + "</" + tag + ">")
+}
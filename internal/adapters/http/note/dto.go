@@ -0,0 +1,45 @@
+package notehttp
+
+// noteRequest transports note creation/update payloads from HTTP.
+// Type is "basic" (the default) or "cloze"; it cannot change after creation.
+// A note written with a note type sets NoteTypeID and Fields instead of Type, Front and Back,
+// and is updated by sending its Fields.
+type noteRequest struct {
+	Type       string            `json:"type"`
+	Front      string            `json:"front"`
+	Back       string            `json:"back"`
+	NoteTypeID string            `json:"noteTypeId"`
+	Fields     map[string]string `json:"fields"`
+	OwnerID    string            `json:"ownerId"`
+	DeckID     string            `json:"deckId"`
+	Reverse    bool              `json:"reverse"`
+}
+
+// noteResponse captures a note together with the cards generated from it.
+type noteResponse struct {
+	ID         string            `json:"id"`
+	OwnerID    string            `json:"ownerId"`
+	Type       string            `json:"type"`
+	Front      string            `json:"front"`
+	Back       string            `json:"back"`
+	NoteTypeID string            `json:"noteTypeId,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Reverse    bool              `json:"reverse"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+	Cards      []cardResponse    `json:"cards,omitempty"`
+}
+
+// cardResponse is one generated card with its own schedule.
+type cardResponse struct {
+	ID          string `json:"id"`
+	DeckID      string `json:"deckId,omitempty"`
+	Question    string `json:"question"`
+	Answer      string `json:"answer"`
+	Reversed    bool   `json:"reversed,omitempty"`
+	ClozeIndex  int    `json:"clozeIndex,omitempty"`
+	Interval    int    `json:"interval"`
+	Repetitions int    `json:"repetitions"`
+	DueAt       string `json:"dueAt"`
+	Suspended   bool   `json:"suspended"`
+}
@@ -0,0 +1,190 @@
+package notehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	noteapp "flash2fy/internal/app/application/note"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/formula"
+	"flash2fy/internal/app/domain/note"
+	"flash2fy/internal/app/domain/notetype"
+)
+
+// Handler exposes HTTP endpoints for notes and their generated cards.
+type Handler struct {
+	service *noteapp.Service
+}
+
+func NewHandler(service *noteapp.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.createNote)
+	r.Get("/", h.listNotes)
+	r.Get("/{id}", h.getNote)
+	r.Put("/{id}", h.updateNote)
+	r.Delete("/{id}", h.deleteNote)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) createNote(w http.ResponseWriter, r *http.Request) {
+	var req noteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	var (
+		n     note.Note
+		cards []card.Card
+		err   error
+	)
+	if req.NoteTypeID != "" {
+		n, cards, err = h.service.CreateTypedNote(req.NoteTypeID, req.Fields, req.OwnerID, req.DeckID, req.Reverse)
+	} else {
+		n, cards, err = h.service.CreateNoteInDeck(card.Type(req.Type), req.Front, req.Back, req.OwnerID, req.DeckID, req.Reverse)
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toResponse(n, cards))
+}
+
+func (h *Handler) listNotes(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	notes, err := h.service.ListNotes(ownerID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]noteResponse, 0, len(notes))
+	for _, n := range notes {
+		result = append(result, toResponse(n, nil))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) getNote(w http.ResponseWriter, r *http.Request) {
+	n, cards, err := h.service.GetNote(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(n, cards))
+}
+
+func (h *Handler) updateNote(w http.ResponseWriter, r *http.Request) {
+	var req noteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	var (
+		n     note.Note
+		cards []card.Card
+		err   error
+	)
+	if req.Fields != nil {
+		n, cards, err = h.service.UpdateNoteFields(chi.URLParam(r, "id"), req.Fields, req.Reverse)
+	} else {
+		n, cards, err = h.service.UpdateNote(chi.URLParam(r, "id"), req.Front, req.Back, req.Reverse)
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(n, cards))
+}
+
+func (h *Handler) deleteNote(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteNote(chi.URLParam(r, "id")); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func statusFor(err error) int {
+	switch err {
+	case note.ErrNotFound:
+		return http.StatusNotFound
+	case card.ErrEmptyFront, card.ErrUnknownType, note.ErrEmptyBack, note.ErrReverseCloze,
+		card.ErrClozeUnclosed, card.ErrClozeMalformed, card.ErrClozeEmpty, card.ErrClozeMissing,
+		card.ErrUnclosedMarkup, card.ErrUnsafeLink, card.ErrUnclosedMath,
+		formula.ErrEmpty, formula.ErrTooLong, formula.ErrTooDeep, formula.ErrUnbalanced,
+		formula.ErrUnsupported, formula.ErrMissingArgument, formula.ErrMisplacedScript,
+		deck.ErrNotFound, deck.ErrForeignOwner,
+		note.ErrTypedNote, note.ErrUntypedNote, notetype.ErrNotFound, notetype.ErrForeignOwner,
+		notetype.ErrMissingField, notetype.ErrUnknownField, notetype.ErrTemplateTooLong:
+		return http.StatusBadRequest
+	case deck.ErrForbidden:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+func toResponse(n note.Note, cards []card.Card) noteResponse {
+	resp := noteResponse{
+		ID:         n.ID,
+		OwnerID:    n.OwnerID,
+		Type:       string(n.Kind()),
+		Front:      n.Front,
+		Back:       n.Back,
+		NoteTypeID: n.NoteTypeID,
+		Fields:     n.Fields,
+		Reverse:    n.Reverse,
+		CreatedAt:  n.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:  n.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	for _, c := range cards {
+		resp.Cards = append(resp.Cards, cardResponse{
+			ID:          c.ID,
+			DeckID:      c.DeckID,
+			Question:    c.Question(),
+			Answer:      c.Answer(),
+			Reversed:    c.Reversed,
+			ClozeIndex:  c.ClozeIndex,
+			Interval:    c.Review.Interval,
+			Repetitions: c.Review.Repetitions,
+			DueAt:       c.Review.DueAt.Format(time.RFC3339Nano),
+			Suspended:   c.Suspended,
+		})
+	}
+	return resp
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
@@ -0,0 +1,168 @@
+package notehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	notestorage "flash2fy/internal/adapters/storage/note"
+	notetypestorage "flash2fy/internal/adapters/storage/notetype"
+	noteapp "flash2fy/internal/app/application/note"
+	"flash2fy/internal/app/domain/notetype"
+)
+
+type httpTestDeps struct {
+	cards   *cardstorage.MemoryRepository
+	service *noteapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	cards := cardstorage.NewMemoryRepository()
+	service := noteapp.NewService(notestorage.NewMemoryRepository(), cards,
+		noteapp.WithNoteTypes(notetypestorage.NewMemoryRepository()))
+	router := chi.NewRouter()
+	router.Mount("/v1/notes", NewHandler(service).Routes())
+	return httpTestDeps{cards: cards, service: service, handler: router}
+}
+
+func (d httpTestDeps) do(t *testing.T, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	d.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNoteLifecycle(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	rec := deps.do(t, http.MethodPost, "/v1/notes", map[string]any{
+		"front": "Hund", "back": "dog", "ownerId": "user-1", "reverse": true,
+	})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", rec.Code)
+	}
+	var created noteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.Type != "basic" || len(created.Cards) != 2 || !created.Cards[1].Reversed {
+		t.Fatalf("unexpected note payload: %+v", created)
+	}
+	if created.Cards[1].Question != "dog" || created.Cards[1].Answer != "Hund" {
+		t.Fatalf("unexpected reverse card: %+v", created.Cards[1])
+	}
+
+	rec = deps.do(t, http.MethodPut, "/v1/notes/"+created.ID, map[string]any{"front": "der Hund", "back": "dog", "reverse": true})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var updated noteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.Cards[0].ID != created.Cards[0].ID || updated.Cards[1].Answer != "der Hund" {
+		t.Fatalf("expected generated cards updated in place, got %+v", updated.Cards)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/notes?ownerId=user-1", nil)
+	var listed []noteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil || len(listed) != 1 {
+		t.Fatalf("expected one listed note, got %+v (%v)", listed, err)
+	}
+
+	rec = deps.do(t, http.MethodDelete, "/v1/notes/"+created.ID, nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if stored, _ := deps.cards.FindAll(); len(stored) != 0 {
+		t.Fatalf("expected generated cards deleted, got %d", len(stored))
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/notes/"+created.ID, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
+
+func TestCreateNoteValidation(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	for _, payload := range []map[string]any{
+		{"front": "", "back": "x"},
+		{"front": "Hund", "reverse": true},
+		{"type": "cloze", "front": "{{c1::open"},
+		{"type": "audio", "front": "x"},
+	} {
+		rec := deps.do(t, http.MethodPost, "/v1/notes", payload)
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%v: expected status 400, got %d", payload, rec.Code)
+		}
+	}
+}
+
+func TestTypedNote(t *testing.T) {
+	deps := newHTTPTestDeps()
+	nt, err := deps.service.CreateNoteType("Vocabulary", "user-1",
+		[]notetype.Field{{Name: "word", Required: true}, {Name: "meaning", Required: true}},
+		"{{.word}}", "{{.meaning}}")
+	if err != nil {
+		t.Fatalf("create note type failed: %v", err)
+	}
+
+	rec := deps.do(t, http.MethodPost, "/v1/notes", map[string]any{
+		"noteTypeId": nt.ID, "fields": map[string]string{"word": "Hund", "meaning": "dog"}, "ownerId": "user-1",
+	})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created noteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.NoteTypeID != nt.ID || created.Fields["word"] != "Hund" || len(created.Cards) != 1 || created.Cards[0].Answer != "dog" {
+		t.Fatalf("unexpected typed note payload: %+v", created)
+	}
+
+	rec = deps.do(t, http.MethodPut, "/v1/notes/"+created.ID, map[string]any{"front": "Katze", "back": "cat"})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 when editing a typed note's front, got %d", rec.Code)
+	}
+	rec = deps.do(t, http.MethodPut, "/v1/notes/"+created.ID, map[string]any{
+		"fields": map[string]string{"word": "Hund", "meaning": "hound"},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var updated noteResponse
+	if err := json.NewDecoder(rec.Body).Decode(&updated); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if updated.Cards[0].ID != created.Cards[0].ID || updated.Cards[0].Answer != "hound" {
+		t.Fatalf("expected the card re-rendered in place, got %+v", updated.Cards)
+	}
+
+	for _, payload := range []map[string]any{
+		{"noteTypeId": nt.ID, "fields": map[string]string{"word": "Hund"}, "ownerId": "user-1"},
+		{"noteTypeId": nt.ID, "fields": map[string]string{"word": "Hund", "meaning": "dog", "x": "y"}, "ownerId": "user-1"},
+		{"noteTypeId": nt.ID, "fields": map[string]string{"word": "Hund", "meaning": "dog"}, "ownerId": "user-2"},
+		{"noteTypeId": "missing", "fields": map[string]string{"word": "Hund"}, "ownerId": "user-1"},
+	} {
+		if rec := deps.do(t, http.MethodPost, "/v1/notes", payload); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%v: expected status 400, got %d", payload, rec.Code)
+		}
+	}
+}
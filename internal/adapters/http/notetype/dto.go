@@ -0,0 +1,43 @@
+package notetypehttp
+
+// noteTypeRequest transports note type creation/update payloads from HTTP.
+type noteTypeRequest struct {
+	Name          string         `json:"name"`
+	OwnerID       string         `json:"ownerId"`
+	Fields        []fieldPayload `json:"fields"`
+	FrontTemplate string         `json:"frontTemplate"`
+	BackTemplate  string         `json:"backTemplate"`
+}
+
+// fieldPayload is one named field of a note type.
+type fieldPayload struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+// previewRequest carries sample field values, and for POST /preview the draft note type
+// to render them with.
+type previewRequest struct {
+	noteTypeRequest
+	Values map[string]string `json:"values"`
+}
+
+// noteTypeResponse captures the serialized note type returned to clients.
+type noteTypeResponse struct {
+	ID            string         `json:"id"`
+	Name          string         `json:"name"`
+	OwnerID       string         `json:"ownerId"`
+	Fields        []fieldPayload `json:"fields"`
+	FrontTemplate string         `json:"frontTemplate"`
+	BackTemplate  string         `json:"backTemplate"`
+	CreatedAt     string         `json:"createdAt"`
+	UpdatedAt     string         `json:"updatedAt"`
+}
+
+// previewResponse is the front and back a note with the sample values would get.
+type previewResponse struct {
+	Front     string `json:"front"`
+	Back      string `json:"back"`
+	FrontHTML string `json:"frontHtml"`
+	BackHTML  string `json:"backHtml"`
+}
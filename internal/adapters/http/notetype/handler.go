@@ -0,0 +1,209 @@
+package notetypehttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	markuphttp "flash2fy/internal/adapters/http/markup"
+	noteapp "flash2fy/internal/app/application/note"
+	"flash2fy/internal/app/domain/notetype"
+)
+
+// Handler exposes HTTP endpoints for note types and previews of their templates.
+type Handler struct {
+	service *noteapp.Service
+}
+
+func NewHandler(service *noteapp.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.createNoteType)
+	r.Get("/", h.listNoteTypes)
+	r.Post("/preview", h.previewDraft)
+	r.Get("/{id}", h.getNoteType)
+	r.Put("/{id}", h.updateNoteType)
+	r.Delete("/{id}", h.deleteNoteType)
+	r.Post("/{id}/preview", h.previewNoteType)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) createNoteType(w http.ResponseWriter, r *http.Request) {
+	var req noteTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	t, err := h.service.CreateNoteType(req.Name, req.OwnerID, toFields(req.Fields), req.FrontTemplate, req.BackTemplate)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toResponse(t))
+}
+
+func (h *Handler) listNoteTypes(w http.ResponseWriter, r *http.Request) {
+	ownerID := r.URL.Query().Get("ownerId")
+	if ownerID == "" {
+		writeError(w, http.StatusBadRequest, "ownerId query parameter is required")
+		return
+	}
+
+	types, err := h.service.ListNoteTypes(ownerID)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	result := make([]noteTypeResponse, 0, len(types))
+	for _, t := range types {
+		result = append(result, toResponse(t))
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) getNoteType(w http.ResponseWriter, r *http.Request) {
+	t, err := h.service.GetNoteType(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(t))
+}
+
+func (h *Handler) updateNoteType(w http.ResponseWriter, r *http.Request) {
+	var req noteTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	t, err := h.service.UpdateNoteType(chi.URLParam(r, "id"), req.Name, toFields(req.Fields), req.FrontTemplate, req.BackTemplate)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(t))
+}
+
+func (h *Handler) deleteNoteType(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteNoteType(chi.URLParam(r, "id")); err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// previewDraft renders a note type that is still being written.
+func (h *Handler) previewDraft(w http.ResponseWriter, r *http.Request) {
+	var req previewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	h.preview(w, notetype.NoteType{
+		Name:          req.Name,
+		OwnerID:       req.OwnerID,
+		Fields:        toFields(req.Fields),
+		FrontTemplate: req.FrontTemplate,
+		BackTemplate:  req.BackTemplate,
+	}, req.Values)
+}
+
+// previewNoteType renders a saved note type.
+func (h *Handler) previewNoteType(w http.ResponseWriter, r *http.Request) {
+	var req previewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	t, err := h.service.GetNoteType(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+	h.preview(w, t, req.Values)
+}
+
+func (h *Handler) preview(w http.ResponseWriter, t notetype.NoteType, values map[string]string) {
+	front, back, err := h.service.PreviewNoteType(t, values)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, previewResponse{
+		Front:     front,
+		Back:      back,
+		FrontHTML: markuphttp.HTML(front),
+		BackHTML:  markuphttp.HTML(back),
+	})
+}
+
+func statusFor(err error) int {
+	switch err {
+	case notetype.ErrNotFound:
+		return http.StatusNotFound
+	case notetype.ErrEmptyName, notetype.ErrEmptyOwner, notetype.ErrNoFields, notetype.ErrTooManyFields,
+		notetype.ErrInvalidFieldName, notetype.ErrDuplicateField, notetype.ErrEmptyTemplate,
+		notetype.ErrInvalidTemplate, notetype.ErrTemplateTooLong, notetype.ErrMissingField:
+		return http.StatusBadRequest
+	case notetype.ErrInUse:
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func toFields(payload []fieldPayload) []notetype.Field {
+	fields := make([]notetype.Field, 0, len(payload))
+	for _, f := range payload {
+		fields = append(fields, notetype.Field{Name: f.Name, Required: f.Required})
+	}
+	return fields
+}
+
+func toResponse(t notetype.NoteType) noteTypeResponse {
+	fields := make([]fieldPayload, 0, len(t.Fields))
+	for _, f := range t.Fields {
+		fields = append(fields, fieldPayload{Name: f.Name, Required: f.Required})
+	}
+	return noteTypeResponse{
+		ID:            t.ID,
+		Name:          t.Name,
+		OwnerID:       t.OwnerID,
+		Fields:        fields,
+		FrontTemplate: t.FrontTemplate,
+		BackTemplate:  t.BackTemplate,
+		CreatedAt:     t.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:     t.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
@@ -0,0 +1,168 @@
+package notetypehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	notestorage "flash2fy/internal/adapters/storage/note"
+	notetypestorage "flash2fy/internal/adapters/storage/notetype"
+	noteapp "flash2fy/internal/app/application/note"
+)
+
+type httpTestDeps struct {
+	service *noteapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	service := noteapp.NewService(notestorage.NewMemoryRepository(), cardstorage.NewMemoryRepository(),
+		noteapp.WithNoteTypes(notetypestorage.NewMemoryRepository()))
+	router := chi.NewRouter()
+	router.Mount("/v1/note-types", NewHandler(service).Routes())
+	return httpTestDeps{service: service, handler: router}
+}
+
+func (d httpTestDeps) do(t *testing.T, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	d.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+var vocabulary = map[string]any{
+	"name":    "Vocabulary",
+	"ownerId": "user-1",
+	"fields": []map[string]any{
+		{"name": "word", "required": true},
+		{"name": "meaning", "required": true},
+		{"name": "example"},
+	},
+	"frontTemplate": "**{{.word}}**",
+	"backTemplate":  "{{.meaning}}{{if .example}}\n_{{.example}}_{{end}}",
+}
+
+func TestNoteTypeLifecycle(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	rec := deps.do(t, http.MethodPost, "/v1/note-types", vocabulary)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var created noteTypeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if created.ID == "" || len(created.Fields) != 3 || !created.Fields[0].Required || created.Fields[2].Required {
+		t.Fatalf("unexpected note type payload: %+v", created)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/note-types?ownerId=user-1", nil)
+	var listed []noteTypeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("failed to decode list: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID {
+		t.Fatalf("expected the note type listed, got %+v", listed)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/note-types/"+created.ID+"/preview", map[string]any{
+		"values": map[string]string{"word": "Hund", "meaning": "dog", "example": "Der Hund bellt."},
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var preview previewResponse
+	if err := json.NewDecoder(rec.Body).Decode(&preview); err != nil {
+		t.Fatalf("failed to decode preview: %v", err)
+	}
+	if preview.Front != "**Hund**" || preview.FrontHTML != "<strong>Hund</strong>" ||
+		preview.Back != "dog\n_Der Hund bellt._" {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+
+	update := map[string]any{
+		"name":          "Words",
+		"fields":        []map[string]any{{"name": "word", "required": true}, {"name": "meaning"}},
+		"frontTemplate": "{{.word}}",
+		"backTemplate":  "{{.meaning}}",
+	}
+	rec = deps.do(t, http.MethodPut, "/v1/note-types/"+created.ID, update)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	n, _, err := deps.service.CreateTypedNote(created.ID, map[string]string{"word": "Hund"}, "user-1", "", false)
+	if err != nil {
+		t.Fatalf("create typed note failed: %v", err)
+	}
+	if rec := deps.do(t, http.MethodDelete, "/v1/note-types/"+created.ID, nil); rec.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 for a note type in use, got %d", rec.Code)
+	}
+	if err := deps.service.DeleteNote(n.ID); err != nil {
+		t.Fatalf("delete note failed: %v", err)
+	}
+	if rec := deps.do(t, http.MethodDelete, "/v1/note-types/"+created.ID, nil); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+	if rec := deps.do(t, http.MethodGet, "/v1/note-types/"+created.ID, nil); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestPreviewDraftNoteType(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	draft := map[string]any{
+		"name":          "Vocabulary",
+		"fields":        []map[string]any{{"name": "word", "required": true}, {"name": "meaning"}},
+		"frontTemplate": "{{.word}}",
+		"backTemplate":  "{{if .meaning}}{{.meaning}}{{else}}?{{end}}",
+		"values":        map[string]string{"word": "Hund"},
+	}
+	rec := deps.do(t, http.MethodPost, "/v1/note-types/preview", draft)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var preview previewResponse
+	if err := json.NewDecoder(rec.Body).Decode(&preview); err != nil {
+		t.Fatalf("failed to decode preview: %v", err)
+	}
+	if preview.Front != "Hund" || preview.Back != "?" {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+
+	draft["backTemplate"] = "{{range .meaning}}x{{end}}"
+	if rec := deps.do(t, http.MethodPost, "/v1/note-types/preview", draft); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a range template, got %d", rec.Code)
+	}
+}
+
+func TestCreateNoteTypeValidation(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	tests := []map[string]any{
+		{"name": "", "ownerId": "user-1", "fields": []map[string]any{{"name": "word"}}, "frontTemplate": "{{.word}}"},
+		{"name": "Vocabulary", "ownerId": "user-1", "fields": []map[string]any{}, "frontTemplate": "x"},
+		{"name": "Vocabulary", "ownerId": "user-1", "fields": []map[string]any{{"name": "my word"}}, "frontTemplate": "x"},
+		{"name": "Vocabulary", "ownerId": "user-1", "fields": []map[string]any{{"name": "word"}}, "frontTemplate": "{{.meaning}}"},
+	}
+	for _, payload := range tests {
+		if rec := deps.do(t, http.MethodPost, "/v1/note-types", payload); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%v: expected status 400, got %d", payload, rec.Code)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package studyhttp
+
+// startRequest opens a study session for a user, optionally limited to one deck or filtered deck.
+type startRequest struct {
+	UserID   string `json:"userId"`
+	DeckID   string `json:"deckId"`
+	FilterID string `json:"filterId"`
+}
+
+// answerRequest grades the current card of a session.
+type answerRequest struct {
+	CardID string `json:"cardId"`
+	Rating string `json:"rating"`
+}
+
+// sessionResponse captures the serialized study session returned to clients.
+type sessionResponse struct {
+	ID         string `json:"id"`
+	UserID     string `json:"userId"`
+	DeckID     string `json:"deckId,omitempty"`
+	FilterID   string `json:"filterId,omitempty"`
+	Remaining  int    `json:"remaining"`
+	NewStudied int    `json:"newStudied"`
+	Reviewed   int    `json:"reviewed"`
+	Requeued   int    `json:"requeued"`
+	StartedAt  string `json:"startedAt"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+// cardResponse is the card shown to the user during a session.
+type cardResponse struct {
+	ID        string `json:"id"`
+	Front     string `json:"front"`
+	Back      string `json:"back"`
+	FrontHTML string `json:"frontHtml"`
+	BackHTML  string `json:"backHtml"`
+	New       bool   `json:"new"`
+	DueAt     string `json:"dueAt"`
+}
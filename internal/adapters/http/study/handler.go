@@ -0,0 +1,181 @@
+package studyhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	markuphttp "flash2fy/internal/adapters/http/markup"
+	studyapp "flash2fy/internal/app/application/study"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/filter"
+	"flash2fy/internal/app/domain/study"
+)
+
+// Handler exposes HTTP endpoints for study sessions.
+type Handler struct {
+	service *studyapp.Service
+}
+
+func NewHandler(service *studyapp.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Post("/", h.startSession)
+	r.Get("/{id}", h.getSession)
+	r.Get("/{id}/next", h.nextCard)
+	r.Post("/{id}/answers", h.answerCard)
+	r.Post("/{id}/finish", h.finishSession)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) startSession(w http.ResponseWriter, r *http.Request) {
+	var req startRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	var (
+		session study.Session
+		err     error
+	)
+	switch {
+	case req.DeckID != "" && req.FilterID != "":
+		writeError(w, http.StatusBadRequest, "deckId and filterId cannot be combined")
+		return
+	case req.DeckID != "":
+		session, err = h.service.StartDeck(req.UserID, req.DeckID)
+	case req.FilterID != "":
+		session, err = h.service.StartFilter(req.UserID, req.FilterID)
+	default:
+		session, err = h.service.Start(req.UserID)
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toSessionResponse(session))
+}
+
+func (h *Handler) getSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.Get(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSessionResponse(session))
+}
+
+func (h *Handler) nextCard(w http.ResponseWriter, r *http.Request) {
+	c, err := h.service.Next(chi.URLParam(r, "id"))
+	if err == study.ErrQueueEmpty {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCardResponse(c))
+}
+
+func (h *Handler) answerCard(w http.ResponseWriter, r *http.Request) {
+	var req answerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	rating, err := card.ParseRating(req.Rating)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c, err := h.service.Answer(chi.URLParam(r, "id"), req.CardID, rating)
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCardResponse(c))
+}
+
+func (h *Handler) finishSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.service.Finish(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, statusFor(err), err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSessionResponse(session))
+}
+
+func statusFor(err error) int {
+	switch err {
+	case study.ErrSessionNotFound, card.ErrNotFound, deck.ErrNotFound, filter.ErrNotFound:
+		return http.StatusNotFound
+	case study.ErrSessionFinished, study.ErrQueueEmpty, study.ErrNotCurrentCard:
+		return http.StatusConflict
+	case deck.ErrForeignOwner, filter.ErrForeignOwner:
+		return http.StatusForbidden
+	case study.ErrEmptyUser, card.ErrInvalidRating:
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func toSessionResponse(s study.Session) sessionResponse {
+	resp := sessionResponse{
+		ID:         s.ID,
+		UserID:     s.UserID,
+		DeckID:     s.DeckID,
+		FilterID:   s.FilterID,
+		Remaining:  len(s.Queue),
+		NewStudied: s.NewStudied,
+		Reviewed:   s.Reviewed,
+		Requeued:   s.Requeued,
+		StartedAt:  s.StartedAt.Format(time.RFC3339Nano),
+	}
+	if s.Finished() {
+		resp.FinishedAt = s.FinishedAt.Format(time.RFC3339Nano)
+	}
+	return resp
+}
+
+func toCardResponse(c card.Card) cardResponse {
+	return cardResponse{
+		ID:        c.ID,
+		Front:     c.Question(),
+		Back:      c.Answer(),
+		FrontHTML: markuphttp.HTML(c.Question()),
+		BackHTML:  markuphttp.HTML(c.Answer()),
+		New:       c.Review.IsNew(),
+		DueAt:     c.Review.DueAt.Format(time.RFC3339Nano),
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
@@ -0,0 +1,162 @@
+package studyhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	filterstorage "flash2fy/internal/adapters/storage/filter"
+	studystorage "flash2fy/internal/adapters/storage/study"
+	userstorage "flash2fy/internal/adapters/storage/user"
+	cardapp "flash2fy/internal/app/application/card"
+	filterapp "flash2fy/internal/app/application/filter"
+	studyapp "flash2fy/internal/app/application/study"
+)
+
+type httpTestDeps struct {
+	cards   *cardapp.Service
+	filters *filterapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	cardRepo := cardstorage.NewMemoryRepository()
+	userRepo := userstorage.NewMemoryRepository()
+	cards := cardapp.NewService(cardRepo)
+	filters := filterapp.NewService(filterstorage.NewMemoryRepository(), cardRepo)
+	service := studyapp.NewService(cardRepo, cards, studystorage.NewMemoryRepository(), userRepo,
+		studyapp.WithFilters(filters),
+	)
+	router := chi.NewRouter()
+	router.Mount("/v1/sessions", NewHandler(service).Routes())
+	return httpTestDeps{
+		cards:   cards,
+		filters: filters,
+		handler: router,
+	}
+}
+
+func (d httpTestDeps) do(t *testing.T, method, target string, payload any) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if payload != nil {
+		if err := json.NewEncoder(&body).Encode(payload); err != nil {
+			t.Fatalf("encode payload: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	d.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestStudySessionFlow(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.cards.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	rec := deps.do(t, http.MethodPost, "/v1/sessions", map[string]string{"userId": "user-1"})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", rec.Code)
+	}
+	var session sessionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&session); err != nil {
+		t.Fatalf("failed to decode session: %v", err)
+	}
+	if session.Remaining != 1 {
+		t.Fatalf("expected one card queued, got %+v", session)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/sessions/"+session.ID+"/next", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var next cardResponse
+	if err := json.NewDecoder(rec.Body).Decode(&next); err != nil {
+		t.Fatalf("failed to decode card: %v", err)
+	}
+	if next.ID != created.ID || !next.New {
+		t.Fatalf("unexpected next card: %+v", next)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/sessions/"+session.ID+"/answers", map[string]string{"cardId": created.ID, "rating": "nope"})
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for unknown rating, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/sessions/"+session.ID+"/answers", map[string]string{"cardId": created.ID, "rating": "good"})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodGet, "/v1/sessions/"+session.ID+"/next", nil)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204 once the queue is empty, got %d", rec.Code)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/sessions/"+session.ID+"/finish", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&session); err != nil {
+		t.Fatalf("failed to decode session: %v", err)
+	}
+	if session.NewStudied != 1 || session.FinishedAt == "" {
+		t.Fatalf("unexpected finished session: %+v", session)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/sessions/"+session.ID+"/finish", nil)
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected status 409 when finishing twice, got %d", rec.Code)
+	}
+}
+
+func TestStudyFilteredDeck(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	if _, err := deps.cards.CreateCard("der Hund", "dog", "user-1", "noun"); err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+	if _, err := deps.cards.CreateCard("laufen", "to run", "user-1", "verb"); err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+	nouns, err := deps.filters.CreateFilter("Nouns", "tag:noun", "user-1")
+	if err != nil {
+		t.Fatalf("setup filter failed: %v", err)
+	}
+
+	rec := deps.do(t, http.MethodPost, "/v1/sessions", map[string]string{"userId": "user-1", "filterId": nouns.ID})
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var session sessionResponse
+	if err := json.NewDecoder(rec.Body).Decode(&session); err != nil {
+		t.Fatalf("failed to decode session: %v", err)
+	}
+	if session.Remaining != 1 || session.FilterID != nouns.ID {
+		t.Fatalf("expected one filtered card queued, got %+v", session)
+	}
+
+	rec = deps.do(t, http.MethodPost, "/v1/sessions", map[string]string{"userId": "user-2", "filterId": nouns.ID})
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403 for another user's filter, got %d", rec.Code)
+	}
+}
+
+func TestStudySessionNotFound(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	rec := deps.do(t, http.MethodGet, "/v1/sessions/missing/next", nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,23 @@
+package userhttp
+
+// schedulerRequest selects the spaced-repetition algorithm of a user.
+type schedulerRequest struct {
+	Scheduler        string `json:"scheduler"`
+	DailyNewLimit    int    `json:"dailyNewLimit"`
+	DailyReviewLimit int    `json:"dailyReviewLimit"`
+}
+
+// limitsRequest sets the daily study limits of a user; zero restores the default.
+type limitsRequest struct {
+	NewCards int `json:"newCards"`
+	Reviews  int `json:"reviews"`
+}
+
+// userResponse captures the serialized user representation returned to clients.
+type userResponse struct {
+	ID               string `json:"id"`
+	Nickname         string `json:"nickname"`
+	Scheduler        string `json:"scheduler"`
+	DailyNewLimit    int    `json:"dailyNewLimit"`
+	DailyReviewLimit int    `json:"dailyReviewLimit"`
+}
@@ -0,0 +1,121 @@
+package userhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	userapp "flash2fy/internal/app/application/user"
+	"flash2fy/internal/app/domain/user"
+)
+
+// Handler exposes HTTP endpoints for user operations.
+type Handler struct {
+	service *userapp.Service
+}
+
+func NewHandler(service *userapp.Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+
+	r.Get("/{id}", h.getUser)
+	r.Put("/{id}/scheduler", h.setScheduler)
+	r.Put("/{id}/limits", h.setLimits)
+
+	return r
+}
+
+type errorResponse struct {
+	Message string `json:"message"`
+}
+
+func (h *Handler) getUser(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	u, err := h.service.GetUser(id)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == user.ErrNotFound {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(u))
+}
+
+func (h *Handler) setScheduler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req schedulerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	scheduler := user.Scheduler(strings.ToLower(strings.TrimSpace(req.Scheduler)))
+	u, err := h.service.SetScheduler(id, scheduler)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case user.ErrNotFound:
+			status = http.StatusNotFound
+		case user.ErrUnknownScheduler:
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(u))
+}
+
+func (h *Handler) setLimits(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req limitsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request payload")
+		return
+	}
+
+	u, err := h.service.SetDailyLimits(id, req.NewCards, req.Reviews)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch err {
+		case user.ErrNotFound:
+			status = http.StatusNotFound
+		case user.ErrNegativeLimit:
+			status = http.StatusBadRequest
+		}
+		writeError(w, status, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toResponse(u))
+}
+
+func toResponse(u user.User) userResponse {
+	return userResponse{
+		ID:               u.ID,
+		Nickname:         u.Nickname,
+		Scheduler:        string(u.PreferredScheduler()),
+		DailyNewLimit:    u.DailyNewLimit,
+		DailyReviewLimit: u.DailyReviewLimit,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Message: message})
+}
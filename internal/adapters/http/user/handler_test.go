@@ -0,0 +1,100 @@
+package userhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	userstorage "flash2fy/internal/adapters/storage/user"
+	userapp "flash2fy/internal/app/application/user"
+	"flash2fy/internal/app/domain/user"
+)
+
+type httpTestDeps struct {
+	service *userapp.Service
+	handler http.Handler
+}
+
+func newHTTPTestDeps() httpTestDeps {
+	repo := userstorage.NewMemoryRepository()
+	service := userapp.NewService(repo)
+	router := chi.NewRouter()
+	router.Mount("/v1/users", NewHandler(service).Routes())
+	return httpTestDeps{
+		service: service,
+		handler: router,
+	}
+}
+
+func TestGetUserEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateUser("alice")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/"+created.ID, nil)
+	rec := httptest.NewRecorder()
+
+	deps.handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var resp userResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ID != created.ID || resp.Nickname != "alice" || resp.Scheduler != string(user.DefaultScheduler) {
+		t.Fatalf("unexpected response payload: %+v", resp)
+	}
+}
+
+func TestSetSchedulerEndpoint(t *testing.T) {
+	deps := newHTTPTestDeps()
+
+	created, err := deps.service.CreateUser("alice")
+	if err != nil {
+		t.Fatalf("setup create failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		id         string
+		scheduler  string
+		wantStatus int
+	}{
+		{"switch to leitner", created.ID, "Leitner", http.StatusOK},
+		{"unknown scheduler", created.ID, "anki", http.StatusBadRequest},
+		{"missing user", "missing", "fsrs", http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]string{"scheduler": tc.scheduler})
+			req := httptest.NewRequest(http.MethodPut, "/v1/users/"+tc.id+"/scheduler", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			deps.handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d", tc.wantStatus, rec.Code)
+			}
+		})
+	}
+
+	stored, err := deps.service.GetUser(created.ID)
+	if err != nil {
+		t.Fatalf("get user failed: %v", err)
+	}
+	if stored.Scheduler != user.SchedulerLeitner {
+		t.Fatalf("expected leitner scheduler persisted, got %q", stored.Scheduler)
+	}
+}
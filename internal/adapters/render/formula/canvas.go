@@ -0,0 +1,101 @@
+package formularender
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+// canvas draws text straight onto the image and collects strokes in a rasterizer that is
+// drawn once at the end. Every polygon is wound the same way, so overlapping strokes merge
+// instead of cancelling out.
+type canvas struct {
+	img  *image.RGBA
+	path *vector.Rasterizer
+}
+
+func newCanvas(img *image.RGBA) *canvas {
+	size := img.Bounds().Size()
+	return &canvas{img: img, path: vector.NewRasterizer(size.X, size.Y)}
+}
+
+// text draws s with its baseline starting at x, y.
+func (c *canvas) text(face font.Face, s string, x, y float64) {
+	d := font.Drawer{
+		Dst:  c.img,
+		Src:  image.Black,
+		Face: face,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)},
+	}
+	d.DrawString(s)
+}
+
+type point struct{ x, y float64 }
+
+// polyline strokes the segments between points with lines width pixels wide, rounding joints.
+func (c *canvas) polyline(width float64, points ...point) {
+	for i := 1; i < len(points); i++ {
+		c.segment(points[i-1], points[i], width)
+		if i < len(points)-1 {
+			c.disc(points[i], width/2)
+		}
+	}
+}
+
+func (c *canvas) segment(from, to point, width float64) {
+	dx, dy := to.x-from.x, to.y-from.y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	nx, ny := -dy/length*width/2, dx/length*width/2
+	c.polygon(
+		point{from.x + nx, from.y + ny},
+		point{to.x + nx, to.y + ny},
+		point{to.x - nx, to.y - ny},
+		point{from.x - nx, from.y - ny},
+	)
+}
+
+// rect fills the rectangle between the corners.
+func (c *canvas) rect(x0, y0, x1, y1 float64) {
+	c.polygon(point{x0, y0}, point{x0, y1}, point{x1, y1}, point{x1, y0})
+}
+
+// disc fills a circle.
+func (c *canvas) disc(center point, radius float64) {
+	const steps = 16
+	points := make([]point, steps)
+	for i := range points {
+		angle := -2 * math.Pi * float64(i) / steps
+		points[i] = point{center.x + radius*math.Cos(angle), center.y + radius*math.Sin(angle)}
+	}
+	c.polygon(points...)
+}
+
+func (c *canvas) polygon(points ...point) {
+	c.path.MoveTo(float32(points[0].x), float32(points[0].y))
+	for _, p := range points[1:] {
+		c.path.LineTo(float32(p.x), float32(p.y))
+	}
+	c.path.ClosePath()
+}
+
+func (c *canvas) flush() {
+	c.path.Draw(c.img, c.img.Bounds(), image.Black, image.Point{})
+}
+
+// arc returns points along the circle around center from one angle to another, in degrees
+// counterclockwise with y pointing up as in the em coordinates of drawn glyphs.
+func arc(center point, radius, from, to float64) []point {
+	const steps = 16
+	points := make([]point, steps+1)
+	for i := range points {
+		angle := (from + (to-from)*float64(i)/steps) * math.Pi / 180
+		points[i] = point{center.x + radius*math.Cos(angle), center.y + radius*math.Sin(angle)}
+	}
+	return points
+}
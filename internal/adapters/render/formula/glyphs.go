@@ -0,0 +1,94 @@
+package formularender
+
+// substitutes replaces characters the Go fonts lack by ones they have that look the same or
+// close enough.
+var substitutes = map[rune]string{
+	'ϵ': "ε", 'ϑ': "θ", 'ϕ': "φ", 'ℏ': "ħ", '⋅': "·", '∗': "*", '∼': "~", '⋯': "···", '‖': "||",
+}
+
+// doubleStruck maps the blackboard bold letters, which the Go fonts lack, to the letters set in
+// bold instead.
+var doubleStruck = map[rune]string{
+	'ℝ': "R", 'ℕ': "N", 'ℤ': "Z", 'ℚ': "Q", 'ℂ': "C",
+}
+
+// shape is a glyph the Go fonts lack, drawn as strokes. Advance and the points are in em,
+// with x to the right and y up from the baseline.
+type shape struct {
+	advance float64
+	strokes [][]point
+}
+
+// strokeWidth is the width in em of drawn glyphs, accents and radical signs.
+const strokeWidth = 0.055
+
+var shapes = map[rune]shape{
+	'∈': {0.8, [][]point{
+		join([]point{{0.7, 0.57}}, arc(point{0.43, 0.3}, 0.27, 90, 270), []point{{0.7, 0.03}}),
+		{{0.16, 0.3}, {0.7, 0.3}},
+	}},
+	'∉': {0.8, [][]point{
+		join([]point{{0.7, 0.57}}, arc(point{0.43, 0.3}, 0.27, 90, 270), []point{{0.7, 0.03}}),
+		{{0.16, 0.3}, {0.7, 0.3}},
+		{{0.3, -0.1}, {0.58, 0.7}},
+	}},
+	'⊂': {0.8, [][]point{
+		join([]point{{0.7, 0.57}}, arc(point{0.43, 0.3}, 0.27, 90, 270), []point{{0.7, 0.03}}),
+	}},
+	'⊆': {0.8, [][]point{
+		join([]point{{0.7, 0.62}}, arc(point{0.43, 0.38}, 0.24, 90, 270), []point{{0.7, 0.14}}),
+		{{0.16, 0}, {0.7, 0}},
+	}},
+	'∀': {0.7, [][]point{
+		{{0.05, 0.72}, {0.35, 0}, {0.65, 0.72}},
+		{{0.17, 0.36}, {0.53, 0.36}},
+	}},
+	'∃': {0.7, [][]point{
+		{{0.1, 0.72}, {0.55, 0.72}, {0.55, 0}, {0.1, 0}},
+		{{0.15, 0.36}, {0.55, 0.36}},
+	}},
+	'∇': {0.8, [][]point{
+		{{0.08, 0.7}, {0.72, 0.7}, {0.4, 0}, {0.08, 0.7}},
+	}},
+	'⇒': {1, [][]point{
+		{{0.1, 0.38}, {0.75, 0.38}},
+		{{0.1, 0.22}, {0.75, 0.22}},
+		{{0.6, 0.52}, {0.88, 0.3}, {0.6, 0.08}},
+	}},
+	'⇔': {1.1, [][]point{
+		{{0.22, 0.38}, {0.88, 0.38}},
+		{{0.22, 0.22}, {0.88, 0.22}},
+		{{0.75, 0.52}, {1, 0.3}, {0.75, 0.08}},
+		{{0.35, 0.52}, {0.1, 0.3}, {0.35, 0.08}},
+	}},
+	'∓': {0.7, [][]point{
+		{{0.1, 0.58}, {0.6, 0.58}},
+		{{0.1, 0.28}, {0.6, 0.28}},
+		{{0.35, 0.03}, {0.35, 0.53}},
+	}},
+	'⟨': {0.43, [][]point{{{0.33, 0.75}, {0.1, 0.3}, {0.33, -0.15}}}},
+	'⟩': {0.43, [][]point{{{0.1, 0.75}, {0.33, 0.3}, {0.1, -0.15}}}},
+	'∝': {0.8, [][]point{
+		join([]point{{0.72, 0.5}}, arc(point{0.3, 0.3}, 0.16, 35, 325), []point{{0.72, 0.1}}),
+	}},
+	'∘': {0.6, [][]point{arc(point{0.3, 0.3}, 0.12, 0, 360)}},
+}
+
+func join(parts ...[]point) []point {
+	var points []point
+	for _, part := range parts {
+		points = append(points, part...)
+	}
+	return points
+}
+
+// draw strokes the shape at size pixels with its baseline starting at x, y.
+func (s shape) draw(c *canvas, x, y, size float64) {
+	for _, stroke := range s.strokes {
+		points := make([]point, len(stroke))
+		for i, p := range stroke {
+			points[i] = point{x + p.x*size, y - p.y*size}
+		}
+		c.polyline(strokeWidth*size, points...)
+	}
+}
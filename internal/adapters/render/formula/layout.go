@@ -0,0 +1,481 @@
+package formularender
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"flash2fy/internal/app/domain/formula"
+)
+
+// box is a laid out part of a formula. Sizes are in pixels; ascent and descent extend above and
+// below the baseline, and draw paints the box with its baseline starting at x, y.
+type box struct {
+	width, ascent, descent float64
+	draw                   func(c *canvas, x, y float64)
+}
+
+func (b box) height() float64 {
+	return b.ascent + b.descent
+}
+
+// raised returns b moved up by dy pixels.
+func raised(b box, dy float64) box {
+	return box{
+		width:   b.width,
+		ascent:  b.ascent + dy,
+		descent: b.descent - dy,
+		draw:    func(c *canvas, x, y float64) { b.draw(c, x, y-dy) },
+	}
+}
+
+func space(width float64) box {
+	return box{width: width, draw: func(*canvas, float64, float64) {}}
+}
+
+// level is the size formulas are set at and whether they are displayed on their own line,
+// where fractions keep their size and sums and products take their scripts as limits.
+type level struct {
+	size    float64
+	display bool
+	script  bool
+}
+
+type layout struct {
+	renderer *Renderer
+	base     float64
+}
+
+// smaller returns the level of scripts and of fractions in inline formulas.
+func (l *layout) smaller(lv level) level {
+	return level{size: math.Max(lv.size*0.7, l.base*0.5), script: true}
+}
+
+// axis returns the height above the baseline of the middle of + and of fraction bars.
+func (l *layout) axis(size float64) float64 {
+	bounds, _, _ := l.renderer.face(regular, size).GlyphBounds('+')
+	return -float64(bounds.Min.Y+bounds.Max.Y) / 2 / 64
+}
+
+func (l *layout) node(n formula.Node, lv level) box {
+	switch n.Kind {
+	case formula.NodeRow:
+		return l.row(n.Children, lv)
+	case formula.NodeIdent:
+		if utf8.RuneCountInString(n.Text) == 1 {
+			return l.text(n.Text, italic, lv.size)
+		}
+		return l.text(n.Text, regular, lv.size)
+	case formula.NodeNumber, formula.NodeOperator, formula.NodeFunction, formula.NodeText:
+		return l.text(n.Text, regular, lv.size)
+	case formula.NodeSpace:
+		return space(n.Width * lv.size)
+	case formula.NodeLargeOp:
+		return l.largeOp(n.Text, lv)
+	case formula.NodeFraction:
+		return l.fraction(n, lv)
+	case formula.NodeRoot:
+		return l.root(n, lv)
+	case formula.NodeScripts:
+		return l.scripts(n, lv)
+	case formula.NodeAccent:
+		return l.accent(n, lv)
+	case formula.NodeFenced:
+		return l.fenced(n, lv)
+	}
+	return space(0)
+}
+
+var (
+	relations = map[string]bool{
+		"=": true, "<": true, ">": true, "≤": true, "≥": true, "≠": true, "≈": true, "≡": true,
+		"∼": true, "∝": true, "→": true, "←": true, "↔": true, "⇒": true, "⇔": true, "∈": true,
+		"∉": true, "⊂": true, "⊆": true, ":": true,
+	}
+	binaries = map[string]bool{
+		"+": true, "−": true, "±": true, "∓": true, "×": true, "÷": true, "⋅": true, "∗": true,
+		"∘": true, "∪": true, "∩": true,
+	}
+	openings = map[string]bool{"(": true, "[": true, "{": true, "⟨": true}
+)
+
+// row lays children side by side with TeX's spacing around relations and binary operators,
+// which scripts go without.
+func (l *layout) row(children []formula.Node, lv level) box {
+	var boxes []box
+	for i, child := range children {
+		b := l.node(child, lv)
+		spacing := 0.0
+		if child.Kind == formula.NodeOperator && !lv.script {
+			switch {
+			case relations[child.Text]:
+				spacing = 5.0 / 18
+			case binaries[child.Text] && i > 0 && !unary(children[i-1]):
+				spacing = 4.0 / 18
+			}
+		}
+		if spacing > 0 {
+			boxes = append(boxes, space(spacing*lv.size), b, space(spacing*lv.size))
+		} else {
+			boxes = append(boxes, b)
+		}
+		if child.Kind == formula.NodeOperator && (child.Text == "," || child.Text == ";") && !lv.script {
+			boxes = append(boxes, space(3.0/18*lv.size))
+		}
+		if child.Kind == formula.NodeFunction && i+1 < len(children) && children[i+1].Kind != formula.NodeOperator {
+			boxes = append(boxes, space(3.0/18*lv.size))
+		}
+	}
+	return hbox(boxes)
+}
+
+// unary reports whether a binary operator after prev is a sign, as in (-x) or a = -b.
+func unary(prev formula.Node) bool {
+	return prev.Kind == formula.NodeOperator && (relations[prev.Text] || binaries[prev.Text] || openings[prev.Text] || prev.Text == ",")
+}
+
+func hbox(boxes []box) box {
+	row := box{}
+	for _, b := range boxes {
+		row.width += b.width
+		row.ascent = math.Max(row.ascent, b.ascent)
+		row.descent = math.Max(row.descent, b.descent)
+	}
+	row.draw = func(c *canvas, x, y float64) {
+		for _, b := range boxes {
+			b.draw(c, x, y)
+			x += b.width
+		}
+	}
+	return row
+}
+
+// text lays out characters of the style, substituting those the Go fonts lack.
+func (l *layout) text(s string, st style, size float64) box {
+	var boxes []box
+	for _, r := range s {
+		switch {
+		case substitutes[r] != "":
+			boxes = append(boxes, l.text(substitutes[r], st, size))
+		case doubleStruck[r] != "":
+			boxes = append(boxes, l.text(doubleStruck[r], bold, size))
+		default:
+			boxes = append(boxes, l.glyph(r, st, size))
+		}
+	}
+	return hbox(boxes)
+}
+
+func (l *layout) glyph(r rune, st style, size float64) box {
+	if s, ok := shapes[r]; ok {
+		return box{
+			width:   s.advance * size,
+			ascent:  0.75 * size,
+			descent: 0.15 * size,
+			draw:    func(c *canvas, x, y float64) { s.draw(c, x, y, size) },
+		}
+	}
+
+	face := l.renderer.face(st, size)
+	bounds, advance, ok := face.GlyphBounds(r)
+	if !ok && st != regular {
+		return l.glyph(r, regular, size)
+	}
+	if !ok {
+		return l.missing(size)
+	}
+	return box{
+		width:   float64(advance) / 64,
+		ascent:  math.Max(0, -float64(bounds.Min.Y)/64),
+		descent: math.Max(0, float64(bounds.Max.Y)/64),
+		draw:    func(c *canvas, x, y float64) { c.text(face, string(r), x, y) },
+	}
+}
+
+// missing draws an empty rectangle for characters neither the fonts nor the shapes have.
+func (l *layout) missing(size float64) box {
+	return box{
+		width:  0.6 * size,
+		ascent: 0.7 * size,
+		draw: func(c *canvas, x, y float64) {
+			w := strokeWidth * size
+			c.polyline(w,
+				point{x + 0.1*size, y}, point{x + 0.1*size, y - 0.7*size},
+				point{x + 0.5*size, y - 0.7*size}, point{x + 0.5*size, y}, point{x + 0.1*size, y})
+		},
+	}
+}
+
+// largeOp lays out ∑, ∏ and integrals bigger than text and centred on the axis.
+func (l *layout) largeOp(symbol string, lv level) box {
+	scale := 1.3
+	if lv.display {
+		scale = 1.8
+	}
+	size := lv.size * scale
+
+	var b box
+	switch symbol {
+	case "∬":
+		b = l.text("∫∫", regular, size)
+	case "∮":
+		integral := l.text("∫", regular, size)
+		b = integral
+		b.draw = func(c *canvas, x, y float64) {
+			integral.draw(c, x, y)
+			center := point{x + integral.width/2, y - (integral.ascent-integral.descent)/2}
+			c.polyline(strokeWidth*lv.size, arc(center, 0.18*size, 0, 360)...)
+		}
+	default:
+		b = l.text(symbol, regular, size)
+	}
+	return raised(b, l.axis(lv.size)-(b.ascent-b.descent)/2)
+}
+
+func (l *layout) fraction(n formula.Node, lv level) box {
+	inner := l.smaller(lv)
+	if lv.display {
+		inner = level{size: lv.size}
+	}
+	num := l.node(n.Children[0], inner)
+	den := l.node(n.Children[1], inner)
+
+	thickness := math.Max(1, 0.05*lv.size)
+	gap := 0.12 * lv.size
+	margin := 0.1 * lv.size
+	axis := l.axis(lv.size)
+	width := math.Max(num.width, den.width) + 2*margin
+
+	return box{
+		width:   width,
+		ascent:  axis + thickness/2 + gap + num.height(),
+		descent: den.height() + gap + thickness/2 - axis,
+		draw: func(c *canvas, x, y float64) {
+			bar := y - axis
+			num.draw(c, x+(width-num.width)/2, bar-thickness/2-gap-num.descent)
+			c.rect(x+margin/2, bar-thickness/2, x+width-margin/2, bar+thickness/2)
+			den.draw(c, x+(width-den.width)/2, bar+thickness/2+gap+den.ascent)
+		},
+	}
+}
+
+func (l *layout) root(n formula.Node, lv level) box {
+	body := l.node(n.Children[0], lv)
+	size := lv.size
+	thickness := math.Max(1, strokeWidth*size)
+	top := body.ascent + 0.12*size + thickness
+	bottom := body.descent
+	sign := 0.55 * size
+
+	offset, ascent := 0.0, top+thickness/2
+	var degree *box
+	if len(n.Children) > 1 {
+		d := l.node(n.Children[1], l.smaller(l.smaller(lv)))
+		degree = &d
+		offset = math.Max(0, d.width-0.3*size)
+		ascent = math.Max(ascent, 0.5*(top+bottom)-bottom+d.descent+d.ascent)
+	}
+
+	return box{
+		width:   offset + sign + body.width + 0.15*size,
+		ascent:  ascent,
+		descent: bottom + 0.05*size,
+		draw: func(c *canvas, x, y float64) {
+			sx, yTop, yBottom, h := x+offset, y-top, y+bottom, top+bottom
+			c.polyline(thickness,
+				point{sx + 0.05*size, yBottom - 0.4*h},
+				point{sx + 0.15*size, yBottom - 0.46*h},
+				point{sx + 0.28*size, yBottom},
+				point{sx + sign, yTop},
+				point{sx + sign + body.width + 0.1*size, yTop},
+			)
+			body.draw(c, sx+sign+0.05*size, y)
+			if degree != nil {
+				degree.draw(c, sx+0.3*size-degree.width, yBottom-0.5*h-degree.descent)
+			}
+		},
+	}
+}
+
+// scripts lays out sub- and superscripts, or limits under and over sums and lim in display
+// formulas.
+func (l *layout) scripts(n formula.Node, lv level) box {
+	base := l.node(n.Children[0], lv)
+	small := l.smaller(lv)
+	var sub, sup *box
+	if n.Sub != nil {
+		b := l.node(*n.Sub, small)
+		sub = &b
+	}
+	if n.Sup != nil {
+		b := l.node(*n.Sup, small)
+		sup = &b
+	}
+
+	if lv.display && n.Children[0].Limits() {
+		return l.limits(base, sub, sup, lv)
+	}
+
+	size := lv.size
+	up := math.Max(0.4*size, base.ascent-0.25*size)
+	down := math.Max(0.2*size, base.descent+0.05*size)
+	width := 0.0
+	if sup != nil {
+		up = math.Max(up, sup.descent+0.25*size)
+		width = sup.width
+	}
+	if sub != nil {
+		down = math.Max(down, sub.ascent-0.6*size)
+		width = math.Max(width, sub.width)
+	}
+	if sub != nil && sup != nil {
+		if gap := (up - sup.descent) - (sub.ascent - down); gap < 0.1*size {
+			down += 0.1*size - gap
+		}
+	}
+
+	b := box{width: base.width + width + 0.05*size, ascent: base.ascent, descent: base.descent}
+	if sup != nil {
+		b.ascent = math.Max(b.ascent, up+sup.ascent)
+	}
+	if sub != nil {
+		b.descent = math.Max(b.descent, down+sub.descent)
+	}
+	b.draw = func(c *canvas, x, y float64) {
+		base.draw(c, x, y)
+		if sup != nil {
+			sup.draw(c, x+base.width, y-up)
+		}
+		if sub != nil {
+			sub.draw(c, x+base.width, y+down)
+		}
+	}
+	return b
+}
+
+func (l *layout) limits(base box, sub, sup *box, lv level) box {
+	gap := 0.15 * lv.size
+	b := box{width: base.width, ascent: base.ascent, descent: base.descent}
+	if sup != nil {
+		b.width = math.Max(b.width, sup.width)
+		b.ascent += gap + sup.height()
+	}
+	if sub != nil {
+		b.width = math.Max(b.width, sub.width)
+		b.descent += gap + sub.height()
+	}
+	b.draw = func(c *canvas, x, y float64) {
+		base.draw(c, x+(b.width-base.width)/2, y)
+		if sup != nil {
+			sup.draw(c, x+(b.width-sup.width)/2, y-base.ascent-gap-sup.descent)
+		}
+		if sub != nil {
+			sub.draw(c, x+(b.width-sub.width)/2, y+base.descent+gap+sub.ascent)
+		}
+	}
+	return b
+}
+
+// accent draws the mark of \vec, \hat, \bar, \dot or \tilde above the body.
+func (l *layout) accent(n formula.Node, lv level) box {
+	body := l.node(n.Children[0], lv)
+	size := lv.size
+	mark, gap := 0.22*size, 0.08*size
+	width := math.Max(body.width, 0.4*size)
+
+	return box{
+		width:   width,
+		ascent:  math.Max(body.ascent, 0.5*size) + gap + mark,
+		descent: body.descent,
+		draw: func(c *canvas, x, y float64) {
+			body.draw(c, x+(width-body.width)/2, y)
+			stroke := strokeWidth * size
+			bottom := y - math.Max(body.ascent, 0.5*size) - gap
+			top, middle := bottom-mark, bottom-mark/2
+			left, right, center := x+0.05*size, x+width-0.05*size, x+width/2
+			switch n.Text {
+			case formula.AccentVector:
+				c.polyline(stroke, point{left, middle}, point{right, middle})
+				c.polyline(stroke, point{right - 0.12*size, top}, point{right, middle}, point{right - 0.12*size, bottom})
+			case formula.AccentHat:
+				half := math.Min(width/2-0.05*size, 0.25*size)
+				c.polyline(stroke, point{center - half, bottom}, point{center, top}, point{center + half, bottom})
+			case formula.AccentBar:
+				c.polyline(stroke, point{left, middle}, point{right, middle})
+			case formula.AccentDot:
+				c.disc(point{center, middle}, 0.07*size)
+			case formula.AccentTilde:
+				var wave []point
+				for i := 0; i <= 16; i++ {
+					t := float64(i) / 16
+					wave = append(wave, point{left + (right-left)*t, middle - math.Sin(2*math.Pi*t)*mark/3})
+				}
+				c.polyline(stroke, wave...)
+			}
+		},
+	}
+}
+
+// fenced lays out \left and \right delimiters as tall as the body, drawing them as strokes once
+// the font's glyphs would be too short.
+func (l *layout) fenced(n formula.Node, lv level) box {
+	body := l.node(n.Children[1], lv)
+	axis := l.axis(lv.size)
+	half := math.Max(body.ascent-axis, body.descent+axis) + 0.1*lv.size
+	return hbox([]box{
+		l.delimiter(n.Children[0].Text, half, axis, lv),
+		body,
+		l.delimiter(n.Children[2].Text, half, axis, lv),
+	})
+}
+
+func (l *layout) delimiter(symbol string, half, axis float64, lv level) box {
+	if symbol == "" {
+		return space(0.1 * lv.size)
+	}
+	glyph := l.text(symbol, regular, lv.size)
+	if glyph.height() >= 2*half {
+		return glyph
+	}
+
+	size := lv.size
+	width := 0.4 * size
+	stroke := strokeWidth * size
+	return box{
+		width:   width,
+		ascent:  axis + half,
+		descent: half - axis,
+		draw: func(c *canvas, x, y float64) {
+			top, bottom := y-axis-half, y-axis+half
+			// at maps u across the width, mirrored for closing delimiters, and v down the height.
+			at := func(u, v float64) point {
+				if closing[symbol] {
+					u = 1 - u
+				}
+				return point{x + 0.08*size + u*(width-0.16*size), top + v*(bottom-top)}
+			}
+			switch symbol {
+			case "(", ")":
+				var curve []point
+				for i := 0; i <= 16; i++ {
+					v := float64(i) / 16
+					curve = append(curve, at(1-math.Sin(math.Pi*v), v))
+				}
+				c.polyline(stroke, curve...)
+			case "[", "]":
+				c.polyline(stroke, at(1, 0), at(0.2, 0), at(0.2, 1), at(1, 1))
+			case "{", "}":
+				c.polyline(stroke, at(1, 0), at(0.5, 0.06), at(0.5, 0.44), at(0, 0.5),
+					at(0.5, 0.56), at(0.5, 0.94), at(1, 1))
+			case "⟨", "⟩":
+				c.polyline(stroke, at(1, 0), at(0, 0.5), at(1, 1))
+			case "|":
+				c.polyline(stroke, at(0.5, 0), at(0.5, 1))
+			case "‖":
+				c.polyline(stroke, at(0.25, 0), at(0.25, 1))
+				c.polyline(stroke, at(0.75, 0), at(0.75, 1))
+			}
+		},
+	}
+}
+
+var closing = map[string]bool{")": true, "]": true, "}": true, "⟩": true}
@@ -0,0 +1,115 @@
+package formularender
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+
+	"flash2fy/internal/app/domain/formula"
+)
+
+const (
+	// fontSize is the size in pixels of formula text.
+	fontSize = 32.0
+	// minFontSize is the smallest size formulas too wide for maxWidth are shrunk to.
+	minFontSize = 14.0
+	// maxWidth is the width in pixels above which formulas are drawn smaller.
+	maxWidth = 4000.0
+	// maxAspect is the largest width to height ratio Telegram accepts for photos.
+	maxAspect = 20
+)
+
+type style int
+
+const (
+	regular style = iota
+	italic
+	bold
+)
+
+type faceKey struct {
+	style style
+	size  float64
+}
+
+// Renderer draws formulas as black on white PNG images with the Go fonts. Glyphs the fonts
+// lack, such as ∈ or ⇒, are drawn as strokes.
+type Renderer struct {
+	// mu guards faces, which are not safe for concurrent use.
+	mu    sync.Mutex
+	fonts map[style]*opentype.Font
+	faces map[faceKey]font.Face
+}
+
+func NewRenderer() (*Renderer, error) {
+	fonts := make(map[style]*opentype.Font)
+	for s, ttf := range map[style][]byte{regular: goregular.TTF, italic: goitalic.TTF, bold: gobold.TTF} {
+		f, err := opentype.Parse(ttf)
+		if err != nil {
+			return nil, fmt.Errorf("parse font: %w", err)
+		}
+		fonts[s] = f
+	}
+	return &Renderer{fonts: fonts, faces: make(map[faceKey]font.Face)}, nil
+}
+
+// Render draws the formula as a PNG image.
+func (r *Renderer) Render(f formula.Formula) ([]byte, error) {
+	root, err := f.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	l := &layout{renderer: r, base: fontSize}
+	b := l.node(root, level{size: fontSize, display: f.Display})
+	if b.width+0.6*fontSize > maxWidth {
+		size := math.Max(minFontSize, fontSize*maxWidth/(b.width+0.6*fontSize))
+		l = &layout{renderer: r, base: size}
+		b = l.node(root, level{size: size, display: f.Display})
+	}
+
+	padding := 0.3 * l.base
+	width := int(math.Ceil(b.width + 2*padding))
+	height := int(math.Ceil(b.height() + 2*padding))
+	if width > height*maxAspect {
+		height = (width + maxAspect - 1) / maxAspect
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	c := newCanvas(img)
+	b.draw(c, padding, (float64(height)-b.height())/2+b.ascent)
+	c.flush()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode formula image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// face returns the font face of the style at size pixels, rounded to half pixels so faces can
+// be reused across formulas.
+func (r *Renderer) face(s style, size float64) font.Face {
+	key := faceKey{style: s, size: math.Round(size*2) / 2}
+	if face, ok := r.faces[key]; ok {
+		return face
+	}
+	// NewFace does not fail for parsed fonts.
+	face, _ := opentype.NewFace(r.fonts[s], &opentype.FaceOptions{Size: key.size, DPI: 72, Hinting: font.HintingNone})
+	r.faces[key] = face
+	return face
+}
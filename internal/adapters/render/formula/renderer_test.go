@@ -0,0 +1,107 @@
+package formularender
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"flash2fy/internal/app/domain/formula"
+)
+
+func newTestRenderer(t *testing.T) *Renderer {
+	t.Helper()
+	r, err := NewRenderer()
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	return r
+}
+
+func decode(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	return img
+}
+
+func darkPixels(img image.Image) int {
+	dark := 0
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r < 0x8000 {
+				dark++
+			}
+		}
+	}
+	return dark
+}
+
+func TestRenderDrawsFormula(t *testing.T) {
+	r := newTestRenderer(t)
+
+	inline, err := r.Render(formula.Formula{Source: `\frac{a}{b}`})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	display, err := r.Render(formula.Formula{Source: `\frac{a}{b}`, Display: true})
+	if err != nil {
+		t.Fatalf("render display failed: %v", err)
+	}
+
+	small, big := decode(t, inline), decode(t, display)
+	if darkPixels(small) == 0 {
+		t.Fatalf("expected the formula to be drawn")
+	}
+	if big.Bounds().Dy() <= small.Bounds().Dy() {
+		t.Fatalf("expected display fractions to be taller, got %v and %v", small.Bounds(), big.Bounds())
+	}
+}
+
+func TestRenderKeepsAspectRatioForTelegram(t *testing.T) {
+	r := newTestRenderer(t)
+
+	data, err := r.Render(formula.Formula{Source: "a+b+c+d+e+f+g+h+i+j+k+l+m+n+o+p+q+r+s+t+u+v+w+x+y+z"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	size := decode(t, data).Bounds().Size()
+	if size.X > size.Y*maxAspect {
+		t.Fatalf("expected an aspect ratio of at most %d, got %v", maxAspect, size)
+	}
+}
+
+func TestRenderRejectsInvalidFormula(t *testing.T) {
+	r := newTestRenderer(t)
+
+	if _, err := r.Render(formula.Formula{Source: `x^`}); err != formula.ErrMissingArgument {
+		t.Fatalf("expected ErrMissingArgument, got %v", err)
+	}
+}
+
+func TestEverySymbolHasAGlyph(t *testing.T) {
+	r := newTestRenderer(t)
+	face := r.face(regular, fontSize)
+
+	symbols := "αβγδϵεζηθϑικλμνξπρστυϕφχψωΓΔΘΛΞΠΣΥΦΨΩ∞∂∇ℏℓ⋅×÷±∓∗∘≤≥≠≈≡∼∝→←↔⇒⇔∈∉⊂⊆∪∩∀∃…⋯⟨⟩‖′∑∏∫−ℝℕℤℚℂ"
+	for _, symbol := range symbols {
+		if _, ok := shapes[symbol]; ok {
+			continue
+		}
+		text := string(symbol)
+		if s, ok := substitutes[symbol]; ok {
+			text = s
+		}
+		if s, ok := doubleStruck[symbol]; ok {
+			text = s
+		}
+		for _, c := range text {
+			if _, ok := face.GlyphAdvance(c); !ok {
+				t.Errorf("no glyph for %q", symbol)
+			}
+		}
+	}
+}
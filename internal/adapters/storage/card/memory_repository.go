@@ -1,20 +1,30 @@
 package cardstorage
 
 import (
+	"sort"
 	"sync"
+	"time"
 
 	"flash2fy/internal/app/domain/card"
 )
 
+// snippetWords caps the length of search snippets.
+const snippetWords = 20
+
 // MemoryRepository persists cards in memory; useful for demos and tests.
+// It keeps an inverted index from each word to the cards containing it for searches.
 type MemoryRepository struct {
-	mu    sync.RWMutex
-	store map[string]card.Card
+	mu     sync.RWMutex
+	store  map[string]card.Card
+	index  map[string]map[string]int // word → card id → occurrences
+	length map[string]int            // card id → number of words
 }
 
 func NewMemoryRepository() *MemoryRepository {
 	return &MemoryRepository{
-		store: make(map[string]card.Card),
+		store:  make(map[string]card.Card),
+		index:  make(map[string]map[string]int),
+		length: make(map[string]int),
 	}
 }
 
@@ -22,7 +32,10 @@ func (r *MemoryRepository) Save(c card.Card) (card.Card, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	c.Tags = append([]string(nil), c.Tags...)
+	r.unindex(c.ID)
 	r.store[c.ID] = c
+	r.reindex(c)
 	return c, nil
 }
 
@@ -31,7 +44,7 @@ func (r *MemoryRepository) FindByID(id string) (card.Card, error) {
 	defer r.mu.RUnlock()
 
 	c, ok := r.store[id]
-	if !ok {
+	if !ok || c.Trashed() {
 		return card.Card{}, card.ErrNotFound
 	}
 	return c, nil
@@ -43,8 +56,167 @@ func (r *MemoryRepository) FindAll() ([]card.Card, error) {
 
 	cards := make([]card.Card, 0, len(r.store))
 	for _, c := range r.store {
-		cards = append(cards, c)
+		if !c.Trashed() {
+			cards = append(cards, c)
+		}
+	}
+	return cards, nil
+}
+
+func (r *MemoryRepository) FindByOwner(ownerID string) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if !c.Trashed() && c.OwnerID == ownerID {
+			cards = append(cards, c)
+		}
+	}
+	return cards, nil
+}
+
+// FindTagged returns the cards matching the tag filter, oldest first.
+func (r *MemoryRepository) FindTagged(filter card.TagFilter) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if !c.Trashed() && filter.Matches(c) {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].CreatedAt.Before(cards[j].CreatedAt)
+	})
+	return cards, nil
+}
+
+// FindMatching returns the cards meeting the criteria, oldest first.
+func (r *MemoryRepository) FindMatching(criteria card.Criteria) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if !c.Trashed() && criteria.Matches(c) {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].CreatedAt.Before(cards[j].CreatedAt)
+	})
+	return cards, nil
+}
+
+// FindDue returns the owner's available cards due at now, the most overdue first.
+func (r *MemoryRepository) FindDue(ownerID string, now time.Time) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if !c.Trashed() && c.OwnerID == ownerID && c.Review.IsDue(now) && c.Available(now) {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		if !cards[i].Review.DueAt.Equal(cards[j].Review.DueAt) {
+			return cards[i].Review.DueAt.Before(cards[j].Review.DueAt)
+		}
+		if !cards[i].CreatedAt.Equal(cards[j].CreatedAt) {
+			return cards[i].CreatedAt.Before(cards[j].CreatedAt)
+		}
+		return cards[i].ID < cards[j].ID
+	})
+	return cards, nil
+}
+
+// FindByNote returns the cards generated from the note, oldest first,
+// forward before reversed and in cloze index order.
+func (r *MemoryRepository) FindByNote(noteID string) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if !c.Trashed() && c.NoteID == noteID {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		a, b := cards[i], cards[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if a.Reversed != b.Reversed {
+			return !a.Reversed
+		}
+		return a.ClozeIndex < b.ClozeIndex
+	})
+	return cards, nil
+}
+
+// FindByDeck returns the cards in the deck, oldest first.
+func (r *MemoryRepository) FindByDeck(deckID string) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if !c.Trashed() && c.DeckID == deckID {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].CreatedAt.Before(cards[j].CreatedAt)
+	})
+	return cards, nil
+}
+
+// FindTrashed returns the owner's cards in the trash, the most recently deleted first.
+func (r *MemoryRepository) FindTrashed(ownerID string) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if c.Trashed() && c.OwnerID == ownerID {
+			cards = append(cards, c)
+		}
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].DeletedAt.After(cards[j].DeletedAt)
+	})
+	return cards, nil
+}
+
+func (r *MemoryRepository) FindTrashedByID(id string) (card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	c, ok := r.store[id]
+	if !ok || !c.Trashed() {
+		return card.Card{}, card.ErrNotFound
+	}
+	return c, nil
+}
+
+// FindTrashedBefore returns the cards of every owner deleted before t, the oldest deletion first.
+func (r *MemoryRepository) FindTrashedBefore(t time.Time) ([]card.Card, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var cards []card.Card
+	for _, c := range r.store {
+		if c.Trashed() && c.DeletedAt.Before(t) {
+			cards = append(cards, c)
+		}
 	}
+	sort.Slice(cards, func(i, j int) bool {
+		return cards[i].DeletedAt.Before(cards[j].DeletedAt)
+	})
 	return cards, nil
 }
 
@@ -55,7 +227,10 @@ func (r *MemoryRepository) Update(c card.Card) (card.Card, error) {
 	if _, ok := r.store[c.ID]; !ok {
 		return card.Card{}, card.ErrNotFound
 	}
+	c.Tags = append([]string(nil), c.Tags...)
+	r.unindex(c.ID)
 	r.store[c.ID] = c
+	r.reindex(c)
 	return c, nil
 }
 
@@ -66,6 +241,94 @@ func (r *MemoryRepository) Delete(id string) error {
 	if _, ok := r.store[id]; !ok {
 		return card.ErrNotFound
 	}
+	r.unindex(id)
 	delete(r.store, id)
 	return nil
 }
+
+// Search returns up to limit cards containing every word of the query, the most relevant first.
+// A card ranks higher the larger the share of its words that match.
+func (r *MemoryRepository) Search(ownerID, query string, limit int) ([]card.SearchHit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := unique(card.Tokenize(query))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var hits []card.SearchHit
+	for id, occurrences := range r.index[terms[0]] {
+		c := r.store[id]
+		if c.Trashed() || c.OwnerID != ownerID {
+			continue
+		}
+		matched := occurrences
+		for _, term := range terms[1:] {
+			n, ok := r.index[term][id]
+			if !ok {
+				matched = 0
+				break
+			}
+			matched += n
+		}
+		if matched == 0 {
+			continue
+		}
+		hits = append(hits, card.SearchHit{
+			Card:    c,
+			Rank:    float64(matched) / float64(r.length[id]),
+			Snippet: card.Highlight(c.SearchText(), terms, snippetWords),
+		})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hits[i].Card.CreatedAt.Before(hits[j].Card.CreatedAt)
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	return hits, nil
+}
+
+// reindex adds the words of the card to the index; callers hold the write lock.
+func (r *MemoryRepository) reindex(c card.Card) {
+	words := card.Tokenize(c.SearchText())
+	for _, word := range words {
+		if r.index[word] == nil {
+			r.index[word] = make(map[string]int)
+		}
+		r.index[word][c.ID]++
+	}
+	r.length[c.ID] = len(words)
+}
+
+// unindex removes the words of the stored card from the index; callers hold the write lock.
+func (r *MemoryRepository) unindex(id string) {
+	existing, ok := r.store[id]
+	if !ok {
+		return
+	}
+	for _, word := range card.Tokenize(existing.SearchText()) {
+		delete(r.index[word], id)
+		if len(r.index[word]) == 0 {
+			delete(r.index, word)
+		}
+	}
+	delete(r.length, id)
+}
+
+func unique(words []string) []string {
+	seen := make(map[string]bool, len(words))
+	result := make([]string, 0, len(words))
+	for _, w := range words {
+		if !seen[w] {
+			seen[w] = true
+			result = append(result, w)
+		}
+	}
+	return result
+}
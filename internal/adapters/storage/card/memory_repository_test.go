@@ -65,6 +65,185 @@ func TestMemoryRepositoryCRUD(t *testing.T) {
 	}
 }
 
+func TestMemoryRepositoryFindByOwner(t *testing.T) {
+	repo := NewMemoryRepository()
+	for _, c := range []card.Card{
+		{ID: "card-1", Front: "A", OwnerID: "user-1"},
+		{ID: "card-2", Front: "B", OwnerID: "user-2"},
+		{ID: "card-3", Front: "C", OwnerID: "user-1"},
+	} {
+		if _, err := repo.Save(c); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	owned, err := repo.FindByOwner("user-1")
+	if err != nil {
+		t.Fatalf("findByOwner failed: %v", err)
+	}
+	if len(owned) != 2 {
+		t.Fatalf("expected 2 cards for user-1, got %+v", owned)
+	}
+	for _, c := range owned {
+		if c.OwnerID != "user-1" {
+			t.Fatalf("unexpected owner in result: %+v", c)
+		}
+	}
+}
+
+func TestMemoryRepositoryFindDue(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+	for _, c := range []card.Card{
+		{ID: "later", Front: "A", OwnerID: "user-1", Review: card.ReviewState{DueAt: now.Add(time.Hour)}},
+		{ID: "overdue", Front: "B", OwnerID: "user-1", Review: card.ReviewState{DueAt: now.Add(-time.Hour)}},
+		{ID: "due", Front: "C", OwnerID: "user-1", Review: card.ReviewState{DueAt: now}},
+		{ID: "other", Front: "D", OwnerID: "user-2", Review: card.ReviewState{DueAt: now.Add(-time.Hour)}},
+	} {
+		if _, err := repo.Save(c); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	due, err := repo.FindDue("user-1", now)
+	if err != nil {
+		t.Fatalf("findDue failed: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "overdue" || due[1].ID != "due" {
+		t.Fatalf("expected overdue then due card, got %+v", due)
+	}
+}
+
+func TestMemoryRepositoryFindByNote(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+
+	for _, c := range []card.Card{
+		{ID: "reverse", Front: "dog", NoteID: "note-1", Reversed: true, CreatedAt: now},
+		{ID: "forward", Front: "Hund", NoteID: "note-1", CreatedAt: now},
+		{ID: "standalone", Front: "Katze", CreatedAt: now},
+	} {
+		if _, err := repo.Save(c); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	cards, err := repo.FindByNote("note-1")
+	if err != nil {
+		t.Fatalf("findByNote failed: %v", err)
+	}
+	if len(cards) != 2 || cards[0].ID != "forward" || cards[1].ID != "reverse" {
+		t.Fatalf("expected forward then reverse card, got %+v", cards)
+	}
+}
+
+func TestMemoryRepositoryFindTagged(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+
+	for _, c := range []card.Card{
+		{ID: "easy", Front: "der", Tags: []string{"grammar"}, CreatedAt: now},
+		{ID: "hard", Front: "Konjunktiv", Tags: []string{"grammar", "hard"}, CreatedAt: now.Add(time.Second)},
+		{ID: "untagged", Front: "Hund", CreatedAt: now},
+	} {
+		if _, err := repo.Save(c); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	cards, err := repo.FindTagged(card.TagFilter{Include: []string{"grammar"}, Exclude: []string{"hard"}})
+	if err != nil {
+		t.Fatalf("findTagged failed: %v", err)
+	}
+	if len(cards) != 1 || cards[0].ID != "easy" {
+		t.Fatalf("expected only the easy grammar card, got %+v", cards)
+	}
+
+	cards, err = repo.FindTagged(card.TagFilter{Exclude: []string{"grammar"}})
+	if err != nil || len(cards) != 1 || cards[0].ID != "untagged" {
+		t.Fatalf("expected only the untagged card, got %+v (%v)", cards, err)
+	}
+}
+
+func TestMemoryRepositorySearch(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+
+	for _, c := range []card.Card{
+		{ID: "dog", Front: "der Hund", Back: "the dog", OwnerID: "user-1", CreatedAt: now},
+		{ID: "dogs", Front: "der Hund, die Hunde", Back: "the dog, the dogs; a dog barks at another dog", OwnerID: "user-1", CreatedAt: now},
+		{ID: "cat", Front: "die Katze", Back: "the cat", OwnerID: "user-1", CreatedAt: now},
+		{ID: "foreign", Front: "le chien", Back: "the dog", OwnerID: "user-2", CreatedAt: now},
+	} {
+		if _, err := repo.Save(c); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	hits, err := repo.Search("user-1", "the DOG", 10)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Card.ID != "dog" || hits[1].Card.ID != "dogs" {
+		t.Fatalf("expected the dog cards ranked by match share, got %+v", hits)
+	}
+	if hits[0].Snippet != "der Hund **the** **dog**" {
+		t.Fatalf("unexpected snippet %q", hits[0].Snippet)
+	}
+
+	if _, err := repo.Update(card.Card{ID: "dog", Front: "der Elefant", Back: "the elephant", OwnerID: "user-1", CreatedAt: now}); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if err := repo.Delete("dogs"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if hits, _ := repo.Search("user-1", "dog", 10); len(hits) != 0 {
+		t.Fatalf("expected the index to follow updates and deletes, got %+v", hits)
+	}
+	if hits, _ := repo.Search("user-2", "dog", 10); len(hits) != 1 || hits[0].Card.ID != "foreign" {
+		t.Fatalf("expected the other owner's card found for them, got %+v", hits)
+	}
+	if hits, _ := repo.Search("", "dog", 10); len(hits) != 0 {
+		t.Fatalf("expected no search across owners, got %+v", hits)
+	}
+}
+
+func TestMemoryRepositoryTrash(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+	for i, id := range []string{"old", "recent", "live"} {
+		c := card.Card{ID: id, Front: "der Hund", Back: "dog", OwnerID: "user-1", CreatedAt: now, Review: card.ReviewState{DueAt: now}}
+		if id != "live" {
+			c.DeletedAt = now.Add(time.Duration(i) * time.Hour)
+		}
+		repo.Save(c)
+	}
+
+	if _, err := repo.FindByID("old"); err != card.ErrNotFound {
+		t.Fatalf("expected trashed cards hidden from FindByID, got %v", err)
+	}
+	if owned, _ := repo.FindByOwner("user-1"); len(owned) != 1 || owned[0].ID != "live" {
+		t.Fatalf("expected trashed cards hidden from FindByOwner, got %+v", owned)
+	}
+	if due, _ := repo.FindDue("user-1", now); len(due) != 1 {
+		t.Fatalf("expected trashed cards hidden from FindDue, got %+v", due)
+	}
+	if hits, _ := repo.Search("user-1", "Hund", 10); len(hits) != 1 {
+		t.Fatalf("expected trashed cards hidden from Search, got %+v", hits)
+	}
+
+	trash, _ := repo.FindTrashed("user-1")
+	if len(trash) != 2 || trash[0].ID != "recent" || trash[1].ID != "old" {
+		t.Fatalf("expected the trash most recently deleted first, got %+v", trash)
+	}
+	if _, err := repo.FindTrashedByID("live"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for a card outside the trash, got %v", err)
+	}
+	if expired, _ := repo.FindTrashedBefore(now.Add(30 * time.Minute)); len(expired) != 1 || expired[0].ID != "old" {
+		t.Fatalf("expected only the card deleted before the cutoff, got %+v", expired)
+	}
+}
+
 func TestMemoryRepositoryNotFound(t *testing.T) {
 	repo := NewMemoryRepository()
 
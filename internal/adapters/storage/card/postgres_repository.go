@@ -5,10 +5,21 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"flash2fy/internal/app/domain/card"
 )
 
+// typeMap scans PostgreSQL arrays, which database/sql cannot do on its own.
+var typeMap = pgtype.NewMap()
+
+const cardColumns = `id, front, back, owner_id, created_at, updated_at,
+		ease_factor, stability, difficulty, box, interval_days, repetitions, lapses, due_at, reviewed_at,
+		suspended, leech, buried_until, card_type, cloze_index, note_id, reversed, deck_id, tags, deleted_at`
+
 // PostgresRepository persists cards in a PostgreSQL database.
 type PostgresRepository struct {
 	db *sql.DB
@@ -20,10 +31,16 @@ func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 
 func (r *PostgresRepository) Save(c card.Card) (card.Card, error) {
 	const query = `
-		INSERT INTO cards (id, front, back, owner_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+		INSERT INTO cards (` + cardColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)`
 
-	if _, err := r.db.ExecContext(context.Background(), query, c.ID, c.Front, c.Back, c.OwnerID, c.CreatedAt, c.UpdatedAt); err != nil {
+	if _, err := r.db.ExecContext(context.Background(), query,
+		c.ID, c.Front, c.Back, c.OwnerID, c.CreatedAt, c.UpdatedAt,
+		c.Review.EaseFactor, c.Review.Stability, c.Review.Difficulty, c.Review.Box, c.Review.Interval, c.Review.Repetitions,
+		c.Review.Lapses, c.Review.DueAt, nullTime(c.Review.ReviewedAt),
+		c.Suspended, c.Leech, nullTime(c.BuriedUntil), c.Kind(), c.ClozeIndex, nullString(c.NoteID), c.Reversed, nullString(c.DeckID), tagArray(c.Tags),
+		nullTime(c.DeletedAt),
+	); err != nil {
 		return card.Card{}, fmt.Errorf("insert card: %w", err)
 	}
 
@@ -32,12 +49,11 @@ func (r *PostgresRepository) Save(c card.Card) (card.Card, error) {
 
 func (r *PostgresRepository) FindByID(id string) (card.Card, error) {
 	const query = `
-		SELECT id, front, back, owner_id, created_at, updated_at
+		SELECT ` + cardColumns + `
 		FROM cards
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
-	var c card.Card
-	err := r.db.QueryRowContext(context.Background(), query, id).Scan(&c.ID, &c.Front, &c.Back, &c.OwnerID, &c.CreatedAt, &c.UpdatedAt)
+	c, err := scanCard(r.db.QueryRowContext(context.Background(), query, id))
 	if errors.Is(err, sql.ErrNoRows) {
 		return card.Card{}, card.ErrNotFound
 	}
@@ -50,39 +66,257 @@ func (r *PostgresRepository) FindByID(id string) (card.Card, error) {
 
 func (r *PostgresRepository) FindAll() ([]card.Card, error) {
 	const query = `
-		SELECT id, front, back, owner_id, created_at, updated_at
+		SELECT ` + cardColumns + `
 		FROM cards
+		WHERE deleted_at IS NULL
 		ORDER BY created_at ASC`
 
-	rows, err := r.db.QueryContext(context.Background(), query)
+	cards, err := r.query(query)
 	if err != nil {
 		return nil, fmt.Errorf("list cards: %w", err)
 	}
+	return cards, nil
+}
+
+func (r *PostgresRepository) FindByOwner(ownerID string) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE owner_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC`
+
+	cards, err := r.query(query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list cards by owner: %w", err)
+	}
+	return cards, nil
+}
+
+// FindDue returns the owner's available cards due at now, the most overdue first.
+func (r *PostgresRepository) FindDue(ownerID string, now time.Time) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE owner_id = $1 AND deleted_at IS NULL AND due_at <= $2
+			AND NOT suspended AND (buried_until IS NULL OR buried_until <= $2)
+		ORDER BY due_at ASC, created_at ASC, id ASC`
+
+	cards, err := r.query(query, ownerID, now)
+	if err != nil {
+		return nil, fmt.Errorf("list due cards: %w", err)
+	}
+	return cards, nil
+}
+
+// FindTagged returns the cards matching the tag filter, oldest first.
+func (r *PostgresRepository) FindTagged(filter card.TagFilter) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE deleted_at IS NULL AND tags @> $1 AND NOT tags && $2
+		ORDER BY created_at ASC`
+
+	cards, err := r.query(query, tagArray(filter.Include), tagArray(filter.Exclude))
+	if err != nil {
+		return nil, fmt.Errorf("list cards by tags: %w", err)
+	}
+	return cards, nil
+}
+
+// Search returns up to limit cards containing every word of the query, ranked by
+// ts_rank over the search_vector column.
+func (r *PostgresRepository) Search(ownerID, query string, limit int) ([]card.SearchHit, error) {
+	const statement = `
+		SELECT ` + cardColumns + `,
+			ts_rank(search_vector, q) AS rank,
+			ts_headline('simple', front || ' ' || back, q, $3)
+		FROM cards, plainto_tsquery('simple', $1) AS q
+		WHERE search_vector @@ q AND deleted_at IS NULL AND owner_id = $2
+		ORDER BY rank DESC, created_at ASC
+		LIMIT $4`
+
+	options := fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=%d, MinWords=5`,
+		card.HighlightStart, card.HighlightEnd, snippetWords)
+	rows, err := r.db.QueryContext(context.Background(), statement, query, ownerID, options, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search cards: %w", err)
+	}
 	defer rows.Close()
 
-	var cards []card.Card
+	var hits []card.SearchHit
 	for rows.Next() {
-		var c card.Card
-		if err := rows.Scan(&c.ID, &c.Front, &c.Back, &c.OwnerID, &c.CreatedAt, &c.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("scan card: %w", err)
+		var hit card.SearchHit
+		hit.Card, err = scanCard(rows, &hit.Rank, &hit.Snippet)
+		if err != nil {
+			return nil, fmt.Errorf("scan search hit: %w", err)
 		}
-		cards = append(cards, c)
+		hits = append(hits, hit)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate cards: %w", err)
+		return nil, fmt.Errorf("iterate search hits: %w", err)
+	}
+
+	return hits, nil
+}
+
+// FindMatching returns the cards meeting the criteria, oldest first.
+func (r *PostgresRepository) FindMatching(criteria card.Criteria) ([]card.Card, error) {
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"owner_id = " + arg(criteria.OwnerID), "deleted_at IS NULL"}
+	if criteria.DeckIDs != nil {
+		conditions = append(conditions, "deck_id = ANY("+arg(criteria.DeckIDs)+")")
+	}
+	if len(criteria.Tags.Include) > 0 {
+		conditions = append(conditions, "tags @> "+arg(criteria.Tags.Include))
+	}
+	if len(criteria.Tags.Exclude) > 0 {
+		conditions = append(conditions, "NOT tags && "+arg(criteria.Tags.Exclude))
+	}
+	if len(criteria.Words) > 0 {
+		conditions = append(conditions, "search_vector @@ plainto_tsquery('simple', "+arg(strings.Join(criteria.Words, " "))+")")
+	}
+	if !criteria.AddedAfter.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(criteria.AddedAfter))
+	}
+	if len(criteria.States) > 0 || len(criteria.NotStates) > 0 {
+		now := arg(criteria.Now)
+		for _, state := range criteria.States {
+			conditions = append(conditions, stateCondition(state, now))
+		}
+		for _, state := range criteria.NotStates {
+			conditions = append(conditions, "NOT "+stateCondition(state, now))
+		}
 	}
 
+	query := `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY created_at ASC`
+
+	cards, err := r.query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list matching cards: %w", err)
+	}
+	return cards, nil
+}
+
+// stateCondition mirrors card.Card.InState in SQL; now is the placeholder of the current time.
+func stateCondition(state card.State, now string) string {
+	switch state {
+	case card.StateDue:
+		return "(reviewed_at IS NOT NULL AND due_at <= " + now + " AND NOT suspended AND (buried_until IS NULL OR buried_until <= " + now + "))"
+	case card.StateNew:
+		return "(reviewed_at IS NULL)"
+	case card.StateSuspended:
+		return "(suspended)"
+	case card.StateLeech:
+		return "(leech)"
+	}
+	return "(FALSE)"
+}
+
+// FindByNote returns the cards generated from the note, oldest first,
+// forward before reversed and in cloze index order.
+func (r *PostgresRepository) FindByNote(noteID string) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE note_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC, reversed ASC, cloze_index ASC`
+
+	cards, err := r.query(query, noteID)
+	if err != nil {
+		return nil, fmt.Errorf("list cards by note: %w", err)
+	}
+	return cards, nil
+}
+
+// FindByDeck returns the cards in the deck, oldest first.
+func (r *PostgresRepository) FindByDeck(deckID string) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE deck_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at ASC`
+
+	cards, err := r.query(query, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("list cards by deck: %w", err)
+	}
+	return cards, nil
+}
+
+// FindTrashed returns the owner's cards in the trash, the most recently deleted first.
+func (r *PostgresRepository) FindTrashed(ownerID string) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE owner_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC`
+
+	cards, err := r.query(query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list trashed cards: %w", err)
+	}
+	return cards, nil
+}
+
+func (r *PostgresRepository) FindTrashedByID(id string) (card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	c, err := scanCard(r.db.QueryRowContext(context.Background(), query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return card.Card{}, card.ErrNotFound
+	}
+	if err != nil {
+		return card.Card{}, fmt.Errorf("find trashed card by id: %w", err)
+	}
+
+	return c, nil
+}
+
+// FindTrashedBefore returns the cards of every owner deleted before t, the oldest deletion first.
+func (r *PostgresRepository) FindTrashedBefore(t time.Time) ([]card.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM cards
+		WHERE deleted_at < $1
+		ORDER BY deleted_at ASC`
+
+	cards, err := r.query(query, t)
+	if err != nil {
+		return nil, fmt.Errorf("list expired trashed cards: %w", err)
+	}
 	return cards, nil
 }
 
 func (r *PostgresRepository) Update(c card.Card) (card.Card, error) {
 	const query = `
 		UPDATE cards
-		SET front = $1, back = $2, owner_id = $3, updated_at = $4
-		WHERE id = $5`
+		SET front = $1, back = $2, owner_id = $3, updated_at = $4,
+			ease_factor = $5, stability = $6, difficulty = $7, box = $8, interval_days = $9, repetitions = $10,
+			lapses = $11, due_at = $12, reviewed_at = $13,
+			suspended = $14, leech = $15, buried_until = $16, card_type = $17, cloze_index = $18,
+			note_id = $19, reversed = $20, deck_id = $21, tags = $22, deleted_at = $23
+		WHERE id = $24`
 
-	res, err := r.db.ExecContext(context.Background(), query, c.Front, c.Back, c.OwnerID, c.UpdatedAt, c.ID)
+	res, err := r.db.ExecContext(context.Background(), query,
+		c.Front, c.Back, c.OwnerID, c.UpdatedAt,
+		c.Review.EaseFactor, c.Review.Stability, c.Review.Difficulty, c.Review.Box, c.Review.Interval, c.Review.Repetitions,
+		c.Review.Lapses, c.Review.DueAt, nullTime(c.Review.ReviewedAt),
+		c.Suspended, c.Leech, nullTime(c.BuriedUntil), c.Kind(), c.ClozeIndex, nullString(c.NoteID), c.Reversed, nullString(c.DeckID), tagArray(c.Tags),
+		nullTime(c.DeletedAt), c.ID,
+	)
 	if err != nil {
 		return card.Card{}, fmt.Errorf("update card: %w", err)
 	}
@@ -115,3 +349,75 @@ func (r *PostgresRepository) Delete(id string) error {
 	}
 	return nil
 }
+
+func (r *PostgresRepository) query(query string, args ...any) ([]card.Card, error) {
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cards []card.Card
+	for rows.Next() {
+		c, err := scanCard(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan card: %w", err)
+		}
+		cards = append(cards, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanCard reads the cardColumns of a row; extra receives any columns selected after them.
+func scanCard(row rowScanner, extra ...any) (card.Card, error) {
+	var (
+		c           card.Card
+		reviewedAt  sql.NullTime
+		buriedUntil sql.NullTime
+		noteID      sql.NullString
+		deckID      sql.NullString
+		deletedAt   sql.NullTime
+	)
+	dest := []any{
+		&c.ID, &c.Front, &c.Back, &c.OwnerID, &c.CreatedAt, &c.UpdatedAt,
+		&c.Review.EaseFactor, &c.Review.Stability, &c.Review.Difficulty, &c.Review.Box, &c.Review.Interval, &c.Review.Repetitions,
+		&c.Review.Lapses, &c.Review.DueAt, &reviewedAt,
+		&c.Suspended, &c.Leech, &buriedUntil, &c.Type, &c.ClozeIndex, &noteID, &c.Reversed, &deckID, typeMap.SQLScanner(&c.Tags),
+		&deletedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return card.Card{}, err
+	}
+	c.Review.ReviewedAt = reviewedAt.Time
+	c.BuriedUntil = buriedUntil.Time
+	c.NoteID = noteID.String
+	c.DeckID = deckID.String
+	c.DeletedAt = deletedAt.Time
+
+	return c, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// tagArray stores cards without tags as an empty array rather than NULL.
+func tagArray(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,78 @@
+package catalogstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+type linkKey struct {
+	deckID       string
+	sourceCardID string
+}
+
+// MemoryLinkRepository persists the card links of subscribed decks in memory; suitable for tests and demos.
+type MemoryLinkRepository struct {
+	mu    sync.RWMutex
+	store map[linkKey]catalog.Link
+}
+
+func NewMemoryLinkRepository() *MemoryLinkRepository {
+	return &MemoryLinkRepository{
+		store: make(map[linkKey]catalog.Link),
+	}
+}
+
+func (r *MemoryLinkRepository) Save(l catalog.Link) (catalog.Link, error) {
+	if err := l.Validate(); err != nil {
+		return catalog.Link{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[linkKey{deckID: l.DeckID, sourceCardID: l.SourceCardID}] = l
+	return l, nil
+}
+
+// FindByDeck returns the links of the subscribed deck ordered by source card.
+func (r *MemoryLinkRepository) FindByDeck(deckID string) ([]catalog.Link, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var links []catalog.Link
+	for _, l := range r.store {
+		if l.DeckID == deckID {
+			links = append(links, l)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].SourceCardID < links[j].SourceCardID
+	})
+	return links, nil
+}
+
+func (r *MemoryLinkRepository) Delete(deckID, sourceCardID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := linkKey{deckID: deckID, sourceCardID: sourceCardID}
+	if _, ok := r.store[key]; !ok {
+		return catalog.ErrLinkNotFound
+	}
+	delete(r.store, key)
+	return nil
+}
+
+func (r *MemoryLinkRepository) DeleteByDeck(deckID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.store {
+		if key.deckID == deckID {
+			delete(r.store, key)
+		}
+	}
+	return nil
+}
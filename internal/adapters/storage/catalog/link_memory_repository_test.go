@@ -0,0 +1,47 @@
+package catalogstorage
+
+import (
+	"testing"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+func TestMemoryLinkRepositoryUpsertsPerSourceCard(t *testing.T) {
+	repo := NewMemoryLinkRepository()
+
+	if _, err := repo.Save(catalog.Link{DeckID: "copy-1", SourceCardID: "src-1"}); err != catalog.ErrEmptyLink {
+		t.Fatalf("expected ErrEmptyLink, got %v", err)
+	}
+	for _, l := range []catalog.Link{
+		{DeckID: "copy-1", CardID: "card-2", SourceCardID: "src-2", Front: "laufen"},
+		{DeckID: "copy-1", CardID: "card-1", SourceCardID: "src-1", Front: "der Hund"},
+		{DeckID: "copy-2", CardID: "card-3", SourceCardID: "src-1", Front: "der Hund"},
+		{DeckID: "copy-1", CardID: "card-1", SourceCardID: "src-1", Front: "der Hund", Conflict: true, UpstreamFront: "Hund"},
+	} {
+		if _, err := repo.Save(l); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	links, _ := repo.FindByDeck("copy-1")
+	if len(links) != 2 || links[0].SourceCardID != "src-1" || !links[0].Conflict {
+		t.Fatalf("expected the replaced link first, got %+v", links)
+	}
+
+	if err := repo.Delete("copy-1", "src-2"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := repo.Delete("copy-1", "src-2"); err != catalog.ErrLinkNotFound {
+		t.Fatalf("expected ErrLinkNotFound, got %v", err)
+	}
+
+	if err := repo.DeleteByDeck("copy-1"); err != nil {
+		t.Fatalf("delete by deck failed: %v", err)
+	}
+	if links, _ := repo.FindByDeck("copy-1"); len(links) != 0 {
+		t.Fatalf("expected no links left, got %+v", links)
+	}
+	if links, _ := repo.FindByDeck("copy-2"); len(links) != 1 {
+		t.Fatalf("expected other decks untouched, got %+v", links)
+	}
+}
@@ -0,0 +1,101 @@
+package catalogstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+const linkColumns = `deck_id, card_id, source_card_id, front, back, conflict, upstream_front, upstream_back`
+
+// PostgresLinkRepository persists the card links of subscribed decks in PostgreSQL.
+type PostgresLinkRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresLinkRepository(db *sql.DB) *PostgresLinkRepository {
+	return &PostgresLinkRepository{db: db}
+}
+
+func (r *PostgresLinkRepository) Save(l catalog.Link) (catalog.Link, error) {
+	if err := l.Validate(); err != nil {
+		return catalog.Link{}, err
+	}
+
+	const query = `
+		INSERT INTO catalog_subscription_cards (` + linkColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (deck_id, source_card_id) DO UPDATE
+		SET card_id = EXCLUDED.card_id, front = EXCLUDED.front, back = EXCLUDED.back, conflict = EXCLUDED.conflict,
+			upstream_front = EXCLUDED.upstream_front, upstream_back = EXCLUDED.upstream_back`
+
+	_, err := r.db.ExecContext(context.Background(), query,
+		l.DeckID, l.CardID, l.SourceCardID, l.Front, l.Back, l.Conflict, l.UpstreamFront, l.UpstreamBack,
+	)
+	if err != nil {
+		return catalog.Link{}, fmt.Errorf("upsert card link: %w", err)
+	}
+
+	return l, nil
+}
+
+func (r *PostgresLinkRepository) FindByDeck(deckID string) ([]catalog.Link, error) {
+	const query = `
+		SELECT ` + linkColumns + `
+		FROM catalog_subscription_cards
+		WHERE deck_id = $1
+		ORDER BY source_card_id ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("list card links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []catalog.Link
+	for rows.Next() {
+		var l catalog.Link
+		if err := rows.Scan(&l.DeckID, &l.CardID, &l.SourceCardID, &l.Front, &l.Back, &l.Conflict, &l.UpstreamFront, &l.UpstreamBack); err != nil {
+			return nil, fmt.Errorf("scan card link: %w", err)
+		}
+		links = append(links, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate card links: %w", err)
+	}
+
+	return links, nil
+}
+
+func (r *PostgresLinkRepository) Delete(deckID, sourceCardID string) error {
+	const query = `
+		DELETE FROM catalog_subscription_cards
+		WHERE deck_id = $1 AND source_card_id = $2`
+
+	res, err := r.db.ExecContext(context.Background(), query, deckID, sourceCardID)
+	if err != nil {
+		return fmt.Errorf("delete card link: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete card link rows affected: %w", err)
+	}
+	if affected == 0 {
+		return catalog.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *PostgresLinkRepository) DeleteByDeck(deckID string) error {
+	const query = `
+		DELETE FROM catalog_subscription_cards
+		WHERE deck_id = $1`
+
+	if _, err := r.db.ExecContext(context.Background(), query, deckID); err != nil {
+		return fmt.Errorf("delete card links: %w", err)
+	}
+	return nil
+}
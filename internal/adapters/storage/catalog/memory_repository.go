@@ -0,0 +1,91 @@
+package catalogstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/catalog"
+)
+
+// MemoryRepository persists catalog listings in memory; suitable for tests and demos.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]catalog.Listing
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]catalog.Listing),
+	}
+}
+
+func (r *MemoryRepository) Save(l catalog.Listing) (catalog.Listing, error) {
+	if err := l.Validate(); err != nil {
+		return catalog.Listing{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[l.DeckID] = l
+	return l, nil
+}
+
+func (r *MemoryRepository) FindByDeck(deckID string) (catalog.Listing, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	l, ok := r.store[deckID]
+	if !ok {
+		return catalog.Listing{}, catalog.ErrNotFound
+	}
+	return l, nil
+}
+
+// Search returns the listings whose title and description contain every word of query,
+// limited to language unless it is empty, most recently published first.
+func (r *MemoryRepository) Search(query, language string) ([]catalog.Listing, error) {
+	terms := card.Tokenize(query)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var listings []catalog.Listing
+	for _, l := range r.store {
+		if language != "" && l.Language != language {
+			continue
+		}
+		if matchesAll(card.Tokenize(l.SearchText()), terms) {
+			listings = append(listings, l)
+		}
+	}
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].PublishedAt.After(listings[j].PublishedAt)
+	})
+	return listings, nil
+}
+
+func (r *MemoryRepository) Delete(deckID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[deckID]; !ok {
+		return catalog.ErrNotFound
+	}
+	delete(r.store, deckID)
+	return nil
+}
+
+func matchesAll(words, terms []string) bool {
+	present := make(map[string]bool, len(words))
+	for _, w := range words {
+		present[w] = true
+	}
+	for _, t := range terms {
+		if !present[t] {
+			return false
+		}
+	}
+	return true
+}
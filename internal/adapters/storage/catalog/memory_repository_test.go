@@ -0,0 +1,44 @@
+package catalogstorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+func TestMemoryRepositorySearch(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+
+	for _, l := range []catalog.Listing{
+		{DeckID: "deck-1", OwnerID: "user-1", Title: "German A1 vocabulary", Language: "de", PublishedAt: now},
+		{DeckID: "deck-2", OwnerID: "user-1", Title: "German verbs", Description: "Irregular verbs", Language: "de", PublishedAt: now.Add(time.Minute)},
+		{DeckID: "deck-3", OwnerID: "user-2", Title: "Spanish verbs", Language: "es", PublishedAt: now.Add(2 * time.Minute)},
+	} {
+		if _, err := repo.Save(l); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	all, _ := repo.Search("", "")
+	if len(all) != 3 || all[0].DeckID != "deck-3" {
+		t.Fatalf("expected every listing newest first, got %+v", all)
+	}
+	if verbs, _ := repo.Search("Verbs", ""); len(verbs) != 2 {
+		t.Fatalf("expected two verb decks, got %+v", verbs)
+	}
+	if german, _ := repo.Search("verbs", "de"); len(german) != 1 || german[0].DeckID != "deck-2" {
+		t.Fatalf("expected only the German verbs, got %+v", german)
+	}
+
+	if _, err := repo.Save(catalog.Listing{DeckID: "deck-4", Title: "Bad", Language: "german"}); err != catalog.ErrInvalidLanguage {
+		t.Fatalf("expected ErrInvalidLanguage, got %v", err)
+	}
+	if err := repo.Delete("deck-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByDeck("deck-1"); err != catalog.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
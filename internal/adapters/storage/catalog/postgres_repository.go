@@ -0,0 +1,122 @@
+package catalogstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+const listingColumns = `deck_id, owner_id, title, language, description, published_at, updated_at`
+
+// PostgresRepository persists catalog listings in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(l catalog.Listing) (catalog.Listing, error) {
+	if err := l.Validate(); err != nil {
+		return catalog.Listing{}, err
+	}
+
+	const query = `
+		INSERT INTO catalog_listings (` + listingColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (deck_id) DO UPDATE
+		SET title = EXCLUDED.title, language = EXCLUDED.language,
+			description = EXCLUDED.description, updated_at = EXCLUDED.updated_at`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		l.DeckID, l.OwnerID, l.Title, l.Language, l.Description, l.PublishedAt, l.UpdatedAt,
+	); err != nil {
+		return catalog.Listing{}, fmt.Errorf("upsert catalog listing: %w", err)
+	}
+
+	return l, nil
+}
+
+func (r *PostgresRepository) FindByDeck(deckID string) (catalog.Listing, error) {
+	const query = `
+		SELECT ` + listingColumns + `
+		FROM catalog_listings
+		WHERE deck_id = $1`
+
+	l, err := scanListing(r.db.QueryRowContext(context.Background(), query, deckID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return catalog.Listing{}, catalog.ErrNotFound
+	}
+	if err != nil {
+		return catalog.Listing{}, fmt.Errorf("find catalog listing: %w", err)
+	}
+
+	return l, nil
+}
+
+// Search matches the words of query against the title and description with the same
+// language-neutral 'simple' configuration used for card search.
+func (r *PostgresRepository) Search(query, language string) ([]catalog.Listing, error) {
+	const statement = `
+		SELECT ` + listingColumns + `
+		FROM catalog_listings
+		WHERE ($1 = '' OR to_tsvector('simple', title || ' ' || description) @@ plainto_tsquery('simple', $1))
+		  AND ($2 = '' OR language = $2)
+		ORDER BY published_at DESC`
+
+	rows, err := r.db.QueryContext(context.Background(), statement, query, language)
+	if err != nil {
+		return nil, fmt.Errorf("search catalog: %w", err)
+	}
+	defer rows.Close()
+
+	var listings []catalog.Listing
+	for rows.Next() {
+		l, err := scanListing(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan catalog listing: %w", err)
+		}
+		listings = append(listings, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate catalog listings: %w", err)
+	}
+
+	return listings, nil
+}
+
+func (r *PostgresRepository) Delete(deckID string) error {
+	const query = `
+		DELETE FROM catalog_listings
+		WHERE deck_id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, deckID)
+	if err != nil {
+		return fmt.Errorf("delete catalog listing: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete catalog listing rows affected: %w", err)
+	}
+	if affected == 0 {
+		return catalog.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanListing(row rowScanner) (catalog.Listing, error) {
+	var l catalog.Listing
+	if err := row.Scan(&l.DeckID, &l.OwnerID, &l.Title, &l.Language, &l.Description, &l.PublishedAt, &l.UpdatedAt); err != nil {
+		return catalog.Listing{}, err
+	}
+	return l, nil
+}
@@ -0,0 +1,71 @@
+package catalogstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+// MemorySubscriptionRepository persists deck subscriptions in memory; suitable for tests and demos.
+type MemorySubscriptionRepository struct {
+	mu    sync.RWMutex
+	store map[string]catalog.Subscription
+}
+
+func NewMemorySubscriptionRepository() *MemorySubscriptionRepository {
+	return &MemorySubscriptionRepository{
+		store: make(map[string]catalog.Subscription),
+	}
+}
+
+func (r *MemorySubscriptionRepository) Save(s catalog.Subscription) (catalog.Subscription, error) {
+	if err := s.Validate(); err != nil {
+		return catalog.Subscription{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[s.DeckID] = s
+	return s, nil
+}
+
+func (r *MemorySubscriptionRepository) FindByDeck(deckID string) (catalog.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.store[deckID]
+	if !ok {
+		return catalog.Subscription{}, catalog.ErrSubscriptionNotFound
+	}
+	return s, nil
+}
+
+// FindByUser returns the subscriptions of the user, oldest first.
+func (r *MemorySubscriptionRepository) FindByUser(userID string) ([]catalog.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var subscriptions []catalog.Subscription
+	for _, s := range r.store {
+		if s.UserID == userID {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].CreatedAt.Before(subscriptions[j].CreatedAt)
+	})
+	return subscriptions, nil
+}
+
+func (r *MemorySubscriptionRepository) Delete(deckID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[deckID]; !ok {
+		return catalog.ErrSubscriptionNotFound
+	}
+	delete(r.store, deckID)
+	return nil
+}
@@ -0,0 +1,104 @@
+package catalogstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/catalog"
+)
+
+const subscriptionColumns = `deck_id, source_id, user_id, created_at, synced_at`
+
+// PostgresSubscriptionRepository persists deck subscriptions in PostgreSQL.
+type PostgresSubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresSubscriptionRepository(db *sql.DB) *PostgresSubscriptionRepository {
+	return &PostgresSubscriptionRepository{db: db}
+}
+
+func (r *PostgresSubscriptionRepository) Save(s catalog.Subscription) (catalog.Subscription, error) {
+	if err := s.Validate(); err != nil {
+		return catalog.Subscription{}, err
+	}
+
+	const query = `
+		INSERT INTO catalog_subscriptions (` + subscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (deck_id) DO UPDATE SET synced_at = EXCLUDED.synced_at`
+
+	if _, err := r.db.ExecContext(context.Background(), query, s.DeckID, s.SourceID, s.UserID, s.CreatedAt, s.SyncedAt); err != nil {
+		return catalog.Subscription{}, fmt.Errorf("upsert subscription: %w", err)
+	}
+
+	return s, nil
+}
+
+func (r *PostgresSubscriptionRepository) FindByDeck(deckID string) (catalog.Subscription, error) {
+	const query = `
+		SELECT ` + subscriptionColumns + `
+		FROM catalog_subscriptions
+		WHERE deck_id = $1`
+
+	var s catalog.Subscription
+	err := r.db.QueryRowContext(context.Background(), query, deckID).
+		Scan(&s.DeckID, &s.SourceID, &s.UserID, &s.CreatedAt, &s.SyncedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return catalog.Subscription{}, catalog.ErrSubscriptionNotFound
+		}
+		return catalog.Subscription{}, fmt.Errorf("select subscription: %w", err)
+	}
+	return s, nil
+}
+
+func (r *PostgresSubscriptionRepository) FindByUser(userID string) ([]catalog.Subscription, error) {
+	const query = `
+		SELECT ` + subscriptionColumns + `
+		FROM catalog_subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	var subscriptions []catalog.Subscription
+	for rows.Next() {
+		var s catalog.Subscription
+		if err := rows.Scan(&s.DeckID, &s.SourceID, &s.UserID, &s.CreatedAt, &s.SyncedAt); err != nil {
+			return nil, fmt.Errorf("scan subscription: %w", err)
+		}
+		subscriptions = append(subscriptions, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *PostgresSubscriptionRepository) Delete(deckID string) error {
+	const query = `
+		DELETE FROM catalog_subscriptions
+		WHERE deck_id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, deckID)
+	if err != nil {
+		return fmt.Errorf("delete subscription: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete subscription rows affected: %w", err)
+	}
+	if affected == 0 {
+		return catalog.ErrSubscriptionNotFound
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package deckstorage
+
+import (
+	"sync"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+// MemoryInviteRepository persists pending deck invites in memory; suitable for tests and demos.
+type MemoryInviteRepository struct {
+	mu    sync.RWMutex
+	store map[string]deck.Invite
+}
+
+func NewMemoryInviteRepository() *MemoryInviteRepository {
+	return &MemoryInviteRepository{
+		store: make(map[string]deck.Invite),
+	}
+}
+
+func (r *MemoryInviteRepository) Save(i deck.Invite) (deck.Invite, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[i.Token] = i
+	return i, nil
+}
+
+func (r *MemoryInviteRepository) FindByToken(token string) (deck.Invite, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i, ok := r.store[token]
+	if !ok {
+		return deck.Invite{}, deck.ErrInviteNotFound
+	}
+	return i, nil
+}
+
+func (r *MemoryInviteRepository) Delete(token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[token]; !ok {
+		return deck.ErrInviteNotFound
+	}
+	delete(r.store, token)
+	return nil
+}
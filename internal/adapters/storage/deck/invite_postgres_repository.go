@@ -0,0 +1,78 @@
+package deckstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+const inviteColumns = `token, deck_id, role, created_by, created_at, expires_at`
+
+// PostgresInviteRepository persists pending deck invites in PostgreSQL.
+type PostgresInviteRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresInviteRepository(db *sql.DB) *PostgresInviteRepository {
+	return &PostgresInviteRepository{db: db}
+}
+
+func (r *PostgresInviteRepository) Save(i deck.Invite) (deck.Invite, error) {
+	const query = `
+		INSERT INTO deck_invites (` + inviteColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		i.Token, i.DeckID, string(i.Role), i.CreatedBy, i.CreatedAt, i.ExpiresAt,
+	); err != nil {
+		return deck.Invite{}, fmt.Errorf("insert deck invite: %w", err)
+	}
+
+	return i, nil
+}
+
+func (r *PostgresInviteRepository) FindByToken(token string) (deck.Invite, error) {
+	const query = `
+		SELECT ` + inviteColumns + `
+		FROM deck_invites
+		WHERE token = $1`
+
+	var (
+		i    deck.Invite
+		role string
+	)
+	err := r.db.QueryRowContext(context.Background(), query, token).Scan(
+		&i.Token, &i.DeckID, &role, &i.CreatedBy, &i.CreatedAt, &i.ExpiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return deck.Invite{}, deck.ErrInviteNotFound
+	}
+	if err != nil {
+		return deck.Invite{}, fmt.Errorf("find deck invite: %w", err)
+	}
+
+	i.Role = deck.Role(role)
+	return i, nil
+}
+
+func (r *PostgresInviteRepository) Delete(token string) error {
+	const query = `
+		DELETE FROM deck_invites
+		WHERE token = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, token)
+	if err != nil {
+		return fmt.Errorf("delete deck invite: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete deck invite rows affected: %w", err)
+	}
+	if affected == 0 {
+		return deck.ErrInviteNotFound
+	}
+	return nil
+}
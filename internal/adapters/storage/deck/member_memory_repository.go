@@ -0,0 +1,75 @@
+package deckstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+type memberKey struct {
+	deckID string
+	userID string
+}
+
+// MemoryMemberRepository persists deck memberships in memory; suitable for tests and demos.
+type MemoryMemberRepository struct {
+	mu    sync.RWMutex
+	store map[memberKey]deck.Member
+}
+
+func NewMemoryMemberRepository() *MemoryMemberRepository {
+	return &MemoryMemberRepository{
+		store: make(map[memberKey]deck.Member),
+	}
+}
+
+func (r *MemoryMemberRepository) Save(m deck.Member) (deck.Member, error) {
+	if err := m.Validate(); err != nil {
+		return deck.Member{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[memberKey{deckID: m.DeckID, userID: m.UserID}] = m
+	return m, nil
+}
+
+// FindByDeck returns the members of the deck, oldest first.
+func (r *MemoryMemberRepository) FindByDeck(deckID string) ([]deck.Member, error) {
+	return r.find(func(m deck.Member) bool { return m.DeckID == deckID }), nil
+}
+
+// FindByUser returns the memberships of the user, oldest first.
+func (r *MemoryMemberRepository) FindByUser(userID string) ([]deck.Member, error) {
+	return r.find(func(m deck.Member) bool { return m.UserID == userID }), nil
+}
+
+func (r *MemoryMemberRepository) Delete(deckID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{deckID: deckID, userID: userID}
+	if _, ok := r.store[key]; !ok {
+		return deck.ErrMemberNotFound
+	}
+	delete(r.store, key)
+	return nil
+}
+
+func (r *MemoryMemberRepository) find(match func(deck.Member) bool) []deck.Member {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var members []deck.Member
+	for _, m := range r.store {
+		if match(m) {
+			members = append(members, m)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].CreatedAt.Before(members[j].CreatedAt)
+	})
+	return members
+}
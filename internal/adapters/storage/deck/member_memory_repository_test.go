@@ -0,0 +1,60 @@
+package deckstorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+func TestMemoryMemberRepository(t *testing.T) {
+	repo := NewMemoryMemberRepository()
+	now := time.Now().UTC()
+
+	if _, err := repo.Save(deck.Member{DeckID: "deck-1", UserID: "user-2", Role: deck.RoleViewer, CreatedAt: now}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := repo.Save(deck.Member{DeckID: "deck-1", UserID: "user-2", Role: deck.RoleEditor, CreatedAt: now}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := repo.Save(deck.Member{DeckID: "deck-2", UserID: "user-2", Role: deck.RoleViewer, CreatedAt: now.Add(time.Second)}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	members, err := repo.FindByDeck("deck-1")
+	if err != nil || len(members) != 1 || members[0].Role != deck.RoleEditor {
+		t.Fatalf("expected the role to be replaced, got %+v (%v)", members, err)
+	}
+	if memberships, _ := repo.FindByUser("user-2"); len(memberships) != 2 || memberships[0].DeckID != "deck-1" {
+		t.Fatalf("expected both memberships oldest first, got %+v", memberships)
+	}
+
+	if _, err := repo.Save(deck.Member{DeckID: "deck-1", UserID: "user-3", Role: deck.RoleOwner}); err != deck.ErrInvalidRole {
+		t.Fatalf("expected ErrInvalidRole, got %v", err)
+	}
+
+	if err := repo.Delete("deck-1", "user-2"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := repo.Delete("deck-1", "user-2"); err != deck.ErrMemberNotFound {
+		t.Fatalf("expected ErrMemberNotFound, got %v", err)
+	}
+}
+
+func TestMemoryInviteRepository(t *testing.T) {
+	repo := NewMemoryInviteRepository()
+	invite := deck.Invite{Token: "token-1", DeckID: "deck-1", Role: deck.RoleViewer, CreatedBy: "user-1"}
+
+	if _, err := repo.Save(invite); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if found, err := repo.FindByToken("token-1"); err != nil || found.DeckID != "deck-1" {
+		t.Fatalf("expected the invite, got %+v (%v)", found, err)
+	}
+	if err := repo.Delete("token-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByToken("token-1"); err != deck.ErrInviteNotFound {
+		t.Fatalf("expected ErrInviteNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package deckstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+const memberColumns = `deck_id, user_id, role, created_at`
+
+// PostgresMemberRepository persists deck memberships in PostgreSQL.
+type PostgresMemberRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresMemberRepository(db *sql.DB) *PostgresMemberRepository {
+	return &PostgresMemberRepository{db: db}
+}
+
+func (r *PostgresMemberRepository) Save(m deck.Member) (deck.Member, error) {
+	if err := m.Validate(); err != nil {
+		return deck.Member{}, err
+	}
+
+	const query = `
+		INSERT INTO deck_members (` + memberColumns + `)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (deck_id, user_id) DO UPDATE SET role = EXCLUDED.role`
+
+	if _, err := r.db.ExecContext(context.Background(), query, m.DeckID, m.UserID, string(m.Role), m.CreatedAt); err != nil {
+		return deck.Member{}, fmt.Errorf("upsert deck member: %w", err)
+	}
+
+	return m, nil
+}
+
+func (r *PostgresMemberRepository) FindByDeck(deckID string) ([]deck.Member, error) {
+	const query = `
+		SELECT ` + memberColumns + `
+		FROM deck_members
+		WHERE deck_id = $1
+		ORDER BY created_at ASC`
+
+	return r.query(query, deckID)
+}
+
+func (r *PostgresMemberRepository) FindByUser(userID string) ([]deck.Member, error) {
+	const query = `
+		SELECT ` + memberColumns + `
+		FROM deck_members
+		WHERE user_id = $1
+		ORDER BY created_at ASC`
+
+	return r.query(query, userID)
+}
+
+func (r *PostgresMemberRepository) Delete(deckID, userID string) error {
+	const query = `
+		DELETE FROM deck_members
+		WHERE deck_id = $1 AND user_id = $2`
+
+	res, err := r.db.ExecContext(context.Background(), query, deckID, userID)
+	if err != nil {
+		return fmt.Errorf("delete deck member: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete deck member rows affected: %w", err)
+	}
+	if affected == 0 {
+		return deck.ErrMemberNotFound
+	}
+	return nil
+}
+
+func (r *PostgresMemberRepository) query(query string, args ...any) ([]deck.Member, error) {
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list deck members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []deck.Member
+	for rows.Next() {
+		var (
+			m    deck.Member
+			role string
+		)
+		if err := rows.Scan(&m.DeckID, &m.UserID, &role, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan deck member: %w", err)
+		}
+		m.Role = deck.Role(role)
+		members = append(members, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deck members: %w", err)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,86 @@
+package deckstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+// MemoryRepository persists decks in memory; suitable for tests and demos.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]deck.Deck
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]deck.Deck),
+	}
+}
+
+func (r *MemoryRepository) Save(d deck.Deck) (deck.Deck, error) {
+	if err := d.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[d.ID] = d
+	return d, nil
+}
+
+func (r *MemoryRepository) FindByID(id string) (deck.Deck, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	d, ok := r.store[id]
+	if !ok {
+		return deck.Deck{}, deck.ErrNotFound
+	}
+	return d, nil
+}
+
+// FindByOwner returns the owner's decks, oldest first.
+func (r *MemoryRepository) FindByOwner(ownerID string) ([]deck.Deck, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var decks []deck.Deck
+	for _, d := range r.store {
+		if d.OwnerID == ownerID {
+			decks = append(decks, d)
+		}
+	}
+	sort.Slice(decks, func(i, j int) bool {
+		return decks[i].CreatedAt.Before(decks[j].CreatedAt)
+	})
+	return decks, nil
+}
+
+func (r *MemoryRepository) Update(d deck.Deck) (deck.Deck, error) {
+	if err := d.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[d.ID]; !ok {
+		return deck.Deck{}, deck.ErrNotFound
+	}
+	r.store[d.ID] = d
+	return d, nil
+}
+
+func (r *MemoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[id]; !ok {
+		return deck.ErrNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
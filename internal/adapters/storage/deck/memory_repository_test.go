@@ -0,0 +1,52 @@
+package deckstorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+func TestMemoryRepositoryCRUD(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+	d := deck.Deck{ID: "deck-1", Name: "German", OwnerID: "user-1", CreatedAt: now, UpdatedAt: now}
+
+	if _, err := repo.Save(d); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := repo.Save(deck.Deck{ID: "deck-2", Name: "French", OwnerID: "user-2", CreatedAt: now}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	owned, err := repo.FindByOwner("user-1")
+	if err != nil || len(owned) != 1 || owned[0].ID != "deck-1" {
+		t.Fatalf("expected only user-1 deck, got %+v (%v)", owned, err)
+	}
+
+	d.Description = "A1 vocabulary"
+	if _, err := repo.Update(d); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if found, _ := repo.FindByID("deck-1"); found.Description != "A1 vocabulary" {
+		t.Fatalf("expected updated description, got %q", found.Description)
+	}
+
+	if err := repo.Delete("deck-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByID("deck-1"); err != deck.ErrNotFound {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestMemoryRepositoryValidates(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	if _, err := repo.Save(deck.Deck{ID: "deck-1", OwnerID: "user-1"}); err != deck.ErrEmptyName {
+		t.Fatalf("expected ErrEmptyName, got %v", err)
+	}
+	if _, err := repo.Save(deck.Deck{ID: "deck-1", Name: "German"}); err != deck.ErrEmptyOwner {
+		t.Fatalf("expected ErrEmptyOwner, got %v", err)
+	}
+}
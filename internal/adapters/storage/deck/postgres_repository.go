@@ -0,0 +1,153 @@
+package deckstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+const deckColumns = `id, name, description, owner_id, parent_id, source_id, created_at, updated_at`
+
+// PostgresRepository persists decks in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(d deck.Deck) (deck.Deck, error) {
+	if err := d.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+
+	const query = `
+		INSERT INTO decks (` + deckColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		d.ID, d.Name, d.Description, d.OwnerID, nullString(d.ParentID), nullString(d.SourceID), d.CreatedAt, d.UpdatedAt,
+	); err != nil {
+		return deck.Deck{}, fmt.Errorf("insert deck: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *PostgresRepository) FindByID(id string) (deck.Deck, error) {
+	const query = `
+		SELECT ` + deckColumns + `
+		FROM decks
+		WHERE id = $1`
+
+	d, err := scanDeck(r.db.QueryRowContext(context.Background(), query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return deck.Deck{}, deck.ErrNotFound
+	}
+	if err != nil {
+		return deck.Deck{}, fmt.Errorf("find deck by id: %w", err)
+	}
+
+	return d, nil
+}
+
+func (r *PostgresRepository) FindByOwner(ownerID string) ([]deck.Deck, error) {
+	const query = `
+		SELECT ` + deckColumns + `
+		FROM decks
+		WHERE owner_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list decks by owner: %w", err)
+	}
+	defer rows.Close()
+
+	var decks []deck.Deck
+	for rows.Next() {
+		d, err := scanDeck(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan deck: %w", err)
+		}
+		decks = append(decks, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate decks: %w", err)
+	}
+
+	return decks, nil
+}
+
+func (r *PostgresRepository) Update(d deck.Deck) (deck.Deck, error) {
+	if err := d.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+
+	const query = `
+		UPDATE decks
+		SET name = $1, description = $2, owner_id = $3, parent_id = $4, source_id = $5, updated_at = $6
+		WHERE id = $7`
+
+	res, err := r.db.ExecContext(context.Background(), query,
+		d.Name, d.Description, d.OwnerID, nullString(d.ParentID), nullString(d.SourceID), d.UpdatedAt, d.ID,
+	)
+	if err != nil {
+		return deck.Deck{}, fmt.Errorf("update deck: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return deck.Deck{}, fmt.Errorf("update deck rows affected: %w", err)
+	}
+	if affected == 0 {
+		return deck.Deck{}, deck.ErrNotFound
+	}
+
+	return d, nil
+}
+
+func (r *PostgresRepository) Delete(id string) error {
+	const query = `
+		DELETE FROM decks
+		WHERE id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return fmt.Errorf("delete deck: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete deck rows affected: %w", err)
+	}
+	if affected == 0 {
+		return deck.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDeck(row rowScanner) (deck.Deck, error) {
+	var (
+		d        deck.Deck
+		parentID sql.NullString
+		sourceID sql.NullString
+	)
+	if err := row.Scan(&d.ID, &d.Name, &d.Description, &d.OwnerID, &parentID, &sourceID, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return deck.Deck{}, err
+	}
+	d.ParentID = parentID.String
+	d.SourceID = sourceID.String
+	return d, nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
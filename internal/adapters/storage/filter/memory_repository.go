@@ -0,0 +1,86 @@
+package filterstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/filter"
+)
+
+// MemoryRepository persists saved filtered decks in memory; suitable for tests and demos.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]filter.Filter
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]filter.Filter),
+	}
+}
+
+func (r *MemoryRepository) Save(f filter.Filter) (filter.Filter, error) {
+	if err := f.Validate(); err != nil {
+		return filter.Filter{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[f.ID] = f
+	return f, nil
+}
+
+func (r *MemoryRepository) FindByID(id string) (filter.Filter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	f, ok := r.store[id]
+	if !ok {
+		return filter.Filter{}, filter.ErrNotFound
+	}
+	return f, nil
+}
+
+// FindByOwner returns the owner's filtered decks, oldest first.
+func (r *MemoryRepository) FindByOwner(ownerID string) ([]filter.Filter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var filters []filter.Filter
+	for _, f := range r.store {
+		if f.OwnerID == ownerID {
+			filters = append(filters, f)
+		}
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		return filters[i].CreatedAt.Before(filters[j].CreatedAt)
+	})
+	return filters, nil
+}
+
+func (r *MemoryRepository) Update(f filter.Filter) (filter.Filter, error) {
+	if err := f.Validate(); err != nil {
+		return filter.Filter{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[f.ID]; !ok {
+		return filter.Filter{}, filter.ErrNotFound
+	}
+	r.store[f.ID] = f
+	return f, nil
+}
+
+func (r *MemoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[id]; !ok {
+		return filter.ErrNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
@@ -0,0 +1,49 @@
+package filterstorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/filter"
+)
+
+func TestMemoryRepositoryCRUD(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+	f := filter.Filter{ID: "filter-1", Name: "Hard grammar", Query: "tag:grammar tag:hard", OwnerID: "user-1", CreatedAt: now}
+
+	if _, err := repo.Save(f); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := repo.Save(filter.Filter{ID: "filter-2", Name: "Due", Query: "is:due", OwnerID: "user-2", CreatedAt: now}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	owned, err := repo.FindByOwner("user-1")
+	if err != nil || len(owned) != 1 || owned[0].ID != "filter-1" {
+		t.Fatalf("expected only user-1 filter, got %+v (%v)", owned, err)
+	}
+
+	f.Query = "tag:grammar"
+	if _, err := repo.Update(f); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if found, _ := repo.FindByID("filter-1"); found.Query != "tag:grammar" {
+		t.Fatalf("expected updated query, got %q", found.Query)
+	}
+
+	if err := repo.Delete("filter-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByID("filter-1"); err != filter.ErrNotFound {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestMemoryRepositoryValidates(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	if _, err := repo.Save(filter.Filter{ID: "filter-1", Name: "Empty", OwnerID: "user-1"}); err != filter.ErrEmptyQuery {
+		t.Fatalf("expected ErrEmptyQuery, got %v", err)
+	}
+}
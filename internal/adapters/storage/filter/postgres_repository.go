@@ -0,0 +1,141 @@
+package filterstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/filter"
+)
+
+const filterColumns = `id, name, query, owner_id, created_at, updated_at`
+
+// PostgresRepository persists saved filtered decks in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(f filter.Filter) (filter.Filter, error) {
+	if err := f.Validate(); err != nil {
+		return filter.Filter{}, err
+	}
+
+	const query = `
+		INSERT INTO filtered_decks (` + filterColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		f.ID, f.Name, f.Query, f.OwnerID, f.CreatedAt, f.UpdatedAt,
+	); err != nil {
+		return filter.Filter{}, fmt.Errorf("insert filtered deck: %w", err)
+	}
+
+	return f, nil
+}
+
+func (r *PostgresRepository) FindByID(id string) (filter.Filter, error) {
+	const query = `
+		SELECT ` + filterColumns + `
+		FROM filtered_decks
+		WHERE id = $1`
+
+	f, err := scanFilter(r.db.QueryRowContext(context.Background(), query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return filter.Filter{}, filter.ErrNotFound
+	}
+	if err != nil {
+		return filter.Filter{}, fmt.Errorf("find filtered deck by id: %w", err)
+	}
+
+	return f, nil
+}
+
+func (r *PostgresRepository) FindByOwner(ownerID string) ([]filter.Filter, error) {
+	const query = `
+		SELECT ` + filterColumns + `
+		FROM filtered_decks
+		WHERE owner_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list filtered decks by owner: %w", err)
+	}
+	defer rows.Close()
+
+	var filters []filter.Filter
+	for rows.Next() {
+		f, err := scanFilter(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan filtered deck: %w", err)
+		}
+		filters = append(filters, f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate filtered decks: %w", err)
+	}
+
+	return filters, nil
+}
+
+func (r *PostgresRepository) Update(f filter.Filter) (filter.Filter, error) {
+	if err := f.Validate(); err != nil {
+		return filter.Filter{}, err
+	}
+
+	const query = `
+		UPDATE filtered_decks
+		SET name = $1, query = $2, owner_id = $3, updated_at = $4
+		WHERE id = $5`
+
+	res, err := r.db.ExecContext(context.Background(), query, f.Name, f.Query, f.OwnerID, f.UpdatedAt, f.ID)
+	if err != nil {
+		return filter.Filter{}, fmt.Errorf("update filtered deck: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return filter.Filter{}, fmt.Errorf("update filtered deck rows affected: %w", err)
+	}
+	if affected == 0 {
+		return filter.Filter{}, filter.ErrNotFound
+	}
+
+	return f, nil
+}
+
+func (r *PostgresRepository) Delete(id string) error {
+	const query = `
+		DELETE FROM filtered_decks
+		WHERE id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return fmt.Errorf("delete filtered deck: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete filtered deck rows affected: %w", err)
+	}
+	if affected == 0 {
+		return filter.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanFilter(row rowScanner) (filter.Filter, error) {
+	var f filter.Filter
+	if err := row.Scan(&f.ID, &f.Name, &f.Query, &f.OwnerID, &f.CreatedAt, &f.UpdatedAt); err != nil {
+		return filter.Filter{}, err
+	}
+	return f, nil
+}
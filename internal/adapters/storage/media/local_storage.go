@@ -0,0 +1,109 @@
+package mediastorage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+// LocalStorage keeps media files as plain files in a directory of the local filesystem.
+type LocalStorage struct {
+	root string
+}
+
+// NewLocalStorage stores files under root, creating the directory when it does not exist.
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("create media directory: %w", err)
+	}
+	return &LocalStorage{root: root}, nil
+}
+
+// Put writes content to a temporary file first and renames it into place, so readers never
+// see a partially written file.
+func (s *LocalStorage) Put(key string, content io.Reader) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(s.root, ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("create media file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("write media file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("close media file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("store media file: %w", err)
+	}
+	return n, nil
+}
+
+func (s *LocalStorage) Open(key string) (io.ReadSeekCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, media.ErrNotFound
+		}
+		return nil, fmt.Errorf("open media file: %w", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return media.ErrNotFound
+		}
+		return fmt.Errorf("delete media file: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix deletes every file whose key starts with prefix, such as the renderings left in a
+// cache directory by a previous run.
+func (s *LocalStorage) DeletePrefix(prefix string) error {
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return fmt.Errorf("list media files: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if err := s.Delete(e.Name()); err != nil && err != media.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// path maps key to a file directly inside the root. Keys that could escape it are unknown.
+func (s *LocalStorage) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, ".") || strings.ContainsAny(key, `/\`) {
+		return "", media.ErrNotFound
+	}
+	return filepath.Join(s.root, key), nil
+}
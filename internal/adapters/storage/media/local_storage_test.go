@@ -0,0 +1,75 @@
+package mediastorage
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+func TestLocalStorageStoresFilesUnderRoot(t *testing.T) {
+	root := t.TempDir()
+	storage, err := NewLocalStorage(root)
+	if err != nil {
+		t.Fatalf("new storage failed: %v", err)
+	}
+
+	n, err := storage.Put("m-1", strings.NewReader("picture"))
+	if err != nil || n != 7 {
+		t.Fatalf("expected 7 bytes stored, got %d, %v", n, err)
+	}
+
+	f, err := storage.Open("m-1")
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	content, _ := io.ReadAll(f)
+	f.Close()
+	if string(content) != "picture" {
+		t.Fatalf("unexpected content %q", content)
+	}
+
+	entries, _ := os.ReadDir(root)
+	if len(entries) != 1 {
+		t.Fatalf("expected only the stored file to remain, got %d entries", len(entries))
+	}
+
+	for _, key := range []string{"", "../m-1", "a/b", ".upload-1"} {
+		if _, err := storage.Open(key); err != media.ErrNotFound {
+			t.Fatalf("expected ErrNotFound for key %q, got %v", key, err)
+		}
+	}
+
+	if err := storage.Delete("m-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := storage.Delete("m-1"); err != media.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestLocalStorageDeletePrefix(t *testing.T) {
+	storage, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("new storage failed: %v", err)
+	}
+	for _, key := range []string{"formula-a.png", "formula-b.png", "m-1"} {
+		if _, err := storage.Put(key, strings.NewReader(key)); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+
+	if err := storage.DeletePrefix("formula-"); err != nil {
+		t.Fatalf("delete prefix failed: %v", err)
+	}
+	if _, err := storage.Open("formula-a.png"); err != media.ErrNotFound {
+		t.Fatalf("expected the prefixed files deleted, got %v", err)
+	}
+	if f, err := storage.Open("m-1"); err != nil {
+		t.Fatalf("expected other files kept, got %v", err)
+	} else {
+		f.Close()
+	}
+}
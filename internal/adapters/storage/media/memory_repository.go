@@ -0,0 +1,74 @@
+package mediastorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+// MemoryRepository persists media attachments in memory; suitable for tests and demos.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]media.Attachment
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]media.Attachment),
+	}
+}
+
+func (r *MemoryRepository) Save(a media.Attachment) (media.Attachment, error) {
+	if err := a.Validate(); err != nil {
+		return media.Attachment{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[a.ID] = a
+	return a, nil
+}
+
+func (r *MemoryRepository) FindByID(id string) (media.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	a, ok := r.store[id]
+	if !ok {
+		return media.Attachment{}, media.ErrNotFound
+	}
+	return a, nil
+}
+
+// FindByCard returns the attachments of the card, oldest first.
+func (r *MemoryRepository) FindByCard(cardID string) ([]media.Attachment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var attachments []media.Attachment
+	for _, a := range r.store {
+		if a.CardID == cardID {
+			attachments = append(attachments, a)
+		}
+	}
+	sort.Slice(attachments, func(i, j int) bool {
+		if attachments[i].CreatedAt.Equal(attachments[j].CreatedAt) {
+			return attachments[i].ID < attachments[j].ID
+		}
+		return attachments[i].CreatedAt.Before(attachments[j].CreatedAt)
+	})
+	return attachments, nil
+}
+
+func (r *MemoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[id]; !ok {
+		return media.ErrNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
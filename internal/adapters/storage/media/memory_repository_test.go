@@ -0,0 +1,49 @@
+package mediastorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+func TestMemoryRepositoryListsAttachmentsPerCard(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if _, err := repo.Save(media.Attachment{ID: "m-0", CardID: "card-1", Side: "top", ContentType: "image/png", Size: 1}); err != media.ErrInvalidSide {
+		t.Fatalf("expected ErrInvalidSide, got %v", err)
+	}
+	if _, err := repo.Save(media.Attachment{ID: "m-0", CardID: "card-1", Side: media.SideFront, ContentType: "text/plain", Size: 1}); err != media.ErrUnsupportedType {
+		t.Fatalf("expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := repo.Save(media.Attachment{ID: "m-0", CardID: "card-1", Side: media.SideFront, ContentType: "image/png", Size: media.MaxImageSize + 1}); err != media.ErrTooLarge {
+		t.Fatalf("expected ErrTooLarge, got %v", err)
+	}
+
+	for _, a := range []media.Attachment{
+		{ID: "m-2", CardID: "card-1", Side: media.SideBack, ContentType: "image/png", Size: 10, CreatedAt: now.Add(time.Minute)},
+		{ID: "m-1", CardID: "card-1", Side: media.SideFront, ContentType: "image/jpeg", Size: 10, CreatedAt: now},
+		{ID: "m-3", CardID: "card-2", Side: media.SideFront, ContentType: "image/gif", Size: 10, CreatedAt: now},
+		{ID: "m-4", CardID: "card-2", Side: media.SideBack, ContentType: "audio/ogg", Size: media.MaxImageSize + 1, CreatedAt: now},
+	} {
+		if _, err := repo.Save(a); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	attachments, _ := repo.FindByCard("card-1")
+	if len(attachments) != 2 || attachments[0].ID != "m-1" || attachments[1].ID != "m-2" {
+		t.Fatalf("expected the card's attachments oldest first, got %+v", attachments)
+	}
+
+	if err := repo.Delete("m-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByID("m-1"); err != media.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := repo.Delete("m-1"); err != media.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package mediastorage
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+// MemoryStorage keeps media files in memory; suitable for tests and demos.
+type MemoryStorage struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		files: make(map[string][]byte),
+	}
+}
+
+func (s *MemoryStorage) Put(key string, content io.Reader) (int64, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.files[key] = data
+	return int64(len(data)), nil
+}
+
+func (s *MemoryStorage) Open(key string) (io.ReadSeekCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, ok := s.files[key]
+	if !ok {
+		return nil, media.ErrNotFound
+	}
+	return nopCloser{bytes.NewReader(data)}, nil
+}
+
+func (s *MemoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.files[key]; !ok {
+		return media.ErrNotFound
+	}
+	delete(s.files, key)
+	return nil
+}
+
+type nopCloser struct {
+	io.ReadSeeker
+}
+
+func (nopCloser) Close() error { return nil }
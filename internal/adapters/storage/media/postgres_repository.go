@@ -0,0 +1,124 @@
+package mediastorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/media"
+)
+
+const columns = `id, card_id, side, content_type, filename, size, created_at`
+
+// PostgresRepository persists media attachments in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(a media.Attachment) (media.Attachment, error) {
+	if err := a.Validate(); err != nil {
+		return media.Attachment{}, err
+	}
+
+	const query = `
+		INSERT INTO card_media (` + columns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE SET
+			side = EXCLUDED.side,
+			content_type = EXCLUDED.content_type,
+			filename = EXCLUDED.filename,
+			size = EXCLUDED.size`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		a.ID, a.CardID, string(a.Side), a.ContentType, a.Filename, a.Size, a.CreatedAt,
+	); err != nil {
+		return media.Attachment{}, fmt.Errorf("upsert media: %w", err)
+	}
+
+	return a, nil
+}
+
+func (r *PostgresRepository) FindByID(id string) (media.Attachment, error) {
+	const query = `
+		SELECT ` + columns + `
+		FROM card_media
+		WHERE id = $1`
+
+	a, err := scan(r.db.QueryRowContext(context.Background(), query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return media.Attachment{}, media.ErrNotFound
+		}
+		return media.Attachment{}, fmt.Errorf("select media: %w", err)
+	}
+	return a, nil
+}
+
+func (r *PostgresRepository) FindByCard(cardID string) ([]media.Attachment, error) {
+	const query = `
+		SELECT ` + columns + `
+		FROM card_media
+		WHERE card_id = $1
+		ORDER BY created_at ASC, id ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("list media: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []media.Attachment
+	for rows.Next() {
+		a, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan media: %w", err)
+		}
+		attachments = append(attachments, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate media: %w", err)
+	}
+
+	return attachments, nil
+}
+
+func (r *PostgresRepository) Delete(id string) error {
+	const query = `
+		DELETE FROM card_media
+		WHERE id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return fmt.Errorf("delete media: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete media rows affected: %w", err)
+	}
+	if affected == 0 {
+		return media.ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(s scanner) (media.Attachment, error) {
+	var (
+		a    media.Attachment
+		side string
+	)
+	if err := s.Scan(&a.ID, &a.CardID, &side, &a.ContentType, &a.Filename, &a.Size, &a.CreatedAt); err != nil {
+		return media.Attachment{}, err
+	}
+	a.Side = media.Side(side)
+	return a, nil
+}
@@ -0,0 +1,95 @@
+package notestorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/note"
+)
+
+// MemoryRepository persists notes in memory; suitable for tests and demos.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]note.Note
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]note.Note),
+	}
+}
+
+func (r *MemoryRepository) Save(n note.Note) (note.Note, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[n.ID] = n
+	return n, nil
+}
+
+func (r *MemoryRepository) FindByID(id string) (note.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n, ok := r.store[id]
+	if !ok {
+		return note.Note{}, note.ErrNotFound
+	}
+	return n, nil
+}
+
+// FindByOwner returns the owner's notes, oldest first.
+func (r *MemoryRepository) FindByOwner(ownerID string) ([]note.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var notes []note.Note
+	for _, n := range r.store {
+		if n.OwnerID == ownerID {
+			notes = append(notes, n)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.Before(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+// FindByNoteType returns the notes written with the note type, oldest first.
+func (r *MemoryRepository) FindByNoteType(noteTypeID string) ([]note.Note, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var notes []note.Note
+	for _, n := range r.store {
+		if n.NoteTypeID == noteTypeID {
+			notes = append(notes, n)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.Before(notes[j].CreatedAt)
+	})
+	return notes, nil
+}
+
+func (r *MemoryRepository) Update(n note.Note) (note.Note, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[n.ID]; !ok {
+		return note.Note{}, note.ErrNotFound
+	}
+	r.store[n.ID] = n
+	return n, nil
+}
+
+func (r *MemoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[id]; !ok {
+		return note.ErrNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
@@ -0,0 +1,49 @@
+package notestorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/note"
+)
+
+func TestMemoryRepositoryCRUD(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+	n := note.Note{ID: "note-1", OwnerID: "user-1", Front: "Hund", Back: "dog", Reverse: true, CreatedAt: now, UpdatedAt: now}
+
+	if _, err := repo.Save(n); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := repo.Save(note.Note{ID: "note-2", OwnerID: "user-2", Front: "Katze", CreatedAt: now}); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	found, err := repo.FindByID("note-1")
+	if err != nil || found.Back != "dog" || !found.Reverse {
+		t.Fatalf("unexpected note after find: %+v (%v)", found, err)
+	}
+
+	owned, err := repo.FindByOwner("user-1")
+	if err != nil || len(owned) != 1 || owned[0].ID != "note-1" {
+		t.Fatalf("expected only user-1 note, got %+v (%v)", owned, err)
+	}
+
+	n.Back = "hound"
+	if _, err := repo.Update(n); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if found, _ := repo.FindByID("note-1"); found.Back != "hound" {
+		t.Fatalf("expected updated back, got %q", found.Back)
+	}
+
+	if err := repo.Delete("note-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByID("note-1"); err != note.ErrNotFound {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+	if _, err := repo.Update(n); err != note.ErrNotFound {
+		t.Fatalf("expected ErrNotFound on update of missing note, got %v", err)
+	}
+}
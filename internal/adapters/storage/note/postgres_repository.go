@@ -0,0 +1,189 @@
+package notestorage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/note"
+)
+
+const noteColumns = `id, owner_id, note_type, front, back, reverse, created_at, updated_at, note_type_id, fields`
+
+// PostgresRepository persists notes in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(n note.Note) (note.Note, error) {
+	const query = `
+		INSERT INTO notes (` + noteColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	fields, err := encodeFields(n.Fields)
+	if err != nil {
+		return note.Note{}, err
+	}
+	if _, err := r.db.ExecContext(context.Background(), query,
+		n.ID, n.OwnerID, n.Kind(), n.Front, n.Back, n.Reverse, n.CreatedAt, n.UpdatedAt, nullString(n.NoteTypeID), fields,
+	); err != nil {
+		return note.Note{}, fmt.Errorf("insert note: %w", err)
+	}
+
+	return n, nil
+}
+
+func (r *PostgresRepository) FindByID(id string) (note.Note, error) {
+	const query = `
+		SELECT ` + noteColumns + `
+		FROM notes
+		WHERE id = $1`
+
+	n, err := scanNote(r.db.QueryRowContext(context.Background(), query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return note.Note{}, note.ErrNotFound
+	}
+	if err != nil {
+		return note.Note{}, fmt.Errorf("find note by id: %w", err)
+	}
+
+	return n, nil
+}
+
+func (r *PostgresRepository) FindByOwner(ownerID string) ([]note.Note, error) {
+	const query = `
+		SELECT ` + noteColumns + `
+		FROM notes
+		WHERE owner_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list notes by owner: %w", err)
+	}
+	return scanNotes(rows)
+}
+
+func (r *PostgresRepository) FindByNoteType(noteTypeID string) ([]note.Note, error) {
+	const query = `
+		SELECT ` + noteColumns + `
+		FROM notes
+		WHERE note_type_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, noteTypeID)
+	if err != nil {
+		return nil, fmt.Errorf("list notes by note type: %w", err)
+	}
+	return scanNotes(rows)
+}
+
+func scanNotes(rows *sql.Rows) ([]note.Note, error) {
+	defer rows.Close()
+
+	var notes []note.Note
+	for rows.Next() {
+		n, err := scanNote(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+		notes = append(notes, n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+func (r *PostgresRepository) Update(n note.Note) (note.Note, error) {
+	const query = `
+		UPDATE notes
+		SET owner_id = $1, note_type = $2, front = $3, back = $4, reverse = $5, updated_at = $6,
+			note_type_id = $7, fields = $8
+		WHERE id = $9`
+
+	fields, err := encodeFields(n.Fields)
+	if err != nil {
+		return note.Note{}, err
+	}
+	res, err := r.db.ExecContext(context.Background(), query,
+		n.OwnerID, n.Kind(), n.Front, n.Back, n.Reverse, n.UpdatedAt, nullString(n.NoteTypeID), fields, n.ID,
+	)
+	if err != nil {
+		return note.Note{}, fmt.Errorf("update note: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return note.Note{}, fmt.Errorf("update note rows affected: %w", err)
+	}
+	if affected == 0 {
+		return note.Note{}, note.ErrNotFound
+	}
+
+	return n, nil
+}
+
+func (r *PostgresRepository) Delete(id string) error {
+	const query = `
+		DELETE FROM notes
+		WHERE id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return fmt.Errorf("delete note: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete note rows affected: %w", err)
+	}
+	if affected == 0 {
+		return note.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNote(row rowScanner) (note.Note, error) {
+	var (
+		n          note.Note
+		noteTypeID sql.NullString
+		fields     []byte
+	)
+	if err := row.Scan(&n.ID, &n.OwnerID, &n.Type, &n.Front, &n.Back, &n.Reverse, &n.CreatedAt, &n.UpdatedAt, &noteTypeID, &fields); err != nil {
+		return note.Note{}, err
+	}
+	n.NoteTypeID = noteTypeID.String
+	if len(fields) > 0 {
+		if err := json.Unmarshal(fields, &n.Fields); err != nil {
+			return note.Note{}, fmt.Errorf("decode note fields: %w", err)
+		}
+	}
+	return n, nil
+}
+
+// encodeFields stores the field values of typed notes as a JSON object, and nothing for others.
+func encodeFields(fields map[string]string) (any, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("encode note fields: %w", err)
+	}
+	return string(raw), nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,86 @@
+package notetypestorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/notetype"
+)
+
+// MemoryRepository persists note types in memory; suitable for tests and demos.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]notetype.NoteType
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]notetype.NoteType),
+	}
+}
+
+func (r *MemoryRepository) Save(t notetype.NoteType) (notetype.NoteType, error) {
+	if err := t.Validate(); err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[t.ID] = t
+	return t, nil
+}
+
+func (r *MemoryRepository) FindByID(id string) (notetype.NoteType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	t, ok := r.store[id]
+	if !ok {
+		return notetype.NoteType{}, notetype.ErrNotFound
+	}
+	return t, nil
+}
+
+// FindByOwner returns the owner's note types, oldest first.
+func (r *MemoryRepository) FindByOwner(ownerID string) ([]notetype.NoteType, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var types []notetype.NoteType
+	for _, t := range r.store {
+		if t.OwnerID == ownerID {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].CreatedAt.Before(types[j].CreatedAt)
+	})
+	return types, nil
+}
+
+func (r *MemoryRepository) Update(t notetype.NoteType) (notetype.NoteType, error) {
+	if err := t.Validate(); err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[t.ID]; !ok {
+		return notetype.NoteType{}, notetype.ErrNotFound
+	}
+	r.store[t.ID] = t
+	return t, nil
+}
+
+func (r *MemoryRepository) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[id]; !ok {
+		return notetype.ErrNotFound
+	}
+	delete(r.store, id)
+	return nil
+}
@@ -0,0 +1,69 @@
+package notetypestorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/notetype"
+)
+
+func vocabulary(id, ownerID string, createdAt time.Time) notetype.NoteType {
+	return notetype.NoteType{
+		ID:            id,
+		OwnerID:       ownerID,
+		Name:          "Vocabulary",
+		Fields:        []notetype.Field{{Name: "word", Required: true}, {Name: "meaning", Required: true}, {Name: "example"}},
+		FrontTemplate: "{{.word}}",
+		BackTemplate:  "{{.meaning}}",
+		CreatedAt:     createdAt,
+	}
+}
+
+func TestMemoryRepositoryCRUD(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+
+	if _, err := repo.Save(vocabulary("type-1", "user-1", now)); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if _, err := repo.Save(vocabulary("type-2", "user-2", now)); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	owned, err := repo.FindByOwner("user-1")
+	if err != nil || len(owned) != 1 || owned[0].ID != "type-1" {
+		t.Fatalf("expected only user-1 note type, got %+v (%v)", owned, err)
+	}
+
+	updated := vocabulary("type-1", "user-1", now)
+	updated.BackTemplate = "{{.meaning}} ({{.example}})"
+	if _, err := repo.Update(updated); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if found, _ := repo.FindByID("type-1"); found.BackTemplate != updated.BackTemplate {
+		t.Fatalf("expected updated template, got %q", found.BackTemplate)
+	}
+
+	if err := repo.Delete("type-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := repo.FindByID("type-1"); err != notetype.ErrNotFound {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestMemoryRepositoryValidates(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	invalid := vocabulary("type-1", "user-1", time.Now())
+	invalid.Fields = append(invalid.Fields, notetype.Field{Name: "word"})
+	if _, err := repo.Save(invalid); err != notetype.ErrDuplicateField {
+		t.Fatalf("expected ErrDuplicateField, got %v", err)
+	}
+
+	invalid = vocabulary("type-1", "user-1", time.Now())
+	invalid.Fields = []notetype.Field{{Name: "part of speech"}}
+	if _, err := repo.Save(invalid); err != notetype.ErrInvalidFieldName {
+		t.Fatalf("expected ErrInvalidFieldName, got %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package notetypestorage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/notetype"
+)
+
+const noteTypeColumns = `id, owner_id, name, fields, front_template, back_template, created_at, updated_at`
+
+// PostgresRepository persists note types in PostgreSQL, with their fields as a JSON array.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+// fieldRecord is the JSON form of a field in the fields column.
+type fieldRecord struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+}
+
+func (r *PostgresRepository) Save(t notetype.NoteType) (notetype.NoteType, error) {
+	if err := t.Validate(); err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	const query = `
+		INSERT INTO note_types (` + noteTypeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	fields, err := encodeFields(t.Fields)
+	if err != nil {
+		return notetype.NoteType{}, err
+	}
+	if _, err := r.db.ExecContext(context.Background(), query,
+		t.ID, t.OwnerID, t.Name, fields, t.FrontTemplate, t.BackTemplate, t.CreatedAt, t.UpdatedAt,
+	); err != nil {
+		return notetype.NoteType{}, fmt.Errorf("insert note type: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *PostgresRepository) FindByID(id string) (notetype.NoteType, error) {
+	const query = `
+		SELECT ` + noteTypeColumns + `
+		FROM note_types
+		WHERE id = $1`
+
+	t, err := scanNoteType(r.db.QueryRowContext(context.Background(), query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return notetype.NoteType{}, notetype.ErrNotFound
+	}
+	if err != nil {
+		return notetype.NoteType{}, fmt.Errorf("find note type by id: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *PostgresRepository) FindByOwner(ownerID string) ([]notetype.NoteType, error) {
+	const query = `
+		SELECT ` + noteTypeColumns + `
+		FROM note_types
+		WHERE owner_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("list note types by owner: %w", err)
+	}
+	defer rows.Close()
+
+	var types []notetype.NoteType
+	for rows.Next() {
+		t, err := scanNoteType(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan note type: %w", err)
+		}
+		types = append(types, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate note types: %w", err)
+	}
+
+	return types, nil
+}
+
+func (r *PostgresRepository) Update(t notetype.NoteType) (notetype.NoteType, error) {
+	if err := t.Validate(); err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	const query = `
+		UPDATE note_types
+		SET name = $1, fields = $2, front_template = $3, back_template = $4, updated_at = $5
+		WHERE id = $6`
+
+	fields, err := encodeFields(t.Fields)
+	if err != nil {
+		return notetype.NoteType{}, err
+	}
+	res, err := r.db.ExecContext(context.Background(), query,
+		t.Name, fields, t.FrontTemplate, t.BackTemplate, t.UpdatedAt, t.ID,
+	)
+	if err != nil {
+		return notetype.NoteType{}, fmt.Errorf("update note type: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return notetype.NoteType{}, fmt.Errorf("update note type rows affected: %w", err)
+	}
+	if affected == 0 {
+		return notetype.NoteType{}, notetype.ErrNotFound
+	}
+
+	return t, nil
+}
+
+func (r *PostgresRepository) Delete(id string) error {
+	const query = `
+		DELETE FROM note_types
+		WHERE id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, id)
+	if err != nil {
+		return fmt.Errorf("delete note type: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete note type rows affected: %w", err)
+	}
+	if affected == 0 {
+		return notetype.ErrNotFound
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNoteType(row rowScanner) (notetype.NoteType, error) {
+	var (
+		t      notetype.NoteType
+		fields []byte
+	)
+	if err := row.Scan(&t.ID, &t.OwnerID, &t.Name, &fields, &t.FrontTemplate, &t.BackTemplate, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	var records []fieldRecord
+	if err := json.Unmarshal(fields, &records); err != nil {
+		return notetype.NoteType{}, fmt.Errorf("decode note type fields: %w", err)
+	}
+	for _, f := range records {
+		t.Fields = append(t.Fields, notetype.Field{Name: f.Name, Required: f.Required})
+	}
+	return t, nil
+}
+
+func encodeFields(fields []notetype.Field) (string, error) {
+	records := make([]fieldRecord, 0, len(fields))
+	for _, f := range fields {
+		records = append(records, fieldRecord{Name: f.Name, Required: f.Required})
+	}
+	raw, err := json.Marshal(records)
+	if err != nil {
+		return "", fmt.Errorf("encode note type fields: %w", err)
+	}
+	return string(raw), nil
+}
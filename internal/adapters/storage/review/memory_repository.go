@@ -0,0 +1,53 @@
+package reviewstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/review"
+)
+
+// MemoryRepository keeps review logs in memory; useful for demos and tests.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	byID   map[string]review.Log
+	byCard map[string][]string
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		byID:   make(map[string]review.Log),
+		byCard: make(map[string][]string),
+	}
+}
+
+func (r *MemoryRepository) Save(l review.Log) (review.Log, error) {
+	if err := l.Validate(); err != nil {
+		return review.Log{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byID[l.ID]; ok {
+		return review.Log{}, review.ErrDuplicate
+	}
+	r.byID[l.ID] = l
+	r.byCard[l.CardID] = append(r.byCard[l.CardID], l.ID)
+	return l, nil
+}
+
+// FindByCard returns the card's logs, oldest first.
+func (r *MemoryRepository) FindByCard(cardID string) ([]review.Log, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logs := make([]review.Log, 0, len(r.byCard[cardID]))
+	for _, id := range r.byCard[cardID] {
+		logs = append(logs, r.byID[id])
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].ReviewedAt.Before(logs[j].ReviewedAt)
+	})
+	return logs, nil
+}
@@ -0,0 +1,48 @@
+package reviewstorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/review"
+)
+
+func TestMemoryRepositorySaveAndFind(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+
+	logs := []review.Log{
+		{ID: "log-2", CardID: "card-1", Rating: card.RatingGood, ReviewedAt: now},
+		{ID: "log-1", CardID: "card-1", Rating: card.RatingAgain, ReviewedAt: now.Add(-time.Hour)},
+		{ID: "log-3", CardID: "card-2", Rating: card.RatingEasy, ReviewedAt: now},
+	}
+	for _, l := range logs {
+		if _, err := repo.Save(l); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	found, err := repo.FindByCard("card-1")
+	if err != nil {
+		t.Fatalf("findByCard failed: %v", err)
+	}
+	if len(found) != 2 || found[0].ID != "log-1" || found[1].ID != "log-2" {
+		t.Fatalf("expected card-1 logs oldest first, got %+v", found)
+	}
+
+	if _, err := repo.Save(logs[0]); err != review.ErrDuplicate {
+		t.Fatalf("expected ErrDuplicate when rewriting a log, got %v", err)
+	}
+}
+
+func TestMemoryRepositoryValidation(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	if _, err := repo.Save(review.Log{ID: "log-1", Rating: card.RatingGood}); err != review.ErrEmptyCardID {
+		t.Fatalf("expected ErrEmptyCardID, got %v", err)
+	}
+	if _, err := repo.Save(review.Log{ID: "log-1", CardID: "card-1"}); err != card.ErrInvalidRating {
+		t.Fatalf("expected ErrInvalidRating, got %v", err)
+	}
+}
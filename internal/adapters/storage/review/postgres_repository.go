@@ -0,0 +1,81 @@
+package reviewstorage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"flash2fy/internal/app/domain/review"
+)
+
+// PostgresRepository persists review logs in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(l review.Log) (review.Log, error) {
+	if err := l.Validate(); err != nil {
+		return review.Log{}, err
+	}
+
+	const query = `
+		INSERT INTO review_logs (id, card_id, user_id, rating, previous_interval, next_interval, elapsed_days, answer_time_ms, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO NOTHING`
+
+	result, err := r.db.ExecContext(context.Background(), query,
+		l.ID, l.CardID, l.UserID, int(l.Rating), l.PreviousInterval, l.NextInterval, l.ElapsedDays,
+		l.AnswerTime.Milliseconds(), l.ReviewedAt,
+	)
+	if err != nil {
+		return review.Log{}, fmt.Errorf("insert review log: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return review.Log{}, fmt.Errorf("insert review log: %w", err)
+	}
+	if inserted == 0 {
+		return review.Log{}, review.ErrDuplicate
+	}
+
+	return l, nil
+}
+
+// FindByCard returns the card's logs, oldest first.
+func (r *PostgresRepository) FindByCard(cardID string) ([]review.Log, error) {
+	const query = `
+		SELECT id, card_id, user_id, rating, previous_interval, next_interval, elapsed_days, answer_time_ms, reviewed_at
+		FROM review_logs
+		WHERE card_id = $1
+		ORDER BY reviewed_at ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("list review logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []review.Log
+	for rows.Next() {
+		var (
+			l        review.Log
+			answerMS int64
+		)
+		if err := rows.Scan(&l.ID, &l.CardID, &l.UserID, &l.Rating, &l.PreviousInterval, &l.NextInterval, &l.ElapsedDays, &answerMS, &l.ReviewedAt); err != nil {
+			return nil, fmt.Errorf("scan review log: %w", err)
+		}
+		l.AnswerTime = time.Duration(answerMS) * time.Millisecond
+		logs = append(logs, l)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate review logs: %w", err)
+	}
+
+	return logs, nil
+}
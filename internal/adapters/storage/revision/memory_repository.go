@@ -0,0 +1,72 @@
+package revisionstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/app/domain/revision"
+)
+
+// MemoryRepository keeps card revisions in memory; useful for demos and tests.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	byCard map[string]map[int]revision.Revision
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{byCard: make(map[string]map[int]revision.Revision)}
+}
+
+func (r *MemoryRepository) Save(rev revision.Revision) (revision.Revision, error) {
+	if err := rev.Validate(); err != nil {
+		return revision.Revision{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	revisions, ok := r.byCard[rev.CardID]
+	if !ok {
+		revisions = make(map[int]revision.Revision)
+		r.byCard[rev.CardID] = revisions
+	}
+	if _, ok := revisions[rev.Number]; ok {
+		return revision.Revision{}, revision.ErrDuplicate
+	}
+	revisions[rev.Number] = rev
+	return rev, nil
+}
+
+// FindByCard returns the card's revisions, oldest first.
+func (r *MemoryRepository) FindByCard(cardID string) ([]revision.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	revisions := make([]revision.Revision, 0, len(r.byCard[cardID]))
+	for _, rev := range r.byCard[cardID] {
+		revisions = append(revisions, rev)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+	return revisions, nil
+}
+
+func (r *MemoryRepository) FindByNumber(cardID string, number int) (revision.Revision, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rev, ok := r.byCard[cardID][number]
+	if !ok {
+		return revision.Revision{}, revision.ErrNotFound
+	}
+	return rev, nil
+}
+
+func (r *MemoryRepository) DeleteByCard(cardID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.byCard, cardID)
+	return nil
+}
@@ -0,0 +1,68 @@
+package revisionstorage
+
+import (
+	"testing"
+
+	"flash2fy/internal/app/domain/revision"
+)
+
+func TestMemoryRepositorySaveAndFind(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	revisions := []revision.Revision{
+		{CardID: "card-1", Number: 2, Front: "der Hund", Channel: revision.ChannelHTTP},
+		{CardID: "card-1", Number: 1, Front: "Hund"},
+		{CardID: "card-2", Number: 1, Front: "Katze", Channel: revision.ChannelTelegram},
+	}
+	for _, rev := range revisions {
+		if _, err := repo.Save(rev); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	found, err := repo.FindByCard("card-1")
+	if err != nil {
+		t.Fatalf("findByCard failed: %v", err)
+	}
+	if len(found) != 2 || found[0].Front != "Hund" || found[1].Front != "der Hund" {
+		t.Fatalf("expected card-1 revisions oldest first, got %+v", found)
+	}
+	if rev, err := repo.FindByNumber("card-1", 2); err != nil || rev.Front != "der Hund" {
+		t.Fatalf("expected revision 2, got %+v (%v)", rev, err)
+	}
+	if _, err := repo.FindByNumber("card-2", 2); err != revision.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if _, err := repo.Save(revisions[0]); err != revision.ErrDuplicate {
+		t.Fatalf("expected ErrDuplicate when rewriting a revision, got %v", err)
+	}
+
+	if err := repo.DeleteByCard("card-1"); err != nil {
+		t.Fatalf("deleteByCard failed: %v", err)
+	}
+	if found, _ := repo.FindByCard("card-1"); len(found) != 0 {
+		t.Fatalf("expected card-1 revisions deleted, got %+v", found)
+	}
+	if found, _ := repo.FindByCard("card-2"); len(found) != 1 {
+		t.Fatalf("expected card-2 revisions kept, got %+v", found)
+	}
+}
+
+func TestMemoryRepositoryValidation(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	tests := []struct {
+		rev  revision.Revision
+		want error
+	}{
+		{revision.Revision{Number: 1}, revision.ErrEmptyCardID},
+		{revision.Revision{CardID: "card-1"}, revision.ErrInvalidNumber},
+		{revision.Revision{CardID: "card-1", Number: 1, Channel: "email"}, revision.ErrInvalidChannel},
+	}
+	for _, tc := range tests {
+		if _, err := repo.Save(tc.rev); err != tc.want {
+			t.Fatalf("%+v: expected %v, got %v", tc.rev, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package revisionstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/app/domain/revision"
+)
+
+const revisionColumns = `card_id, number, front, back, editor_id, channel, created_at`
+
+// PostgresRepository persists card revisions in PostgreSQL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(rev revision.Revision) (revision.Revision, error) {
+	if err := rev.Validate(); err != nil {
+		return revision.Revision{}, err
+	}
+
+	const query = `
+		INSERT INTO card_revisions (` + revisionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		rev.CardID, rev.Number, rev.Front, rev.Back, rev.EditorID, string(rev.Channel), rev.CreatedAt,
+	); err != nil {
+		return revision.Revision{}, fmt.Errorf("insert card revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// FindByCard returns the card's revisions, oldest first.
+func (r *PostgresRepository) FindByCard(cardID string) ([]revision.Revision, error) {
+	const query = `
+		SELECT ` + revisionColumns + `
+		FROM card_revisions
+		WHERE card_id = $1
+		ORDER BY number ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, cardID)
+	if err != nil {
+		return nil, fmt.Errorf("list card revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []revision.Revision
+	for rows.Next() {
+		rev, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan card revision: %w", err)
+		}
+		revisions = append(revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate card revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *PostgresRepository) FindByNumber(cardID string, number int) (revision.Revision, error) {
+	const query = `
+		SELECT ` + revisionColumns + `
+		FROM card_revisions
+		WHERE card_id = $1 AND number = $2`
+
+	rev, err := scan(r.db.QueryRowContext(context.Background(), query, cardID, number))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return revision.Revision{}, revision.ErrNotFound
+		}
+		return revision.Revision{}, fmt.Errorf("find card revision: %w", err)
+	}
+	return rev, nil
+}
+
+func (r *PostgresRepository) DeleteByCard(cardID string) error {
+	const query = `DELETE FROM card_revisions WHERE card_id = $1`
+
+	if _, err := r.db.ExecContext(context.Background(), query, cardID); err != nil {
+		return fmt.Errorf("delete card revisions: %w", err)
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scan(s scanner) (revision.Revision, error) {
+	var (
+		rev     revision.Revision
+		channel string
+	)
+	if err := s.Scan(&rev.CardID, &rev.Number, &rev.Front, &rev.Back, &rev.EditorID, &channel, &rev.CreatedAt); err != nil {
+		return revision.Revision{}, err
+	}
+	rev.Channel = revision.Channel(channel)
+	return rev, nil
+}
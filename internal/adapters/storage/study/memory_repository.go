@@ -0,0 +1,67 @@
+package studystorage
+
+import (
+	"sync"
+	"time"
+
+	"flash2fy/internal/app/domain/study"
+)
+
+// MemoryRepository keeps study sessions in memory; sessions are short-lived so this is the default store.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	store map[string]study.Session
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		store: make(map[string]study.Session),
+	}
+}
+
+func (r *MemoryRepository) Save(s study.Session) (study.Session, error) {
+	if err := s.Validate(); err != nil {
+		return study.Session{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.store[s.ID] = s.Clone()
+	return s, nil
+}
+
+func (r *MemoryRepository) FindByID(id string) (study.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.store[id]
+	if !ok {
+		return study.Session{}, study.ErrSessionNotFound
+	}
+	return s.Clone(), nil
+}
+
+func (r *MemoryRepository) FindByUserSince(userID string, since time.Time) ([]study.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var sessions []study.Session
+	for _, s := range r.store {
+		if s.UserID == userID && !s.StartedAt.Before(since) {
+			sessions = append(sessions, s.Clone())
+		}
+	}
+	return sessions, nil
+}
+
+func (r *MemoryRepository) Update(s study.Session) (study.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.store[s.ID]; !ok {
+		return study.Session{}, study.ErrSessionNotFound
+	}
+	r.store[s.ID] = s.Clone()
+	return s, nil
+}
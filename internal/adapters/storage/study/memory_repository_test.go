@@ -0,0 +1,68 @@
+package studystorage
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/study"
+)
+
+func TestMemoryRepositoryLifecycle(t *testing.T) {
+	repo := NewMemoryRepository()
+	now := time.Now().UTC()
+	s := study.Session{
+		ID:        "session-1",
+		UserID:    "user-1",
+		Queue:     []study.Item{{CardID: "card-1"}, {CardID: "card-2", New: true}},
+		StartedAt: now,
+	}
+
+	if _, err := repo.Save(s); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	s.Queue[0].CardID = "mutated"
+	found, err := repo.FindByID(s.ID)
+	if err != nil {
+		t.Fatalf("find failed: %v", err)
+	}
+	if found.Queue[0].CardID != "card-1" {
+		t.Fatalf("expected stored queue to be isolated from caller, got %+v", found.Queue)
+	}
+
+	found.Queue = found.Queue[1:]
+	found.Reviewed = 1
+	if _, err := repo.Update(found); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	today, err := repo.FindByUserSince("user-1", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("findByUserSince failed: %v", err)
+	}
+	if len(today) != 1 || today[0].Reviewed != 1 || len(today[0].Queue) != 1 {
+		t.Fatalf("unexpected sessions: %+v", today)
+	}
+
+	later, err := repo.FindByUserSince("user-1", now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("findByUserSince failed: %v", err)
+	}
+	if len(later) != 0 {
+		t.Fatalf("expected no sessions started after the cutoff, got %+v", later)
+	}
+}
+
+func TestMemoryRepositoryNotFound(t *testing.T) {
+	repo := NewMemoryRepository()
+
+	if _, err := repo.FindByID("missing"); err != study.ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+	if _, err := repo.Update(study.Session{ID: "missing", UserID: "user-1"}); err != study.ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound on update, got %v", err)
+	}
+	if _, err := repo.Save(study.Session{ID: "no-user"}); err != study.ErrEmptyUser {
+		t.Fatalf("expected ErrEmptyUser, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package telecardstorage
 
 import (
+	"sort"
 	"sync"
 
 	"flash2fy/internal/telegram/domain"
@@ -62,3 +63,24 @@ func (r *MemoryRepository) DeleteByCoreID(coreID string) error {
 
 	return nil
 }
+
+// ChatIDsByOwner returns the distinct chats in which the owner created cards.
+func (r *MemoryRepository) ChatIDsByOwner(ownerTelegramID int64) ([]int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[int64]struct{})
+	chats := make([]int64, 0)
+	for _, card := range r.byID {
+		if card.OwnerTelegramID != ownerTelegramID || card.ChatID == 0 {
+			continue
+		}
+		if _, ok := seen[card.ChatID]; ok {
+			continue
+		}
+		seen[card.ChatID] = struct{}{}
+		chats = append(chats, card.ChatID)
+	}
+	sort.Slice(chats, func(i, j int) bool { return chats[i] < chats[j] })
+	return chats, nil
+}
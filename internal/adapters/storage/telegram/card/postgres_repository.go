@@ -0,0 +1,113 @@
+package telecardstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/telegram/domain"
+)
+
+const cardColumns = `id, core_card_id, owner_telegram_id, chat_id`
+
+// PostgresRepository persists Telegram card projections in PostgreSQL, so cards keep their chat
+// across restarts for restores and reminders.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(card domain.Card) (domain.Card, error) {
+	if err := card.Validate(); err != nil {
+		return domain.Card{}, err
+	}
+
+	const query = `
+		INSERT INTO telegram_cards (` + cardColumns + `)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE
+		SET core_card_id = EXCLUDED.core_card_id,
+			owner_telegram_id = EXCLUDED.owner_telegram_id,
+			chat_id = EXCLUDED.chat_id`
+
+	if _, err := r.db.ExecContext(context.Background(), query,
+		card.ID, card.CoreCardID, card.OwnerTelegramID, card.ChatID,
+	); err != nil {
+		return domain.Card{}, fmt.Errorf("save telegram card: %w", err)
+	}
+
+	return card, nil
+}
+
+func (r *PostgresRepository) FindByCoreID(coreID string) (domain.Card, error) {
+	const query = `
+		SELECT ` + cardColumns + `
+		FROM telegram_cards
+		WHERE core_card_id = $1`
+
+	var card domain.Card
+	err := r.db.QueryRowContext(context.Background(), query, coreID).Scan(
+		&card.ID, &card.CoreCardID, &card.OwnerTelegramID, &card.ChatID,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Card{}, domain.ErrCardNotFound
+	}
+	if err != nil {
+		return domain.Card{}, fmt.Errorf("find telegram card: %w", err)
+	}
+
+	return card, nil
+}
+
+func (r *PostgresRepository) DeleteByCoreID(coreID string) error {
+	const query = `
+		DELETE FROM telegram_cards
+		WHERE core_card_id = $1`
+
+	res, err := r.db.ExecContext(context.Background(), query, coreID)
+	if err != nil {
+		return fmt.Errorf("delete telegram card: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("delete telegram card rows affected: %w", err)
+	}
+	if affected == 0 {
+		return domain.ErrCardNotFound
+	}
+	return nil
+}
+
+// ChatIDsByOwner returns the distinct chats in which the owner created cards.
+func (r *PostgresRepository) ChatIDsByOwner(ownerTelegramID int64) ([]int64, error) {
+	const query = `
+		SELECT DISTINCT chat_id
+		FROM telegram_cards
+		WHERE owner_telegram_id = $1 AND chat_id <> 0
+		ORDER BY chat_id ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query, ownerTelegramID)
+	if err != nil {
+		return nil, fmt.Errorf("list telegram chats: %w", err)
+	}
+	defer rows.Close()
+
+	chats := make([]int64, 0)
+	for rows.Next() {
+		var chatID int64
+		if err := rows.Scan(&chatID); err != nil {
+			return nil, fmt.Errorf("scan telegram chat: %w", err)
+		}
+		chats = append(chats, chatID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate telegram chats: %w", err)
+	}
+
+	return chats, nil
+}
@@ -0,0 +1,57 @@
+package telereminderstorage
+
+import (
+	"sort"
+	"sync"
+
+	"flash2fy/internal/telegram/domain"
+)
+
+// MemoryRepository stores Telegram reminder schedules in memory.
+type MemoryRepository struct {
+	mu   sync.RWMutex
+	byID map[int64]domain.Reminder
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		byID: make(map[int64]domain.Reminder),
+	}
+}
+
+func (r *MemoryRepository) Save(reminder domain.Reminder) (domain.Reminder, error) {
+	if err := reminder.Validate(); err != nil {
+		return domain.Reminder{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byID[reminder.TelegramID] = reminder
+	return reminder, nil
+}
+
+func (r *MemoryRepository) FindByTelegramID(id int64) (domain.Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reminder, ok := r.byID[id]
+	if !ok {
+		return domain.Reminder{}, domain.ErrReminderNotFound
+	}
+	return reminder, nil
+}
+
+func (r *MemoryRepository) FindEnabled() ([]domain.Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reminders := make([]domain.Reminder, 0, len(r.byID))
+	for _, reminder := range r.byID {
+		if reminder.Enabled {
+			reminders = append(reminders, reminder)
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool { return reminders[i].TelegramID < reminders[j].TelegramID })
+	return reminders, nil
+}
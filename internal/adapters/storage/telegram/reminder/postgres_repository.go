@@ -0,0 +1,113 @@
+package telereminderstorage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"flash2fy/internal/telegram/domain"
+)
+
+const reminderColumns = `telegram_id, core_user_id, hour, minute, timezone, enabled, last_sent_at`
+
+// PostgresRepository persists Telegram reminder schedules in PostgreSQL so they survive restarts.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) Save(reminder domain.Reminder) (domain.Reminder, error) {
+	if err := reminder.Validate(); err != nil {
+		return domain.Reminder{}, err
+	}
+
+	const query = `
+		INSERT INTO telegram_reminders (` + reminderColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (telegram_id) DO UPDATE
+		SET core_user_id = EXCLUDED.core_user_id,
+			hour = EXCLUDED.hour,
+			minute = EXCLUDED.minute,
+			timezone = EXCLUDED.timezone,
+			enabled = EXCLUDED.enabled,
+			last_sent_at = EXCLUDED.last_sent_at`
+
+	lastSent := sql.NullTime{Time: reminder.LastSentAt, Valid: !reminder.LastSentAt.IsZero()}
+	if _, err := r.db.ExecContext(context.Background(), query,
+		reminder.TelegramID, reminder.CoreUserID, reminder.Hour, reminder.Minute,
+		reminder.Timezone, reminder.Enabled, lastSent,
+	); err != nil {
+		return domain.Reminder{}, fmt.Errorf("save reminder: %w", err)
+	}
+
+	return reminder, nil
+}
+
+func (r *PostgresRepository) FindByTelegramID(id int64) (domain.Reminder, error) {
+	const query = `
+		SELECT ` + reminderColumns + `
+		FROM telegram_reminders
+		WHERE telegram_id = $1`
+
+	reminder, err := scanReminder(r.db.QueryRowContext(context.Background(), query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Reminder{}, domain.ErrReminderNotFound
+	}
+	if err != nil {
+		return domain.Reminder{}, fmt.Errorf("find reminder: %w", err)
+	}
+
+	return reminder, nil
+}
+
+func (r *PostgresRepository) FindEnabled() ([]domain.Reminder, error) {
+	const query = `
+		SELECT ` + reminderColumns + `
+		FROM telegram_reminders
+		WHERE enabled
+		ORDER BY telegram_id ASC`
+
+	rows, err := r.db.QueryContext(context.Background(), query)
+	if err != nil {
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var reminders []domain.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan reminder: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReminder(row rowScanner) (domain.Reminder, error) {
+	var (
+		reminder domain.Reminder
+		lastSent sql.NullTime
+	)
+	if err := row.Scan(
+		&reminder.TelegramID, &reminder.CoreUserID, &reminder.Hour, &reminder.Minute,
+		&reminder.Timezone, &reminder.Enabled, &lastSent,
+	); err != nil {
+		return domain.Reminder{}, err
+	}
+	reminder.LastSentAt = lastSent.Time
+	return reminder, nil
+}
@@ -0,0 +1,53 @@
+package telereviewstorage
+
+import (
+	"sync"
+
+	"flash2fy/internal/telegram/domain"
+)
+
+// MemoryRepository stores the active review session of each Telegram chat in memory.
+type MemoryRepository struct {
+	mu     sync.RWMutex
+	byChat map[int64]domain.ReviewSession
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		byChat: make(map[int64]domain.ReviewSession),
+	}
+}
+
+func (r *MemoryRepository) Save(session domain.ReviewSession) (domain.ReviewSession, error) {
+	if err := session.Validate(); err != nil {
+		return domain.ReviewSession{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byChat[session.ChatID] = session
+	return session, nil
+}
+
+func (r *MemoryRepository) FindByChatID(chatID int64) (domain.ReviewSession, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.byChat[chatID]
+	if !ok {
+		return domain.ReviewSession{}, domain.ErrReviewNotFound
+	}
+	return session, nil
+}
+
+func (r *MemoryRepository) DeleteByChatID(chatID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.byChat[chatID]; !ok {
+		return domain.ErrReviewNotFound
+	}
+	delete(r.byChat, chatID)
+	return nil
+}
@@ -24,10 +24,10 @@ func (r *PostgresRepository) Save(u user.User) (user.User, error) {
 	}
 
 	const query = `
-		INSERT INTO users (id, nickname)
-		VALUES ($1, $2)`
+		INSERT INTO users (id, nickname, scheduler, daily_new_limit, daily_review_limit)
+		VALUES ($1, $2, $3, $4, $5)`
 
-	if _, err := r.db.ExecContext(context.Background(), query, u.ID, u.Nickname); err != nil {
+	if _, err := r.db.ExecContext(context.Background(), query, u.ID, u.Nickname, u.Scheduler, u.DailyNewLimit, u.DailyReviewLimit); err != nil {
 		return user.User{}, fmt.Errorf("insert user: %w", err)
 	}
 
@@ -36,12 +36,12 @@ func (r *PostgresRepository) Save(u user.User) (user.User, error) {
 
 func (r *PostgresRepository) FindByID(id string) (user.User, error) {
 	const query = `
-		SELECT id, nickname
+		SELECT id, nickname, scheduler, daily_new_limit, daily_review_limit
 		FROM users
 		WHERE id = $1`
 
 	var u user.User
-	err := r.db.QueryRowContext(context.Background(), query, id).Scan(&u.ID, &u.Nickname)
+	err := r.db.QueryRowContext(context.Background(), query, id).Scan(&u.ID, &u.Nickname, &u.Scheduler, &u.DailyNewLimit, &u.DailyReviewLimit)
 	if errors.Is(err, sql.ErrNoRows) {
 		return user.User{}, user.ErrNotFound
 	}
@@ -54,7 +54,7 @@ func (r *PostgresRepository) FindByID(id string) (user.User, error) {
 
 func (r *PostgresRepository) FindAll() ([]user.User, error) {
 	const query = `
-		SELECT id, nickname
+		SELECT id, nickname, scheduler, daily_new_limit, daily_review_limit
 		FROM users
 		ORDER BY nickname ASC`
 
@@ -67,7 +67,7 @@ func (r *PostgresRepository) FindAll() ([]user.User, error) {
 	var users []user.User
 	for rows.Next() {
 		var u user.User
-		if err := rows.Scan(&u.ID, &u.Nickname); err != nil {
+		if err := rows.Scan(&u.ID, &u.Nickname, &u.Scheduler, &u.DailyNewLimit, &u.DailyReviewLimit); err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
 		users = append(users, u)
@@ -87,10 +87,10 @@ func (r *PostgresRepository) Update(u user.User) (user.User, error) {
 
 	const query = `
 		UPDATE users
-		SET nickname = $1
-		WHERE id = $2`
+		SET nickname = $1, scheduler = $2, daily_new_limit = $3, daily_review_limit = $4
+		WHERE id = $5`
 
-	res, err := r.db.ExecContext(context.Background(), query, u.Nickname, u.ID)
+	res, err := r.db.ExecContext(context.Background(), query, u.Nickname, u.Scheduler, u.DailyNewLimit, u.DailyReviewLimit, u.ID)
 	if err != nil {
 		return user.User{}, fmt.Errorf("update user: %w", err)
 	}
@@ -4,20 +4,37 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 
 	telegramcardapp "flash2fy/internal/telegram/application/card"
+	telegramcatalogapp "flash2fy/internal/telegram/application/catalog"
+	telegramdeckapp "flash2fy/internal/telegram/application/deck"
+	telegramformulaapp "flash2fy/internal/telegram/application/formula"
+	telegramreminderapp "flash2fy/internal/telegram/application/reminder"
+	telegramreviewapp "flash2fy/internal/telegram/application/review"
 	telegramuserapp "flash2fy/internal/telegram/application/user"
 )
 
+// Services groups the Telegram application services driven by the bot.
+type Services struct {
+	Cards     *telegramcardapp.Service
+	Users     *telegramuserapp.Service
+	Reviews   *telegramreviewapp.Service
+	Reminders *telegramreminderapp.Service
+	Decks     *telegramdeckapp.Service
+	Catalog   *telegramcatalogapp.Service
+	Formulas  *telegramformulaapp.Service
+}
+
 // Bot exposes Telegram commands to manage flashcards.
 type Bot struct {
-	cardService *telegramcardapp.Service
-	userService *telegramuserapp.Service
-	client      *bot.Bot
+	services Services
+	handler  *updateHandler
+	client   *bot.Bot
 }
 
 // WebhookConfig configures the Telegram bot webhook.
@@ -28,18 +45,40 @@ type WebhookConfig struct {
 	AllowedUpdates     []string
 }
 
-// New constructs a Telegram bot configured to create cards via chat messages.
-func New(token string, cardService *telegramcardapp.Service, userService *telegramuserapp.Service, options ...bot.Option) (*Bot, error) {
+// New constructs a Telegram bot configured to create and review cards via chat messages.
+func New(token string, services Services, options ...bot.Option) (*Bot, error) {
 	if token == "" {
 		return nil, errors.New("telegram bot token must not be empty")
 	}
 	handler := &updateHandler{
-		cardService: cardService,
-		userService: userService,
+		cardService:     services.Cards,
+		userService:     services.Users,
+		reviewService:   services.Reviews,
+		reminderService: services.Reminders,
+		deckService:     services.Decks,
+		catalogService:  services.Catalog,
+		formulaService:  services.Formulas,
 		send: func(ctx context.Context, client *bot.Bot, params *bot.SendMessageParams) error {
 			_, err := client.SendMessage(ctx, params)
 			return err
 		},
+		edit: func(ctx context.Context, client *bot.Bot, params *bot.EditMessageTextParams) error {
+			_, err := client.EditMessageText(ctx, params)
+			return err
+		},
+		answer: func(ctx context.Context, client *bot.Bot, params *bot.AnswerCallbackQueryParams) error {
+			_, err := client.AnswerCallbackQuery(ctx, params)
+			return err
+		},
+		sendPhoto: func(ctx context.Context, client *bot.Bot, params *bot.SendPhotoParams) error {
+			_, err := client.SendPhoto(ctx, params)
+			return err
+		},
+		sendVoice: func(ctx context.Context, client *bot.Bot, params *bot.SendVoiceParams) error {
+			_, err := client.SendVoice(ctx, params)
+			return err
+		},
+		download: downloadFile,
 	}
 
 	opts := []bot.Option{
@@ -53,12 +92,17 @@ func New(token string, cardService *telegramcardapp.Service, userService *telegr
 	}
 
 	return &Bot{
-		cardService: cardService,
-		userService: userService,
-		client:      client,
+		services: services,
+		handler:  handler,
+		client:   client,
 	}, nil
 }
 
+// SendReminder tells the chat how many cards are due and offers a button to start reviewing.
+func (b *Bot) SendReminder(ctx context.Context, chatID int64, due int) error {
+	return b.handler.sendReminder(ctx, b.client, chatID, due)
+}
+
 // Start begins polling for Telegram updates and blocks until ctx is cancelled.
 func (b *Bot) Start(ctx context.Context) {
 	b.client.Start(ctx)
@@ -95,15 +139,59 @@ func (b *Bot) WebhookHandler() http.HandlerFunc {
 }
 
 type updateHandler struct {
-	cardService *telegramcardapp.Service
-	userService *telegramuserapp.Service
-	send        func(ctx context.Context, client *bot.Bot, params *bot.SendMessageParams) error
+	cardService     *telegramcardapp.Service
+	userService     *telegramuserapp.Service
+	reviewService   *telegramreviewapp.Service
+	reminderService *telegramreminderapp.Service
+	deckService     *telegramdeckapp.Service
+	catalogService  *telegramcatalogapp.Service
+	formulaService  *telegramformulaapp.Service
+	send            func(ctx context.Context, client *bot.Bot, params *bot.SendMessageParams) error
+	edit            func(ctx context.Context, client *bot.Bot, params *bot.EditMessageTextParams) error
+	answer          func(ctx context.Context, client *bot.Bot, params *bot.AnswerCallbackQueryParams) error
+	sendPhoto       func(ctx context.Context, client *bot.Bot, params *bot.SendPhotoParams) error
+	sendVoice       func(ctx context.Context, client *bot.Bot, params *bot.SendVoiceParams) error
+	download        func(ctx context.Context, client *bot.Bot, fileID string) (io.ReadCloser, error)
 }
 
 func (h *updateHandler) handle(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.CallbackQuery != nil {
+		h.handleCallback(ctx, b, update.CallbackQuery)
+		return
+	}
+	if update.Message != nil && len(update.Message.Photo) > 0 {
+		h.handlePhoto(ctx, b, update)
+		return
+	}
+	if update.Message != nil && (update.Message.Voice != nil || update.Message.Audio != nil) {
+		h.handleAudio(ctx, b, update)
+		return
+	}
 	if update.Message == nil || update.Message.Text == "" {
 		return
 	}
 
 	h.dispatch(ctx, b, update)
 }
+
+// downloadFile fetches the content of a file sent to the bot through the Bot API file endpoint.
+func downloadFile(ctx context.Context, client *bot.Bot, fileID string) (io.ReadCloser, error) {
+	file, err := client.GetFile(ctx, &bot.GetFileParams{FileID: fileID})
+	if err != nil {
+		return nil, fmt.Errorf("get file: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, fmt.Errorf("build file request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download file: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download file: unexpected status %s", resp.Status)
+	}
+	return resp.Body, nil
+}
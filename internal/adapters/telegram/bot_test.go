@@ -3,6 +3,8 @@ package telegram
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -13,14 +15,35 @@ import (
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 
+	formularender "flash2fy/internal/adapters/render/formula"
 	cardstorage "flash2fy/internal/adapters/storage/card"
+	catalogstorage "flash2fy/internal/adapters/storage/catalog"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	studystorage "flash2fy/internal/adapters/storage/study"
 	telecardstorage "flash2fy/internal/adapters/storage/telegram/card"
+	telereminderstorage "flash2fy/internal/adapters/storage/telegram/reminder"
+	telereviewstorage "flash2fy/internal/adapters/storage/telegram/review"
 	teleuserstorage "flash2fy/internal/adapters/storage/telegram/user"
 	userstorage "flash2fy/internal/adapters/storage/user"
 	appcardapp "flash2fy/internal/app/application/card"
+	appcatalogapp "flash2fy/internal/app/application/catalog"
+	appdeckapp "flash2fy/internal/app/application/deck"
+	appformulaapp "flash2fy/internal/app/application/formula"
+	appstudyapp "flash2fy/internal/app/application/study"
 	appuserapp "flash2fy/internal/app/application/user"
 	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/formula"
+	"flash2fy/internal/app/domain/media"
+	"flash2fy/internal/app/domain/revision"
+	appuser "flash2fy/internal/app/domain/user"
 	telegramcardapp "flash2fy/internal/telegram/application/card"
+	telegramcatalogapp "flash2fy/internal/telegram/application/catalog"
+	telegramdeckapp "flash2fy/internal/telegram/application/deck"
+	telegramformulaapp "flash2fy/internal/telegram/application/formula"
+	telegramreminderapp "flash2fy/internal/telegram/application/reminder"
+	telegramreviewapp "flash2fy/internal/telegram/application/review"
 	telegramuserapp "flash2fy/internal/telegram/application/user"
 	telegrmdomain "flash2fy/internal/telegram/domain"
 )
@@ -123,6 +146,60 @@ func TestHandleCreateCard(t *testing.T) {
 	}
 }
 
+func TestExtractTags(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantText string
+		wantTags []string
+	}{
+		{"der Hund #german #nouns", "der Hund", []string{"german", "nouns"}},
+		{"#verbs gehen", "gehen", []string{"verbs"}},
+		{"C# is not a tag", "C# is not a tag", nil},
+		{"no tags", "no tags", nil},
+	}
+
+	for _, tc := range tests {
+		text, tags := extractTags(tc.input)
+		if text != tc.wantText || strings.Join(tags, ",") != strings.Join(tc.wantTags, ",") {
+			t.Fatalf("extractTags(%q) = %q, %v; want %q, %v", tc.input, text, tags, tc.wantText, tc.wantTags)
+		}
+	}
+}
+
+func TestHandleCreateCardWithTags(t *testing.T) {
+	cardService, userService, appCardRepo, _, _, _ := newTelegramServices()
+
+	var capturedMessage string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			capturedMessage = params.Text
+			return nil
+		},
+	}
+
+	update := &models.Update{
+		Message: &models.Message{
+			Chat: models.Chat{ID: 123},
+			From: &models.User{ID: 555, FirstName: "John"},
+			Text: "der Hund #German",
+		},
+	}
+	h.handleCreateCard(context.Background(), nil, update, update.Message.Text)
+
+	cards, err := appCardRepo.FindAll()
+	if err != nil || len(cards) != 1 {
+		t.Fatalf("expected 1 card persisted, got %d (%v)", len(cards), err)
+	}
+	if cards[0].Front != "der Hund" || len(cards[0].Tags) != 1 || cards[0].Tags[0] != "german" {
+		t.Fatalf("expected tag stripped from the front, got %+v", cards[0])
+	}
+	if !strings.Contains(capturedMessage, "Tags: #german") {
+		t.Fatalf("expected tags in the reply, got %q", capturedMessage)
+	}
+}
+
 func TestHandleCreateCardRequiresFront(t *testing.T) {
 	cardService, userService, appCardRepo, _, _, _ := newTelegramServices()
 
@@ -152,15 +229,57 @@ func TestHandleCreateCardRequiresFront(t *testing.T) {
 
 type failingAppCardService struct{}
 
-func (failingAppCardService) CreateCard(string, string, string) (card.Card, error) {
+func (failingAppCardService) CreateCard(string, string, string, ...string) (card.Card, error) {
 	return card.Card{}, errors.New("boom")
 }
 
-func (failingAppCardService) GetCard(string) (card.Card, error) {
+func (failingAppCardService) GetCard(string, string) (card.Card, error) {
 	return card.Card{}, card.ErrNotFound
 }
 
-func (failingAppCardService) DeleteCard(string) error { return nil }
+func (failingAppCardService) EditCard(string, string, string, revision.Editor) (card.Card, error) {
+	return card.Card{}, errors.New("boom")
+}
+
+func (failingAppCardService) DeleteCard(string, string) error { return nil }
+
+func (failingAppCardService) Trash(string) ([]card.Card, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingAppCardService) RestoreCard(string, string) (card.Card, error) {
+	return card.Card{}, errors.New("boom")
+}
+
+func (failingAppCardService) PurgeCard(string, string) error { return nil }
+
+func (failingAppCardService) LeitnerBoxes(string) ([]card.LeitnerBox, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingAppCardService) SuspendCard(string, string) (card.Card, error) {
+	return card.Card{}, errors.New("boom")
+}
+
+func (failingAppCardService) UnsuspendCard(string, string) (card.Card, error) {
+	return card.Card{}, errors.New("boom")
+}
+
+func (failingAppCardService) Search(string, string, int) ([]card.SearchHit, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingAppCardService) AttachMedia(string, string, media.Side, string, string, io.Reader) (media.Attachment, error) {
+	return media.Attachment{}, errors.New("boom")
+}
+
+func (failingAppCardService) Media(string, string) ([]media.Attachment, error) {
+	return nil, errors.New("boom")
+}
+
+func (failingAppCardService) OpenMedia(string, string, string) (media.Attachment, io.ReadSeekCloser, error) {
+	return media.Attachment{}, nil, errors.New("boom")
+}
 
 type noopTelegramCardRepo struct{}
 
@@ -171,6 +290,9 @@ func (noopTelegramCardRepo) FindByCoreID(string) (telegrmdomain.Card, error) {
 	return telegrmdomain.Card{}, telegrmdomain.ErrCardNotFound
 }
 func (noopTelegramCardRepo) DeleteByCoreID(string) error { return nil }
+func (noopTelegramCardRepo) ChatIDsByOwner(int64) ([]int64, error) {
+	return nil, nil
+}
 
 func TestHandleCreateCardPropagatesError(t *testing.T) {
 	appUserRepo := userstorage.NewMemoryRepository()
@@ -252,7 +374,7 @@ func TestConfigureWebhook(t *testing.T) {
 	t.Cleanup(server.Close)
 
 	cardService, userService, _, _, _, _ := newTelegramServices()
-	tg, err := New(token, cardService, userService, bot.WithSkipGetMe(), bot.WithServerURL(server.URL))
+	tg, err := New(token, Services{Cards: cardService, Users: userService}, bot.WithSkipGetMe(), bot.WithServerURL(server.URL))
 	if err != nil {
 		t.Fatalf("init telegram bot: %v", err)
 	}
@@ -297,7 +419,7 @@ func TestConfigureWebhookError(t *testing.T) {
 	t.Cleanup(server.Close)
 
 	cardService, userService, _, _, _, _ := newTelegramServices()
-	tg, err := New(token, cardService, userService, bot.WithSkipGetMe(), bot.WithServerURL(server.URL))
+	tg, err := New(token, Services{Cards: cardService, Users: userService}, bot.WithSkipGetMe(), bot.WithServerURL(server.URL))
 	if err != nil {
 		t.Fatalf("init telegram bot: %v", err)
 	}
@@ -313,3 +435,853 @@ func TestConfigureWebhookError(t *testing.T) {
 		t.Fatalf("expected no secret_token to be sent, got %q", secretReceived)
 	}
 }
+
+func TestHandleModeCommand(t *testing.T) {
+	cardService, userService, _, _, appUserRepo, teleUserRepo := newTelegramServices()
+
+	var messages []string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			messages = append(messages, params.Text)
+			return nil
+		},
+	}
+
+	newUpdate := func(text string) *models.Update {
+		return &models.Update{
+			Message: &models.Message{
+				Chat: models.Chat{ID: 321},
+				From: &models.User{ID: 77, FirstName: "Kid"},
+				Text: text,
+			},
+		}
+	}
+
+	h.dispatch(context.Background(), nil, newUpdate("/mode leitner"))
+	h.dispatch(context.Background(), nil, newUpdate("/mode"))
+	h.dispatch(context.Background(), nil, newUpdate("/mode anki"))
+
+	if len(messages) != 3 {
+		t.Fatalf("expected 3 replies, got %d", len(messages))
+	}
+	if !strings.Contains(messages[0], "switched to leitner") {
+		t.Fatalf("expected switch confirmation, got %q", messages[0])
+	}
+	if !strings.Contains(messages[1], "Your study mode is leitner") {
+		t.Fatalf("expected current mode, got %q", messages[1])
+	}
+	if !strings.Contains(messages[2], "Failed to switch study mode") {
+		t.Fatalf("expected failure for unknown mode, got %q", messages[2])
+	}
+
+	teleUser, err := teleUserRepo.FindByTelegramID(77)
+	if err != nil {
+		t.Fatalf("expected telegram user projection: %v", err)
+	}
+	coreUser, err := appUserRepo.FindByID(teleUser.CoreUserID)
+	if err != nil {
+		t.Fatalf("expected core user: %v", err)
+	}
+	if coreUser.Scheduler != appuser.SchedulerLeitner {
+		t.Fatalf("expected leitner scheduler persisted, got %q", coreUser.Scheduler)
+	}
+}
+
+func TestHandleBoxesCommand(t *testing.T) {
+	cardService, userService, _, _, _, _ := newTelegramServices()
+
+	var captured string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			captured = params.Text
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 88, FirstName: "Kid"}
+	h.dispatch(context.Background(), nil, &models.Update{
+		Message: &models.Message{Chat: models.Chat{ID: 1}, From: from, Text: "Capital of France?"},
+	})
+	h.dispatch(context.Background(), nil, &models.Update{
+		Message: &models.Message{Chat: models.Chat{ID: 1}, From: from, Text: "/boxes"},
+	})
+
+	if !strings.Contains(captured, "Box 1 (every day): 1 cards") {
+		t.Fatalf("expected first box to hold the new card, got %q", captured)
+	}
+	if !strings.Contains(captured, "Box 5 (every 16 days): 0 cards") {
+		t.Fatalf("expected last box listed, got %q", captured)
+	}
+}
+
+func TestReviewCommandAndCallbacks(t *testing.T) {
+	appCardRepo := cardstorage.NewMemoryRepository()
+	appCardService := appcardapp.NewService(appCardRepo)
+	appUserRepo := userstorage.NewMemoryRepository()
+	appUserService := appuserapp.NewService(appUserRepo)
+	appStudyService := appstudyapp.NewService(appCardRepo, appCardService, studystorage.NewMemoryRepository(), appUserRepo)
+
+	var sent []*bot.SendMessageParams
+	var edited []*bot.EditMessageTextParams
+	var answered, notices []string
+	h := &updateHandler{
+		cardService:   telegramcardapp.NewService(appCardService, telecardstorage.NewMemoryRepository()),
+		userService:   telegramuserapp.NewService(appUserService, teleuserstorage.NewMemoryRepository()),
+		reviewService: telegramreviewapp.NewService(appStudyService, telereviewstorage.NewMemoryRepository()),
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			sent = append(sent, params)
+			return nil
+		},
+		edit: func(ctx context.Context, _ *bot.Bot, params *bot.EditMessageTextParams) error {
+			edited = append(edited, params)
+			return nil
+		},
+		answer: func(ctx context.Context, _ *bot.Bot, params *bot.AnswerCallbackQueryParams) error {
+			answered = append(answered, params.CallbackQueryID)
+			notices = append(notices, params.Text)
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 99, FirstName: "Rev"}
+	chat := models.Chat{ID: 5}
+	h.handle(context.Background(), nil, &models.Update{
+		Message: &models.Message{Chat: chat, From: from, Text: "/review"},
+	})
+	if got := sent[len(sent)-1].Text; got != messageReviewNothing {
+		t.Fatalf("expected nothing-due message, got %q", got)
+	}
+
+	h.handle(context.Background(), nil, &models.Update{
+		Message: &models.Message{Chat: chat, From: from, Text: "Capital of France?"},
+	})
+	h.handle(context.Background(), nil, &models.Update{
+		Message: &models.Message{Chat: chat, From: from, Text: "/review"},
+	})
+
+	prompt := sent[len(sent)-1]
+	markup, ok := prompt.ReplyMarkup.(*models.InlineKeyboardMarkup)
+	if !ok || len(markup.InlineKeyboard) != 1 {
+		t.Fatalf("expected show-answer keyboard, got %#v", prompt.ReplyMarkup)
+	}
+	if !strings.Contains(prompt.Text, "Capital of France?") {
+		t.Fatalf("expected card front in prompt, got %q", prompt.Text)
+	}
+
+	callbackFrom := func(user *models.User, id, data string) {
+		h.handle(context.Background(), nil, &models.Update{
+			CallbackQuery: &models.CallbackQuery{
+				ID:      id,
+				From:    *user,
+				Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: chat}},
+				Data:    data,
+			},
+		})
+	}
+	callback := func(id, data string) { callbackFrom(from, id, data) }
+
+	callback("q1", markup.InlineKeyboard[0][0].CallbackData)
+	grades, ok := edited[len(edited)-1].ReplyMarkup.(*models.InlineKeyboardMarkup)
+	if !ok || len(grades.InlineKeyboard[0]) != 4 {
+		t.Fatalf("expected four grade buttons, got %#v", edited[len(edited)-1].ReplyMarkup)
+	}
+	for _, button := range grades.InlineKeyboard[0] {
+		if len(button.CallbackData) > 64 {
+			t.Fatalf("callback data exceeds Telegram limit: %q", button.CallbackData)
+		}
+	}
+
+	stranger := &models.User{ID: 100, FirstName: "Mallory"}
+	editsBefore := len(edited)
+	callbackFrom(stranger, "q-stranger", grades.InlineKeyboard[0][3].CallbackData)
+	if len(edited) != editsBefore || notices[len(notices)-1] != messageReviewForeign {
+		t.Fatalf("expected another user's grade rejected without editing, got %q", notices[len(notices)-1])
+	}
+	if pending, _ := appCardRepo.FindAll(); !pending[0].Review.IsNew() {
+		t.Fatalf("expected card left ungraded by another user, got %+v", pending[0])
+	}
+
+	callback("q2", grades.InlineKeyboard[0][2].CallbackData)
+	if got := edited[len(edited)-1].Text; !strings.HasPrefix(got, "Session finished") {
+		t.Fatalf("expected session summary, got %q", got)
+	}
+	if len(answered) != 3 {
+		t.Fatalf("expected every callback to be answered, got %v", answered)
+	}
+
+	reviewed, err := appCardRepo.FindAll()
+	if err != nil || reviewed[0].Review.IsNew() {
+		t.Fatalf("expected card to be graded, got %+v (%v)", reviewed, err)
+	}
+
+	callback("q3", grades.InlineKeyboard[0][2].CallbackData)
+	if got := edited[len(edited)-1].Text; got != messageReviewExpired {
+		t.Fatalf("expected stale callback to report expiry, got %q", got)
+	}
+}
+
+func TestHandleRemindersCommand(t *testing.T) {
+	appCardRepo := cardstorage.NewMemoryRepository()
+	appCardService := appcardapp.NewService(appCardRepo)
+	teleCardRepo := telecardstorage.NewMemoryRepository()
+	cardService, userService, _, _, _, _ := newTelegramServices()
+
+	var captured string
+	h := &updateHandler{
+		cardService:     cardService,
+		userService:     userService,
+		reminderService: telegramreminderapp.NewService(appCardService, telereminderstorage.NewMemoryRepository(), teleCardRepo),
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			captured = params.Text
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 77, FirstName: "Sleepy"}
+	run := func(text string) {
+		h.dispatch(context.Background(), nil, &models.Update{
+			Message: &models.Message{Chat: models.Chat{ID: 1}, From: from, Text: text},
+		})
+	}
+
+	run("/reminders")
+	if !strings.Contains(captured, "on at 09:00 (UTC)") {
+		t.Fatalf("expected default reminder, got %q", captured)
+	}
+
+	run("/reminders off")
+	if captured != messageRemindersOff {
+		t.Fatalf("expected opt-out confirmation, got %q", captured)
+	}
+
+	run("/reminders 07:45 Asia/Tokyo")
+	if !strings.Contains(captured, "on at 07:45 (Asia/Tokyo)") {
+		t.Fatalf("expected rescheduled reminder, got %q", captured)
+	}
+
+	run("/reminders 25:00")
+	if !strings.HasPrefix(captured, "Failed to update reminders") {
+		t.Fatalf("expected invalid time to be rejected, got %q", captured)
+	}
+}
+
+func TestSendReminderOffersReview(t *testing.T) {
+	var captured *bot.SendMessageParams
+	h := &updateHandler{
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			captured = params
+			return nil
+		},
+	}
+
+	if err := h.sendReminder(context.Background(), nil, 12, 3); err != nil {
+		t.Fatalf("send reminder failed: %v", err)
+	}
+	if captured.ChatID != int64(12) || !strings.Contains(captured.Text, "3 cards due") {
+		t.Fatalf("unexpected reminder %+v", captured)
+	}
+	markup, ok := captured.ReplyMarkup.(*models.InlineKeyboardMarkup)
+	if !ok || markup.InlineKeyboard[0][0].CallbackData != callbackReviewStart {
+		t.Fatalf("expected start-review button, got %#v", captured.ReplyMarkup)
+	}
+}
+
+func TestSuspendButtons(t *testing.T) {
+	cardService, userService, appCardRepo, _, _, _ := newTelegramServices()
+
+	var sent *bot.SendMessageParams
+	var edited *bot.EditMessageTextParams
+	var notice string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			sent = params
+			return nil
+		},
+		edit: func(ctx context.Context, _ *bot.Bot, params *bot.EditMessageTextParams) error {
+			edited = params
+			return nil
+		},
+		answer: func(ctx context.Context, _ *bot.Bot, params *bot.AnswerCallbackQueryParams) error {
+			notice = params.Text
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 66, FirstName: "Leech"}
+	chat := models.Chat{ID: 3}
+	h.handle(context.Background(), nil, &models.Update{
+		Message: &models.Message{Chat: chat, From: from, Text: "Tricky word"},
+	})
+
+	markup, ok := sent.ReplyMarkup.(*models.InlineKeyboardMarkup)
+	if !ok || !strings.HasPrefix(markup.InlineKeyboard[0][0].CallbackData, callbackCardSuspend) {
+		t.Fatalf("expected suspend button on created card, got %#v", sent.ReplyMarkup)
+	}
+
+	pressAs := func(user *models.User, data string) {
+		h.handle(context.Background(), nil, &models.Update{
+			CallbackQuery: &models.CallbackQuery{
+				ID:      "q",
+				From:    *user,
+				Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 4, Chat: chat, Text: sent.Text}},
+				Data:    data,
+			},
+		})
+	}
+	press := func(data string) { pressAs(from, data) }
+
+	cardID := strings.TrimPrefix(markup.InlineKeyboard[0][0].CallbackData, callbackCardSuspend)
+	pressAs(&models.User{ID: 67, FirstName: "Mallory"}, markup.InlineKeyboard[0][0].CallbackData)
+	if stored, _ := appCardRepo.FindByID(cardID); stored.Suspended || notice != messageCardUnavailable || edited != nil {
+		t.Fatalf("expected another user's suspend rejected, got %+v (%q)", stored, notice)
+	}
+
+	press(markup.InlineKeyboard[0][0].CallbackData)
+	stored, err := appCardRepo.FindByID(cardID)
+	if err != nil || !stored.Suspended {
+		t.Fatalf("expected card suspended, got %+v (%v)", stored, err)
+	}
+	toggled, ok := edited.ReplyMarkup.(*models.InlineKeyboardMarkup)
+	if !ok || toggled.InlineKeyboard[0][0].CallbackData != callbackCardUnsuspend+cardID {
+		t.Fatalf("expected unsuspend button after suspending, got %#v", edited.ReplyMarkup)
+	}
+	if edited.Text != sent.Text {
+		t.Fatalf("expected message text kept, got %q", edited.Text)
+	}
+
+	press(toggled.InlineKeyboard[0][0].CallbackData)
+	if stored, _ := appCardRepo.FindByID(cardID); stored.Suspended {
+		t.Fatalf("expected card unsuspended")
+	}
+}
+
+func TestFindCommand(t *testing.T) {
+	cardService, userService, _, _, _, _ := newTelegramServices()
+
+	var messages []string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			messages = append(messages, params.Text)
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 555, FirstName: "John"}
+	for _, text := range []string{"der Hund bellt", "die Katze schläft"} {
+		update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: from, Text: text}}
+		h.handleCreateCard(context.Background(), nil, update, text)
+	}
+
+	find := func(text string) string {
+		messages = nil
+		update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: from, Text: text}}
+		h.handleCommand(context.Background(), nil, update, text)
+		if len(messages) != 1 {
+			t.Fatalf("expected one reply to %q, got %v", text, messages)
+		}
+		return messages[0]
+	}
+
+	if reply := find("/find hund"); !strings.Contains(reply, "<b>Hund</b>") || strings.Contains(reply, "Katze") {
+		t.Fatalf("expected only the dog card highlighted, got %q", reply)
+	}
+	if reply := find("/find Elefant"); reply != messageFindNone {
+		t.Fatalf("expected no results, got %q", reply)
+	}
+	if reply := find("/find"); reply != messageFindUsage {
+		t.Fatalf("expected usage hint, got %q", reply)
+	}
+}
+
+func TestEditCommand(t *testing.T) {
+	cardService, userService, appCardRepo, _, _, _ := newTelegramServices()
+
+	var messages []string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			messages = append(messages, params.Text)
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 555, FirstName: "John"}
+	h.handleCreateCard(context.Background(), nil, &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: from, Text: "Hund"}}, "Hund")
+	created := &models.Message{Text: messages[0]}
+	cardID := repliedCardID(created)
+
+	edit := func(text string, reply *models.Message, sender *models.User) string {
+		messages = nil
+		update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: sender, Text: text, ReplyToMessage: reply}}
+		h.handleCommand(context.Background(), nil, update, text)
+		if len(messages) != 1 {
+			t.Fatalf("expected one reply to %q, got %v", text, messages)
+		}
+		return messages[0]
+	}
+
+	if reply := edit("/edit der Hund | the dog", created, from); !strings.HasPrefix(reply, "Card updated") {
+		t.Fatalf("expected the card updated, got %q", reply)
+	}
+	if reply := edit("/edit **der** Hund", created, from); !strings.Contains(reply, "<b>der</b> Hund") {
+		t.Fatalf("expected the new front shown as Telegram HTML, got %q", reply)
+	}
+	if c, _ := appCardRepo.FindByID(cardID); c.Front != "**der** Hund" || c.Back != "the dog" {
+		t.Fatalf("expected the front changed and the back kept, got %+v", c)
+	}
+
+	if reply := edit("/edit der Hund", nil, from); reply != messageEditUsage {
+		t.Fatalf("expected usage hint without a replied card, got %q", reply)
+	}
+	if reply := edit("/edit", created, from); reply != messageEditUsage {
+		t.Fatalf("expected usage hint without content, got %q", reply)
+	}
+	if reply := edit("/edit mine", created, &models.User{ID: 556, FirstName: "Eve"}); !strings.HasPrefix(reply, "Failed to update the card") {
+		t.Fatalf("expected another user's card to be refused, got %q", reply)
+	}
+}
+
+func TestTrashCommands(t *testing.T) {
+	cardService, userService, appCardRepo, _, _, _ := newTelegramServices()
+
+	var messages []string
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			messages = append(messages, params.Text)
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 555, FirstName: "John"}
+	h.handleCreateCard(context.Background(), nil, &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: from, Text: "Hund"}}, "Hund")
+	created := &models.Message{Text: messages[0]}
+	cardID := repliedCardID(created)
+
+	command := func(text string, reply *models.Message, sender *models.User) string {
+		messages = nil
+		update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: sender, Text: text, ReplyToMessage: reply}}
+		h.handleCommand(context.Background(), nil, update, text)
+		if len(messages) != 1 {
+			t.Fatalf("expected one reply to %q, got %v", text, messages)
+		}
+		return messages[0]
+	}
+
+	if reply := command("/delete", nil, from); reply != messageDeleteUsage {
+		t.Fatalf("expected usage hint without a replied card, got %q", reply)
+	}
+	if reply := command("/delete", created, &models.User{ID: 556, FirstName: "Eve"}); !strings.HasPrefix(reply, "Failed to delete the card") {
+		t.Fatalf("expected another user's card to be refused, got %q", reply)
+	}
+	if reply := command("/delete", created, from); !strings.Contains(reply, "/restore "+cardID) {
+		t.Fatalf("expected the card moved to the trash, got %q", reply)
+	}
+	if _, err := appCardRepo.FindByID(cardID); err != card.ErrNotFound {
+		t.Fatalf("expected the card hidden, got %v", err)
+	}
+
+	if reply := command("/trash", nil, from); !strings.HasPrefix(reply, messageTrashHeader) || !strings.Contains(reply, "/restore "+cardID) {
+		t.Fatalf("expected the card listed in the trash, got %q", reply)
+	}
+	if reply := command("/restore", nil, from); reply != messageRestoreUsage {
+		t.Fatalf("expected usage hint without a card ID, got %q", reply)
+	}
+	if reply := command("/restore "+cardID, nil, from); !strings.HasPrefix(reply, "Card restored") {
+		t.Fatalf("expected the card restored, got %q", reply)
+	}
+	if reply := command("/trash", nil, from); reply != messageTrashEmpty {
+		t.Fatalf("expected an empty trash, got %q", reply)
+	}
+}
+
+func TestStartAcceptsDeckInvite(t *testing.T) {
+	_, userService, _, _, _, _ := newTelegramServices()
+	appDecks := appdeckapp.NewService(deckstorage.NewMemoryRepository(), cardstorage.NewMemoryRepository(),
+		appdeckapp.WithSharing(deckstorage.NewMemoryMemberRepository(), deckstorage.NewMemoryInviteRepository()),
+	)
+
+	var messages []string
+	h := &updateHandler{
+		userService: userService,
+		deckService: telegramdeckapp.NewService(appDecks),
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			messages = append(messages, params.Text)
+			return nil
+		},
+	}
+
+	d, err := appDecks.CreateDeck("German", "", "teacher", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	invite, err := appDecks.Invite(d.ID, "teacher", deck.RoleEditor)
+	if err != nil {
+		t.Fatalf("invite failed: %v", err)
+	}
+
+	start := func(text string) string {
+		messages = nil
+		update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: &models.User{ID: 555, FirstName: "Ann"}, Text: text}}
+		h.handleCommand(context.Background(), nil, update, text)
+		if len(messages) != 1 {
+			t.Fatalf("expected one reply to %q, got %v", text, messages)
+		}
+		return messages[0]
+	}
+
+	if reply := start("/start " + invite.Token); reply != `You joined the deck "German" as editor ✅` {
+		t.Fatalf("unexpected reply: %q", reply)
+	}
+	coreUser, _, _ := userService.EnsureUser(555, "Ann", "")
+	if shared, _ := appDecks.SharedDecks(coreUser.ID); len(shared) != 1 || shared[0].Deck.ID != d.ID {
+		t.Fatalf("expected the bot user to be a member, got %+v", shared)
+	}
+
+	if reply := start("/start " + invite.Token); reply != messageInviteGone {
+		t.Fatalf("expected a used invite to be rejected, got %q", reply)
+	}
+	if reply := start("/start"); reply != messageUsage {
+		t.Fatalf("expected the usage hint, got %q", reply)
+	}
+}
+
+func TestCatalogAndCloneCommands(t *testing.T) {
+	_, userService, _, _, _, _ := newTelegramServices()
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	appCatalog := appcatalogapp.NewService(catalogstorage.NewMemoryRepository(), decks, cards)
+	appDecks := appdeckapp.NewService(decks, cards)
+	appCards := appcardapp.NewService(cards, appcardapp.WithDecks(decks))
+
+	var messages []string
+	h := &updateHandler{
+		userService:    userService,
+		catalogService: telegramcatalogapp.NewService(appCatalog),
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			messages = append(messages, params.Text)
+			return nil
+		},
+	}
+
+	d, err := appDecks.CreateDeck("German", "", "teacher", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	if _, err := appCards.CreateCardInDeck("der Hund", "dog", "teacher", d.ID); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := appCatalog.Publish(d.ID, "teacher", "German basics", "de", "Animals"); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	command := func(text string) string {
+		messages = nil
+		update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: &models.User{ID: 555, FirstName: "Ann"}, Text: text}}
+		h.handleCommand(context.Background(), nil, update, text)
+		if len(messages) != 1 {
+			t.Fatalf("expected one reply to %q, got %v", text, messages)
+		}
+		return messages[0]
+	}
+
+	if reply := command("/catalog animals"); reply != "Public decks:\n1. German basics [de], 1 cards\n   /clone "+d.ID {
+		t.Fatalf("unexpected catalog reply: %q", reply)
+	}
+	if reply := command("/catalog spanish"); reply != messageCatalogNone {
+		t.Fatalf("expected no matches, got %q", reply)
+	}
+	if reply := command("/clone"); reply != messageCloneUsage {
+		t.Fatalf("expected the clone hint, got %q", reply)
+	}
+	if reply := command("/clone unknown"); reply != messageCloneMissing {
+		t.Fatalf("expected unknown decks to be rejected, got %q", reply)
+	}
+	if reply := command("/clone " + d.ID); reply != `Deck "German" cloned with 1 cards ✅` {
+		t.Fatalf("unexpected clone reply: %q", reply)
+	}
+
+	if reply := command("/sync"); reply != "Subscriptions synced ✅\nAdded: 0\nUpdated: 0\nRemoved: 0\nConflicts: 0" {
+		t.Fatalf("unexpected sync reply: %q", reply)
+	}
+
+	coreUser, _, _ := userService.EnsureUser(555, "Ann", "")
+	owned, _ := decks.FindByOwner(coreUser.ID)
+	if len(owned) != 1 || owned[0].SourceID != d.ID {
+		t.Fatalf("expected a cloned deck for the bot user, got %+v", owned)
+	}
+}
+
+func TestCardContentIsSentAsTelegramHTML(t *testing.T) {
+	cardService, userService, _, _, _, _ := newTelegramServices()
+
+	var sent []*bot.SendMessageParams
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			sent = append(sent, params)
+			return nil
+		},
+	}
+
+	text := "**der** Hund <3 & `code`"
+	update := &models.Update{Message: &models.Message{Chat: models.Chat{ID: 123}, From: &models.User{ID: 555, FirstName: "Ann"}, Text: text}}
+	h.handleCreateCard(context.Background(), nil, update, text)
+
+	if len(sent) != 1 || sent[0].ParseMode != models.ParseModeHTML {
+		t.Fatalf("expected one HTML message, got %+v", sent)
+	}
+	if !strings.Contains(sent[0].Text, "Front: <b>der</b> Hund &lt;3 &amp; <code>code</code>") {
+		t.Fatalf("expected rendered front, got %q", sent[0].Text)
+	}
+
+	sent = nil
+	invalid := "*unclosed"
+	update.Message.Text = invalid
+	h.handleCreateCard(context.Background(), nil, update, invalid)
+	if len(sent) != 1 || sent[0].Text != fmt.Sprintf(messageCreateFail, card.ErrUnclosedMarkup) {
+		t.Fatalf("expected invalid markdown to be rejected, got %+v", sent)
+	}
+}
+
+func TestTelegramHTML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"**a** *b* ~~c~~ `d<e>`", "<b>a</b> <i>b</i> <s>c</s> <code>d&lt;e&gt;</code>"},
+		{"see [docs](https://go.dev/?a=1&b=2)\nnext", `see <a href="https://go.dev/?a=1&amp;b=2">docs</a>` + "\nnext"},
+		{"plain <tag> & snake_case", "plain &lt;tag&gt; &amp; snake_case"},
+		{"*broken <b>", "*broken &lt;b&gt;"},
+		{"energy $E = mc^2$ costs $5", "energy <code>E = mc^2</code> costs $5"},
+	}
+	for _, tc := range tests {
+		if got := telegramHTML(tc.in); got != tc.want {
+			t.Fatalf("telegramHTML(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestPhotoCreatesCardWithImage(t *testing.T) {
+	appCardRepo := cardstorage.NewMemoryRepository()
+	attachments := mediastorage.NewMemoryRepository()
+	appCardService := appcardapp.NewService(appCardRepo, appcardapp.WithMedia(attachments, mediastorage.NewMemoryStorage()))
+	cardService := telegramcardapp.NewService(appCardService, telecardstorage.NewMemoryRepository())
+	_, userService, _, _, _, _ := newTelegramServices()
+
+	var (
+		sent       []string
+		downloaded string
+	)
+	h := &updateHandler{
+		cardService: cardService,
+		userService: userService,
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			sent = append(sent, params.Text)
+			return nil
+		},
+		download: func(ctx context.Context, _ *bot.Bot, fileID string) (io.ReadCloser, error) {
+			downloaded = fileID
+			return io.NopCloser(strings.NewReader("jpeg-bytes")), nil
+		},
+	}
+
+	update := &models.Update{Message: &models.Message{
+		Chat: models.Chat{ID: 123},
+		From: &models.User{ID: 555, FirstName: "Ann"},
+		Photo: []models.PhotoSize{
+			{FileID: "small", Width: 90, Height: 60},
+			{FileID: "large", Width: 1280, Height: 853},
+			{FileID: "medium", Width: 320, Height: 213},
+		},
+	}}
+	h.handle(context.Background(), nil, update)
+	if len(sent) != 1 || sent[0] != messagePhotoCaption || downloaded != "" {
+		t.Fatalf("expected a photo without caption to be refused, got %q", sent)
+	}
+
+	sent = nil
+	update.Message.Caption = "Which bone? #anatomy"
+	h.handle(context.Background(), nil, update)
+	if downloaded != "large" {
+		t.Fatalf("expected the largest photo to be downloaded, got %q", downloaded)
+	}
+	if len(sent) != 1 || !strings.Contains(sent[0], "Front: Which bone?") || !strings.Contains(sent[0], messageCreateImage) {
+		t.Fatalf("expected the photo card to be confirmed, got %q", sent)
+	}
+
+	cards, _ := appCardRepo.FindAll()
+	if len(cards) != 1 || len(cards[0].Tags) != 1 || cards[0].Tags[0] != "anatomy" {
+		t.Fatalf("expected one tagged card, got %+v", cards)
+	}
+	listed, _ := attachments.FindByCard(cards[0].ID)
+	if len(listed) != 1 || listed[0].Side != media.SideFront {
+		t.Fatalf("expected the photo on the front of the card, got %+v", listed)
+	}
+}
+
+func TestVoiceReplyAttachesPronunciationPlayedDuringReview(t *testing.T) {
+	appCardRepo := cardstorage.NewMemoryRepository()
+	appCardService := appcardapp.NewService(appCardRepo,
+		appcardapp.WithMedia(mediastorage.NewMemoryRepository(), mediastorage.NewMemoryStorage()))
+	appUserRepo := userstorage.NewMemoryRepository()
+	appStudyService := appstudyapp.NewService(appCardRepo, appCardService, studystorage.NewMemoryRepository(), appUserRepo)
+
+	var (
+		sent   []*bot.SendMessageParams
+		edited []*bot.EditMessageTextParams
+		voices []*bot.SendVoiceParams
+	)
+	h := &updateHandler{
+		cardService:   telegramcardapp.NewService(appCardService, telecardstorage.NewMemoryRepository()),
+		userService:   telegramuserapp.NewService(appuserapp.NewService(appUserRepo), teleuserstorage.NewMemoryRepository()),
+		reviewService: telegramreviewapp.NewService(appStudyService, telereviewstorage.NewMemoryRepository()),
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			sent = append(sent, params)
+			return nil
+		},
+		edit: func(ctx context.Context, _ *bot.Bot, params *bot.EditMessageTextParams) error {
+			edited = append(edited, params)
+			return nil
+		},
+		answer: func(ctx context.Context, _ *bot.Bot, params *bot.AnswerCallbackQueryParams) error {
+			return nil
+		},
+		sendVoice: func(ctx context.Context, _ *bot.Bot, params *bot.SendVoiceParams) error {
+			voices = append(voices, params)
+			return nil
+		},
+		download: func(ctx context.Context, _ *bot.Bot, fileID string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("ogg-" + fileID)), nil
+		},
+	}
+
+	from := &models.User{ID: 77, FirstName: "Ann"}
+	chat := models.Chat{ID: 5}
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Text: "der Hund"}})
+	created := sent[len(sent)-1]
+
+	voice := &models.Voice{FileID: "voice-1", MimeType: "audio/ogg"}
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Voice: voice}})
+	if got := sent[len(sent)-1].Text; got != messageAudioReply {
+		t.Fatalf("expected a voice message without reply to be refused, got %q", got)
+	}
+
+	reply := &models.Message{Text: created.Text, ReplyMarkup: *created.ReplyMarkup.(*models.InlineKeyboardMarkup)}
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Voice: voice, ReplyToMessage: reply}})
+	cards, _ := appCardRepo.FindAll()
+	if got := sent[len(sent)-1].Text; got != fmt.Sprintf(messageAudioOK, cards[0].ID) {
+		t.Fatalf("expected the pronunciation to be attached, got %q", got)
+	}
+
+	stranger := &models.User{ID: 78, FirstName: "Bob"}
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: stranger, Audio: &models.Audio{FileID: "audio-1", FileName: "hund.mp3"}, ReplyToMessage: reply}})
+	if got := sent[len(sent)-1].Text; got != fmt.Sprintf(messageAudioFail, card.ErrNotFound) {
+		t.Fatalf("expected another user's card to be refused, got %q", got)
+	}
+
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Text: "/review"}})
+	if len(voices) != 0 {
+		t.Fatalf("expected no audio with the question, got %d voice messages", len(voices))
+	}
+	markup := sent[len(sent)-1].ReplyMarkup.(*models.InlineKeyboardMarkup)
+	h.handle(context.Background(), nil, &models.Update{CallbackQuery: &models.CallbackQuery{
+		ID:      "q1",
+		From:    *from,
+		Message: models.MaybeInaccessibleMessage{Message: &models.Message{ID: 10, Chat: chat}},
+		Data:    markup.InlineKeyboard[0][0].CallbackData,
+	}})
+	if len(voices) != 1 || voices[0].ChatID != chat.ID {
+		t.Fatalf("expected the pronunciation with the answer, got %+v", voices)
+	}
+	upload, ok := voices[0].Voice.(*models.InputFileUpload)
+	if !ok {
+		t.Fatalf("expected an uploaded voice file, got %#v", voices[0].Voice)
+	}
+	if content, _ := io.ReadAll(upload.Data); string(content) != "ogg-voice-1" {
+		t.Fatalf("unexpected voice content %q", content)
+	}
+}
+
+func TestRepliedCardID(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *models.Message
+		want    string
+	}{
+		{"none", nil, ""},
+		{"created card", &models.Message{Text: "Card created ✅\nID: card-1\nFront: x"}, "card-1"},
+		{"suspend button", &models.Message{ReplyMarkup: *suspendKeyboard("card-2", true)}, "card-2"},
+		{"grade buttons", &models.Message{ReplyMarkup: *gradeKeyboard("card-3")}, "card-3"},
+		{"other", &models.Message{Text: "Session finished ✅"}, ""},
+	}
+	for _, tc := range tests {
+		if got := repliedCardID(tc.message); got != tc.want {
+			t.Fatalf("%s: repliedCardID = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestReviewSendsFormulasAsPhotos(t *testing.T) {
+	appCardRepo := cardstorage.NewMemoryRepository()
+	appCardService := appcardapp.NewService(appCardRepo)
+	appUserRepo := userstorage.NewMemoryRepository()
+	appStudyService := appstudyapp.NewService(appCardRepo, appCardService, studystorage.NewMemoryRepository(), appUserRepo)
+	renderer, err := formularender.NewRenderer()
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+
+	var (
+		sent   []*bot.SendMessageParams
+		photos []*bot.SendPhotoParams
+	)
+	h := &updateHandler{
+		cardService:    telegramcardapp.NewService(appCardService, telecardstorage.NewMemoryRepository()),
+		userService:    telegramuserapp.NewService(appuserapp.NewService(appUserRepo), teleuserstorage.NewMemoryRepository()),
+		reviewService:  telegramreviewapp.NewService(appStudyService, telereviewstorage.NewMemoryRepository()),
+		formulaService: telegramformulaapp.NewService(appformulaapp.NewService(renderer, mediastorage.NewMemoryStorage())),
+		send: func(ctx context.Context, _ *bot.Bot, params *bot.SendMessageParams) error {
+			sent = append(sent, params)
+			return nil
+		},
+		sendPhoto: func(ctx context.Context, _ *bot.Bot, params *bot.SendPhotoParams) error {
+			photos = append(photos, params)
+			return nil
+		},
+	}
+
+	from := &models.User{ID: 77, FirstName: "Ann"}
+	chat := models.Chat{ID: 5}
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Text: `Solve $\frac{1}$`}})
+	if got := sent[len(sent)-1].Text; got != fmt.Sprintf(messageCreateFail, formula.ErrMissingArgument) {
+		t.Fatalf("expected invalid LaTeX to be rejected, got %q", got)
+	}
+
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Text: `Energy: $E = mc^2$`}})
+	h.handle(context.Background(), nil, &models.Update{Message: &models.Message{Chat: chat, From: from, Text: "/review"}})
+	if !strings.Contains(sent[len(sent)-1].Text, "<code>E = mc^2</code>") {
+		t.Fatalf("expected the LaTeX source in the question, got %q", sent[len(sent)-1].Text)
+	}
+	if len(photos) != 1 || photos[0].ChatID != chat.ID {
+		t.Fatalf("expected the formula as a photo, got %+v", photos)
+	}
+	upload, ok := photos[0].Photo.(*models.InputFileUpload)
+	if !ok {
+		t.Fatalf("expected an uploaded photo, got %#v", photos[0].Photo)
+	}
+	if content, _ := io.ReadAll(upload.Data); !strings.HasPrefix(string(content), "\x89PNG") {
+		t.Fatalf("expected a PNG image")
+	}
+}
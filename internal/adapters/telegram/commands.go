@@ -3,38 +3,355 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
+
+	appcard "flash2fy/internal/app/domain/card"
+	appcatalog "flash2fy/internal/app/domain/catalog"
+	appdeck "flash2fy/internal/app/domain/deck"
+	appuser "flash2fy/internal/app/domain/user"
+	telegrmdomain "flash2fy/internal/telegram/domain"
 )
 
 func (h *updateHandler) dispatch(ctx context.Context, b *bot.Bot, update *models.Update) {
-	chatID := update.Message.Chat.ID
 	text := strings.TrimSpace(update.Message.Text)
 
 	if strings.HasPrefix(text, "/") {
-		h.handleCommand(ctx, b, chatID, text)
+		h.handleCommand(ctx, b, update, text)
 		return
 	}
 
 	h.handleCreateCard(ctx, b, update, text)
 }
 
-func (h *updateHandler) handleCommand(ctx context.Context, b *bot.Bot, chatID int64, text string) {
-	command, _ := splitCommand(text)
+func (h *updateHandler) handleCommand(ctx context.Context, b *bot.Bot, update *models.Update, text string) {
+	chatID := update.Message.Chat.ID
+	command, payload := splitCommand(text)
 	switch command {
-	case "/start", "/help":
+	case "/start":
+		if payload != "" {
+			h.handleInvite(ctx, b, update, payload)
+			return
+		}
 		h.sendMessage(ctx, b, chatID, messageUsage)
+	case "/help":
+		h.sendMessage(ctx, b, chatID, messageUsage)
+	case "/mode":
+		h.handleMode(ctx, b, update, payload)
+	case "/review":
+		h.handleReview(ctx, b, update)
+	case "/reminders":
+		h.handleReminders(ctx, b, update, payload)
+	case "/boxes":
+		h.handleBoxes(ctx, b, update)
+	case "/find":
+		h.handleFind(ctx, b, update, payload)
+	case "/edit":
+		h.handleEdit(ctx, b, update, payload)
+	case "/delete":
+		h.handleDelete(ctx, b, update)
+	case "/trash":
+		h.handleTrash(ctx, b, update)
+	case "/restore":
+		h.handleRestore(ctx, b, update, strings.TrimSpace(payload))
+	case "/catalog":
+		h.handleCatalog(ctx, b, update, payload)
+	case "/clone":
+		h.handleClone(ctx, b, update, payload)
+	case "/sync":
+		h.handleSync(ctx, b, update)
+	default:
+		h.sendMessage(ctx, b, chatID, messageUnknownCmd)
+	}
+}
+
+func (h *updateHandler) handleMode(ctx context.Context, b *bot.Bot, update *models.Update, payload string) {
+	chatID := update.Message.Chat.ID
+	coreUser, _, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	if payload == "" {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageModeCurrent, coreUser.PreferredScheduler()))
+		return
+	}
+
+	updated, err := h.userService.SetScheduler(coreUser.ID, appuser.Scheduler(strings.ToLower(payload)))
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageModeFail, err))
+		return
+	}
+
+	h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageModeOK, updated.PreferredScheduler()))
+}
+
+func (h *updateHandler) handleBoxes(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	boxes, err := h.cardService.LeitnerBoxes(ctxUser)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageBoxesFail, err))
+		return
+	}
+
+	lines := []string{messageBoxesHeader}
+	for _, box := range boxes {
+		lines = append(lines, fmt.Sprintf(messageBoxLine, box.Box, formatDays(box.Cadence), box.Cards))
+	}
+	h.sendMessage(ctx, b, chatID, strings.Join(lines, "\n"))
+}
+
+func (h *updateHandler) handleFind(ctx context.Context, b *bot.Bot, update *models.Update, query string) {
+	chatID := update.Message.Chat.ID
+	if strings.TrimSpace(query) == "" {
+		h.sendMessage(ctx, b, chatID, messageFindUsage)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	hits, err := h.cardService.Search(ctxUser, query)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageFindFail, err))
+		return
+	}
+	if len(hits) == 0 {
+		h.sendMessage(ctx, b, chatID, messageFindNone)
+		return
+	}
+
+	lines := []string{messageFindHeader}
+	for i, hit := range hits {
+		lines = append(lines, fmt.Sprintf(messageFindLine, i+1, telegramHTML(hit.Snippet), hit.Card.ID))
+	}
+	h.sendHTML(ctx, b, chatID, strings.Join(lines, "\n"))
+}
+
+// handleEdit replaces the content of the card the command replies to with "<front> | <back>",
+// keeping the back when no | is given.
+func (h *updateHandler) handleEdit(ctx context.Context, b *bot.Bot, update *models.Update, payload string) {
+	chatID := update.Message.Chat.ID
+	cardID := repliedCardID(update.Message.ReplyToMessage)
+	front, back, withBack := strings.Cut(payload, "|")
+	front = strings.TrimSpace(front)
+	if cardID == "" || front == "" {
+		h.sendMessage(ctx, b, chatID, messageEditUsage)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	if withBack {
+		back = strings.TrimSpace(back)
+	} else {
+		current, err := h.cardService.GetCard(cardID, ctxUser)
+		if err != nil {
+			h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageEditFail, err))
+			return
+		}
+		back = current.Back
+	}
+
+	edited, err := h.cardService.EditCard(cardID, ctxUser, front, back)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageEditFail, err))
+		return
+	}
+	h.sendHTML(ctx, b, chatID, fmt.Sprintf(messageEditOK, edited.ID, telegramHTML(edited.Front), telegramHTML(edited.Back)))
+}
+
+// handleDelete moves the card the command replies to into the trash.
+func (h *updateHandler) handleDelete(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	cardID := repliedCardID(update.Message.ReplyToMessage)
+	if cardID == "" {
+		h.sendMessage(ctx, b, chatID, messageDeleteUsage)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	if err := h.cardService.DeleteCard(cardID, ctxUser); err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageDeleteFail, err))
+		return
+	}
+	h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageDeleteOK, cardID))
+}
+
+func (h *updateHandler) handleTrash(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	trash, err := h.cardService.Trash(ctxUser)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageTrashFail, err))
+		return
+	}
+	if len(trash) == 0 {
+		h.sendMessage(ctx, b, chatID, messageTrashEmpty)
+		return
+	}
+
+	lines := []string{messageTrashHeader}
+	for i, c := range trash {
+		lines = append(lines, fmt.Sprintf(messageTrashLine, i+1, c.Front, c.ID, c.PurgeAt().Format(time.DateOnly)))
+	}
+	h.sendMessage(ctx, b, chatID, strings.Join(lines, "\n"))
+}
+
+func (h *updateHandler) handleRestore(ctx context.Context, b *bot.Bot, update *models.Update, cardID string) {
+	chatID := update.Message.Chat.ID
+	if cardID == "" {
+		h.sendMessage(ctx, b, chatID, messageRestoreUsage)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	restored, err := h.cardService.RestoreCard(cardID, ctxUser)
+	switch {
+	case err == appcard.ErrTrashExpired:
+		h.sendMessage(ctx, b, chatID, messageRestoreGone)
+	case err != nil:
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageRestoreFail, err))
 	default:
+		h.sendHTML(ctx, b, chatID, fmt.Sprintf(messageRestoreOK, restored.ID, telegramHTML(restored.Front)))
+	}
+}
+
+func (h *updateHandler) handleCatalog(ctx context.Context, b *bot.Bot, update *models.Update, query string) {
+	chatID := update.Message.Chat.ID
+	if h.catalogService == nil {
+		h.sendMessage(ctx, b, chatID, messageUnknownCmd)
+		return
+	}
+
+	entries, err := h.catalogService.Browse(query)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCatalogFail, err))
+		return
+	}
+	if len(entries) == 0 {
+		h.sendMessage(ctx, b, chatID, messageCatalogNone)
+		return
+	}
+
+	lines := []string{messageCatalogHeader}
+	for i, e := range entries {
+		lines = append(lines, fmt.Sprintf(messageCatalogLine, i+1, e.Listing.Title, e.Listing.Language, e.Cards, e.Listing.DeckID))
+	}
+	h.sendMessage(ctx, b, chatID, strings.Join(lines, "\n"))
+}
+
+func (h *updateHandler) handleClone(ctx context.Context, b *bot.Bot, update *models.Update, deckID string) {
+	chatID := update.Message.Chat.ID
+	if h.catalogService == nil {
 		h.sendMessage(ctx, b, chatID, messageUnknownCmd)
+		return
+	}
+	if deckID == "" {
+		h.sendMessage(ctx, b, chatID, messageCloneUsage)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	clone, cards, err := h.catalogService.Clone(ctxUser, deckID)
+	switch err {
+	case nil:
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCloneOK, clone.Name, len(cards)))
+	case appcatalog.ErrNotFound:
+		h.sendMessage(ctx, b, chatID, messageCloneMissing)
+	default:
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCloneFail, err))
+	}
+}
+
+func (h *updateHandler) handleSync(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	if h.catalogService == nil {
+		h.sendMessage(ctx, b, chatID, messageUnknownCmd)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	report, err := h.catalogService.Sync(ctxUser)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageSyncFail, err))
+		return
+	}
+	h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageSyncOK, report.Added, report.Updated, report.Removed, report.Conflicts))
+}
+
+// handleInvite accepts the deck invite carried by a t.me/<bot>?start=<token> deep link.
+func (h *updateHandler) handleInvite(ctx context.Context, b *bot.Bot, update *models.Update, token string) {
+	chatID := update.Message.Chat.ID
+	if h.deckService == nil {
+		h.sendMessage(ctx, b, chatID, messageUsage)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	joined, member, err := h.deckService.AcceptInvite(ctxUser, token)
+	switch err {
+	case nil:
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageInviteOK, joined.Name, member.Role))
+	case appdeck.ErrInviteNotFound, appdeck.ErrInviteExpired:
+		h.sendMessage(ctx, b, chatID, messageInviteGone)
+	default:
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageInviteFail, err))
 	}
 }
 
 func (h *updateHandler) handleCreateCard(ctx context.Context, b *bot.Bot, update *models.Update, message string) {
 	chatID := update.Message.Chat.ID
-	front := strings.TrimSpace(message)
+	front, tags := extractTags(message)
 	if front == "" {
 		h.sendMessage(ctx, b, chatID, messageEmptyIgnore)
 		return
@@ -45,24 +362,46 @@ func (h *updateHandler) handleCreateCard(ctx context.Context, b *bot.Bot, update
 		return
 	}
 
-	telegramID := update.Message.From.ID
-	name := strings.TrimSpace(update.Message.From.FirstName + " " + update.Message.From.LastName)
-	username := update.Message.From.Username
-
-	_, ctxUser, err := h.userService.EnsureUser(telegramID, name, username)
+	_, ctxUser, err := h.ensureUser(update.Message.From)
 	if err != nil {
 		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCreateFail, err))
 		return
 	}
 
-	card, err := h.cardService.CreateCard(front, "", ctxUser, chatID)
+	card, err := h.cardService.CreateCard(front, "", ctxUser, chatID, tags...)
 	if err != nil {
 		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCreateFail, err))
 		return
 	}
 
-	response := fmt.Sprintf(messageCreateOK, card.ID, card.Front, card.Back)
-	h.sendMessage(ctx, b, chatID, response)
+	h.sendMarkup(ctx, b, chatID, createdMessage(card), suspendKeyboard(card.ID, false))
+}
+
+// createdMessage confirms a new card, rendered as Telegram HTML.
+func createdMessage(c appcard.Card) string {
+	response := fmt.Sprintf(messageCreateOK, c.ID, telegramHTML(c.Front), telegramHTML(c.Back))
+	if len(c.Tags) > 0 {
+		response += fmt.Sprintf(messageCreateTags, html.EscapeString(formatTags(c.Tags)))
+	}
+	return response
+}
+
+func (h *updateHandler) ensureUser(from *models.User) (appuser.User, telegrmdomain.User, error) {
+	if from == nil {
+		return appuser.User{}, telegrmdomain.User{}, telegrmdomain.ErrEmptyTelegramID
+	}
+
+	name := strings.TrimSpace(from.FirstName + " " + from.LastName)
+	coreUser, ctxUser, err := h.userService.EnsureUser(from.ID, name, from.Username)
+	if err != nil {
+		return appuser.User{}, telegrmdomain.User{}, err
+	}
+	if h.reminderService != nil {
+		if _, err := h.reminderService.Ensure(ctxUser); err != nil {
+			log.Printf("telegram: failed ensuring reminder for %d: %v", from.ID, err)
+		}
+	}
+	return coreUser, ctxUser, nil
 }
 
 func (h *updateHandler) sendMessage(ctx context.Context, b *bot.Bot, chatID int64, message string) {
@@ -74,6 +413,29 @@ func (h *updateHandler) sendMessage(ctx context.Context, b *bot.Bot, chatID int6
 	}
 }
 
+// sendHTML sends a message formatted with Telegram HTML, such as one showing card content.
+func (h *updateHandler) sendHTML(ctx context.Context, b *bot.Bot, chatID int64, message string) {
+	if err := h.send(ctx, b, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      message,
+		ParseMode: models.ParseModeHTML,
+	}); err != nil {
+		log.Printf("telegram: failed sending message: %v", err)
+	}
+}
+
+// sendMarkup sends a message formatted with Telegram HTML together with inline buttons.
+func (h *updateHandler) sendMarkup(ctx context.Context, b *bot.Bot, chatID int64, message string, markup *models.InlineKeyboardMarkup) {
+	if err := h.send(ctx, b, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        message,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: markup,
+	}); err != nil {
+		log.Printf("telegram: failed sending message: %v", err)
+	}
+}
+
 func splitCommand(text string) (cmd string, payload string) {
 	if text == "" {
 		return "", ""
@@ -90,3 +452,10 @@ func splitCommand(text string) (cmd string, payload string) {
 	}
 	return cmd, payload
 }
+
+func formatDays(days int) string {
+	if days == 1 {
+		return "day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// sendFormulas sends the formulas of card content as photos, as Telegram cannot display LaTeX.
+// Failures are only logged, as the card text has already been shown with the LaTeX source.
+func (h *updateHandler) sendFormulas(ctx context.Context, b *bot.Bot, chatID int64, text string) {
+	if h.formulaService == nil {
+		return
+	}
+	images, err := h.formulaService.Images(text)
+	if err != nil {
+		log.Printf("telegram: failed rendering formulas for chat %d: %v", chatID, err)
+		return
+	}
+
+	for i, image := range images {
+		file := &models.InputFileUpload{Filename: fmt.Sprintf("formula-%d.png", i+1), Data: bytes.NewReader(image)}
+		if err := h.sendPhoto(ctx, b, &bot.SendPhotoParams{ChatID: chatID, Photo: file}); err != nil {
+			log.Printf("telegram: failed sending formula to chat %d: %v", chatID, err)
+		}
+	}
+}
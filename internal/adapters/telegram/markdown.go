@@ -0,0 +1,51 @@
+package telegram
+
+import (
+	"html"
+	"strings"
+
+	appcard "flash2fy/internal/app/domain/card"
+)
+
+// telegramHTML renders card Markdown with the HTML subset Telegram accepts in messages sent with
+// the HTML parse mode: b, i, s, code and links. Formulas are shown as their LaTeX source in code;
+// the bot sends them as images alongside. Text is escaped so card content can never inject
+// markup, and content that is not valid Markdown is sent as escaped plain text.
+func telegramHTML(text string) string {
+	spans, err := appcard.ParseMarkdown(text)
+	if err != nil {
+		return html.EscapeString(text)
+	}
+	var b strings.Builder
+	writeTelegramHTML(&b, spans)
+	return b.String()
+}
+
+func writeTelegramHTML(b *strings.Builder, spans []appcard.Span) {
+	for _, s := range spans {
+		switch s.Kind {
+		case appcard.SpanText:
+			b.WriteString(html.EscapeString(s.Text))
+		case appcard.SpanBreak:
+			b.WriteByte('\n')
+		case appcard.SpanCode, appcard.SpanMath, appcard.SpanDisplayMath:
+			b.WriteString("<code>" + html.EscapeString(s.Text) + "</code>")
+		case appcard.SpanBold:
+			wrapTelegramHTML(b, "b", s.Children)
+		case appcard.SpanItalic:
+			wrapTelegramHTML(b, "i", s.Children)
+		case appcard.SpanStrike:
+			wrapTelegramHTML(b, "s", s.Children)
+		case appcard.SpanLink:
+			b.WriteString(`<a href="` + html.EscapeString(s.URL) + `">`)
+			writeTelegramHTML(b, s.Children)
+			b.WriteString("</a>")
+		}
+	}
+}
+
+func wrapTelegramHTML(b *strings.Builder, tag string, children []appcard.Span) {
+	b.WriteString("<" + tag + ">")
+	writeTelegramHTML(b, children)
+	b.WriteString("</" + tag + ">")
+}
@@ -0,0 +1,186 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	appcard "flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/media"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+// handlePhoto creates a card from a photo; the caption becomes the front text and its #words tag the card.
+func (h *updateHandler) handlePhoto(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	front, tags := extractTags(update.Message.Caption)
+	if front == "" {
+		h.sendMessage(ctx, b, chatID, messagePhotoCaption)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCreateFail, err))
+		return
+	}
+
+	photo, err := h.download(ctx, b, largestPhoto(update.Message.Photo).FileID)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCreateFail, err))
+		return
+	}
+	defer photo.Close()
+
+	card, err := h.cardService.CreatePhotoCard(front, photo, ctxUser, chatID, tags...)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageCreateFail, err))
+		return
+	}
+
+	h.sendMarkup(ctx, b, chatID, createdMessage(card)+messageCreateImage, suspendKeyboard(card.ID, false))
+}
+
+// largestPhoto picks the best resolution Telegram offers of a photo.
+func largestPhoto(sizes []models.PhotoSize) models.PhotoSize {
+	largest := sizes[0]
+	for _, size := range sizes[1:] {
+		if size.Width*size.Height > largest.Width*largest.Height {
+			largest = size
+		}
+	}
+	return largest
+}
+
+// handleAudio attaches a voice message or audio file to the back of the card the message replies to.
+func (h *updateHandler) handleAudio(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	cardID := repliedCardID(update.Message.ReplyToMessage)
+	if cardID == "" {
+		h.sendMessage(ctx, b, chatID, messageAudioReply)
+		return
+	}
+
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	fileID, filename, contentType := audioFile(update.Message)
+	audio, err := h.download(ctx, b, fileID)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageAudioFail, err))
+		return
+	}
+	defer audio.Close()
+
+	if _, err := h.cardService.AttachAudio(cardID, ctxUser, filename, contentType, audio); err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageAudioFail, err))
+		return
+	}
+
+	h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageAudioOK, cardID))
+}
+
+// audioFile describes the voice message or audio file of a message. Voice messages are always OGG/Opus.
+func audioFile(message *models.Message) (fileID, filename, contentType string) {
+	if message.Voice != nil {
+		return message.Voice.FileID, "voice.ogg", "audio/ogg"
+	}
+	contentType = message.Audio.MimeType
+	if contentType == "" {
+		contentType = "audio/mpeg"
+	}
+	return message.Audio.FileID, message.Audio.FileName, contentType
+}
+
+// repliedCardID finds the card a bot message is about through the card ID in its buttons,
+// or the "ID:" line of a card confirmation. It returns "" for other messages.
+func repliedCardID(message *models.Message) string {
+	if message == nil {
+		return ""
+	}
+	for _, row := range message.ReplyMarkup.InlineKeyboard {
+		for _, button := range row {
+			if id := callbackCardID(button.CallbackData); id != "" {
+				return id
+			}
+		}
+	}
+	for _, line := range strings.Split(message.Text, "\n") {
+		if id, ok := strings.CutPrefix(line, "ID: "); ok {
+			return strings.TrimSpace(id)
+		}
+	}
+	return ""
+}
+
+// callbackCardID returns the card ID carried by the callback data of a card or review button.
+func callbackCardID(data string) string {
+	for _, prefix := range []string{callbackCardSuspend, callbackCardUnsuspend, callbackReviewShow, callbackReviewSuspend} {
+		if id, ok := strings.CutPrefix(data, prefix); ok {
+			return id
+		}
+	}
+	if payload, ok := strings.CutPrefix(data, callbackReviewGrade); ok {
+		_, id, _ := strings.Cut(payload, ":")
+		return id
+	}
+	return ""
+}
+
+// sendCardMedia sends the images and audio attached to one side of a card as photos and voice
+// messages. Failures are only logged, as the card text has already been shown.
+func (h *updateHandler) sendCardMedia(ctx context.Context, b *bot.Bot, chatID int64, owner telegrmdomain.User, cardID string, side media.Side) {
+	if h.cardService == nil {
+		return
+	}
+	attachments, err := h.cardService.Media(cardID, owner, side)
+	if err != nil {
+		log.Printf("telegram: failed listing media of card %s: %v", cardID, err)
+		return
+	}
+
+	for _, a := range attachments {
+		content, err := h.cardService.OpenMedia(cardID, owner, a.ID)
+		if err != nil {
+			log.Printf("telegram: failed opening media %s: %v", a.ID, err)
+			continue
+		}
+		filename := a.Filename
+		if filename == "" {
+			filename = a.ID
+		}
+		file := &models.InputFileUpload{Filename: filename, Data: content}
+		switch a.Kind() {
+		case media.KindImage:
+			err = h.sendPhoto(ctx, b, &bot.SendPhotoParams{ChatID: chatID, Photo: file})
+		case media.KindAudio:
+			err = h.sendVoice(ctx, b, &bot.SendVoiceParams{ChatID: chatID, Voice: file})
+		}
+		content.Close()
+		if err != nil {
+			log.Printf("telegram: failed sending media %s to chat %d: %v", a.ID, chatID, err)
+		}
+	}
+}
+
+// questionSide and answerSide tell which side of a card is asked and which revealed; reversed cards ask their back.
+func questionSide(c appcard.Card) media.Side {
+	if c.Reversed {
+		return media.SideBack
+	}
+	return media.SideFront
+}
+
+func answerSide(c appcard.Card) media.Side {
+	if c.Reversed {
+		return media.SideFront
+	}
+	return media.SideBack
+}
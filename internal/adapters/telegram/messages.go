@@ -1,9 +1,70 @@
 package telegram
 
 const (
-	messageUsage       = "Send any text message to create a card with that text on the front. Back will be empty. Use /help for this hint."
-	messageUnknownCmd  = "Unknown command. " + messageUsage
-	messageEmptyIgnore = "Empty cards are ignored. " + messageUsage
-	messageCreateOK    = "Card created ✅\nID: %s\nFront: %s\nBack: %s"
-	messageCreateFail  = "Failed to create card: %v"
+	messageUsage         = "Send any text message to create a card with that text on the front. Back will be empty. #words in the message tag the card.\nSend a photo with a caption to create a card with the image and the caption on the front.\nReply to a card with a voice message or audio file to attach its pronunciation.\nReply to a card with /edit <front> | <back> to change it; without | only the front changes.\nReply to a card with /delete to move it to the trash; /trash lists it and /restore <card ID> brings it back within 30 days.\n/mode [sm2|fsrs|leitner] shows or switches your study mode.\n/boxes shows how your cards are spread across Leitner boxes.\n/find <words> searches your cards.\n/catalog [words] browses public decks and /clone <deck ID> copies one into your collection.\n/sync pulls the latest changes into the decks you subscribe to.\n/review starts a study session with today's due cards.\n/reminders [on|off|HH:MM [Timezone]] manages your daily study reminder.\nUse /help for this hint."
+	messageUnknownCmd    = "Unknown command. " + messageUsage
+	messageEmptyIgnore   = "Empty cards are ignored. " + messageUsage
+	messageCreateOK      = "Card created ✅\nID: %s\nFront: %s\nBack: %s"
+	messageCreateTags    = "\nTags: %s"
+	messageCreateFail    = "Failed to create card: %v"
+	messageCreateImage   = "\nImage: on the front 🖼"
+	messagePhotoCaption  = "Add a caption to the photo, it becomes the front of the card, e.g. Which bone is this? #anatomy"
+	messageAudioReply    = "Reply to one of your cards with the voice message or audio file to attach it as pronunciation."
+	messageAudioOK       = "Pronunciation attached to card %s 🔊"
+	messageAudioFail     = "Failed to attach the pronunciation: %v"
+	messageEditUsage     = "Reply to one of your cards with the new content, e.g. /edit der Hund | the dog"
+	messageEditOK        = "Card updated ✅\nID: %s\nFront: %s\nBack: %s"
+	messageEditFail      = "Failed to update the card: %v"
+	messageDeleteUsage   = "Reply to one of your cards with /delete to move it to the trash."
+	messageDeleteOK      = "Card moved to the trash 🗑\nRestore it within 30 days with /restore %s"
+	messageDeleteFail    = "Failed to delete the card: %v"
+	messageTrashHeader   = "Your trash:"
+	messageTrashLine     = "%d. %s\n   /restore %s (purged on %s)"
+	messageTrashEmpty    = "Your trash is empty."
+	messageTrashFail     = "Failed to load your trash: %v"
+	messageRestoreUsage  = "Tell me which card to restore, e.g. /restore <card ID> from /trash"
+	messageRestoreOK     = "Card restored ✅\nID: %s\nFront: %s"
+	messageRestoreGone   = "This card was deleted more than 30 days ago and can no longer be restored."
+	messageRestoreFail   = "Failed to restore the card: %v"
+	messageUserFail      = "Failed to load your profile: %v"
+	messageModeCurrent   = "Your study mode is %s. Switch with /mode sm2, /mode fsrs or /mode leitner."
+	messageModeOK        = "Study mode switched to %s ✅"
+	messageModeFail      = "Failed to switch study mode: %v"
+	messageBoxesHeader   = "Your Leitner boxes:"
+	messageBoxLine       = "Box %d (every %s): %d cards"
+	messageBoxesFail     = "Failed to load Leitner boxes: %v"
+	messageFindUsage     = "Tell me what to look for, e.g. /find der Hund"
+	messageFindHeader    = "Cards found:"
+	messageFindLine      = "%d. %s\n   ID: %s"
+	messageFindNone      = "No cards match your search."
+	messageFindFail      = "Failed to search cards: %v"
+	messageCatalogHeader = "Public decks:"
+	messageCatalogLine   = "%d. %s [%s], %d cards\n   /clone %s"
+	messageCatalogNone   = "No public decks match your search."
+	messageCatalogFail   = "Failed to browse the catalog: %v"
+	messageCloneUsage    = "Tell me which deck to clone, e.g. /clone <deck ID> from /catalog"
+	messageCloneOK       = "Deck %q cloned with %d cards ✅"
+	messageCloneMissing  = "This deck is not in the public catalog."
+	messageCloneFail     = "Failed to clone the deck: %v"
+	messageSyncOK        = "Subscriptions synced ✅\nAdded: %d\nUpdated: %d\nRemoved: %d\nConflicts: %d"
+	messageSyncFail      = "Failed to sync your subscriptions: %v"
+	messageInviteOK      = "You joined the deck %q as %s ✅"
+	messageInviteGone    = "This invite is no longer valid. Ask the deck owner for a new one."
+	messageInviteFail    = "Failed to accept the invite: %v"
+
+	messageReviewFront     = "❓ %s"
+	messageReviewBack      = "❓ %s\n\n💡 %s"
+	messageReviewNothing   = "Nothing to review right now 🎉"
+	messageReviewDone      = "Session finished ✅\nNew: %d\nReviewed: %d\nRepeated: %d"
+	messageReviewFail      = "Failed to run review: %v"
+	messageReviewExpired   = "This review is no longer active. Send /review to start again."
+	messageUnknownAction   = "Unknown action."
+	messageSuspendFail     = "Failed to change card suspension: %v"
+	messageReviewForeign   = "This review belongs to someone else. Send /review to start your own."
+	messageCardUnavailable = "This card is not available to you."
+
+	messageReminder      = "⏰ You have %d cards due today."
+	messageRemindersOn   = "Daily reminders are on at %s (%s). Change with /reminders HH:MM [Timezone] or stop with /reminders off."
+	messageRemindersOff  = "Daily reminders are off. Turn them back on with /reminders on."
+	messageRemindersFail = "Failed to update reminders: %v"
 )
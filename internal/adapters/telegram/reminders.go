@@ -0,0 +1,64 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+func (h *updateHandler) handleReminders(ctx context.Context, b *bot.Bot, update *models.Update, payload string) {
+	chatID := update.Message.Chat.ID
+	_, ctxUser, err := h.ensureUser(update.Message.From)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	var reminder telegrmdomain.Reminder
+	fields := strings.Fields(payload)
+	switch {
+	case len(fields) == 0:
+		reminder, err = h.reminderService.Ensure(ctxUser)
+	case strings.EqualFold(fields[0], "off"):
+		reminder, err = h.reminderService.SetEnabled(ctxUser, false)
+	case strings.EqualFold(fields[0], "on"):
+		reminder, err = h.reminderService.SetEnabled(ctxUser, true)
+	default:
+		var hour, minute int
+		hour, minute, err = telegrmdomain.ParseReminderClock(fields[0])
+		if err == nil {
+			timezone := ""
+			if len(fields) > 1 {
+				timezone = fields[1]
+			}
+			reminder, err = h.reminderService.SetTime(ctxUser, hour, minute, timezone)
+		}
+	}
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageRemindersFail, err))
+		return
+	}
+
+	if !reminder.Enabled {
+		h.sendMessage(ctx, b, chatID, messageRemindersOff)
+		return
+	}
+	h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageRemindersOn, reminder.Clock(), reminder.Timezone))
+}
+
+func (h *updateHandler) sendReminder(ctx context.Context, b *bot.Bot, chatID int64, due int) error {
+	return h.send(ctx, b, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   fmt.Sprintf(messageReminder, due),
+		ReplyMarkup: &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{
+				{{Text: "Start review", CallbackData: callbackReviewStart}},
+			},
+		},
+	})
+}
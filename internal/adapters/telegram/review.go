@@ -0,0 +1,252 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+
+	appcard "flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/study"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+const (
+	callbackReviewStart   = "review:start"
+	callbackReviewShow    = "review:show:"
+	callbackReviewGrade   = "review:grade:"
+	callbackReviewSuspend = "review:suspend:"
+	callbackCardSuspend   = "card:suspend:"
+	callbackCardUnsuspend = "card:unsuspend:"
+)
+
+var reviewRatings = []struct {
+	rating appcard.Rating
+	label  string
+}{
+	{appcard.RatingAgain, "Again"},
+	{appcard.RatingHard, "Hard"},
+	{appcard.RatingGood, "Good"},
+	{appcard.RatingEasy, "Easy"},
+}
+
+func (h *updateHandler) handleReview(ctx context.Context, b *bot.Bot, update *models.Update) {
+	h.startReview(ctx, b, update.Message.Chat.ID, update.Message.From)
+}
+
+func (h *updateHandler) startReview(ctx context.Context, b *bot.Bot, chatID int64, from *models.User) {
+	_, ctxUser, err := h.ensureUser(from)
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	next, err := h.reviewService.Start(ctxUser, chatID)
+	if err == study.ErrQueueEmpty {
+		h.sendMessage(ctx, b, chatID, messageReviewNothing)
+		return
+	}
+	if err != nil {
+		h.sendMessage(ctx, b, chatID, fmt.Sprintf(messageReviewFail, err))
+		return
+	}
+
+	h.sendMarkup(ctx, b, chatID, fmt.Sprintf(messageReviewFront, telegramHTML(next.Question())), showAnswerKeyboard(next.ID))
+	h.sendCardMedia(ctx, b, chatID, ctxUser, next.ID, questionSide(next))
+	h.sendFormulas(ctx, b, chatID, next.Question())
+}
+
+func (h *updateHandler) handleCallback(ctx context.Context, b *bot.Bot, query *models.CallbackQuery) {
+	if query.Message.Message == nil {
+		h.answerCallback(ctx, b, query.ID, messageReviewExpired)
+		return
+	}
+	chatID := query.Message.Message.Chat.ID
+	messageID := query.Message.Message.ID
+	if query.Data == callbackReviewStart {
+		h.startReview(ctx, b, chatID, &query.From)
+		h.answerCallback(ctx, b, query.ID, "")
+		return
+	}
+
+	// Callback data can be forged, so every action is checked against the user pressing the button.
+	_, ctxUser, err := h.ensureUser(&query.From)
+	if err != nil {
+		h.answerCallback(ctx, b, query.ID, fmt.Sprintf(messageUserFail, err))
+		return
+	}
+
+	var notice string
+	switch {
+	case strings.HasPrefix(query.Data, callbackReviewShow):
+		notice = h.showAnswer(ctx, b, chatID, messageID, ctxUser, strings.TrimPrefix(query.Data, callbackReviewShow))
+	case strings.HasPrefix(query.Data, callbackReviewGrade):
+		notice = h.gradeAnswer(ctx, b, chatID, messageID, ctxUser, strings.TrimPrefix(query.Data, callbackReviewGrade))
+	case strings.HasPrefix(query.Data, callbackReviewSuspend):
+		notice = h.suspendDuringReview(ctx, b, chatID, messageID, ctxUser, strings.TrimPrefix(query.Data, callbackReviewSuspend))
+	case strings.HasPrefix(query.Data, callbackCardSuspend):
+		notice = h.toggleSuspension(ctx, b, chatID, messageID, ctxUser, strings.TrimPrefix(query.Data, callbackCardSuspend), true)
+	case strings.HasPrefix(query.Data, callbackCardUnsuspend):
+		notice = h.toggleSuspension(ctx, b, chatID, messageID, ctxUser, strings.TrimPrefix(query.Data, callbackCardUnsuspend), false)
+	default:
+		notice = messageUnknownAction
+	}
+
+	h.answerCallback(ctx, b, query.ID, notice)
+}
+
+// showAnswer reveals the answer of the current card; it returns a notice for the callback
+// when the session belongs to another user, leaving their message untouched.
+func (h *updateHandler) showAnswer(ctx context.Context, b *bot.Bot, chatID int64, messageID int, owner telegrmdomain.User, cardID string) string {
+	current, err := h.reviewService.Current(chatID, owner)
+	if err == telegrmdomain.ErrReviewForeign {
+		return messageReviewForeign
+	}
+	if err != nil || current.ID != cardID {
+		h.editMessage(ctx, b, chatID, messageID, messageReviewExpired, nil)
+		return ""
+	}
+
+	h.editHTML(ctx, b, chatID, messageID, fmt.Sprintf(messageReviewBack, telegramHTML(current.Question()), telegramHTML(current.Answer())), gradeKeyboard(current.ID))
+	h.sendCardMedia(ctx, b, chatID, owner, current.ID, answerSide(current))
+	h.sendFormulas(ctx, b, chatID, current.Answer())
+	return ""
+}
+
+func (h *updateHandler) gradeAnswer(ctx context.Context, b *bot.Bot, chatID int64, messageID int, owner telegrmdomain.User, payload string) string {
+	ratingName, cardID, ok := strings.Cut(payload, ":")
+	rating, err := appcard.ParseRating(ratingName)
+	if !ok || err != nil {
+		h.editMessage(ctx, b, chatID, messageID, messageReviewExpired, nil)
+		return ""
+	}
+
+	next, err := h.reviewService.Answer(chatID, owner, cardID, rating)
+	if err == telegrmdomain.ErrReviewForeign {
+		return messageReviewForeign
+	}
+	h.continueReview(ctx, b, chatID, messageID, owner, next, err)
+	return ""
+}
+
+func (h *updateHandler) suspendDuringReview(ctx context.Context, b *bot.Bot, chatID int64, messageID int, owner telegrmdomain.User, cardID string) string {
+	if _, err := h.reviewService.Current(chatID, owner); err == telegrmdomain.ErrReviewForeign {
+		return messageReviewForeign
+	}
+	if _, err := h.cardService.SuspendCard(cardID, owner); err == appcard.ErrNotFound {
+		return messageCardUnavailable
+	} else if err != nil {
+		h.editMessage(ctx, b, chatID, messageID, fmt.Sprintf(messageSuspendFail, err), nil)
+		return ""
+	}
+
+	next, err := h.reviewService.Current(chatID, owner)
+	h.continueReview(ctx, b, chatID, messageID, owner, next, err)
+	return ""
+}
+
+// continueReview shows the next card of the chat's session, or its summary once the queue is empty.
+func (h *updateHandler) continueReview(ctx context.Context, b *bot.Bot, chatID int64, messageID int, owner telegrmdomain.User, next appcard.Card, err error) {
+	switch err {
+	case nil:
+		h.editHTML(ctx, b, chatID, messageID, fmt.Sprintf(messageReviewFront, telegramHTML(next.Question())), showAnswerKeyboard(next.ID))
+		h.sendCardMedia(ctx, b, chatID, owner, next.ID, questionSide(next))
+		h.sendFormulas(ctx, b, chatID, next.Question())
+	case study.ErrQueueEmpty:
+		summary, err := h.reviewService.Finish(chatID)
+		if err != nil {
+			h.editMessage(ctx, b, chatID, messageID, fmt.Sprintf(messageReviewFail, err), nil)
+			return
+		}
+		h.editMessage(ctx, b, chatID, messageID, fmt.Sprintf(messageReviewDone, summary.NewStudied, summary.Reviewed, summary.Requeued), nil)
+	default:
+		h.editMessage(ctx, b, chatID, messageID, messageReviewExpired, nil)
+	}
+}
+
+func showAnswerKeyboard(cardID string) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: "Show answer", CallbackData: callbackReviewShow + cardID}},
+		},
+	}
+}
+
+func gradeKeyboard(cardID string) *models.InlineKeyboardMarkup {
+	row := make([]models.InlineKeyboardButton, 0, len(reviewRatings))
+	for _, r := range reviewRatings {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         r.label,
+			CallbackData: callbackReviewGrade + r.rating.String() + ":" + cardID,
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+		row,
+		{{Text: "Suspend", CallbackData: callbackReviewSuspend + cardID}},
+	}}
+}
+
+// toggleSuspension suspends or unsuspends a card of the user pressing the button; cards of
+// other users are left alone and reported through the returned callback notice.
+func (h *updateHandler) toggleSuspension(ctx context.Context, b *bot.Bot, chatID int64, messageID int, owner telegrmdomain.User, cardID string, suspend bool) string {
+	change := h.cardService.UnsuspendCard
+	if suspend {
+		change = h.cardService.SuspendCard
+	}
+	updated, err := change(cardID, owner)
+	if err == appcard.ErrNotFound {
+		return messageCardUnavailable
+	}
+	if err != nil {
+		h.editMessage(ctx, b, chatID, messageID, fmt.Sprintf(messageSuspendFail, err), nil)
+		return ""
+	}
+
+	h.editHTML(ctx, b, chatID, messageID, createdMessage(updated), suspendKeyboard(updated.ID, updated.Suspended))
+	return ""
+}
+
+// suspendKeyboard offers to suspend an active card or unsuspend a suspended one.
+func suspendKeyboard(cardID string, suspended bool) *models.InlineKeyboardMarkup {
+	button := models.InlineKeyboardButton{Text: "Suspend", CallbackData: callbackCardSuspend + cardID}
+	if suspended {
+		button = models.InlineKeyboardButton{Text: "Unsuspend", CallbackData: callbackCardUnsuspend + cardID}
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{{button}}}
+}
+
+func (h *updateHandler) editMessage(ctx context.Context, b *bot.Bot, chatID int64, messageID int, text string, markup *models.InlineKeyboardMarkup) {
+	h.editText(ctx, b, chatID, messageID, text, "", markup)
+}
+
+// editHTML replaces a message with text formatted with Telegram HTML, such as card content.
+func (h *updateHandler) editHTML(ctx context.Context, b *bot.Bot, chatID int64, messageID int, text string, markup *models.InlineKeyboardMarkup) {
+	h.editText(ctx, b, chatID, messageID, text, models.ParseModeHTML, markup)
+}
+
+func (h *updateHandler) editText(ctx context.Context, b *bot.Bot, chatID int64, messageID int, text string, mode models.ParseMode, markup *models.InlineKeyboardMarkup) {
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+		ParseMode: mode,
+	}
+	if markup != nil {
+		params.ReplyMarkup = markup
+	}
+	if err := h.edit(ctx, b, params); err != nil {
+		log.Printf("telegram: failed editing message: %v", err)
+	}
+}
+
+func (h *updateHandler) answerCallback(ctx context.Context, b *bot.Bot, queryID, text string) {
+	if err := h.answer(ctx, b, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: queryID,
+		Text:            text,
+	}); err != nil {
+		log.Printf("telegram: failed answering callback: %v", err)
+	}
+}
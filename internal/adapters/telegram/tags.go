@@ -0,0 +1,28 @@
+package telegram
+
+import (
+	"regexp"
+	"strings"
+)
+
+// hashtagPattern matches a #tag standing on its own, at the start of the text or after whitespace.
+var hashtagPattern = regexp.MustCompile(`(^|\s)#([\p{L}\p{N}_][\p{L}\p{N}_-]*)`)
+
+// extractTags removes the #tags from the message and returns the remaining text and the tags.
+func extractTags(message string) (string, []string) {
+	var tags []string
+	for _, match := range hashtagPattern.FindAllStringSubmatch(message, -1) {
+		tags = append(tags, match[2])
+	}
+	text := hashtagPattern.ReplaceAllString(message, "$1")
+	return strings.TrimSpace(text), tags
+}
+
+// formatTags renders tags the way they are typed in the chat.
+func formatTags(tags []string) string {
+	formatted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		formatted = append(formatted, "#"+tag)
+	}
+	return strings.Join(formatted, " ")
+}
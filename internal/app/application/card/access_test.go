@@ -0,0 +1,244 @@
+package cardapp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	reviewstorage "flash2fy/internal/adapters/storage/review"
+	revisionstorage "flash2fy/internal/adapters/storage/revision"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/media"
+	"flash2fy/internal/app/domain/revision"
+)
+
+// newSharedDeckService returns a service with a deck of "teacher" shared with "viewer" and
+// "editor", one card in it and one standalone card of the teacher.
+func newSharedDeckService(t *testing.T) (*Service, deck.Deck, card.Card, card.Card) {
+	t.Helper()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	decks := deckstorage.NewMemoryRepository()
+	members := deckstorage.NewMemoryMemberRepository()
+	service := NewService(cardstorage.NewMemoryRepository(),
+		WithDecks(decks),
+		WithDeckMembers(members),
+		WithReviewLog(reviewstorage.NewMemoryRepository()),
+		WithRevisions(revisionstorage.NewMemoryRepository()),
+		WithMedia(mediastorage.NewMemoryRepository(), mediastorage.NewMemoryStorage()),
+		WithClock(func() time.Time { return now }),
+	)
+
+	shared, _ := decks.Save(deck.Deck{ID: "deck-1", Name: "German", OwnerID: "teacher", CreatedAt: now, UpdatedAt: now})
+	for userID, role := range map[string]deck.Role{"viewer": deck.RoleViewer, "editor": deck.RoleEditor} {
+		if _, err := members.Save(deck.Member{DeckID: shared.ID, UserID: userID, Role: role, CreatedAt: now}); err != nil {
+			t.Fatalf("share failed: %v", err)
+		}
+	}
+	inDeck, err := service.CreateCardInDeck("der Hund", "dog", "teacher", shared.ID)
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	standalone, err := service.CreateCard("die Katze", "cat", "teacher")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	return service, shared, inDeck, standalone
+}
+
+func TestCardReadsRequireViewer(t *testing.T) {
+	service, shared, inDeck, standalone := newSharedDeckService(t)
+
+	reads := map[string]func(id, actorID string) error{
+		"get": func(id, actorID string) error {
+			_, err := service.GetCard(id, actorID)
+			return err
+		},
+		"reviews": func(id, actorID string) error {
+			_, _, err := service.CardReviews(id, actorID)
+			return err
+		},
+		"revisions": func(id, actorID string) error {
+			_, err := service.Revisions(id, actorID)
+			return err
+		},
+		"grade": func(id, actorID string) error {
+			_, err := service.GradeCard(id, card.RatingGood, 0, actorID)
+			return err
+		},
+	}
+	for name, read := range reads {
+		if err := read(inDeck.ID, "viewer"); err != nil {
+			t.Fatalf("%s: expected a viewer of the deck allowed, got %v", name, err)
+		}
+		if err := read(inDeck.ID, "stranger"); err != card.ErrForeignOwner {
+			t.Fatalf("%s: expected ErrForeignOwner for a stranger, got %v", name, err)
+		}
+		if err := read(standalone.ID, "viewer"); err != card.ErrForeignOwner {
+			t.Fatalf("%s: expected ErrForeignOwner for another user's standalone card, got %v", name, err)
+		}
+		if err := read(standalone.ID, ""); err != card.ErrForeignOwner {
+			t.Fatalf("%s: expected ErrForeignOwner without an actor, got %v", name, err)
+		}
+	}
+
+	if listed, err := service.ListCards("viewer"); err != nil || len(listed) != 1 || listed[0].ID != inDeck.ID {
+		t.Fatalf("expected the viewer to list the shared deck's card only, got %+v (%v)", listed, err)
+	}
+	if listed, err := service.ListCards("stranger"); err != nil || len(listed) != 0 {
+		t.Fatalf("expected a stranger to list nothing, got %+v (%v)", listed, err)
+	}
+	if listed, err := service.ListCardsInDeck(shared.ID, "viewer"); err != nil || len(listed) != 1 {
+		t.Fatalf("expected the viewer to list the deck, got %+v (%v)", listed, err)
+	}
+	if _, err := service.ListCardsInDeck(shared.ID, "stranger"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner listing another user's deck, got %v", err)
+	}
+	filter, _ := card.ParseTagFilter(nil)
+	if _, err := service.ListCardsTagged(shared.ID, filter, "stranger"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner listing another user's tagged cards, got %v", err)
+	}
+}
+
+func TestCardWritesRequireEditor(t *testing.T) {
+	service, shared, inDeck, standalone := newSharedDeckService(t)
+
+	writes := map[string]func(id, actorID string) error{
+		"update": func(id, actorID string) error {
+			_, err := service.UpdateCard(id, "der Hund", "the dog", actorID)
+			return err
+		},
+		"edit": func(id, actorID string) error {
+			_, err := service.EditCard(id, "der Hund", "the dog", revision.Editor{UserID: actorID, Channel: revision.ChannelHTTP})
+			return err
+		},
+		"tags": func(id, actorID string) error {
+			_, err := service.SetTags(id, []string{"noun"}, actorID)
+			return err
+		},
+		"move": func(id, actorID string) error {
+			_, err := service.MoveCard(id, shared.ID, actorID)
+			return err
+		},
+		"suspend": func(id, actorID string) error {
+			_, err := service.SuspendCard(id, actorID)
+			return err
+		},
+		"unsuspend": func(id, actorID string) error {
+			_, err := service.UnsuspendCard(id, actorID)
+			return err
+		},
+		"bury": func(id, actorID string) error {
+			_, err := service.BuryCard(id, actorID)
+			return err
+		},
+	}
+	for name, write := range writes {
+		if err := write(inDeck.ID, "viewer"); err != deck.ErrForbidden {
+			t.Fatalf("%s: expected ErrForbidden for a viewer, got %v", name, err)
+		}
+		if err := write(inDeck.ID, "stranger"); err != card.ErrForeignOwner {
+			t.Fatalf("%s: expected ErrForeignOwner for a stranger, got %v", name, err)
+		}
+		if err := write(standalone.ID, "editor"); err != card.ErrForeignOwner {
+			t.Fatalf("%s: expected ErrForeignOwner for another user's standalone card, got %v", name, err)
+		}
+		if err := write(inDeck.ID, "editor"); err != nil {
+			t.Fatalf("%s: expected an editor of the deck allowed, got %v", name, err)
+		}
+	}
+
+	if _, err := service.MoveCard(standalone.ID, shared.ID, "teacher"); err != nil {
+		t.Fatalf("expected the owner to move a card into the deck, got %v", err)
+	}
+	if _, err := service.RevertCard(inDeck.ID, 1, revision.Editor{UserID: "viewer"}); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden reverting as a viewer, got %v", err)
+	}
+}
+
+func TestTrashRequiresEditor(t *testing.T) {
+	service, _, inDeck, standalone := newSharedDeckService(t)
+
+	if err := service.DeleteCard(inDeck.ID, "viewer"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden deleting as a viewer, got %v", err)
+	}
+	if err := service.DeleteCard(standalone.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner deleting another user's card, got %v", err)
+	}
+	if err := service.DeleteCard(inDeck.ID, "editor"); err != nil {
+		t.Fatalf("expected an editor to delete, got %v", err)
+	}
+	if err := service.DeleteCard(standalone.ID, "teacher"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	if _, err := service.RestoreCard(inDeck.ID, "viewer"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden restoring as a viewer, got %v", err)
+	}
+	if _, err := service.RestoreCard(standalone.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner restoring another user's card, got %v", err)
+	}
+	if err := service.PurgeCard(inDeck.ID, "viewer"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden purging as a viewer, got %v", err)
+	}
+	if err := service.PurgeCard(standalone.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner purging another user's card, got %v", err)
+	}
+
+	if _, err := service.RestoreCard(inDeck.ID, "editor"); err != nil {
+		t.Fatalf("expected an editor to restore, got %v", err)
+	}
+	if err := service.PurgeCard(standalone.ID, "teacher"); err != nil {
+		t.Fatalf("expected the owner to purge, got %v", err)
+	}
+}
+
+func TestMediaRequiresDeckRoles(t *testing.T) {
+	service, _, inDeck, standalone := newSharedDeckService(t)
+	attach := func(cardID, actorID string) (media.Attachment, error) {
+		return service.AttachMedia(cardID, actorID, media.SideFront, "a.png", "image/png", strings.NewReader("png"))
+	}
+
+	if _, err := attach(inDeck.ID, "viewer"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden attaching as a viewer, got %v", err)
+	}
+	if _, err := attach(inDeck.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner attaching as a stranger, got %v", err)
+	}
+	if _, err := attach(standalone.ID, "editor"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner attaching to another user's standalone card, got %v", err)
+	}
+	attachment, err := attach(inDeck.ID, "editor")
+	if err != nil {
+		t.Fatalf("expected an editor to attach, got %v", err)
+	}
+
+	if listed, err := service.Media(inDeck.ID, "viewer"); err != nil || len(listed) != 1 {
+		t.Fatalf("expected a viewer to list the media, got %+v (%v)", listed, err)
+	}
+	if _, err := service.Media(inDeck.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner listing as a stranger, got %v", err)
+	}
+	_, content, err := service.OpenMedia(inDeck.ID, attachment.ID, "viewer")
+	if err != nil {
+		t.Fatalf("expected a viewer to open the media, got %v", err)
+	}
+	content.Close()
+	if _, _, err := service.OpenMedia(inDeck.ID, attachment.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner opening as a stranger, got %v", err)
+	}
+
+	if err := service.DeleteMedia(inDeck.ID, attachment.ID, "viewer"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden deleting as a viewer, got %v", err)
+	}
+	if err := service.DeleteMedia(inDeck.ID, attachment.ID, "stranger"); err != card.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner deleting as a stranger, got %v", err)
+	}
+	if err := service.DeleteMedia(inDeck.ID, attachment.ID, "editor"); err != nil {
+		t.Fatalf("expected an editor to delete, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package cardapp
+
+import (
+	"math"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+const (
+	fsrsDecay            = -0.5
+	fsrsFactor           = 19.0 / 81.0
+	fsrsMaxInterval      = 36500
+	fsrsDefaultRetention = 0.9
+)
+
+// fsrsDefaultWeights are the FSRS-4.5 model parameters trained on the Anki review corpus.
+var fsrsDefaultWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206, 5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072, 0.0793, 0.3246, 1.587, 0.2272, 2.8755,
+}
+
+// FSRSScheduler implements the Free Spaced Repetition Scheduler (FSRS-4.5).
+// It models each card by its memory stability (days until recall probability drops to
+// the desired retention) and difficulty (1-10), and schedules the next review when the
+// predicted recall probability reaches the desired retention.
+type FSRSScheduler struct {
+	weights   [17]float64
+	retention float64
+}
+
+// NewFSRSScheduler returns an FSRS scheduler using the default weights and a 90% desired retention.
+func NewFSRSScheduler() FSRSScheduler {
+	return FSRSScheduler{weights: fsrsDefaultWeights, retention: fsrsDefaultRetention}
+}
+
+// Schedule applies FSRS to state after a review graded with rating at now.
+func (f FSRSScheduler) Schedule(state card.ReviewState, rating card.Rating, now time.Time) card.ReviewState {
+	next := state
+	grade := float64(rating)
+
+	if state.IsNew() || state.Stability <= 0 {
+		next.Stability = f.weights[rating-1]
+		next.Difficulty = f.initDifficulty(grade)
+	} else {
+		elapsed := now.Sub(state.ReviewedAt).Hours() / 24
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		recall := f.retrievability(elapsed, state.Stability)
+
+		if rating == card.RatingAgain {
+			next.Stability = f.forgetStability(state.Difficulty, state.Stability, recall)
+		} else {
+			next.Stability = f.recallStability(state.Difficulty, state.Stability, recall, rating)
+		}
+		next.Difficulty = f.nextDifficulty(state.Difficulty, grade)
+	}
+
+	if rating == card.RatingAgain {
+		next.Repetitions = 0
+	} else {
+		next.Repetitions++
+	}
+
+	next.Interval = f.interval(next.Stability)
+	next.ReviewedAt = now
+	next.DueAt = now.AddDate(0, 0, next.Interval)
+	return next
+}
+
+func (f FSRSScheduler) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+func (f FSRSScheduler) interval(stability float64) int {
+	days := stability / fsrsFactor * (math.Pow(f.retention, 1/fsrsDecay) - 1)
+	return int(math.Min(math.Max(math.Round(days), 1), fsrsMaxInterval))
+}
+
+func (f FSRSScheduler) initDifficulty(grade float64) float64 {
+	return clampDifficulty(f.weights[4] - (grade-3)*f.weights[5])
+}
+
+func (f FSRSScheduler) nextDifficulty(difficulty, grade float64) float64 {
+	next := difficulty - f.weights[6]*(grade-3)
+	// Mean reversion towards the initial difficulty of a "good" answer keeps D from drifting.
+	return clampDifficulty(f.weights[7]*f.initDifficulty(3) + (1-f.weights[7])*next)
+}
+
+func (f FSRSScheduler) recallStability(difficulty, stability, recall float64, rating card.Rating) float64 {
+	hardPenalty, easyBonus := 1.0, 1.0
+	switch rating {
+	case card.RatingHard:
+		hardPenalty = f.weights[15]
+	case card.RatingEasy:
+		easyBonus = f.weights[16]
+	}
+
+	growth := math.Exp(f.weights[8]) *
+		(11 - difficulty) *
+		math.Pow(stability, -f.weights[9]) *
+		(math.Exp(f.weights[10]*(1-recall)) - 1) *
+		hardPenalty *
+		easyBonus
+	return stability * (1 + growth)
+}
+
+func (f FSRSScheduler) forgetStability(difficulty, stability, recall float64) float64 {
+	next := f.weights[11] *
+		math.Pow(difficulty, -f.weights[12]) *
+		(math.Pow(stability+1, f.weights[13]) - 1) *
+		math.Exp(f.weights[14]*(1-recall))
+	return math.Min(next, stability)
+}
+
+func clampDifficulty(d float64) float64 {
+	return math.Min(math.Max(d, 1), 10)
+}
@@ -0,0 +1,75 @@
+package cardapp
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+func TestFSRSSchedulerFirstReview(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	scheduler := NewFSRSScheduler()
+
+	tests := []struct {
+		rating       card.Rating
+		wantInterval int
+	}{
+		{card.RatingAgain, 1},
+		{card.RatingHard, 1},
+		{card.RatingGood, 4},
+		{card.RatingEasy, 14},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.rating.String(), func(t *testing.T) {
+			next := scheduler.Schedule(card.NewReviewState(now), tc.rating, now)
+			if next.Stability != fsrsDefaultWeights[tc.rating-1] {
+				t.Fatalf("expected initial stability %v, got %v", fsrsDefaultWeights[tc.rating-1], next.Stability)
+			}
+			if next.Difficulty < 1 || next.Difficulty > 10 {
+				t.Fatalf("difficulty out of range: %v", next.Difficulty)
+			}
+			if next.Interval != tc.wantInterval {
+				t.Fatalf("expected interval %d, got %d", tc.wantInterval, next.Interval)
+			}
+			if !next.DueAt.Equal(now.AddDate(0, 0, tc.wantInterval)) {
+				t.Fatalf("unexpected due date %v", next.DueAt)
+			}
+		})
+	}
+}
+
+func TestFSRSSchedulerSubsequentReviews(t *testing.T) {
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	scheduler := NewFSRSScheduler()
+
+	first := scheduler.Schedule(card.NewReviewState(start), card.RatingGood, start)
+	second := scheduler.Schedule(first, card.RatingGood, first.DueAt)
+	if second.Stability <= first.Stability {
+		t.Fatalf("expected stability to grow after a successful review: %v -> %v", first.Stability, second.Stability)
+	}
+	if second.Interval <= first.Interval {
+		t.Fatalf("expected interval to grow: %d -> %d", first.Interval, second.Interval)
+	}
+	if second.Repetitions != 2 {
+		t.Fatalf("expected 2 repetitions, got %d", second.Repetitions)
+	}
+
+	hard := scheduler.Schedule(first, card.RatingHard, first.DueAt)
+	easy := scheduler.Schedule(first, card.RatingEasy, first.DueAt)
+	if !(hard.Stability < second.Stability && second.Stability < easy.Stability) {
+		t.Fatalf("expected hard < good < easy stability, got %v, %v, %v", hard.Stability, second.Stability, easy.Stability)
+	}
+
+	lapse := scheduler.Schedule(second, card.RatingAgain, second.DueAt)
+	if lapse.Stability >= second.Stability {
+		t.Fatalf("expected a lapse to reduce stability: %v -> %v", second.Stability, lapse.Stability)
+	}
+	if lapse.Difficulty <= second.Difficulty {
+		t.Fatalf("expected a lapse to raise difficulty: %v -> %v", second.Difficulty, lapse.Difficulty)
+	}
+	if lapse.Repetitions != 0 || lapse.Interval >= second.Interval {
+		t.Fatalf("expected lapse to restart repetitions with a shorter interval, got %+v", lapse)
+	}
+}
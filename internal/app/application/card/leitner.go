@@ -0,0 +1,51 @@
+package cardapp
+
+import (
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+// DefaultLeitnerCadence lists the review interval in days of each Leitner box, starting with box 1.
+var DefaultLeitnerCadence = []int{1, 2, 4, 8, 16}
+
+// LeitnerScheduler implements the Leitner box system: a recalled card moves up one box,
+// a forgotten one goes back to box 1, and each box is reviewed at its own cadence.
+type LeitnerScheduler struct {
+	cadence []int
+}
+
+// NewLeitnerScheduler builds a scheduler with one box per cadence entry; an empty cadence uses DefaultLeitnerCadence.
+func NewLeitnerScheduler(cadence []int) LeitnerScheduler {
+	if len(cadence) == 0 {
+		cadence = DefaultLeitnerCadence
+	}
+	return LeitnerScheduler{cadence: append([]int(nil), cadence...)}
+}
+
+// Cadence returns the review interval in days of each box, starting with box 1.
+func (l LeitnerScheduler) Cadence() []int {
+	return append([]int(nil), l.cadence...)
+}
+
+// Schedule moves the card between boxes after a review graded with rating at now.
+// Any rating other than Again counts as a correct answer.
+func (l LeitnerScheduler) Schedule(state card.ReviewState, rating card.Rating, now time.Time) card.ReviewState {
+	next := state
+
+	if rating == card.RatingAgain {
+		next.Box = 1
+		next.Repetitions = 0
+	} else {
+		next.Box = state.LeitnerBox() + 1
+		if next.Box > len(l.cadence) {
+			next.Box = len(l.cadence)
+		}
+		next.Repetitions++
+	}
+
+	next.Interval = l.cadence[next.Box-1]
+	next.ReviewedAt = now
+	next.DueAt = now.AddDate(0, 0, next.Interval)
+	return next
+}
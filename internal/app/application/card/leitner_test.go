@@ -0,0 +1,48 @@
+package cardapp
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+func TestLeitnerSchedulerPromotesAndDemotes(t *testing.T) {
+	now := time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)
+	scheduler := NewLeitnerScheduler([]int{1, 3, 7})
+
+	state := card.NewReviewState(now)
+	if state.LeitnerBox() != 1 {
+		t.Fatalf("expected new card in box 1, got %d", state.LeitnerBox())
+	}
+
+	steps := []struct {
+		rating       card.Rating
+		wantBox      int
+		wantInterval int
+	}{
+		{card.RatingGood, 2, 3},
+		{card.RatingHard, 3, 7},
+		{card.RatingEasy, 3, 7},
+		{card.RatingAgain, 1, 1},
+		{card.RatingGood, 2, 3},
+	}
+
+	for i, step := range steps {
+		state = scheduler.Schedule(state, step.rating, now)
+		if state.Box != step.wantBox || state.Interval != step.wantInterval {
+			t.Fatalf("step %d (%s): expected box %d every %d days, got box %d every %d days",
+				i+1, step.rating, step.wantBox, step.wantInterval, state.Box, state.Interval)
+		}
+		if !state.DueAt.Equal(now.AddDate(0, 0, step.wantInterval)) {
+			t.Fatalf("step %d: unexpected due date %v", i+1, state.DueAt)
+		}
+	}
+}
+
+func TestNewLeitnerSchedulerDefaultCadence(t *testing.T) {
+	scheduler := NewLeitnerScheduler(nil)
+	if got := scheduler.Cadence(); len(got) != len(DefaultLeitnerCadence) {
+		t.Fatalf("expected default cadence %v, got %v", DefaultLeitnerCadence, got)
+	}
+}
@@ -0,0 +1,152 @@
+package cardapp
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/media"
+	"flash2fy/internal/app/ports"
+)
+
+// WithMedia lets media files be attached to cards; the attachments reference files kept by storage.
+func WithMedia(attachments ports.AttachmentRepository, storage ports.MediaStorage) Option {
+	return func(s *Service) {
+		s.attachments = attachments
+		s.storage = storage
+	}
+}
+
+// AttachMedia stores the content read from r and attaches it to one side of the card, which
+// actorID must be able to edit. contentType is the detected type of the content; images over
+// media.MaxImageSize and audio over media.MaxAudioSize are rejected.
+func (s *Service) AttachMedia(cardID, actorID string, side media.Side, filename, contentType string, r io.Reader) (media.Attachment, error) {
+	if s.attachments == nil || s.storage == nil {
+		return media.Attachment{}, media.ErrUnavailable
+	}
+	if _, err := s.findFor(cardID, actorID, deck.RoleEditor); err != nil {
+		return media.Attachment{}, err
+	}
+	if !side.Valid() {
+		return media.Attachment{}, media.ErrInvalidSide
+	}
+	contentType = media.CanonicalType(contentType)
+	if !media.Supported(contentType) {
+		return media.Attachment{}, media.ErrUnsupportedType
+	}
+
+	attachment := media.Attachment{
+		ID:          uuid.NewString(),
+		CardID:      cardID,
+		Side:        side,
+		ContentType: contentType,
+		Filename:    cleanFilename(filename),
+		CreatedAt:   s.now(),
+	}
+	size, err := s.storage.Put(attachment.ID, io.LimitReader(r, media.Limit(contentType)+1))
+	if err != nil {
+		return media.Attachment{}, err
+	}
+	attachment.Size = size
+
+	// Validation happens on save, after the content is stored and its size known.
+	saved, err := s.attachments.Save(attachment)
+	if err != nil {
+		_ = s.storage.Delete(attachment.ID)
+		return media.Attachment{}, err
+	}
+	return saved, nil
+}
+
+// Media returns the attachments of a card actorID may view, oldest first.
+func (s *Service) Media(cardID, actorID string) ([]media.Attachment, error) {
+	if _, err := s.findFor(cardID, actorID, deck.RoleViewer); err != nil {
+		return nil, err
+	}
+	if s.attachments == nil {
+		return nil, nil
+	}
+	return s.attachments.FindByCard(cardID)
+}
+
+// OpenMedia returns an attachment of a card actorID may view together with its content, which
+// the caller must close.
+func (s *Service) OpenMedia(cardID, id, actorID string) (media.Attachment, io.ReadSeekCloser, error) {
+	attachment, err := s.findAttachment(cardID, id, actorID, deck.RoleViewer)
+	if err != nil {
+		return media.Attachment{}, nil, err
+	}
+	content, err := s.storage.Open(attachment.ID)
+	if err != nil {
+		return media.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteMedia removes an attachment of a card actorID may edit, and its file.
+func (s *Service) DeleteMedia(cardID, id, actorID string) error {
+	attachment, err := s.findAttachment(cardID, id, actorID, deck.RoleEditor)
+	if err != nil {
+		return err
+	}
+	return s.removeAttachment(attachment)
+}
+
+// deleteMedia removes every attachment of a deleted card.
+func (s *Service) deleteMedia(cardID string) error {
+	if s.attachments == nil {
+		return nil
+	}
+	attachments, err := s.attachments.FindByCard(cardID)
+	if err != nil {
+		return err
+	}
+	for _, a := range attachments {
+		if err := s.removeAttachment(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeAttachment deletes the attachment and its file; a file already gone is not an error.
+func (s *Service) removeAttachment(a media.Attachment) error {
+	if err := s.attachments.Delete(a.ID); err != nil {
+		return err
+	}
+	if err := s.storage.Delete(a.ID); err != nil && err != media.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+// findAttachment looks an attachment up once actorID holds the required role on its card,
+// treating attachments of other cards as unknown.
+func (s *Service) findAttachment(cardID, id, actorID string, required deck.Role) (media.Attachment, error) {
+	if _, err := s.findFor(cardID, actorID, required); err != nil {
+		return media.Attachment{}, err
+	}
+	if s.attachments == nil || s.storage == nil {
+		return media.Attachment{}, media.ErrNotFound
+	}
+	attachment, err := s.attachments.FindByID(id)
+	if err != nil {
+		return media.Attachment{}, err
+	}
+	if attachment.CardID != cardID {
+		return media.Attachment{}, media.ErrNotFound
+	}
+	return attachment, nil
+}
+
+// cleanFilename keeps the base name of an uploaded file, without any directory a client sent.
+func cleanFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(strings.TrimSpace(name), `\`, "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
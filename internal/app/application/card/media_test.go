@@ -0,0 +1,119 @@
+package cardapp
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/media"
+)
+
+func newMediaService(t *testing.T) (*Service, *mediastorage.LocalStorage) {
+	t.Helper()
+	storage, err := mediastorage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("new storage failed: %v", err)
+	}
+	return NewService(cardstorage.NewMemoryRepository(), WithMedia(mediastorage.NewMemoryRepository(), storage)), storage
+}
+
+func TestAttachAndOpenMedia(t *testing.T) {
+	service, _ := newMediaService(t)
+	created, _ := service.CreateCard("Which bone?", "Femur", "user-1")
+
+	attachment, err := service.AttachMedia(created.ID, "user-1", media.SideFront, `C:\scans\leg.png`, "image/png", strings.NewReader("png-bytes"))
+	if err != nil {
+		t.Fatalf("attach failed: %v", err)
+	}
+	if attachment.Filename != "leg.png" || attachment.Size != 9 || attachment.Side != media.SideFront {
+		t.Fatalf("unexpected attachment: %+v", attachment)
+	}
+
+	listed, _ := service.Media(created.ID, "user-1")
+	if len(listed) != 1 || listed[0].ID != attachment.ID {
+		t.Fatalf("expected the attachment to be listed, got %+v", listed)
+	}
+
+	_, content, err := service.OpenMedia(created.ID, attachment.ID, "user-1")
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if string(data) != "png-bytes" {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	other, _ := service.CreateCard("Other", "", "user-1")
+	if _, _, err := service.OpenMedia(other.ID, attachment.ID, "user-1"); err != media.ErrNotFound {
+		t.Fatalf("expected ErrNotFound through another card, got %v", err)
+	}
+}
+
+func TestAttachMediaValidation(t *testing.T) {
+	service, _ := newMediaService(t)
+	created, _ := service.CreateCard("Which bone?", "Femur", "user-1")
+
+	if _, err := service.AttachMedia("missing", "user-1", media.SideFront, "a.png", "image/png", strings.NewReader("x")); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if _, err := service.AttachMedia(created.ID, "user-1", "middle", "a.png", "image/png", strings.NewReader("x")); err != media.ErrInvalidSide {
+		t.Fatalf("expected ErrInvalidSide, got %v", err)
+	}
+	if _, err := service.AttachMedia(created.ID, "user-1", media.SideFront, "a.pdf", "application/pdf", strings.NewReader("x")); err != media.ErrUnsupportedType {
+		t.Fatalf("expected ErrUnsupportedType, got %v", err)
+	}
+	if _, err := service.AttachMedia(created.ID, "user-1", media.SideFront, "a.png", "image/png", strings.NewReader("")); err != media.ErrEmpty {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+
+	large := bytes.NewReader(make([]byte, media.MaxImageSize+10))
+	attachment, err := service.AttachMedia(created.ID, "user-1", media.SideFront, "a.png", "image/png", large)
+	if err != media.ErrTooLarge {
+		t.Fatalf("expected ErrTooLarge, got %v (%+v)", err, attachment)
+	}
+	if large.Len() != 9 {
+		t.Fatalf("expected reading to stop past the limit, %d bytes left", large.Len())
+	}
+
+	if listed, _ := service.Media(created.ID, "user-1"); len(listed) != 0 {
+		t.Fatalf("expected no attachments after rejected uploads, got %+v", listed)
+	}
+}
+
+func TestPurgeCardRemovesMedia(t *testing.T) {
+	service, storage := newMediaService(t)
+	created, _ := service.CreateCard("Which bone?", "Femur", "user-1")
+	front, _ := service.AttachMedia(created.ID, "user-1", media.SideFront, "a.png", "image/png", strings.NewReader("front"))
+	back, _ := service.AttachMedia(created.ID, "user-1", media.SideBack, "b.png", "image/png", strings.NewReader("back"))
+
+	if err := service.DeleteMedia(created.ID, front.ID, "user-1"); err != nil {
+		t.Fatalf("delete media failed: %v", err)
+	}
+	if _, err := storage.Open(front.ID); err != media.ErrNotFound {
+		t.Fatalf("expected the file to be removed, got %v", err)
+	}
+	if err := service.DeleteMedia(created.ID, front.ID, "user-1"); err != media.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	if err := service.DeleteCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("delete card failed: %v", err)
+	}
+	content, err := storage.Open(back.ID)
+	if err != nil {
+		t.Fatalf("expected the file kept while the card is in the trash, got %v", err)
+	}
+	content.Close()
+
+	if err := service.PurgeCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("purge card failed: %v", err)
+	}
+	if _, err := storage.Open(back.ID); err != media.ErrNotFound {
+		t.Fatalf("expected the card's remaining file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package cardapp
+
+import (
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/revision"
+	"flash2fy/internal/app/ports"
+)
+
+// WithRevisions keeps every version of the content of standalone cards in the given repository.
+func WithRevisions(revisions ports.RevisionRepository) Option {
+	return func(s *Service) {
+		s.revisions = revisions
+	}
+}
+
+// EditCard is UpdateCard recording who made the edit and through which channel; the editor
+// must be able to edit the card. Edits that change neither side record nothing.
+func (s *Service) EditCard(id, front, back string, editor revision.Editor) (card.Card, error) {
+	existing, err := s.findFor(id, editor.UserID, deck.RoleEditor)
+	if err != nil {
+		return card.Card{}, err
+	}
+	if existing.NoteID != "" {
+		return card.Card{}, card.ErrManagedByNote
+	}
+	if !editor.Channel.Valid() {
+		return card.Card{}, revision.ErrInvalidChannel
+	}
+
+	previous := existing
+	existing.Front = front
+	existing.Back = back
+	existing.UpdatedAt = s.now()
+
+	if err := existing.Validate(); err != nil {
+		return card.Card{}, err
+	}
+
+	updated, err := s.repo.Update(existing)
+	if err != nil {
+		return card.Card{}, err
+	}
+	if previous.Front == front && previous.Back == back {
+		return updated, nil
+	}
+	if err := s.recordRevision(previous, updated, editor); err != nil {
+		return card.Card{}, err
+	}
+	return updated, nil
+}
+
+// Revisions returns the content history of the card, oldest first. A card never edited since
+// revisions are kept has none.
+func (s *Service) Revisions(id, actorID string) ([]revision.Revision, error) {
+	if _, err := s.findFor(id, actorID, deck.RoleViewer); err != nil {
+		return nil, err
+	}
+	if s.revisions == nil {
+		return nil, nil
+	}
+	return s.revisions.FindByCard(id)
+}
+
+// RevertCard gives the card the content of one of its revisions again. The revert is an edit
+// like any other and is recorded as a new revision, so it can itself be reverted.
+func (s *Service) RevertCard(id string, number int, editor revision.Editor) (card.Card, error) {
+	if _, err := s.findFor(id, editor.UserID, deck.RoleEditor); err != nil {
+		return card.Card{}, err
+	}
+	if s.revisions == nil {
+		return card.Card{}, revision.ErrNotFound
+	}
+	rev, err := s.revisions.FindByNumber(id, number)
+	if err != nil {
+		return card.Card{}, err
+	}
+	return s.EditCard(id, rev.Front, rev.Back, editor)
+}
+
+// recordRevision appends the edited content to the history of the card. The first edit also
+// records the content the card had before, so that it can be reverted to.
+func (s *Service) recordRevision(previous, updated card.Card, editor revision.Editor) error {
+	if s.revisions == nil {
+		return nil
+	}
+	history, err := s.revisions.FindByCard(updated.ID)
+	if err != nil {
+		return err
+	}
+
+	next := 1
+	if len(history) > 0 {
+		next = history[len(history)-1].Number + 1
+	} else {
+		if _, err := s.revisions.Save(revision.Revision{
+			CardID:    previous.ID,
+			Number:    next,
+			Front:     previous.Front,
+			Back:      previous.Back,
+			EditorID:  previous.OwnerID,
+			CreatedAt: previous.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+		next++
+	}
+
+	_, err = s.revisions.Save(revision.Revision{
+		CardID:    updated.ID,
+		Number:    next,
+		Front:     updated.Front,
+		Back:      updated.Back,
+		EditorID:  editor.UserID,
+		Channel:   editor.Channel,
+		CreatedAt: updated.UpdatedAt,
+	})
+	return err
+}
+
+// deleteRevisions removes the history of a deleted card.
+func (s *Service) deleteRevisions(cardID string) error {
+	if s.revisions == nil {
+		return nil
+	}
+	return s.revisions.DeleteByCard(cardID)
+}
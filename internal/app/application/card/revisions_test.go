@@ -0,0 +1,118 @@
+package cardapp
+
+import (
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	revisionstorage "flash2fy/internal/adapters/storage/revision"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/revision"
+)
+
+func newRevisionService(opts ...Option) (*Service, *time.Time) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	opts = append([]Option{
+		WithRevisions(revisionstorage.NewMemoryRepository()),
+		WithClock(func() time.Time { return now }),
+	}, opts...)
+	return NewService(cardstorage.NewMemoryRepository(), opts...), &now
+}
+
+func TestEditCardRecordsRevisions(t *testing.T) {
+	decks := deckstorage.NewMemoryRepository()
+	members := deckstorage.NewMemoryMemberRepository()
+	service, now := newRevisionService(WithDecks(decks), WithDeckMembers(members))
+	shared, _ := decks.Save(deck.Deck{ID: "deck-1", Name: "German", OwnerID: "user-1", CreatedAt: *now, UpdatedAt: *now})
+	members.Save(deck.Member{DeckID: shared.ID, UserID: "user-2", Role: deck.RoleEditor, CreatedAt: *now})
+	created, _ := service.CreateCardInDeck("Hund", "dog", "user-1", shared.ID)
+
+	if revisions, _ := service.Revisions(created.ID, "user-1"); len(revisions) != 0 {
+		t.Fatalf("expected no revisions before the first edit, got %+v", revisions)
+	}
+
+	*now = now.Add(time.Hour)
+	if _, err := service.EditCard(created.ID, "der Hund", "dog", revision.Editor{UserID: "user-2", Channel: revision.ChannelHTTP}); err != nil {
+		t.Fatalf("edit failed: %v", err)
+	}
+	*now = now.Add(time.Hour)
+	if _, err := service.EditCard(created.ID, "der Hund", "the dog", revision.Editor{UserID: "user-1", Channel: revision.ChannelTelegram}); err != nil {
+		t.Fatalf("edit failed: %v", err)
+	}
+	if _, err := service.EditCard(created.ID, "der Hund", "the dog", revision.Editor{UserID: "user-1", Channel: revision.ChannelHTTP}); err != nil {
+		t.Fatalf("unchanged edit failed: %v", err)
+	}
+
+	revisions, err := service.Revisions(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("revisions failed: %v", err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("expected the original and two edits, got %+v", revisions)
+	}
+	if original := revisions[0]; original.Number != 1 || original.Front != "Hund" || original.EditorID != "user-1" || original.Channel != "" {
+		t.Fatalf("unexpected original revision: %+v", original)
+	}
+	if edit := revisions[1]; edit.Number != 2 || edit.Front != "der Hund" || edit.EditorID != "user-2" || edit.Channel != revision.ChannelHTTP {
+		t.Fatalf("unexpected HTTP revision: %+v", edit)
+	}
+	if edit := revisions[2]; edit.Number != 3 || edit.Back != "the dog" || edit.EditorID != "user-1" ||
+		edit.Channel != revision.ChannelTelegram || !edit.CreatedAt.Equal(*now) {
+		t.Fatalf("unexpected Telegram revision: %+v", edit)
+	}
+}
+
+func TestRevertCard(t *testing.T) {
+	service, _ := newRevisionService()
+	created, _ := service.CreateCard("Hund", "dog", "user-1")
+	if _, err := service.UpdateCard(created.ID, "der Hund", "the dog", "user-1"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	reverted, err := service.RevertCard(created.ID, 1, revision.Editor{UserID: "user-1", Channel: revision.ChannelHTTP})
+	if err != nil {
+		t.Fatalf("revert failed: %v", err)
+	}
+	if reverted.Front != "Hund" || reverted.Back != "dog" {
+		t.Fatalf("expected the original content back, got %+v", reverted)
+	}
+
+	revisions, _ := service.Revisions(created.ID, "user-1")
+	if len(revisions) != 3 || revisions[2].Front != "Hund" || revisions[2].Channel != revision.ChannelHTTP {
+		t.Fatalf("expected the revert recorded as a new revision, got %+v", revisions)
+	}
+
+	if _, err := service.RevertCard(created.ID, 7, revision.Editor{UserID: "user-1"}); err != revision.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for an unknown revision, got %v", err)
+	}
+	if _, err := service.RevertCard("missing", 1, revision.Editor{}); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for an unknown card, got %v", err)
+	}
+	if _, err := service.EditCard(created.ID, "x", "y", revision.Editor{UserID: "user-1", Channel: "email"}); err != revision.ErrInvalidChannel {
+		t.Fatalf("expected ErrInvalidChannel, got %v", err)
+	}
+}
+
+func TestPurgeCardRemovesRevisions(t *testing.T) {
+	revisions := revisionstorage.NewMemoryRepository()
+	service := NewService(cardstorage.NewMemoryRepository(), WithRevisions(revisions))
+	created, _ := service.CreateCard("Hund", "dog", "user-1")
+	if _, err := service.UpdateCard(created.ID, "der Hund", "the dog", "user-1"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	if err := service.DeleteCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if found, _ := revisions.FindByCard(created.ID); len(found) != 2 {
+		t.Fatalf("expected revisions kept while the card is in the trash, got %+v", found)
+	}
+	if err := service.PurgeCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	if found, _ := revisions.FindByCard(created.ID); len(found) != 0 {
+		t.Fatalf("expected revisions deleted with the card, got %+v", found)
+	}
+}
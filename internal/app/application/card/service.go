@@ -1,31 +1,167 @@
 package cardapp
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/review"
+	"flash2fy/internal/app/domain/revision"
+	"flash2fy/internal/app/domain/user"
 	"flash2fy/internal/app/ports"
 )
 
+// DefaultLeechThreshold is the number of lapses after which a card is flagged as a leech and suspended.
+const DefaultLeechThreshold = 8
+
+// DefaultSearchLimit and MaxSearchLimit bound how many hits a search returns.
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
 // Service orchestrates card use-cases.
 type Service struct {
-	repo ports.CardRepository
+	repo           ports.CardRepository
+	users          ports.UserRepository
+	decks          ports.DeckRepository
+	members        ports.DeckMemberRepository
+	reviews        ports.ReviewLogRepository
+	attachments    ports.AttachmentRepository
+	revisions      ports.RevisionRepository
+	storage        ports.MediaStorage
+	purgeHooks     []ports.CardPurgeHook
+	schedulers     map[user.Scheduler]ports.Scheduler
+	leitner        LeitnerScheduler
+	leechThreshold int
+	now            func() time.Time
+}
+
+// TagCount reports how many cards carry a tag.
+type TagCount struct {
+	Tag   string
+	Cards int
+}
+
+// Option customises optional Service collaborators.
+type Option func(*Service)
+
+// WithUsers lets the service look up each card owner's preferred scheduler.
+func WithUsers(users ports.UserRepository) Option {
+	return func(s *Service) {
+		s.users = users
+	}
+}
+
+// WithDecks lets cards be placed in decks stored in the given repository.
+func WithDecks(decks ports.DeckRepository) Option {
+	return func(s *Service) {
+		s.decks = decks
+	}
 }
 
-func NewService(repo ports.CardRepository) *Service {
-	return &Service{repo: repo}
+// WithDeckMembers lets the editors a deck is shared with add cards to it.
+func WithDeckMembers(members ports.DeckMemberRepository) Option {
+	return func(s *Service) {
+		s.members = members
+	}
+}
+
+// WithReviewLog records every grade in the given review log repository.
+func WithReviewLog(reviews ports.ReviewLogRepository) Option {
+	return func(s *Service) {
+		s.reviews = reviews
+	}
+}
+
+// WithScheduler registers or replaces the scheduler used for the given algorithm name.
+func WithScheduler(name user.Scheduler, scheduler ports.Scheduler) Option {
+	return func(s *Service) {
+		s.schedulers[name] = scheduler
+	}
+}
+
+// WithLeitnerCadence configures the number of Leitner boxes and the review interval in days of each one.
+func WithLeitnerCadence(cadence []int) Option {
+	return func(s *Service) {
+		s.leitner = NewLeitnerScheduler(cadence)
+		s.schedulers[user.SchedulerLeitner] = s.leitner
+	}
 }
 
-func (s *Service) CreateCard(front, back, ownerID string) (card.Card, error) {
+// WithLeechThreshold sets how many lapses turn a card into a suspended leech; zero disables leech detection.
+func WithLeechThreshold(lapses int) Option {
+	return func(s *Service) {
+		s.leechThreshold = lapses
+	}
+}
+
+// WithPurgeHook tells hook about every card purged for good.
+func WithPurgeHook(hook ports.CardPurgeHook) Option {
+	return func(s *Service) {
+		s.purgeHooks = append(s.purgeHooks, hook)
+	}
+}
+
+// WithClock overrides the time source, mainly for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(repo ports.CardRepository, opts ...Option) *Service {
+	leitner := NewLeitnerScheduler(DefaultLeitnerCadence)
+	s := &Service{
+		repo: repo,
+		schedulers: map[user.Scheduler]ports.Scheduler{
+			user.SchedulerSM2:     SM2Scheduler{},
+			user.SchedulerFSRS:    NewFSRSScheduler(),
+			user.SchedulerLeitner: leitner,
+		},
+		leitner:        leitner,
+		leechThreshold: DefaultLeechThreshold,
+		now:            func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Service) CreateCard(front, back, ownerID string, tags ...string) (card.Card, error) {
+	return s.CreateCardInDeck(front, back, ownerID, "", tags...)
+}
+
+// CreateCardInDeck creates a card of ownerID in a deck; an empty deckID leaves the card outside any deck.
+// ownerID may also be an editor of a shared deck, in which case the card belongs to the deck owner.
+func (s *Service) CreateCardInDeck(front, back, ownerID, deckID string, tags ...string) (card.Card, error) {
+	normalized, err := card.NormalizeTags(tags)
+	if err != nil {
+		return card.Card{}, err
+	}
+	ownerID, err = s.deckOwner(deckID, ownerID)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	now := s.now()
 	newCard := card.Card{
 		ID:        uuid.NewString(),
 		Front:     front,
 		Back:      back,
 		OwnerID:   ownerID,
-		CreatedAt: time.Now().UTC(),
-		UpdatedAt: time.Now().UTC(),
+		DeckID:    deckID,
+		Tags:      normalized,
+		Type:      card.TypeBasic,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Review:    card.NewReviewState(now),
 	}
 	if err := newCard.Validate(); err != nil {
 		return card.Card{}, err
@@ -34,31 +170,490 @@ func (s *Service) CreateCard(front, back, ownerID string) (card.Card, error) {
 	return s.repo.Save(newCard)
 }
 
-func (s *Service) GetCard(id string) (card.Card, error) {
-	return s.repo.FindByID(id)
+// CreateClozeCards creates one card per cloze index found in text; extra is shown with every answer.
+// The cards go into deckID unless it is empty, as with CreateCardInDeck. Nothing is stored when the cloze markup is invalid.
+func (s *Service) CreateClozeCards(text, extra, ownerID, deckID string) ([]card.Card, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, card.ErrEmptyFront
+	}
+	indexes, err := card.ClozeIndexes(text)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, err = s.deckOwner(deckID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	cards := make([]card.Card, 0, len(indexes))
+	for _, index := range indexes {
+		newCard := card.Card{
+			ID:         uuid.NewString(),
+			Front:      text,
+			Back:       extra,
+			OwnerID:    ownerID,
+			DeckID:     deckID,
+			Type:       card.TypeCloze,
+			ClozeIndex: index,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Review:     card.NewReviewState(now),
+		}
+		if err := newCard.Validate(); err != nil {
+			return nil, err
+		}
+		cards = append(cards, newCard)
+	}
+
+	saved := make([]card.Card, 0, len(cards))
+	for _, c := range cards {
+		stored, err := s.repo.Save(c)
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, stored)
+	}
+	return saved, nil
+}
+
+// GetCard returns the card if actorID may view it.
+func (s *Service) GetCard(id, actorID string) (card.Card, error) {
+	return s.findFor(id, actorID, deck.RoleViewer)
+}
+
+// ListCards returns the cards actorID may view: their own cards and those of the decks shared with them.
+func (s *Service) ListCards(actorID string) ([]card.Card, error) {
+	cards, err := s.repo.FindByOwner(actorID)
+	if err != nil {
+		return nil, err
+	}
+	if s.members == nil {
+		return cards, nil
+	}
+	memberships, err := s.members.FindByUser(actorID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		shared, err := s.repo.FindByDeck(m.DeckID)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, shared...)
+	}
+	return cards, nil
+}
+
+// ListCardsInDeck returns the cards in a deck actorID may view.
+func (s *Service) ListCardsInDeck(deckID, actorID string) ([]card.Card, error) {
+	if _, err := s.authorizeDeck(deckID, actorID, deck.RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByDeck(deckID)
+}
+
+// ListCardsTagged returns the cards matching the tag filter, only those in deckID unless it is empty.
+// Without a deck it returns actorID's own matching cards.
+func (s *Service) ListCardsTagged(deckID string, filter card.TagFilter, actorID string) ([]card.Card, error) {
+	var cards []card.Card
+	var err error
+	if deckID == "" {
+		cards, err = s.repo.FindTagged(filter)
+	} else {
+		cards, err = s.ListCardsInDeck(deckID, actorID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	matching := make([]card.Card, 0, len(cards))
+	for _, c := range cards {
+		if filter.Matches(c) && (deckID != "" || c.OwnerID == actorID) {
+			matching = append(matching, c)
+		}
+	}
+	return matching, nil
+}
+
+// Search finds the owner's cards containing every word of the query, the most relevant first.
+// limit falls back to DefaultSearchLimit and is capped at MaxSearchLimit.
+func (s *Service) Search(ownerID, query string, limit int) ([]card.SearchHit, error) {
+	if len(card.Tokenize(query)) == 0 {
+		return nil, card.ErrEmptyQuery
+	}
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	limit = min(limit, MaxSearchLimit)
+
+	return s.repo.Search(ownerID, query, limit)
+}
+
+// SetTags replaces the tags of the card.
+func (s *Service) SetTags(id string, tags []string, actorID string) (card.Card, error) {
+	normalized, err := card.NormalizeTags(tags)
+	if err != nil {
+		return card.Card{}, err
+	}
+	return s.modify(id, actorID, func(c *card.Card) {
+		c.Tags = normalized
+	})
+}
+
+// Tags counts how many of the owner's cards carry each tag, sorted by tag.
+func (s *Service) Tags(ownerID string) ([]TagCount, error) {
+	cards, err := s.repo.FindByOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, c := range cards {
+		for _, tag := range c.Tags {
+			counts[tag]++
+		}
+	}
+	result := make([]TagCount, 0, len(counts))
+	for tag, n := range counts {
+		result = append(result, TagCount{Tag: tag, Cards: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Tag < result[j].Tag
+	})
+	return result, nil
+}
+
+// RenameTag renames the tag on every card of the owner and returns how many cards changed.
+// Renaming to a tag a card already carries merges the two.
+func (s *Service) RenameTag(ownerID, from, to string) (int, error) {
+	return s.MergeTags(ownerID, []string{from}, to)
+}
+
+// MergeTags replaces the source tags with the target tag on every card of the owner
+// and returns how many cards changed.
+func (s *Service) MergeTags(ownerID string, sources []string, target string) (int, error) {
+	if len(sources) == 0 {
+		return 0, card.ErrInvalidTag
+	}
+	normalizedSources, err := card.NormalizeTags(sources)
+	if err != nil {
+		return 0, err
+	}
+	normalizedTarget, err := card.NormalizeTag(target)
+	if err != nil {
+		return 0, err
+	}
+	merged := make(map[string]bool, len(normalizedSources))
+	for _, tag := range normalizedSources {
+		merged[tag] = true
+	}
+
+	cards, err := s.repo.FindByOwner(ownerID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	changed := 0
+	for _, c := range cards {
+		tags := make([]string, 0, len(c.Tags))
+		found := false
+		for _, tag := range c.Tags {
+			if merged[tag] {
+				found = true
+				tag = normalizedTarget
+			}
+			tags = append(tags, tag)
+		}
+		if !found {
+			continue
+		}
+
+		c.Tags, err = card.NormalizeTags(tags)
+		if err != nil {
+			return changed, err
+		}
+		c.UpdatedAt = now
+		if _, err := s.repo.Update(c); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+	return changed, nil
 }
 
-func (s *Service) ListCards() ([]card.Card, error) {
-	return s.repo.FindAll()
+// MoveCard places the card in another deck of its owner; an empty deckID takes it out of its deck.
+// actorID must be able to edit both the card and the target deck.
+func (s *Service) MoveCard(id, deckID, actorID string) (card.Card, error) {
+	existing, err := s.findFor(id, actorID, deck.RoleEditor)
+	if err != nil {
+		return card.Card{}, err
+	}
+	if err := s.checkDeck(deckID, existing.OwnerID); err != nil {
+		return card.Card{}, err
+	}
+	if deckID != "" {
+		if _, err := s.authorizeDeck(deckID, actorID, deck.RoleEditor); err != nil {
+			return card.Card{}, err
+		}
+	}
+
+	existing.DeckID = deckID
+	existing.UpdatedAt = s.now()
+	return s.repo.Update(existing)
+}
+
+// UpdateCard edits a standalone card; cards generated from a note change with the note.
+// The edit is recorded as a revision by actorID through an unknown channel.
+func (s *Service) UpdateCard(id, front, back, actorID string) (card.Card, error) {
+	return s.EditCard(id, front, back, revision.Editor{UserID: actorID})
 }
 
-func (s *Service) UpdateCard(id, front, back string) (card.Card, error) {
-	existing, err := s.repo.FindByID(id)
+// GradeCard records a review of the card and reschedules it with the owner's preferred scheduler.
+// answerTime is how long the user looked at the card before answering; zero when unknown.
+// A card that reaches the leech threshold of lapses is flagged as a leech and suspended, and so is
+// a leech that lapses again after being unsuspended.
+// Studying counts as reading, so viewers of a shared deck may grade its cards.
+func (s *Service) GradeCard(id string, rating card.Rating, answerTime time.Duration, actorID string) (card.Card, error) {
+	if !rating.Valid() {
+		return card.Card{}, card.ErrInvalidRating
+	}
+
+	existing, err := s.findFor(id, actorID, deck.RoleViewer)
 	if err != nil {
 		return card.Card{}, err
 	}
 
-	existing.Front = front
-	existing.Back = back
-	existing.UpdatedAt = time.Now().UTC()
+	scheduler, err := s.schedulerFor(existing.OwnerID)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	now := s.now()
+	previous := existing.Review
+	existing.Review = scheduler.Schedule(previous, rating, now)
+	if rating == card.RatingAgain && previous.Interval > 0 {
+		existing.Review.Lapses = previous.Lapses + 1
+		if s.leechThreshold > 0 && existing.Review.Lapses >= s.leechThreshold && !existing.Leech {
+			existing.Leech = true
+			existing.Suspended = true
+			if !existing.HasTag(card.LeechTag) {
+				existing.Tags = append(existing.Tags, card.LeechTag)
+			}
+			if existing.Tags, err = card.NormalizeTags(existing.Tags); err != nil {
+				return card.Card{}, err
+			}
+		}
+	}
+
+	// The log is written before the card so a failed update can simply be retried: the retry
+	// grades the same state again, derives the same log ID and finds the log already recorded.
+	if s.reviews != nil {
+		entry := review.Log{
+			ID:               gradeLogID(existing.ID, existing.UpdatedAt, previous, rating),
+			CardID:           existing.ID,
+			UserID:           existing.OwnerID,
+			Rating:           rating,
+			PreviousInterval: previous.Interval,
+			NextInterval:     existing.Review.Interval,
+			AnswerTime:       answerTime,
+			ReviewedAt:       now,
+		}
+		if !previous.IsNew() {
+			entry.ElapsedDays = int(now.Sub(previous.ReviewedAt).Hours() / 24)
+		}
+		if _, err := s.reviews.Save(entry); err != nil && err != review.ErrDuplicate {
+			return card.Card{}, err
+		}
+	}
+
+	return s.repo.Update(existing)
+}
+
+// gradeLogID identifies the grading of a card from a given state, so grading the same state
+// twice maps to one review log.
+func gradeLogID(cardID string, updatedAt time.Time, previous card.ReviewState, rating card.Rating) string {
+	key := fmt.Sprintf("%s|%d|%d|%d|%d|%d|%d|%d", cardID, updatedAt.UnixNano(), previous.ReviewedAt.UnixNano(),
+		previous.Repetitions, previous.Lapses, previous.Interval, previous.Box, rating)
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(key)).String()
+}
 
-	if err := existing.Validate(); err != nil {
+// CardReviews returns the review history of a card together with its aggregated statistics.
+func (s *Service) CardReviews(id, actorID string) ([]review.Log, review.Stats, error) {
+	if _, err := s.findFor(id, actorID, deck.RoleViewer); err != nil {
+		return nil, review.Stats{}, err
+	}
+	if s.reviews == nil {
+		return nil, review.Stats{}, nil
+	}
+
+	logs, err := s.reviews.FindByCard(id)
+	if err != nil {
+		return nil, review.Stats{}, err
+	}
+
+	return logs, review.Summarize(logs), nil
+}
+
+// SuspendCard keeps the card out of review queues until it is unsuspended.
+func (s *Service) SuspendCard(id, actorID string) (card.Card, error) {
+	return s.modify(id, actorID, func(c *card.Card) {
+		c.Suspended = true
+	})
+}
+
+// UnsuspendCard returns a suspended or buried card to review queues. A leech loses its flag, but
+// keeps its tag, so its next lapse suspends it again.
+func (s *Service) UnsuspendCard(id, actorID string) (card.Card, error) {
+	return s.modify(id, actorID, func(c *card.Card) {
+		c.Suspended = false
+		c.Leech = false
+		c.BuriedUntil = time.Time{}
+	})
+}
+
+// BuryCard hides the card from review queues until the start of the next day.
+func (s *Service) BuryCard(id, actorID string) (card.Card, error) {
+	now := s.now()
+	return s.modify(id, actorID, func(c *card.Card) {
+		c.BuriedUntil = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	})
+}
+
+// DueCards returns the owner's cards due for review now, including new ones.
+func (s *Service) DueCards(ownerID string) ([]card.Card, error) {
+	return s.repo.FindDue(ownerID, s.now())
+}
+
+// LeitnerBoxes reports how many of the owner's cards sit in each Leitner box.
+func (s *Service) LeitnerBoxes(ownerID string) ([]card.LeitnerBox, error) {
+	cards, err := s.repo.FindByOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	cadence := s.leitner.Cadence()
+	boxes := make([]card.LeitnerBox, len(cadence))
+	for i, days := range cadence {
+		boxes[i] = card.LeitnerBox{Box: i + 1, Cadence: days}
+	}
+	for _, c := range cards {
+		box := c.Review.LeitnerBox()
+		if box > len(boxes) {
+			box = len(boxes)
+		}
+		boxes[box-1].Cards++
+	}
+
+	return boxes, nil
+}
+
+// modify applies change to a card actorID may edit.
+func (s *Service) modify(id, actorID string, change func(*card.Card)) (card.Card, error) {
+	existing, err := s.findFor(id, actorID, deck.RoleEditor)
+	if err != nil {
 		return card.Card{}, err
 	}
 
+	change(&existing)
+	existing.UpdatedAt = s.now()
 	return s.repo.Update(existing)
 }
 
-func (s *Service) DeleteCard(id string) error {
-	return s.repo.Delete(id)
+// checkDeck ensures a card of ownerID may be placed in the deck; an empty deckID needs no deck.
+func (s *Service) checkDeck(deckID, ownerID string) error {
+	if deckID == "" {
+		return nil
+	}
+	d, err := s.findDeck(deckID)
+	if err != nil {
+		return err
+	}
+	return d.Accepts(ownerID)
+}
+
+// deckOwner returns who owns the cards actorID creates in the deck: actorID outside any deck,
+// and the deck owner once actorID is known to own or edit the deck.
+func (s *Service) deckOwner(deckID, actorID string) (string, error) {
+	if deckID == "" {
+		return actorID, nil
+	}
+	d, err := s.authorizeDeck(deckID, actorID, deck.RoleEditor)
+	if err != nil {
+		return "", err
+	}
+	return d.OwnerID, nil
+}
+
+// authorizeDeck returns the deck once actorID is known to hold at least the required role on it.
+func (s *Service) authorizeDeck(deckID, actorID string, required deck.Role) (deck.Deck, error) {
+	d, err := s.findDeck(deckID)
+	if err != nil {
+		return deck.Deck{}, err
+	}
+	var members []deck.Member
+	if s.members != nil && d.OwnerID != actorID {
+		if members, err = s.members.FindByDeck(deckID); err != nil {
+			return deck.Deck{}, err
+		}
+	}
+	if err := d.Authorize(actorID, members, required); err != nil {
+		return deck.Deck{}, err
+	}
+	return d, nil
+}
+
+// findFor returns the card once actorID is known to hold at least the required role on it.
+func (s *Service) findFor(id, actorID string, required deck.Role) (card.Card, error) {
+	c, err := s.repo.FindByID(id)
+	if err != nil {
+		return card.Card{}, err
+	}
+	return c, s.authorize(c, actorID, required)
+}
+
+// authorize checks that actorID holds at least the required role on the card: its owner always
+// does, other users only through their membership of the card's deck. Users without any role
+// get card.ErrForeignOwner, members with too weak a role deck.ErrForbidden.
+func (s *Service) authorize(c card.Card, actorID string, required deck.Role) error {
+	if actorID != "" && c.OwnerID == actorID {
+		return nil
+	}
+	if c.DeckID == "" {
+		return card.ErrForeignOwner
+	}
+	_, err := s.authorizeDeck(c.DeckID, actorID, required)
+	switch err {
+	case deck.ErrNotFound, deck.ErrForeignOwner:
+		return card.ErrForeignOwner
+	}
+	return err
+}
+
+func (s *Service) findDeck(id string) (deck.Deck, error) {
+	if s.decks == nil {
+		return deck.Deck{}, deck.ErrNotFound
+	}
+	return s.decks.FindByID(id)
+}
+
+// schedulerFor resolves the scheduler of the card owner; unknown owners study with the default scheduler.
+func (s *Service) schedulerFor(ownerID string) (ports.Scheduler, error) {
+	name := user.DefaultScheduler
+	if s.users != nil && ownerID != "" {
+		owner, err := s.users.FindByID(ownerID)
+		switch err {
+		case nil:
+			name = owner.PreferredScheduler()
+		case user.ErrNotFound:
+		default:
+			return nil, err
+		}
+	}
+
+	scheduler, ok := s.schedulers[name]
+	if !ok {
+		return nil, user.ErrUnknownScheduler
+	}
+	return scheduler, nil
 }
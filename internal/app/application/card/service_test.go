@@ -1,11 +1,16 @@
 package cardapp
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	cardstorage "flash2fy/internal/adapters/storage/card"
+	reviewstorage "flash2fy/internal/adapters/storage/review"
+	userstorage "flash2fy/internal/adapters/storage/user"
 	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/user"
+	"flash2fy/internal/app/ports"
 )
 
 func TestCreateCard(t *testing.T) {
@@ -50,7 +55,7 @@ func TestUpdateCard(t *testing.T) {
 
 	time.Sleep(time.Millisecond)
 
-	updated, err := service.UpdateCard(created.ID, "New Front", "New Back")
+	updated, err := service.UpdateCard(created.ID, "New Front", "New Back", "user-1")
 	if err != nil {
 		t.Fatalf("update failed: %v", err)
 	}
@@ -66,7 +71,7 @@ func TestUpdateCardNotFound(t *testing.T) {
 	repo := cardstorage.NewMemoryRepository()
 	service := NewService(repo)
 
-	if _, err := service.UpdateCard("missing", "front", "back"); err != card.ErrNotFound {
+	if _, err := service.UpdateCard("missing", "front", "back", "user-1"); err != card.ErrNotFound {
 		t.Fatalf("expected ErrNotFound, got %v", err)
 	}
 }
@@ -80,11 +85,562 @@ func TestDeleteCard(t *testing.T) {
 		t.Fatalf("create failed: %v", err)
 	}
 
-	if err := service.DeleteCard(created.ID); err != nil {
+	if err := service.DeleteCard(created.ID, "user-1"); err != nil {
 		t.Fatalf("delete failed: %v", err)
 	}
 
-	if _, err := service.GetCard(created.ID); err != card.ErrNotFound {
+	if _, err := service.GetCard(created.ID, "user-1"); err != card.ErrNotFound {
 		t.Fatalf("expected ErrNotFound after delete, got %v", err)
 	}
 }
+
+func TestGradeCard(t *testing.T) {
+	repo := cardstorage.NewMemoryRepository()
+	service := NewService(repo)
+
+	created, err := service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if !created.Review.IsNew() || created.Review.EaseFactor != card.DefaultEaseFactor {
+		t.Fatalf("expected fresh review state, got %+v", created.Review)
+	}
+
+	graded, err := service.GradeCard(created.ID, card.RatingGood, 0, "user-1")
+	if err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+	if graded.Review.Repetitions != 1 || graded.Review.Interval != 1 {
+		t.Fatalf("unexpected review state after grading: %+v", graded.Review)
+	}
+	if !graded.Review.DueAt.After(created.Review.DueAt) {
+		t.Fatalf("expected due date to move forward")
+	}
+
+	stored, err := service.GetCard(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if stored.Review != graded.Review {
+		t.Fatalf("expected review state persisted, got %+v", stored.Review)
+	}
+}
+
+func TestGradeCardValidation(t *testing.T) {
+	repo := cardstorage.NewMemoryRepository()
+	service := NewService(repo)
+
+	created, err := service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	if _, err := service.GradeCard(created.ID, card.Rating(0), 0, "user-1"); err != card.ErrInvalidRating {
+		t.Fatalf("expected ErrInvalidRating, got %v", err)
+	}
+	if _, err := service.GradeCard("missing", card.RatingGood, 0, "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGradeCardUsesOwnerScheduler(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	users := userstorage.NewMemoryRepository()
+	if _, err := users.Save(user.User{ID: "sm2-user", Nickname: "classic", Scheduler: user.SchedulerSM2}); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+	if _, err := users.Save(user.User{ID: "fsrs-user", Nickname: "modern", Scheduler: user.SchedulerFSRS}); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+
+	service := NewService(cardstorage.NewMemoryRepository(), WithUsers(users), WithClock(func() time.Time { return now }))
+
+	tests := []struct {
+		ownerID      string
+		wantInterval int
+	}{
+		{"sm2-user", 1},
+		{"fsrs-user", 4},
+		{"unknown-user", 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ownerID, func(t *testing.T) {
+			created, err := service.CreateCard("Front", "Back", tc.ownerID)
+			if err != nil {
+				t.Fatalf("create failed: %v", err)
+			}
+
+			graded, err := service.GradeCard(created.ID, card.RatingGood, 0, tc.ownerID)
+			if err != nil {
+				t.Fatalf("grade failed: %v", err)
+			}
+			if graded.Review.Interval != tc.wantInterval {
+				t.Fatalf("expected interval %d, got %d", tc.wantInterval, graded.Review.Interval)
+			}
+			if !graded.Review.ReviewedAt.Equal(now) || !graded.Review.DueAt.Equal(now.AddDate(0, 0, tc.wantInterval)) {
+				t.Fatalf("expected review timestamps from injected clock, got %+v", graded.Review)
+			}
+		})
+	}
+}
+
+type fixedScheduler struct {
+	interval int
+}
+
+func (f fixedScheduler) Schedule(state card.ReviewState, _ card.Rating, now time.Time) card.ReviewState {
+	state.Interval = f.interval
+	state.ReviewedAt = now
+	state.DueAt = now.AddDate(0, 0, f.interval)
+	return state
+}
+
+func TestGradeCardCustomScheduler(t *testing.T) {
+	service := NewService(cardstorage.NewMemoryRepository(), WithScheduler(user.SchedulerSM2, fixedScheduler{interval: 42}))
+
+	created, err := service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	graded, err := service.GradeCard(created.ID, card.RatingAgain, 0, "user-1")
+	if err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+	if graded.Review.Interval != 42 {
+		t.Fatalf("expected scheduler override to be used, got interval %d", graded.Review.Interval)
+	}
+}
+
+func TestLeitnerBoxes(t *testing.T) {
+	users := userstorage.NewMemoryRepository()
+	if _, err := users.Save(user.User{ID: "kid", Nickname: "kid", Scheduler: user.SchedulerLeitner}); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+	service := NewService(cardstorage.NewMemoryRepository(), WithUsers(users), WithLeitnerCadence([]int{1, 5}))
+
+	first, err := service.CreateCard("One", "1", "kid")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := service.CreateCard("Two", "2", "kid"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := service.CreateCard("Other", "x", "someone-else"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	graded, err := service.GradeCard(first.ID, card.RatingGood, 0, "kid")
+	if err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+	if graded.Review.Box != 2 || graded.Review.Interval != 5 {
+		t.Fatalf("expected card promoted to box 2 with a 5 day cadence, got %+v", graded.Review)
+	}
+
+	boxes, err := service.LeitnerBoxes("kid")
+	if err != nil {
+		t.Fatalf("leitner boxes failed: %v", err)
+	}
+	want := []card.LeitnerBox{{Box: 1, Cadence: 1, Cards: 1}, {Box: 2, Cadence: 5, Cards: 1}}
+	if len(boxes) != len(want) {
+		t.Fatalf("expected %d boxes, got %+v", len(want), boxes)
+	}
+	for i := range want {
+		if boxes[i] != want[i] {
+			t.Fatalf("box %d: expected %+v, got %+v", i+1, want[i], boxes[i])
+		}
+	}
+}
+
+func TestCardReviews(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	clock := now
+	service := NewService(cardstorage.NewMemoryRepository(),
+		WithReviewLog(reviewstorage.NewMemoryRepository()),
+		WithClock(func() time.Time { return clock }),
+	)
+
+	created, err := service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	answers := []struct {
+		rating     card.Rating
+		answerTime time.Duration
+		after      time.Duration
+	}{
+		{card.RatingGood, 4 * time.Second, 0},
+		{card.RatingGood, 2 * time.Second, 24 * time.Hour},
+		{card.RatingAgain, 6 * time.Second, 6 * 24 * time.Hour},
+		{card.RatingGood, 0, 24 * time.Hour},
+	}
+	for _, a := range answers {
+		clock = clock.Add(a.after)
+		if _, err := service.GradeCard(created.ID, a.rating, a.answerTime, "user-1"); err != nil {
+			t.Fatalf("grade failed: %v", err)
+		}
+	}
+
+	logs, stats, err := service.CardReviews(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("card reviews failed: %v", err)
+	}
+	if len(logs) != len(answers) {
+		t.Fatalf("expected %d logs, got %d", len(answers), len(logs))
+	}
+
+	lapse := logs[2]
+	if lapse.Rating != card.RatingAgain || lapse.PreviousInterval != 6 || lapse.NextInterval != 1 || lapse.ElapsedDays != 6 {
+		t.Fatalf("unexpected lapse log: %+v", lapse)
+	}
+	if lapse.UserID != "user-1" || !lapse.ReviewedAt.Equal(now.Add(7*24*time.Hour)) {
+		t.Fatalf("unexpected lapse metadata: %+v", lapse)
+	}
+
+	if stats.Reviews != 4 || stats.Lapses != 1 {
+		t.Fatalf("unexpected counts: %+v", stats)
+	}
+	if stats.AverageAnswerTime != 4*time.Second {
+		t.Fatalf("expected average answer time 4s, got %v", stats.AverageAnswerTime)
+	}
+	if stats.Retention < 0.66 || stats.Retention > 0.67 {
+		t.Fatalf("expected retention of 2/3, got %v", stats.Retention)
+	}
+
+	if _, _, err := service.CardReviews("missing", "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+type flakyUpdateRepository struct {
+	ports.CardRepository
+	failures int
+}
+
+func (r *flakyUpdateRepository) Update(c card.Card) (card.Card, error) {
+	if r.failures > 0 {
+		r.failures--
+		return card.Card{}, errors.New("connection reset")
+	}
+	return r.CardRepository.Update(c)
+}
+
+func TestGradeCardRetryLogsReviewOnce(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	repo := &flakyUpdateRepository{CardRepository: cardstorage.NewMemoryRepository()}
+	service := NewService(repo,
+		WithReviewLog(reviewstorage.NewMemoryRepository()),
+		WithClock(func() time.Time { return now }),
+	)
+
+	created, err := service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	repo.failures = 1
+	if _, err := service.GradeCard(created.ID, card.RatingGood, time.Second, "user-1"); err == nil {
+		t.Fatalf("expected the failed update reported")
+	}
+	if stored, _ := repo.FindByID(created.ID); !stored.Review.IsNew() {
+		t.Fatalf("expected the card left ungraded, got %+v", stored.Review)
+	}
+
+	graded, err := service.GradeCard(created.ID, card.RatingGood, time.Second, "user-1")
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	logs, stats, err := service.CardReviews(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("card reviews failed: %v", err)
+	}
+	if len(logs) != 1 || stats.Reviews != 1 || logs[0].NextInterval != graded.Review.Interval {
+		t.Fatalf("expected a single review logged across the retry, got %+v", logs)
+	}
+
+	now = now.Add(24 * time.Hour)
+	if _, err := service.GradeCard(created.ID, card.RatingGood, time.Second, "user-1"); err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+	if logs, _, _ := service.CardReviews(created.ID, "user-1"); len(logs) != 2 {
+		t.Fatalf("expected the next review logged separately, got %+v", logs)
+	}
+}
+
+func TestGradeCardSuspendsLeech(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	repo := cardstorage.NewMemoryRepository()
+	service := NewService(repo, WithLeechThreshold(2), WithClock(func() time.Time { return now }))
+
+	created, err := service.CreateCard("Hard word", "meaning", "user-1", "verbs")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	ratings := []card.Rating{card.RatingGood, card.RatingAgain, card.RatingGood, card.RatingAgain}
+	var graded card.Card
+	for i, rating := range ratings {
+		graded, err = service.GradeCard(created.ID, rating, 0, "user-1")
+		if err != nil {
+			t.Fatalf("grade %d failed: %v", i, err)
+		}
+		if i == 1 && (graded.Review.Lapses != 1 || graded.Suspended) {
+			t.Fatalf("expected first lapse without suspension, got %+v", graded)
+		}
+		now = now.Add(48 * time.Hour)
+	}
+
+	if graded.Review.Lapses != 2 || !graded.Leech || !graded.Suspended {
+		t.Fatalf("expected card suspended as a leech after 2 lapses, got lapses=%d leech=%v suspended=%v", graded.Review.Lapses, graded.Leech, graded.Suspended)
+	}
+	if stored, _ := repo.FindByID(created.ID); len(stored.Tags) != 2 || !stored.HasTag(card.LeechTag) || !stored.HasTag("verbs") {
+		t.Fatalf("expected the leech tag added to the card's tags, got %v", stored.Tags)
+	}
+	due, err := service.DueCards("user-1")
+	if err != nil {
+		t.Fatalf("due cards failed: %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("expected leech excluded from due cards, got %d", len(due))
+	}
+}
+
+func TestGradeCardSuspendsUnsuspendedLeechAgain(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	service := NewService(cardstorage.NewMemoryRepository(), WithLeechThreshold(1), WithClock(func() time.Time { return now }))
+
+	created, err := service.CreateCard("Hard word", "meaning", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	grade := func(rating card.Rating) card.Card {
+		t.Helper()
+		graded, err := service.GradeCard(created.ID, rating, 0, "user-1")
+		if err != nil {
+			t.Fatalf("grade failed: %v", err)
+		}
+		now = now.Add(48 * time.Hour)
+		return graded
+	}
+
+	grade(card.RatingGood)
+	if graded := grade(card.RatingAgain); !graded.Leech || !graded.Suspended {
+		t.Fatalf("expected the card suspended as a leech, got leech=%v suspended=%v", graded.Leech, graded.Suspended)
+	}
+
+	unsuspended, err := service.UnsuspendCard(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("unsuspend failed: %v", err)
+	}
+	if unsuspended.Suspended || unsuspended.Leech || !unsuspended.HasTag(card.LeechTag) {
+		t.Fatalf("expected the card back in review with its leech tag, got %+v", unsuspended)
+	}
+
+	grade(card.RatingGood)
+	if graded := grade(card.RatingAgain); graded.Review.Lapses != 2 || !graded.Leech || !graded.Suspended {
+		t.Fatalf("expected the leech suspended again on its next lapse, got lapses=%d leech=%v suspended=%v", graded.Review.Lapses, graded.Leech, graded.Suspended)
+	}
+}
+
+func TestSuspendBuryAndUnsuspend(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	service := NewService(cardstorage.NewMemoryRepository(), WithClock(func() time.Time { return now }))
+
+	created, err := service.CreateCard("Front", "Back", "user-1")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	if _, err := service.SuspendCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("suspend failed: %v", err)
+	}
+	if due, _ := service.DueCards("user-1"); len(due) != 0 {
+		t.Fatalf("expected suspended card excluded, got %d due", len(due))
+	}
+
+	if _, err := service.UnsuspendCard(created.ID, "user-1"); err != nil {
+		t.Fatalf("unsuspend failed: %v", err)
+	}
+	if due, _ := service.DueCards("user-1"); len(due) != 1 {
+		t.Fatalf("expected unsuspended card due, got %d", len(due))
+	}
+
+	buried, err := service.BuryCard(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("bury failed: %v", err)
+	}
+	if want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC); !buried.BuriedUntil.Equal(want) {
+		t.Fatalf("expected card buried until %v, got %v", want, buried.BuriedUntil)
+	}
+	if due, _ := service.DueCards("user-1"); len(due) != 0 {
+		t.Fatalf("expected buried card excluded today, got %d due", len(due))
+	}
+	now = now.Add(24 * time.Hour)
+	if due, _ := service.DueCards("user-1"); len(due) != 1 {
+		t.Fatalf("expected buried card back the next day, got %d", len(due))
+	}
+
+	if _, err := service.SuspendCard("missing", "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestCreateClozeCards(t *testing.T) {
+	repo := cardstorage.NewMemoryRepository()
+	service := NewService(repo)
+
+	cards, err := service.CreateClozeCards("The capital of {{c1::France}} is {{c2::Paris::city}}", "Since 508 AD", "user-1", "")
+	if err != nil {
+		t.Fatalf("create cloze failed: %v", err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("expected one card per cloze index, got %d", len(cards))
+	}
+
+	want := []struct {
+		question string
+		answer   string
+	}{
+		{"The capital of [...] is Paris", "The capital of [France] is Paris\n\nSince 508 AD"},
+		{"The capital of France is [city]", "The capital of France is [Paris]\n\nSince 508 AD"},
+	}
+	for i, c := range cards {
+		if c.Type != card.TypeCloze || c.ClozeIndex != i+1 {
+			t.Fatalf("card %d: unexpected type %q index %d", i, c.Type, c.ClozeIndex)
+		}
+		if c.Question() != want[i].question || c.Answer() != want[i].answer {
+			t.Fatalf("card %d: expected %q / %q, got %q / %q", i, want[i].question, want[i].answer, c.Question(), c.Answer())
+		}
+	}
+
+	stored, _ := repo.FindAll()
+	if len(stored) != 2 {
+		t.Fatalf("expected 2 stored cards, got %d", len(stored))
+	}
+}
+
+func TestCreateClozeCardsValidation(t *testing.T) {
+	tests := []struct {
+		text string
+		want error
+	}{
+		{"", card.ErrEmptyFront},
+		{"No deletions here", card.ErrClozeMissing},
+		{"Open {{c1::France is", card.ErrClozeUnclosed},
+		{"Wrong {{x1::France}}", card.ErrClozeMalformed},
+		{"Zero {{c0::France}}", card.ErrClozeMalformed},
+		{"No separator {{c1 France}}", card.ErrClozeMalformed},
+		{"Nested {{c1::a {{c2::b}} }}", card.ErrClozeMalformed},
+		{"Blank {{c1:: }}", card.ErrClozeEmpty},
+	}
+
+	for _, tc := range tests {
+		repo := cardstorage.NewMemoryRepository()
+		service := NewService(repo)
+		if _, err := service.CreateClozeCards(tc.text, "", "user-1", ""); err != tc.want {
+			t.Fatalf("%q: expected %v, got %v", tc.text, tc.want, err)
+		}
+		if stored, _ := repo.FindAll(); len(stored) != 0 {
+			t.Fatalf("%q: expected nothing stored, got %d cards", tc.text, len(stored))
+		}
+	}
+}
+
+func TestUpdateClozeCardRequiresItsIndex(t *testing.T) {
+	service := NewService(cardstorage.NewMemoryRepository())
+
+	cards, err := service.CreateClozeCards("{{c1::one}} and {{c2::two}}", "", "user-1", "")
+	if err != nil {
+		t.Fatalf("create cloze failed: %v", err)
+	}
+	if _, err := service.UpdateCard(cards[1].ID, "{{c1::one}} only", "", "user-1"); err != card.ErrClozeIndexNotFound {
+		t.Fatalf("expected ErrClozeIndexNotFound, got %v", err)
+	}
+}
+
+func TestCreateCardNormalizesTags(t *testing.T) {
+	service := NewService(cardstorage.NewMemoryRepository())
+
+	created, err := service.CreateCard("Hund", "dog", "user-1", "#Nouns", "german", "nouns")
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if len(created.Tags) != 2 || created.Tags[0] != "german" || created.Tags[1] != "nouns" {
+		t.Fatalf("expected sorted unique lowercase tags, got %v", created.Tags)
+	}
+
+	if _, err := service.CreateCard("Katze", "cat", "user-1", "two words"); err != card.ErrInvalidTag {
+		t.Fatalf("expected ErrInvalidTag, got %v", err)
+	}
+}
+
+func TestRenameAndMergeTags(t *testing.T) {
+	service := NewService(cardstorage.NewMemoryRepository())
+
+	first, _ := service.CreateCard("Hund", "dog", "user-1", "noun", "animals")
+	second, _ := service.CreateCard("Katze", "cat", "user-1", "nouns")
+	other, _ := service.CreateCard("chien", "dog", "user-2", "noun")
+
+	changed, err := service.MergeTags("user-1", []string{"noun", "nouns"}, "nouns")
+	if err != nil || changed != 2 {
+		t.Fatalf("expected two cards merged, got %d (%v)", changed, err)
+	}
+	if c, _ := service.GetCard(first.ID, "user-1"); len(c.Tags) != 2 || c.Tags[0] != "animals" || c.Tags[1] != "nouns" {
+		t.Fatalf("unexpected merged tags: %v", c.Tags)
+	}
+	if c, _ := service.GetCard(other.ID, "user-1"); len(c.Tags) != 1 || c.Tags[0] != "noun" {
+		t.Fatalf("expected other owner's tags untouched, got %v", c.Tags)
+	}
+
+	changed, err = service.RenameTag("user-1", "nouns", "substantive")
+	if err != nil || changed != 2 {
+		t.Fatalf("expected two cards renamed, got %d (%v)", changed, err)
+	}
+	if c, _ := service.GetCard(second.ID, "user-1"); len(c.Tags) != 1 || c.Tags[0] != "substantive" {
+		t.Fatalf("unexpected renamed tags: %v", c.Tags)
+	}
+
+	tags, err := service.Tags("user-1")
+	if err != nil || len(tags) != 2 || tags[1].Tag != "substantive" || tags[1].Cards != 2 {
+		t.Fatalf("unexpected tag counts: %+v (%v)", tags, err)
+	}
+}
+
+func TestListCardsTagged(t *testing.T) {
+	service := NewService(cardstorage.NewMemoryRepository())
+
+	grammar, _ := service.CreateCard("der, die, das", "", "user-1", "grammar")
+	if _, err := service.CreateCard("Konjunktiv", "", "user-1", "grammar", "hard"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := service.CreateCard("Hund", "dog", "user-1"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	filter, err := card.ParseTagFilter([]string{"grammar", "-hard"})
+	if err != nil {
+		t.Fatalf("parse filter failed: %v", err)
+	}
+	cards, err := service.ListCardsTagged("", filter, "user-1")
+	if err != nil || len(cards) != 1 || cards[0].ID != grammar.ID {
+		t.Fatalf("expected only the easy grammar card, got %+v (%v)", cards, err)
+	}
+}
+
+func TestSearchRequiresWords(t *testing.T) {
+	service := NewService(cardstorage.NewMemoryRepository())
+	if _, err := service.CreateCard("der Hund", "the dog", "user-1"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	if _, err := service.Search("user-1", " ?! ", 0); err != card.ErrEmptyQuery {
+		t.Fatalf("expected ErrEmptyQuery, got %v", err)
+	}
+	hits, err := service.Search("user-1", "hund", 0)
+	if err != nil || len(hits) != 1 {
+		t.Fatalf("expected one hit, got %+v (%v)", hits, err)
+	}
+}
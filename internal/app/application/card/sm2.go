@@ -0,0 +1,55 @@
+package cardapp
+
+import (
+	"math"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+const minEaseFactor = 1.3
+
+// sm2Quality maps the four review buttons onto the 0-5 SM-2 quality scale.
+var sm2Quality = map[card.Rating]float64{
+	card.RatingAgain: 1,
+	card.RatingHard:  3,
+	card.RatingGood:  4,
+	card.RatingEasy:  5,
+}
+
+// SM2Scheduler implements the classic SuperMemo-2 algorithm.
+type SM2Scheduler struct{}
+
+// Schedule applies SM-2 to state after a review graded with rating at now.
+// Failed recalls restart the repetition count without touching the ease factor, as in the original algorithm.
+func (SM2Scheduler) Schedule(state card.ReviewState, rating card.Rating, now time.Time) card.ReviewState {
+	next := state
+	if next.EaseFactor < minEaseFactor {
+		next.EaseFactor = card.DefaultEaseFactor
+	}
+
+	quality := sm2Quality[rating]
+	if quality < 3 {
+		next.Repetitions = 0
+		next.Interval = 1
+	} else {
+		next.Repetitions++
+		switch next.Repetitions {
+		case 1:
+			next.Interval = 1
+		case 2:
+			next.Interval = 6
+		default:
+			next.Interval = int(math.Round(float64(state.Interval) * next.EaseFactor))
+		}
+
+		next.EaseFactor += 0.1 - (5-quality)*(0.08+(5-quality)*0.02)
+		if next.EaseFactor < minEaseFactor {
+			next.EaseFactor = minEaseFactor
+		}
+	}
+
+	next.ReviewedAt = now
+	next.DueAt = now.AddDate(0, 0, next.Interval)
+	return next
+}
@@ -0,0 +1,61 @@
+package cardapp
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+func TestSM2SchedulerProgression(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	state := card.NewReviewState(now)
+
+	wantIntervals := []int{1, 6, 15}
+	for i, want := range wantIntervals {
+		state = SM2Scheduler{}.Schedule(state, card.RatingGood, now)
+		if state.Interval != want {
+			t.Fatalf("review %d: expected interval %d, got %d", i+1, want, state.Interval)
+		}
+		if state.Repetitions != i+1 {
+			t.Fatalf("review %d: expected %d repetitions, got %d", i+1, i+1, state.Repetitions)
+		}
+		if !state.DueAt.Equal(now.AddDate(0, 0, want)) {
+			t.Fatalf("review %d: unexpected due date %v", i+1, state.DueAt)
+		}
+	}
+	if state.EaseFactor != card.DefaultEaseFactor {
+		t.Fatalf("expected good answers to keep ease %v, got %v", card.DefaultEaseFactor, state.EaseFactor)
+	}
+}
+
+func TestSM2SchedulerAgainResetsRepetitions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	state := card.ReviewState{EaseFactor: 2.2, Interval: 20, Repetitions: 4, DueAt: now}
+
+	next := SM2Scheduler{}.Schedule(state, card.RatingAgain, now)
+	if next.Repetitions != 0 || next.Interval != 1 {
+		t.Fatalf("expected reset to first step, got %+v", next)
+	}
+	if next.EaseFactor != state.EaseFactor {
+		t.Fatalf("expected ease to stay %v after a lapse, got %v", state.EaseFactor, next.EaseFactor)
+	}
+	if !next.ReviewedAt.Equal(now) {
+		t.Fatalf("expected reviewed at %v, got %v", now, next.ReviewedAt)
+	}
+}
+
+func TestSM2SchedulerEaseBounds(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	easy := SM2Scheduler{}.Schedule(card.NewReviewState(now), card.RatingEasy, now)
+	if easy.EaseFactor <= card.DefaultEaseFactor {
+		t.Fatalf("expected easy to raise ease, got %v", easy.EaseFactor)
+	}
+
+	state := card.ReviewState{EaseFactor: 1.35, Interval: 10, Repetitions: 3}
+	hard := SM2Scheduler{}.Schedule(state, card.RatingHard, now)
+	if hard.EaseFactor != minEaseFactor {
+		t.Fatalf("expected ease clamped to %v, got %v", minEaseFactor, hard.EaseFactor)
+	}
+}
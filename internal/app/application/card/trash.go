@@ -0,0 +1,138 @@
+package cardapp
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+)
+
+// DeleteCard moves a standalone card to its owner's trash, where it stays out of lists,
+// searches and reviews until it is restored or purged. Cards generated from a note go with the note.
+func (s *Service) DeleteCard(id, actorID string) error {
+	existing, err := s.findFor(id, actorID, deck.RoleEditor)
+	if err != nil {
+		return err
+	}
+	if existing.NoteID != "" {
+		return card.ErrManagedByNote
+	}
+
+	existing.DeletedAt = s.now()
+	_, err = s.repo.Update(existing)
+	return err
+}
+
+// Trash returns the owner's deleted cards, the most recently deleted first.
+func (s *Service) Trash(ownerID string) ([]card.Card, error) {
+	return s.repo.FindTrashed(ownerID)
+}
+
+// RestoreCard takes a card out of the trash with its content, schedule and attachments.
+// A card whose deck was deleted in the meantime comes back outside any deck.
+func (s *Service) RestoreCard(id, actorID string) (card.Card, error) {
+	existing, err := s.findTrashedFor(id, actorID)
+	if err != nil {
+		return card.Card{}, err
+	}
+	now := s.now()
+	if !existing.PurgeAt().After(now) {
+		return card.Card{}, card.ErrTrashExpired
+	}
+	if existing.DeckID != "" {
+		if _, err := s.findDeck(existing.DeckID); err == deck.ErrNotFound {
+			existing.DeckID = ""
+		} else if err != nil {
+			return card.Card{}, err
+		}
+	}
+
+	existing.DeletedAt = time.Time{}
+	existing.UpdatedAt = now
+	return s.repo.Update(existing)
+}
+
+// PurgeCard removes a card from the trash for good, together with its media attachments, revisions
+// and whatever the purge hooks keep about it.
+func (s *Service) PurgeCard(id, actorID string) error {
+	if _, err := s.findTrashedFor(id, actorID); err != nil {
+		return err
+	}
+	return s.purge(id)
+}
+
+// PurgeNoteCard removes a card generated from a note for good, skipping the trash, once its note
+// is deleted or no longer generates it. The note service has checked the acting user already.
+func (s *Service) PurgeNoteCard(id string) error {
+	existing, err := s.repo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if existing.NoteID == "" {
+		return card.ErrNotFound
+	}
+	return s.purge(id)
+}
+
+// PurgeTrash removes for good every card that has been in the trash for longer than
+// card.TrashRetention and returns them.
+func (s *Service) PurgeTrash() ([]card.Card, error) {
+	expired, err := s.repo.FindTrashedBefore(s.now().Add(-card.TrashRetention))
+	if err != nil {
+		return nil, err
+	}
+	purged := make([]card.Card, 0, len(expired))
+	for _, c := range expired {
+		if err := s.purge(c.ID); err != nil {
+			return purged, err
+		}
+		purged = append(purged, c)
+	}
+	return purged, nil
+}
+
+// RunTrashPurge calls PurgeTrash every interval until ctx is cancelled.
+func (s *Service) RunTrashPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.PurgeTrash(); err != nil {
+			log.Printf("trash: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// findTrashedFor returns a card of the trash once actorID is known to be able to edit it.
+func (s *Service) findTrashedFor(id, actorID string) (card.Card, error) {
+	c, err := s.repo.FindTrashedByID(id)
+	if err != nil {
+		return card.Card{}, err
+	}
+	return c, s.authorize(c, actorID, deck.RoleEditor)
+}
+
+func (s *Service) purge(id string) error {
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+	if err := s.deleteRevisions(id); err != nil {
+		return err
+	}
+	if err := s.deleteMedia(id); err != nil {
+		return err
+	}
+	for _, hook := range s.purgeHooks {
+		if err := hook.CardPurged(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cardapp
+
+import (
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+)
+
+func newTrashService() (*Service, *deckstorage.MemoryRepository, *time.Time) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	decks := deckstorage.NewMemoryRepository()
+	service := NewService(cardstorage.NewMemoryRepository(),
+		WithDecks(decks),
+		WithClock(func() time.Time { return now }),
+	)
+	return service, decks, &now
+}
+
+func TestDeleteCardMovesItToTheTrash(t *testing.T) {
+	service, _, now := newTrashService()
+	kept, _ := service.CreateCard("der Baum", "tree", "user-1")
+	deleted, _ := service.CreateCard("der Hund", "dog", "user-1")
+
+	*now = now.Add(time.Hour)
+	if err := service.DeleteCard(deleted.ID, "user-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := service.DeleteCard(deleted.ID, "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound deleting a card twice, got %v", err)
+	}
+
+	if listed, _ := service.ListCards("user-1"); len(listed) != 1 || listed[0].ID != kept.ID {
+		t.Fatalf("expected the deleted card left out of lists, got %+v", listed)
+	}
+	if due, _ := service.DueCards("user-1"); len(due) != 1 || due[0].ID != kept.ID {
+		t.Fatalf("expected the deleted card left out of reviews, got %+v", due)
+	}
+	if hits, _ := service.Search("user-1", "Hund", 0); len(hits) != 0 {
+		t.Fatalf("expected the deleted card left out of searches, got %+v", hits)
+	}
+
+	trash, err := service.Trash("user-1")
+	if err != nil {
+		t.Fatalf("trash failed: %v", err)
+	}
+	if len(trash) != 1 || trash[0].ID != deleted.ID || !trash[0].DeletedAt.Equal(*now) {
+		t.Fatalf("expected the deleted card in the trash, got %+v", trash)
+	}
+	if other, _ := service.Trash("user-2"); len(other) != 0 {
+		t.Fatalf("expected the trash to be per user, got %+v", other)
+	}
+}
+
+func TestRestoreCard(t *testing.T) {
+	service, decks, now := newTrashService()
+	german, _ := decks.Save(deck.Deck{ID: "deck-1", Name: "German", OwnerID: "user-1", CreatedAt: *now, UpdatedAt: *now})
+	inDeck, _ := service.CreateCardInDeck("der Hund", "dog", "user-1", german.ID)
+	graded, _ := service.GradeCard(inDeck.ID, card.RatingGood, 0, "user-1")
+	outside, _ := service.CreateCardInDeck("die Katze", "cat", "user-1", german.ID)
+	service.DeleteCard(inDeck.ID, "user-1")
+	service.DeleteCard(outside.ID, "user-1")
+
+	*now = now.Add(card.TrashRetention - time.Minute)
+	restored, err := service.RestoreCard(inDeck.ID, "user-1")
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if restored.Trashed() || restored.DeckID != german.ID || restored.Review.Repetitions != graded.Review.Repetitions {
+		t.Fatalf("expected the card back with its deck and schedule, got %+v", restored)
+	}
+	if _, err := service.GetCard(inDeck.ID, "user-1"); err != nil {
+		t.Fatalf("expected the restored card found again, got %v", err)
+	}
+	if _, err := service.RestoreCard(inDeck.ID, "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound restoring a card outside the trash, got %v", err)
+	}
+
+	decks.Delete(german.ID)
+	restored, err = service.RestoreCard(outside.ID, "user-1")
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if restored.DeckID != "" {
+		t.Fatalf("expected the card of a deleted deck back outside any deck, got %q", restored.DeckID)
+	}
+}
+
+func TestRestoreCardAfterRetention(t *testing.T) {
+	service, _, now := newTrashService()
+	created, _ := service.CreateCard("der Hund", "dog", "user-1")
+	service.DeleteCard(created.ID, "user-1")
+
+	*now = now.Add(card.TrashRetention)
+	if _, err := service.RestoreCard(created.ID, "user-1"); err != card.ErrTrashExpired {
+		t.Fatalf("expected ErrTrashExpired, got %v", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	service, _, now := newTrashService()
+	old, _ := service.CreateCard("der Hund", "dog", "user-1")
+	recent, _ := service.CreateCard("die Katze", "cat", "user-2")
+	service.DeleteCard(old.ID, "user-1")
+	*now = now.Add(24 * time.Hour)
+	service.DeleteCard(recent.ID, "user-2")
+
+	*now = now.Add(card.TrashRetention - time.Hour)
+	purged, err := service.PurgeTrash()
+	if err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	if len(purged) != 1 || purged[0].ID != old.ID {
+		t.Fatalf("expected only the expired card purged, got %+v", purged)
+	}
+	if _, err := service.RestoreCard(old.ID, "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected the purged card gone, got %v", err)
+	}
+	if trash, _ := service.Trash("user-2"); len(trash) != 1 {
+		t.Fatalf("expected the recent card kept in the trash, got %+v", trash)
+	}
+
+	if err := service.PurgeCard(recent.ID, "user-2"); err != nil {
+		t.Fatalf("purge card failed: %v", err)
+	}
+	if trash, _ := service.Trash("user-2"); len(trash) != 0 {
+		t.Fatalf("expected the trash emptied, got %+v", trash)
+	}
+	kept, _ := service.CreateCard("der Baum", "tree", "user-1")
+	if err := service.PurgeCard(kept.ID, "user-1"); err != card.ErrNotFound {
+		t.Fatalf("expected only cards in the trash purged, got %v", err)
+	}
+	if err := service.PurgeNoteCard(kept.ID); err != card.ErrNotFound {
+		t.Fatalf("expected standalone cards left to the trash, got %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+package catalogapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/catalog"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/ports"
+)
+
+// Entry is a published deck as shown in the catalog.
+type Entry struct {
+	Listing catalog.Listing
+	Deck    deck.Deck
+	Cards   int
+}
+
+// Service orchestrates the public deck catalog: owners publish their decks and anyone may
+// browse them and clone one into their own collection.
+type Service struct {
+	listings      ports.ListingRepository
+	decks         ports.DeckRepository
+	cards         ports.CardRepository
+	subscriptions ports.SubscriptionRepository
+	links         ports.LinkRepository
+	trash         CardTrash
+	now           func() time.Time
+}
+
+// CardTrash moves cards to their owner's trash.
+type CardTrash interface {
+	DeleteCard(id, actorID string) error
+}
+
+// Option customises optional Service collaborators.
+type Option func(*Service)
+
+// WithClock overrides the time source, mainly for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+// WithSubscriptions lets users subscribe to published decks and pull their later changes.
+func WithSubscriptions(subscriptions ports.SubscriptionRepository, links ports.LinkRepository) Option {
+	return func(s *Service) {
+		s.subscriptions = subscriptions
+		s.links = links
+	}
+}
+
+// WithCardTrash moves the copies Sync removes to the subscriber's trash. Without it they are only
+// deleted from the card repository.
+func WithCardTrash(trash CardTrash) Option {
+	return func(s *Service) {
+		s.trash = trash
+	}
+}
+
+func NewService(listings ports.ListingRepository, decks ports.DeckRepository, cards ports.CardRepository, opts ...Option) *Service {
+	s := &Service{
+		listings: listings,
+		decks:    decks,
+		cards:    cards,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Publish lists the deck in the catalog, or updates its listing when it is already published.
+// Only the deck owner may publish it; its subdecks are published with it.
+func (s *Service) Publish(deckID, userID, title, language, description string) (catalog.Listing, error) {
+	d, err := s.decks.FindByID(deckID)
+	if err != nil {
+		return catalog.Listing{}, err
+	}
+	if err := d.Authorize(userID, nil, deck.RoleOwner); err != nil {
+		return catalog.Listing{}, err
+	}
+
+	now := s.now()
+	l := catalog.Listing{
+		DeckID:      d.ID,
+		OwnerID:     d.OwnerID,
+		Title:       title,
+		Language:    catalog.NormalizeLanguage(language),
+		Description: description,
+		PublishedAt: now,
+		UpdatedAt:   now,
+	}
+	if existing, err := s.listings.FindByDeck(deckID); err == nil {
+		l.PublishedAt = existing.PublishedAt
+	} else if err != catalog.ErrNotFound {
+		return catalog.Listing{}, err
+	}
+	if err := l.Validate(); err != nil {
+		return catalog.Listing{}, err
+	}
+
+	return s.listings.Save(l)
+}
+
+// Unpublish removes the deck from the catalog. Decks cloned from it are kept.
+func (s *Service) Unpublish(deckID, userID string) error {
+	d, err := s.decks.FindByID(deckID)
+	if err != nil {
+		return err
+	}
+	if err := d.Authorize(userID, nil, deck.RoleOwner); err != nil {
+		return err
+	}
+	return s.listings.Delete(deckID)
+}
+
+// Browse returns the published decks whose title and description contain every word of query,
+// in the given language unless it is empty, most recently published first.
+func (s *Service) Browse(query, language string) ([]Entry, error) {
+	listings, err := s.listings.Search(query, catalog.NormalizeLanguage(language))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(listings))
+	for _, l := range listings {
+		entry, err := s.entry(l)
+		if err == deck.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Get returns the catalog entry of a published deck.
+func (s *Service) Get(deckID string) (Entry, error) {
+	l, err := s.listings.FindByDeck(deckID)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry, err := s.entry(l)
+	if err == deck.ErrNotFound {
+		return Entry{}, catalog.ErrNotFound
+	}
+	return entry, err
+}
+
+// Clone copies a published deck, its subdecks and their cards into the user's collection as a new
+// top-level deck. The copies start with a fresh schedule, are detached from any note, and every copied
+// deck keeps its source deck in SourceID for attribution.
+func (s *Service) Clone(deckID, userID string) (deck.Deck, []card.Card, error) {
+	clone, copies, err := s.clone(deckID, userID)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+	cards := make([]card.Card, 0, len(copies))
+	for _, c := range copies {
+		cards = append(cards, c.card)
+	}
+	return clone, cards, nil
+}
+
+// copied pairs a cloned card with the source card it was copied from.
+type copied struct {
+	source card.Card
+	card   card.Card
+}
+
+func (s *Service) clone(deckID, userID string) (deck.Deck, []copied, error) {
+	entry, err := s.Get(deckID)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+	source, err := s.subtree(entry.Deck)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+
+	now := s.now()
+	root := entry.Deck
+	root.Description = entry.Listing.Description
+	clone, err := s.copyDeck(root, userID, "", now)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+
+	targets := map[string]string{root.ID: clone.ID}
+	var copies []copied
+	for _, d := range source {
+		if _, ok := targets[d.ID]; !ok {
+			sub, err := s.copyDeck(d, userID, targets[d.ParentID], now)
+			if err != nil {
+				return deck.Deck{}, nil, err
+			}
+			targets[d.ID] = sub.ID
+		}
+		cards, err := s.cards.FindByDeck(d.ID)
+		if err != nil {
+			return deck.Deck{}, nil, err
+		}
+		for _, c := range cards {
+			cloned, err := s.copyCard(c, userID, targets[d.ID], now)
+			if err != nil {
+				return deck.Deck{}, nil, err
+			}
+			copies = append(copies, copied{source: c, card: cloned})
+		}
+	}
+	return clone, copies, nil
+}
+
+// subtree returns the deck followed by all of its subdecks, parents before their children.
+func (s *Service) subtree(d deck.Deck) ([]deck.Deck, error) {
+	owned, err := s.decks.FindByOwner(d.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	tree := deck.NewTree(owned)
+	ids := tree.Subtree(d.ID)
+	if len(ids) == 0 {
+		return []deck.Deck{d}, nil
+	}
+	decks := make([]deck.Deck, 0, len(ids))
+	for _, id := range ids {
+		sub, _ := tree.Deck(id)
+		decks = append(decks, sub)
+	}
+	return decks, nil
+}
+
+// copyDeck saves a copy of d owned by userID under parentID, keeping d in SourceID.
+func (s *Service) copyDeck(d deck.Deck, userID, parentID string, now time.Time) (deck.Deck, error) {
+	clone := deck.Deck{
+		ID:          uuid.NewString(),
+		Name:        d.Name,
+		Description: d.Description,
+		OwnerID:     userID,
+		ParentID:    parentID,
+		SourceID:    d.ID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := clone.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+	return s.decks.Save(clone)
+}
+
+// copyCard saves a copy of c owned by userID in deckID, with a fresh schedule and detached from any note.
+func (s *Service) copyCard(c card.Card, userID, deckID string, now time.Time) (card.Card, error) {
+	return s.cards.Save(card.Card{
+		ID:         uuid.NewString(),
+		Front:      c.Front,
+		Back:       c.Back,
+		OwnerID:    userID,
+		DeckID:     deckID,
+		Tags:       append([]string(nil), c.Tags...),
+		Type:       c.Type,
+		Reversed:   c.Reversed,
+		ClozeIndex: c.ClozeIndex,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Review:     card.NewReviewState(now),
+	})
+}
+
+func (s *Service) entry(l catalog.Listing) (Entry, error) {
+	d, err := s.decks.FindByID(l.DeckID)
+	if err != nil {
+		return Entry{}, err
+	}
+	decks, err := s.subtree(d)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry := Entry{Listing: l, Deck: d}
+	for _, sub := range decks {
+		cards, err := s.cards.FindByDeck(sub.ID)
+		if err != nil {
+			return Entry{}, err
+		}
+		entry.Cards += len(cards)
+	}
+	return entry, nil
+}
@@ -0,0 +1,179 @@
+package catalogapp
+
+import (
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	catalogstorage "flash2fy/internal/adapters/storage/catalog"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	cardapp "flash2fy/internal/app/application/card"
+	deckapp "flash2fy/internal/app/application/deck"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/catalog"
+	"flash2fy/internal/app/domain/deck"
+)
+
+type fixture struct {
+	catalog *Service
+	cards   *cardapp.Service
+	decks   *deckapp.Service
+}
+
+func newFixture(now func() time.Time) fixture {
+	cards := cardstorage.NewMemoryRepository()
+	decks := deckstorage.NewMemoryRepository()
+	return fixture{
+		catalog: NewService(catalogstorage.NewMemoryRepository(), decks, cards, WithClock(now)),
+		cards:   cardapp.NewService(cards, cardapp.WithDecks(decks)),
+		decks:   deckapp.NewService(decks, cards),
+	}
+}
+
+func TestPublishAndBrowseCatalog(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "", "user-1", "")
+	f.cards.CreateCardInDeck("der Hund", "dog", "user-1", german.ID)
+	f.cards.CreateCardInDeck("die Katze", "cat", "user-1", german.ID)
+	spanish, _ := f.decks.CreateDeck("Spanish", "", "user-2", "")
+
+	if _, err := f.catalog.Publish(german.ID, "user-2", "German basics", "de", ""); err != deck.ErrForeignOwner {
+		t.Fatalf("expected foreign owner error, got %v", err)
+	}
+	if _, err := f.catalog.Publish(german.ID, "user-1", "German basics", "German", ""); err != catalog.ErrInvalidLanguage {
+		t.Fatalf("expected invalid language error, got %v", err)
+	}
+
+	listing, err := f.catalog.Publish(german.ID, "user-1", "German basics", "DE", "Everyday animals")
+	if err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+	if listing.Language != "de" || listing.OwnerID != "user-1" {
+		t.Fatalf("unexpected listing: %+v", listing)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := f.catalog.Publish(spanish.ID, "user-2", "Spanish verbs", "es", "Common verbs"); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	entries, err := f.catalog.Browse("", "")
+	if err != nil {
+		t.Fatalf("browse failed: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Deck.ID != spanish.ID {
+		t.Fatalf("expected newest listing first, got %+v", entries)
+	}
+
+	entries, _ = f.catalog.Browse("animals", "DE")
+	if len(entries) != 1 || entries[0].Deck.ID != german.ID || entries[0].Cards != 2 {
+		t.Fatalf("expected german deck with two cards, got %+v", entries)
+	}
+
+	updated, err := f.catalog.Publish(german.ID, "user-1", "German animals", "de", "")
+	if err != nil {
+		t.Fatalf("republish failed: %v", err)
+	}
+	if !updated.PublishedAt.Equal(listing.PublishedAt) || !updated.UpdatedAt.Equal(now) {
+		t.Fatalf("expected publish time to be kept, got %+v", updated)
+	}
+
+	if err := f.catalog.Unpublish(german.ID, "user-1"); err != nil {
+		t.Fatalf("unpublish failed: %v", err)
+	}
+	if _, err := f.catalog.Get(german.ID); err != catalog.ErrNotFound {
+		t.Fatalf("expected listing to be gone, got %v", err)
+	}
+
+	f.decks.DeleteDeck(spanish.ID, "user-2")
+	entries, _ = f.catalog.Browse("", "")
+	if len(entries) != 0 {
+		t.Fatalf("expected deleted decks to be hidden, got %+v", entries)
+	}
+}
+
+func TestCloneCopiesCardsWithFreshSchedule(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "Nouns", "user-1", "")
+	hund, _ := f.cards.CreateCardInDeck("der Hund", "dog", "user-1", german.ID, "noun")
+	f.cards.GradeCard(hund.ID, card.RatingGood, 0, "user-1")
+	f.cards.SuspendCard(hund.ID, "user-1")
+
+	if _, _, err := f.catalog.Clone(german.ID, "user-2"); err != catalog.ErrNotFound {
+		t.Fatalf("expected unpublished deck to be rejected, got %v", err)
+	}
+	if _, err := f.catalog.Publish(german.ID, "user-1", "German nouns", "de", "Animals"); err != nil {
+		t.Fatalf("publish failed: %v", err)
+	}
+
+	clone, cards, err := f.catalog.Clone(german.ID, "user-2")
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	if clone.ID == german.ID || clone.OwnerID != "user-2" || clone.SourceID != german.ID || clone.ParentID != "" {
+		t.Fatalf("unexpected clone: %+v", clone)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("expected one copied card, got %d", len(cards))
+	}
+
+	copied := cards[0]
+	if copied.ID == hund.ID || copied.OwnerID != "user-2" || copied.DeckID != clone.ID {
+		t.Fatalf("unexpected copy: %+v", copied)
+	}
+	if copied.Front != "der Hund" || len(copied.Tags) != 1 || copied.Tags[0] != "noun" {
+		t.Fatalf("expected content and tags to be copied, got %+v", copied)
+	}
+	if copied.Suspended || copied.Review.Repetitions != 0 || !copied.Review.DueAt.Equal(now) {
+		t.Fatalf("expected fresh scheduling state, got %+v", copied)
+	}
+
+	original, _ := f.cards.GetCard(hund.ID, "user-1")
+	if original.OwnerID != "user-1" || !original.Suspended {
+		t.Fatalf("expected source card untouched, got %+v", original)
+	}
+}
+
+func TestCloneCopiesSubdecks(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "", "user-1", "")
+	verbs, _ := f.decks.CreateDeck("Verbs", "", "user-1", german.ID)
+	irregular, _ := f.decks.CreateDeck("Irregular", "", "user-1", verbs.ID)
+	f.cards.CreateCardInDeck("der Hund", "dog", "user-1", german.ID)
+	f.cards.CreateCardInDeck("gehen", "to go", "user-1", verbs.ID)
+	f.cards.CreateCardInDeck("sein", "to be", "user-1", irregular.ID)
+	f.catalog.Publish(german.ID, "user-1", "German", "de", "")
+
+	if entry, _ := f.catalog.Get(german.ID); entry.Cards != 3 {
+		t.Fatalf("expected the listing to count the cards of its subdecks, got %d", entry.Cards)
+	}
+
+	clone, cards, err := f.catalog.Clone(german.ID, "user-2")
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	if len(cards) != 3 {
+		t.Fatalf("expected the cards of the subdecks copied, got %d", len(cards))
+	}
+
+	tree, _ := f.decks.Hierarchy("user-2")
+	if len(tree) != 1 || tree[0].Deck.ID != clone.ID || len(tree[0].Children) != 1 {
+		t.Fatalf("expected the copy to keep the hierarchy, got %+v", tree)
+	}
+	verbsCopy := tree[0].Children[0]
+	if verbsCopy.Deck.Name != "Verbs" || verbsCopy.Deck.SourceID != verbs.ID || len(verbsCopy.Children) != 1 {
+		t.Fatalf("unexpected subdeck copy: %+v", verbsCopy)
+	}
+	if irregularCopy := verbsCopy.Children[0]; irregularCopy.Deck.SourceID != irregular.ID || irregularCopy.Total.Cards != 1 {
+		t.Fatalf("unexpected nested subdeck copy: %+v", irregularCopy)
+	}
+	if tree[0].Own.Cards != 1 || tree[0].Total.Cards != 3 {
+		t.Fatalf("expected each card in the copy of its deck, got %+v", tree[0])
+	}
+}
@@ -0,0 +1,362 @@
+package catalogapp
+
+import (
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/catalog"
+	"flash2fy/internal/app/domain/deck"
+)
+
+// Conflict is a card of a subscribed copy edited both locally and upstream since the last sync.
+type Conflict struct {
+	Link catalog.Link
+	Card card.Card
+}
+
+// Subscribe clones a published deck like Clone and keeps following it, so Sync later brings the
+// author's edits, additions and deletions into the copy.
+func (s *Service) Subscribe(deckID, userID string) (deck.Deck, []card.Card, error) {
+	if s.subscriptions == nil {
+		return deck.Deck{}, nil, catalog.ErrSubscriptionNotFound
+	}
+
+	clone, copies, err := s.clone(deckID, userID)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+
+	cards := make([]card.Card, 0, len(copies))
+	for _, c := range copies {
+		if _, err := s.links.Save(link(clone.ID, c.card.ID, c.source)); err != nil {
+			return deck.Deck{}, nil, err
+		}
+		cards = append(cards, c.card)
+	}
+
+	_, err = s.subscriptions.Save(catalog.Subscription{
+		DeckID:    clone.ID,
+		SourceID:  deckID,
+		UserID:    userID,
+		CreatedAt: clone.CreatedAt,
+		SyncedAt:  clone.CreatedAt,
+	})
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+	return clone, cards, nil
+}
+
+// Subscriptions lists the decks the user follows, oldest subscription first.
+func (s *Service) Subscriptions(userID string) ([]catalog.Subscription, error) {
+	if s.subscriptions == nil {
+		return nil, nil
+	}
+	return s.subscriptions.FindByUser(userID)
+}
+
+// Unsubscribe stops following the source deck. The copy and its cards stay with the user.
+func (s *Service) Unsubscribe(deckID, userID string) error {
+	if _, err := s.subscription(deckID, userID); err != nil {
+		return err
+	}
+	if err := s.links.DeleteByDeck(deckID); err != nil {
+		return err
+	}
+	return s.subscriptions.Delete(deckID)
+}
+
+// Sync pulls the changes made to the source deck and its subdecks since the last sync into the
+// subscribed copy. New source cards are copied with a fresh schedule into the copy of their deck.
+// Edited source cards update their copy in place, keeping its review state, unless the subscriber
+// edited the copy too: those are left untouched and reported as conflicts until resolved. Deleted
+// source cards move their copy to the trash unless it was edited locally, in which case the copy is
+// kept as the subscriber's own card. Copies the subscriber deleted are not brought back.
+func (s *Service) Sync(deckID, userID string) (catalog.SyncReport, error) {
+	sub, err := s.subscription(deckID, userID)
+	if err != nil {
+		return catalog.SyncReport{}, err
+	}
+	target, err := s.decks.FindByID(sub.DeckID)
+	if err != nil {
+		return catalog.SyncReport{}, err
+	}
+	source, err := s.decks.FindByID(sub.SourceID)
+	if err == deck.ErrNotFound {
+		return catalog.SyncReport{}, catalog.ErrSourceGone
+	} else if err != nil {
+		return catalog.SyncReport{}, err
+	}
+
+	now := s.now()
+	upstream, targets, err := s.upstream(source, target, now)
+	if err != nil {
+		return catalog.SyncReport{}, err
+	}
+	links, err := s.links.FindByDeck(sub.DeckID)
+	if err != nil {
+		return catalog.SyncReport{}, err
+	}
+	linked := make(map[string]catalog.Link, len(links))
+	for _, l := range links {
+		linked[l.SourceCardID] = l
+	}
+
+	var report catalog.SyncReport
+	present := make(map[string]bool, len(upstream))
+	for _, u := range upstream {
+		present[u.ID] = true
+		l, ok := linked[u.ID]
+		if !ok {
+			cloned, err := s.copyCard(u, sub.UserID, targets[u.DeckID], now)
+			if err != nil {
+				return catalog.SyncReport{}, err
+			}
+			if _, err := s.links.Save(link(sub.DeckID, cloned.ID, u)); err != nil {
+				return catalog.SyncReport{}, err
+			}
+			report.Added++
+			continue
+		}
+		if err := s.syncCard(l, u, &report); err != nil {
+			return catalog.SyncReport{}, err
+		}
+	}
+
+	for _, l := range links {
+		if present[l.SourceCardID] {
+			continue
+		}
+		local, err := s.cards.FindByID(l.CardID)
+		if err == nil && !edited(local, l) {
+			if err := s.removeCopy(local, sub.UserID); err != nil {
+				return catalog.SyncReport{}, err
+			}
+			report.Removed++
+		} else if err != nil && err != card.ErrNotFound {
+			return catalog.SyncReport{}, err
+		}
+		if err := s.links.Delete(l.DeckID, l.SourceCardID); err != nil {
+			return catalog.SyncReport{}, err
+		}
+	}
+
+	sub.SyncedAt = now
+	if _, err := s.subscriptions.Save(sub); err != nil {
+		return catalog.SyncReport{}, err
+	}
+	return report, nil
+}
+
+// upstream returns the cards of the source deck and its subdecks, together with the copied deck each
+// source deck's new cards go to. Subdecks added upstream since the last sync are copied into the
+// subscriber's hierarchy first.
+func (s *Service) upstream(source, target deck.Deck, now time.Time) ([]card.Card, map[string]string, error) {
+	decks, err := s.subtree(source)
+	if err != nil {
+		return nil, nil, err
+	}
+	copies, err := s.subtree(target)
+	if err != nil {
+		return nil, nil, err
+	}
+	targets := map[string]string{source.ID: target.ID}
+	for _, d := range copies[1:] {
+		if d.SourceID != "" {
+			targets[d.SourceID] = d.ID
+		}
+	}
+
+	var cards []card.Card
+	for _, d := range decks {
+		if _, ok := targets[d.ID]; !ok {
+			sub, err := s.copyDeck(d, target.OwnerID, targets[d.ParentID], now)
+			if err != nil {
+				return nil, nil, err
+			}
+			targets[d.ID] = sub.ID
+		}
+		found, err := s.cards.FindByDeck(d.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		cards = append(cards, found...)
+	}
+	return cards, targets, nil
+}
+
+// SyncAll syncs every subscription of the user and adds up their reports. Subscriptions whose copy
+// or source deck was deleted are skipped.
+func (s *Service) SyncAll(userID string) (catalog.SyncReport, error) {
+	subscriptions, err := s.Subscriptions(userID)
+	if err != nil {
+		return catalog.SyncReport{}, err
+	}
+
+	var total catalog.SyncReport
+	for _, sub := range subscriptions {
+		report, err := s.Sync(sub.DeckID, userID)
+		if err == catalog.ErrSourceGone || err == deck.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return catalog.SyncReport{}, err
+		}
+		total.Added += report.Added
+		total.Updated += report.Updated
+		total.Removed += report.Removed
+		total.Conflicts += report.Conflicts
+	}
+	return total, nil
+}
+
+// Conflicts lists the cards of the subscribed copy waiting for the subscriber to pick a side.
+func (s *Service) Conflicts(deckID, userID string) ([]Conflict, error) {
+	if _, err := s.subscription(deckID, userID); err != nil {
+		return nil, err
+	}
+	links, err := s.links.FindByDeck(deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []Conflict
+	for _, l := range links {
+		if !l.Conflict {
+			continue
+		}
+		local, err := s.cards.FindByID(l.CardID)
+		if err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, Conflict{Link: l, Card: local})
+	}
+	return conflicts, nil
+}
+
+// ResolveConflict settles a conflict on a card of the subscribed copy. KeepUpstream overwrites the
+// local edit with the source content, KeepLocal keeps it; either way the card keeps its review state
+// and later syncs only report new upstream edits.
+func (s *Service) ResolveConflict(deckID, userID, cardID string, keep catalog.Resolution) (card.Card, error) {
+	if !keep.Valid() {
+		return card.Card{}, catalog.ErrInvalidResolution
+	}
+	if _, err := s.subscription(deckID, userID); err != nil {
+		return card.Card{}, err
+	}
+	links, err := s.links.FindByDeck(deckID)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	for _, l := range links {
+		if l.CardID != cardID {
+			continue
+		}
+		if !l.Conflict {
+			return card.Card{}, catalog.ErrNoConflict
+		}
+		local, err := s.cards.FindByID(cardID)
+		if err != nil {
+			return card.Card{}, err
+		}
+		if keep == catalog.KeepUpstream {
+			local.Front, local.Back = l.UpstreamFront, l.UpstreamBack
+			local.UpdatedAt = s.now()
+			if local, err = s.cards.Update(local); err != nil {
+				return card.Card{}, err
+			}
+		}
+		l.Front, l.Back = l.UpstreamFront, l.UpstreamBack
+		clearConflict(&l)
+		if _, err := s.links.Save(l); err != nil {
+			return card.Card{}, err
+		}
+		return local, nil
+	}
+	return card.Card{}, catalog.ErrLinkNotFound
+}
+
+// syncCard applies an upstream card to its linked copy.
+func (s *Service) syncCard(l catalog.Link, u card.Card, report *catalog.SyncReport) error {
+	local, err := s.cards.FindByID(l.CardID)
+	if err == card.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case u.Front == l.Front && u.Back == l.Back:
+		if !l.Conflict {
+			return nil
+		}
+		clearConflict(&l)
+	case local.Front == u.Front && local.Back == u.Back:
+		l.Front, l.Back = u.Front, u.Back
+		clearConflict(&l)
+	case !edited(local, l):
+		local.Front, local.Back = u.Front, u.Back
+		local.Type, local.ClozeIndex = u.Type, u.ClozeIndex
+		local.UpdatedAt = s.now()
+		if _, err := s.cards.Update(local); err != nil {
+			return err
+		}
+		l.Front, l.Back = u.Front, u.Back
+		report.Updated++
+	default:
+		report.Conflicts++
+		if l.Conflict && l.UpstreamFront == u.Front && l.UpstreamBack == u.Back {
+			return nil
+		}
+		l.Conflict = true
+		l.UpstreamFront, l.UpstreamBack = u.Front, u.Back
+	}
+
+	_, err = s.links.Save(l)
+	return err
+}
+
+// removeCopy deletes a copy whose source card was deleted, through the subscriber's trash when
+// the service has one.
+func (s *Service) removeCopy(local card.Card, userID string) error {
+	if s.trash != nil {
+		return s.trash.DeleteCard(local.ID, userID)
+	}
+	return s.cards.Delete(local.ID)
+}
+
+func (s *Service) subscription(deckID, userID string) (catalog.Subscription, error) {
+	if s.subscriptions == nil {
+		return catalog.Subscription{}, catalog.ErrSubscriptionNotFound
+	}
+	sub, err := s.subscriptions.FindByDeck(deckID)
+	if err != nil {
+		return catalog.Subscription{}, err
+	}
+	if sub.UserID != userID {
+		return catalog.Subscription{}, deck.ErrForeignOwner
+	}
+	return sub, nil
+}
+
+func link(deckID, cardID string, source card.Card) catalog.Link {
+	return catalog.Link{
+		DeckID:       deckID,
+		CardID:       cardID,
+		SourceCardID: source.ID,
+		Front:        source.Front,
+		Back:         source.Back,
+	}
+}
+
+// edited reports whether the subscriber changed the copy since the source content in l was applied.
+func edited(local card.Card, l catalog.Link) bool {
+	return local.Front != l.Front || local.Back != l.Back
+}
+
+func clearConflict(l *catalog.Link) {
+	l.Conflict = false
+	l.UpstreamFront, l.UpstreamBack = "", ""
+}
@@ -0,0 +1,271 @@
+package catalogapp
+
+import (
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	catalogstorage "flash2fy/internal/adapters/storage/catalog"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	cardapp "flash2fy/internal/app/application/card"
+	deckapp "flash2fy/internal/app/application/deck"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/catalog"
+	"flash2fy/internal/app/domain/deck"
+)
+
+func newSubscriptionFixture(now func() time.Time) fixture {
+	cards := cardstorage.NewMemoryRepository()
+	decks := deckstorage.NewMemoryRepository()
+	cardService := cardapp.NewService(cards, cardapp.WithDecks(decks))
+	return fixture{
+		catalog: NewService(catalogstorage.NewMemoryRepository(), decks, cards,
+			WithClock(now),
+			WithSubscriptions(catalogstorage.NewMemorySubscriptionRepository(), catalogstorage.NewMemoryLinkRepository()),
+			WithCardTrash(cardService),
+		),
+		cards: cardService,
+		decks: deckapp.NewService(decks, cards),
+	}
+}
+
+func byFront(t *testing.T, f fixture, deckID, userID string) map[string]card.Card {
+	t.Helper()
+	cards, err := f.cards.ListCardsInDeck(deckID, userID)
+	if err != nil {
+		t.Fatalf("list cards failed: %v", err)
+	}
+	set := make(map[string]card.Card, len(cards))
+	for _, c := range cards {
+		set[c.Front] = c
+	}
+	return set
+}
+
+func TestSyncPropagatesUpstreamChangesAndKeepsReviews(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newSubscriptionFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "", "author", "")
+	hund, _ := f.cards.CreateCardInDeck("der Hund", "dog", "author", german.ID)
+	katze, _ := f.cards.CreateCardInDeck("die Katze", "cat", "author", german.ID)
+	baum, _ := f.cards.CreateCardInDeck("der Baum", "tree", "author", german.ID)
+	f.catalog.Publish(german.ID, "author", "German nouns", "de", "")
+
+	copyDeck, copies, err := f.catalog.Subscribe(german.ID, "student")
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if copyDeck.SourceID != german.ID || len(copies) != 3 {
+		t.Fatalf("unexpected subscription copy: %+v with %d cards", copyDeck, len(copies))
+	}
+	if subs, _ := f.catalog.Subscriptions("student"); len(subs) != 1 || subs[0].DeckID != copyDeck.ID {
+		t.Fatalf("expected one subscription, got %+v", subs)
+	}
+
+	local := byFront(t, f, copyDeck.ID, "student")
+	graded, err := f.cards.GradeCard(local["der Hund"].ID, card.RatingGood, 0, "student")
+	if err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	f.cards.UpdateCard(hund.ID, "der Hund", "the dog", "author")
+	f.cards.DeleteCard(katze.ID, "author")
+	f.cards.UpdateCard(baum.ID, "der Baum", "the tree", "author")
+	f.cards.CreateCardInDeck("das Haus", "house", "author", german.ID)
+	f.cards.UpdateCard(local["der Baum"].ID, "der Baum", "a tree", "student")
+
+	report, err := f.catalog.Sync(copyDeck.ID, "student")
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if report != (catalog.SyncReport{Added: 1, Updated: 1, Removed: 1, Conflicts: 1}) {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	local = byFront(t, f, copyDeck.ID, "student")
+	if len(local) != 3 {
+		t.Fatalf("expected three cards after sync, got %+v", local)
+	}
+	if got := local["der Hund"]; got.Back != "the dog" || got.Review.Repetitions != graded.Review.Repetitions || !got.Review.DueAt.Equal(graded.Review.DueAt) {
+		t.Fatalf("expected the edit applied with the review kept, got %+v", got)
+	}
+	if got := local["das Haus"]; got.OwnerID != "student" || got.Review.Repetitions != 0 {
+		t.Fatalf("expected the new card copied fresh, got %+v", got)
+	}
+	if got := local["der Baum"]; got.Back != "a tree" {
+		t.Fatalf("expected the local edit kept on conflict, got %+v", got)
+	}
+	if trash, _ := f.cards.Trash("student"); len(trash) != 1 || trash[0].Front != "die Katze" {
+		t.Fatalf("expected the copy of the deleted card in the subscriber's trash, got %+v", trash)
+	}
+
+	again, _ := f.catalog.Sync(copyDeck.ID, "student")
+	if again != (catalog.SyncReport{Conflicts: 1}) {
+		t.Fatalf("expected only the pending conflict on a second sync, got %+v", again)
+	}
+
+	if _, err := f.catalog.Sync(copyDeck.ID, "stranger"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected foreign owner error, got %v", err)
+	}
+}
+
+func TestResolveConflict(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newSubscriptionFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "", "author", "")
+	hund, _ := f.cards.CreateCardInDeck("der Hund", "dog", "author", german.ID)
+	baum, _ := f.cards.CreateCardInDeck("der Baum", "tree", "author", german.ID)
+	f.catalog.Publish(german.ID, "author", "German nouns", "de", "")
+	copyDeck, _, _ := f.catalog.Subscribe(german.ID, "student")
+
+	local := byFront(t, f, copyDeck.ID, "student")
+	f.cards.UpdateCard(local["der Hund"].ID, "der Hund", "a dog", "student")
+	f.cards.UpdateCard(local["der Baum"].ID, "der Baum", "a tree", "student")
+	f.cards.UpdateCard(hund.ID, "der Hund", "the dog", "author")
+	f.cards.UpdateCard(baum.ID, "der Baum", "the tree", "author")
+
+	if report, _ := f.catalog.Sync(copyDeck.ID, "student"); report.Conflicts != 2 {
+		t.Fatalf("expected two conflicts, got %+v", report)
+	}
+	conflicts, err := f.catalog.Conflicts(copyDeck.ID, "student")
+	if err != nil {
+		t.Fatalf("conflicts failed: %v", err)
+	}
+	if len(conflicts) != 2 || conflicts[0].Link.UpstreamBack == "" {
+		t.Fatalf("unexpected conflicts: %+v", conflicts)
+	}
+
+	if _, err := f.catalog.ResolveConflict(copyDeck.ID, "student", local["der Hund"].ID, "mine"); err != catalog.ErrInvalidResolution {
+		t.Fatalf("expected invalid resolution error, got %v", err)
+	}
+	resolved, err := f.catalog.ResolveConflict(copyDeck.ID, "student", local["der Hund"].ID, catalog.KeepUpstream)
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if resolved.Back != "the dog" {
+		t.Fatalf("expected the upstream content, got %+v", resolved)
+	}
+	kept, err := f.catalog.ResolveConflict(copyDeck.ID, "student", local["der Baum"].ID, catalog.KeepLocal)
+	if err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if kept.Back != "a tree" {
+		t.Fatalf("expected the local content, got %+v", kept)
+	}
+	if _, err := f.catalog.ResolveConflict(copyDeck.ID, "student", kept.ID, catalog.KeepLocal); err != catalog.ErrNoConflict {
+		t.Fatalf("expected ErrNoConflict, got %v", err)
+	}
+
+	if report, _ := f.catalog.Sync(copyDeck.ID, "student"); report != (catalog.SyncReport{}) {
+		t.Fatalf("expected nothing left to sync, got %+v", report)
+	}
+
+	f.cards.UpdateCard(baum.ID, "der Baum", "the big tree", "author")
+	if report, _ := f.catalog.Sync(copyDeck.ID, "student"); report.Conflicts != 1 {
+		t.Fatalf("expected a new upstream edit to conflict again, got %+v", report)
+	}
+}
+
+func TestSyncKeepsEditedCardsRemovedUpstream(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newSubscriptionFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "", "author", "")
+	hund, _ := f.cards.CreateCardInDeck("der Hund", "dog", "author", german.ID)
+	katze, _ := f.cards.CreateCardInDeck("die Katze", "cat", "author", german.ID)
+	f.catalog.Publish(german.ID, "author", "German nouns", "de", "")
+	copyDeck, _, _ := f.catalog.Subscribe(german.ID, "student")
+
+	local := byFront(t, f, copyDeck.ID, "student")
+	f.cards.UpdateCard(local["der Hund"].ID, "der Hund", "my dog", "student")
+	f.cards.DeleteCard(local["die Katze"].ID, "student")
+	f.cards.DeleteCard(hund.ID, "author")
+	f.cards.UpdateCard(katze.ID, "die Katze", "the cat", "author")
+
+	report, err := f.catalog.SyncAll("student")
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if report != (catalog.SyncReport{}) {
+		t.Fatalf("expected no changes, got %+v", report)
+	}
+	local = byFront(t, f, copyDeck.ID, "student")
+	if len(local) != 1 || local["der Hund"].Back != "my dog" {
+		t.Fatalf("expected only the edited card kept, got %+v", local)
+	}
+
+	if err := f.catalog.Unsubscribe(copyDeck.ID, "student"); err != nil {
+		t.Fatalf("unsubscribe failed: %v", err)
+	}
+	if _, err := f.catalog.Sync(copyDeck.ID, "student"); err != catalog.ErrSubscriptionNotFound {
+		t.Fatalf("expected ErrSubscriptionNotFound, got %v", err)
+	}
+
+	f.catalog.Publish(german.ID, "author", "German nouns", "de", "")
+	again, _, _ := f.catalog.Subscribe(german.ID, "student")
+	f.decks.DeleteDeck(german.ID, "author")
+	if _, err := f.catalog.Sync(again.ID, "student"); err != catalog.ErrSourceGone {
+		t.Fatalf("expected ErrSourceGone, got %v", err)
+	}
+}
+
+func TestSyncFollowsSubdecks(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	f := newSubscriptionFixture(func() time.Time { return now })
+
+	german, _ := f.decks.CreateDeck("German", "", "author", "")
+	verbs, _ := f.decks.CreateDeck("Verbs", "", "author", german.ID)
+	gehen, _ := f.cards.CreateCardInDeck("gehen", "go", "author", verbs.ID)
+	sehen, _ := f.cards.CreateCardInDeck("sehen", "to see", "author", verbs.ID)
+	f.catalog.Publish(german.ID, "author", "German", "de", "")
+	copyDeck, copies, err := f.catalog.Subscribe(german.ID, "student")
+	if err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	if len(copies) != 2 {
+		t.Fatalf("expected the subdeck cards copied, got %d", len(copies))
+	}
+	tree, _ := f.decks.Hierarchy("student")
+	verbsCopy := tree[0].Children[0].Deck
+
+	now = now.Add(time.Hour)
+	f.cards.UpdateCard(gehen.ID, "gehen", "to go", "author")
+	f.cards.DeleteCard(sehen.ID, "author")
+	f.cards.CreateCardInDeck("laufen", "to run", "author", verbs.ID)
+	nouns, _ := f.decks.CreateDeck("Nouns", "", "author", german.ID)
+	f.cards.CreateCardInDeck("der Hund", "dog", "author", nouns.ID)
+
+	report, err := f.catalog.Sync(copyDeck.ID, "student")
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if report.Added != 2 || report.Updated != 1 || report.Removed != 1 || report.Conflicts != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	local := byFront(t, f, verbsCopy.ID, "student")
+	if len(local) != 2 || local["gehen"].Back != "to go" || local["laufen"].ID == "" {
+		t.Fatalf("expected the subdeck copy synced, got %+v", local)
+	}
+	tree, _ = f.decks.Hierarchy("student")
+	if len(tree[0].Children) != 2 {
+		t.Fatalf("expected the new upstream subdeck copied, got %+v", tree[0].Children)
+	}
+	nounsCopy := tree[0].Children[0].Deck
+	if nounsCopy.Name != "Nouns" || nounsCopy.SourceID != nouns.ID {
+		t.Fatalf("unexpected copy of the new subdeck: %+v", nounsCopy)
+	}
+	if local := byFront(t, f, nounsCopy.ID, "student"); len(local) != 1 || local["der Hund"].ID == "" {
+		t.Fatalf("expected the new subdeck's card in its copy, got %+v", local)
+	}
+
+	if report, _ := f.catalog.Sync(copyDeck.ID, "student"); report != (catalog.SyncReport{}) {
+		t.Fatalf("expected a second sync to change nothing, got %+v", report)
+	}
+	if tree, _ = f.decks.Hierarchy("student"); len(tree[0].Children) != 2 {
+		t.Fatalf("expected the subdeck copies reused, got %+v", tree[0].Children)
+	}
+}
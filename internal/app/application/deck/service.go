@@ -0,0 +1,330 @@
+package deckapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/ports"
+)
+
+// Counts tallies the cards of a deck that are available for review.
+type Counts struct {
+	Cards int
+	New   int // never reviewed and available now
+	Due   int // reviewed before and due now
+}
+
+func (c *Counts) add(other Counts) {
+	c.Cards += other.Cards
+	c.New += other.New
+	c.Due += other.Due
+}
+
+// Node is a deck within its owner's hierarchy. Own counts the cards of the deck
+// itself and Total those of the whole subtree.
+type Node struct {
+	Deck     deck.Deck
+	Path     string
+	Own      Counts
+	Total    Counts
+	Children []Node
+}
+
+// Service orchestrates deck use-cases. Every use-case on an existing deck names the acting user
+// and requires the role it needs: viewers may read a deck, editors may also rename it, and only
+// its owner may move, share or delete it.
+type Service struct {
+	decks   ports.DeckRepository
+	cards   ports.CardRepository
+	members ports.DeckMemberRepository
+	invites ports.DeckInviteRepository
+	now     func() time.Time
+}
+
+// Option customises optional Service collaborators.
+type Option func(*Service)
+
+// WithSharing lets owners share their decks with other users, directly or through invites.
+func WithSharing(members ports.DeckMemberRepository, invites ports.DeckInviteRepository) Option {
+	return func(s *Service) {
+		s.members = members
+		s.invites = invites
+	}
+}
+
+// WithClock overrides the time source, mainly for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(decks ports.DeckRepository, cards ports.CardRepository, opts ...Option) *Service {
+	s := &Service{
+		decks: decks,
+		cards: cards,
+		now:   func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateDeck creates a deck of the owner, as a subdeck of parentID unless it is empty.
+func (s *Service) CreateDeck(name, description, ownerID, parentID string) (deck.Deck, error) {
+	now := s.now()
+	d := deck.Deck{
+		ID:          uuid.NewString(),
+		Name:        name,
+		Description: description,
+		OwnerID:     ownerID,
+		ParentID:    parentID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := d.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+	if parentID != "" {
+		parent, err := s.decks.FindByID(parentID)
+		if err != nil {
+			return deck.Deck{}, err
+		}
+		if err := parent.Accepts(ownerID); err != nil {
+			return deck.Deck{}, err
+		}
+	}
+
+	return s.decks.Save(d)
+}
+
+// GetDeck returns the deck if userID may view it.
+func (s *Service) GetDeck(id, userID string) (deck.Deck, error) {
+	return s.authorized(id, userID, deck.RoleViewer)
+}
+
+// ListDecks returns the owner's decks.
+func (s *Service) ListDecks(ownerID string) ([]deck.Deck, error) {
+	return s.decks.FindByOwner(ownerID)
+}
+
+// UpdateDeck renames the deck on behalf of an editor or the owner.
+func (s *Service) UpdateDeck(id, userID, name, description string) (deck.Deck, error) {
+	existing, err := s.authorized(id, userID, deck.RoleEditor)
+	if err != nil {
+		return deck.Deck{}, err
+	}
+
+	existing.Name = name
+	existing.Description = description
+	existing.UpdatedAt = s.now()
+
+	if err := existing.Validate(); err != nil {
+		return deck.Deck{}, err
+	}
+
+	return s.decks.Update(existing)
+}
+
+// MoveDeck moves the deck with all of its subdecks under parentID; an empty parentID makes it top-level.
+// Only the owner may move it.
+func (s *Service) MoveDeck(id, userID, parentID string) (deck.Deck, error) {
+	existing, err := s.authorized(id, userID, deck.RoleOwner)
+	if err != nil {
+		return deck.Deck{}, err
+	}
+	if parentID != "" {
+		parent, err := s.decks.FindByID(parentID)
+		if err != nil {
+			return deck.Deck{}, err
+		}
+		if err := parent.Accepts(existing.OwnerID); err != nil {
+			return deck.Deck{}, err
+		}
+	}
+	tree, err := s.tree(existing.OwnerID)
+	if err != nil {
+		return deck.Deck{}, err
+	}
+	if err := tree.CheckParent(id, parentID); err != nil {
+		return deck.Deck{}, err
+	}
+
+	existing.ParentID = parentID
+	existing.UpdatedAt = s.now()
+	return s.decks.Update(existing)
+}
+
+// DeleteDeck removes the deck on behalf of its owner. Its subdecks move up to its parent,
+// its cards are kept outside any deck and its members lose access.
+func (s *Service) DeleteDeck(id, userID string) error {
+	existing, err := s.authorized(id, userID, deck.RoleOwner)
+	if err != nil {
+		return err
+	}
+	tree, err := s.tree(existing.OwnerID)
+	if err != nil {
+		return err
+	}
+	cards, err := s.cards.FindByDeck(id)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	for _, child := range tree.Children(id) {
+		child.ParentID = existing.ParentID
+		child.UpdatedAt = now
+		if _, err := s.decks.Update(child); err != nil {
+			return err
+		}
+	}
+	if err := s.decks.Delete(id); err != nil {
+		return err
+	}
+	if s.members != nil {
+		members, err := s.members.FindByDeck(id)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if err := s.members.Delete(m.DeckID, m.UserID); err != nil && err != deck.ErrMemberNotFound {
+				return err
+			}
+		}
+	}
+
+	for _, c := range cards {
+		c.DeckID = ""
+		c.UpdatedAt = now
+		if _, err := s.cards.Update(c); err != nil && err != card.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+// Hierarchy returns the owner's top-level decks with their subdecks nested below them,
+// each with the counts of its own cards and of its whole subtree.
+func (s *Service) Hierarchy(ownerID string) ([]Node, error) {
+	tree, err := s.tree(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := s.cards.FindByOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	own := make(map[string]Counts)
+	for _, c := range cards {
+		if c.DeckID == "" {
+			continue
+		}
+		counts := own[c.DeckID]
+		counts.Cards++
+		if c.Available(now) {
+			switch {
+			case c.Review.IsNew():
+				counts.New++
+			case !c.Review.DueAt.After(now):
+				counts.Due++
+			}
+		}
+		own[c.DeckID] = counts
+	}
+
+	return buildNodes(tree, "", own), nil
+}
+
+// Counts returns the counts of the deck's own cards and of its whole subtree if userID may view the deck.
+func (s *Service) Counts(id, userID string) (Node, error) {
+	d, err := s.authorized(id, userID, deck.RoleViewer)
+	if err != nil {
+		return Node{}, err
+	}
+	nodes, err := s.Hierarchy(d.OwnerID)
+	if err != nil {
+		return Node{}, err
+	}
+	if node, ok := findNode(nodes, id); ok {
+		return node, nil
+	}
+	return Node{}, deck.ErrNotFound
+}
+
+// Export returns the deck together with all of its cards if userID may view it.
+func (s *Service) Export(id, userID string) (deck.Deck, []card.Card, error) {
+	d, err := s.authorized(id, userID, deck.RoleViewer)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+
+	cards, err := s.cards.FindByDeck(id)
+	if err != nil {
+		return deck.Deck{}, nil, err
+	}
+	return d, cards, nil
+}
+
+// authorized returns the deck once userID is known to hold at least the required role on it.
+func (s *Service) authorized(id, userID string, required deck.Role) (deck.Deck, error) {
+	d, err := s.decks.FindByID(id)
+	if err != nil {
+		return deck.Deck{}, err
+	}
+	var members []deck.Member
+	if s.members != nil && d.OwnerID != userID {
+		if members, err = s.members.FindByDeck(id); err != nil {
+			return deck.Deck{}, err
+		}
+	}
+	if err := d.Authorize(userID, members, required); err != nil {
+		return deck.Deck{}, err
+	}
+	return d, nil
+}
+
+func (s *Service) tree(ownerID string) (deck.Tree, error) {
+	decks, err := s.decks.FindByOwner(ownerID)
+	if err != nil {
+		return deck.Tree{}, err
+	}
+	return deck.NewTree(decks), nil
+}
+
+func buildNodes(tree deck.Tree, parentID string, own map[string]Counts) []Node {
+	children := tree.Children(parentID)
+	nodes := make([]Node, 0, len(children))
+	for _, d := range children {
+		node := Node{
+			Deck:     d,
+			Path:     tree.Path(d.ID),
+			Own:      own[d.ID],
+			Total:    own[d.ID],
+			Children: buildNodes(tree, d.ID, own),
+		}
+		for _, child := range node.Children {
+			node.Total.add(child.Total)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func findNode(nodes []Node, id string) (Node, bool) {
+	for _, n := range nodes {
+		if n.Deck.ID == id {
+			return n, true
+		}
+		if found, ok := findNode(n.Children, id); ok {
+			return found, true
+		}
+	}
+	return Node{}, false
+}
@@ -0,0 +1,224 @@
+package deckapp
+
+import (
+	"testing"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	cardapp "flash2fy/internal/app/application/card"
+	"flash2fy/internal/app/domain/deck"
+)
+
+func newDeckService(opts ...Option) (*Service, *cardapp.Service) {
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	members := deckstorage.NewMemoryMemberRepository()
+	opts = append([]Option{WithSharing(members, deckstorage.NewMemoryInviteRepository())}, opts...)
+	return NewService(decks, cards, opts...), cardapp.NewService(cards, cardapp.WithDecks(decks), cardapp.WithDeckMembers(members))
+}
+
+func TestCreateAndUpdateDeck(t *testing.T) {
+	service, _ := newDeckService()
+
+	created, err := service.CreateDeck("German", "", "user-1", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+
+	updated, err := service.UpdateDeck(created.ID, "user-1", "German A1", "Basic vocabulary")
+	if err != nil {
+		t.Fatalf("update deck failed: %v", err)
+	}
+	if updated.Name != "German A1" || updated.Description != "Basic vocabulary" || updated.OwnerID != "user-1" {
+		t.Fatalf("unexpected updated deck: %+v", updated)
+	}
+
+	if _, err := service.CreateDeck(" ", "", "user-1", ""); err != deck.ErrEmptyName {
+		t.Fatalf("expected ErrEmptyName, got %v", err)
+	}
+	if _, err := service.UpdateDeck(created.ID, "user-1", "", ""); err != deck.ErrEmptyName {
+		t.Fatalf("expected ErrEmptyName on update, got %v", err)
+	}
+}
+
+func TestExportDeck(t *testing.T) {
+	service, cards := newDeckService()
+
+	d, err := service.CreateDeck("German", "", "user-1", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	inDeck, err := cards.CreateCardInDeck("Hund", "dog", "user-1", d.ID)
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := cards.CreateCard("Katze", "cat", "user-1"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	exported, exportedCards, err := service.Export(d.ID, "user-1")
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if exported.ID != d.ID || len(exportedCards) != 1 || exportedCards[0].ID != inDeck.ID {
+		t.Fatalf("expected only the deck card to be exported, got %+v", exportedCards)
+	}
+}
+
+func TestDeleteDeckKeepsCards(t *testing.T) {
+	service, cards := newDeckService()
+
+	d, err := service.CreateDeck("German", "", "user-1", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	created, err := cards.CreateCardInDeck("Hund", "dog", "user-1", d.ID)
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	if err := service.DeleteDeck(d.ID, "user-1"); err != nil {
+		t.Fatalf("delete deck failed: %v", err)
+	}
+	if _, err := service.GetDeck(d.ID, "user-1"); err != deck.ErrNotFound {
+		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	}
+
+	kept, err := cards.GetCard(created.ID, "user-1")
+	if err != nil {
+		t.Fatalf("expected card to survive its deck: %v", err)
+	}
+	if kept.DeckID != "" {
+		t.Fatalf("expected card to leave the deleted deck, got %q", kept.DeckID)
+	}
+}
+
+func TestCardsOnlyMoveIntoOwnDecks(t *testing.T) {
+	service, cards := newDeckService()
+
+	own, err := service.CreateDeck("German", "", "user-1", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	foreign, err := service.CreateDeck("French", "", "user-2", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	created, err := cards.CreateCard("Hund", "dog", "user-1")
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+
+	if _, err := cards.MoveCard(created.ID, foreign.ID, "user-1"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner, got %v", err)
+	}
+	if _, err := cards.MoveCard(created.ID, "missing", "user-1"); err != deck.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for unknown deck, got %v", err)
+	}
+
+	moved, err := cards.MoveCard(created.ID, own.ID, "user-1")
+	if err != nil || moved.DeckID != own.ID {
+		t.Fatalf("expected card moved into own deck, got %+v (%v)", moved, err)
+	}
+	removed, err := cards.MoveCard(created.ID, "", "user-1")
+	if err != nil || removed.DeckID != "" {
+		t.Fatalf("expected card taken out of its deck, got %+v (%v)", removed, err)
+	}
+}
+
+func TestMoveDeckPreventsCycles(t *testing.T) {
+	service, _ := newDeckService()
+
+	spanish, _ := service.CreateDeck("Spanish", "", "user-1", "")
+	verbs, err := service.CreateDeck("Verbs", "", "user-1", spanish.ID)
+	if err != nil {
+		t.Fatalf("create subdeck failed: %v", err)
+	}
+	irregular, err := service.CreateDeck("Irregular", "", "user-1", verbs.ID)
+	if err != nil {
+		t.Fatalf("create subdeck failed: %v", err)
+	}
+
+	if _, err := service.MoveDeck(spanish.ID, "user-1", irregular.ID); err != deck.ErrCycle {
+		t.Fatalf("expected ErrCycle moving a deck under its descendant, got %v", err)
+	}
+	if _, err := service.MoveDeck(verbs.ID, "user-1", verbs.ID); err != deck.ErrCycle {
+		t.Fatalf("expected ErrCycle moving a deck under itself, got %v", err)
+	}
+
+	foreign, _ := service.CreateDeck("French", "", "user-2", "")
+	if _, err := service.MoveDeck(verbs.ID, "user-1", foreign.ID); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner, got %v", err)
+	}
+	if _, err := service.CreateDeck("Nouns", "", "user-2", spanish.ID); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner creating under another owner's deck, got %v", err)
+	}
+
+	moved, err := service.MoveDeck(irregular.ID, "user-1", spanish.ID)
+	if err != nil || moved.ParentID != spanish.ID {
+		t.Fatalf("expected subtree moved under spanish, got %+v (%v)", moved, err)
+	}
+	top, err := service.MoveDeck(verbs.ID, "user-1", "")
+	if err != nil || top.ParentID != "" {
+		t.Fatalf("expected deck moved to the top level, got %+v (%v)", top, err)
+	}
+}
+
+func TestHierarchyAggregatesCounts(t *testing.T) {
+	service, cards := newDeckService()
+
+	spanish, _ := service.CreateDeck("Spanish", "", "user-1", "")
+	verbs, _ := service.CreateDeck("Verbs", "", "user-1", spanish.ID)
+	irregular, _ := service.CreateDeck("Irregular", "", "user-1", verbs.ID)
+
+	for _, tc := range []struct{ front, deckID string }{
+		{"hola", spanish.ID},
+		{"hablar", verbs.ID},
+		{"ser", irregular.ID},
+		{"ir", irregular.ID},
+	} {
+		if _, err := cards.CreateCardInDeck(tc.front, "", "user-1", tc.deckID); err != nil {
+			t.Fatalf("create card failed: %v", err)
+		}
+	}
+	suspended, _ := cards.CreateCardInDeck("tener", "", "user-1", irregular.ID)
+	if _, err := cards.SuspendCard(suspended.ID, "user-1"); err != nil {
+		t.Fatalf("suspend failed: %v", err)
+	}
+
+	nodes, err := service.Hierarchy("user-1")
+	if err != nil {
+		t.Fatalf("hierarchy failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Deck.ID != spanish.ID {
+		t.Fatalf("expected spanish as the only top-level deck, got %+v", nodes)
+	}
+	root := nodes[0]
+	if root.Own.Cards != 1 || root.Total.Cards != 5 || root.Total.New != 4 {
+		t.Fatalf("unexpected root counts: own %+v total %+v", root.Own, root.Total)
+	}
+
+	node, err := service.Counts(irregular.ID, "user-1")
+	if err != nil {
+		t.Fatalf("counts failed: %v", err)
+	}
+	if node.Path != "Spanish::Verbs::Irregular" || node.Total.Cards != 3 || node.Total.New != 2 {
+		t.Fatalf("unexpected irregular node: %+v", node)
+	}
+}
+
+func TestDeleteDeckMovesSubdecksUp(t *testing.T) {
+	service, _ := newDeckService()
+
+	spanish, _ := service.CreateDeck("Spanish", "", "user-1", "")
+	verbs, _ := service.CreateDeck("Verbs", "", "user-1", spanish.ID)
+	irregular, _ := service.CreateDeck("Irregular", "", "user-1", verbs.ID)
+
+	if err := service.DeleteDeck(verbs.ID, "user-1"); err != nil {
+		t.Fatalf("delete deck failed: %v", err)
+	}
+	kept, err := service.GetDeck(irregular.ID, "user-1")
+	if err != nil || kept.ParentID != spanish.ID {
+		t.Fatalf("expected subdeck moved up to spanish, got %+v (%v)", kept, err)
+	}
+}
@@ -0,0 +1,169 @@
+package deckapp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+// InviteTTL is how long an invite can be accepted after it was created.
+const InviteTTL = 7 * 24 * time.Hour
+
+// SharedDeck is a deck of another owner together with the role the member holds on it.
+type SharedDeck struct {
+	Deck deck.Deck
+	Role deck.Role
+}
+
+// Share grants userID the role on the deck, replacing any role the user held before. Only the owner may share.
+func (s *Service) Share(deckID, ownerID, userID string, role deck.Role) (deck.Member, error) {
+	d, err := s.authorized(deckID, ownerID, deck.RoleOwner)
+	if err != nil {
+		return deck.Member{}, err
+	}
+	if s.members == nil {
+		return deck.Member{}, deck.ErrForbidden
+	}
+	return s.addMember(d, userID, role)
+}
+
+// Members returns the users the deck is shared with. Only the owner may list them.
+func (s *Service) Members(deckID, ownerID string) ([]deck.Member, error) {
+	if _, err := s.authorized(deckID, ownerID, deck.RoleOwner); err != nil {
+		return nil, err
+	}
+	if s.members == nil {
+		return nil, nil
+	}
+	return s.members.FindByDeck(deckID)
+}
+
+// Revoke removes userID from the deck's members. The owner may revoke anyone; members may only leave.
+func (s *Service) Revoke(deckID, actorID, userID string) error {
+	required := deck.RoleOwner
+	if actorID == userID {
+		required = deck.RoleViewer
+	}
+	if _, err := s.authorized(deckID, actorID, required); err != nil {
+		return err
+	}
+	if s.members == nil {
+		return deck.ErrMemberNotFound
+	}
+	return s.members.Delete(deckID, userID)
+}
+
+// SharedDecks returns the decks other owners shared with userID.
+func (s *Service) SharedDecks(userID string) ([]SharedDeck, error) {
+	if s.members == nil {
+		return nil, nil
+	}
+	memberships, err := s.members.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]SharedDeck, 0, len(memberships))
+	for _, m := range memberships {
+		d, err := s.decks.FindByID(m.DeckID)
+		if err == deck.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		shared = append(shared, SharedDeck{Deck: d, Role: m.Role})
+	}
+	return shared, nil
+}
+
+// Invite creates a single-use token that grants the role on the deck to whoever accepts it
+// within InviteTTL. Only the owner may invite.
+func (s *Service) Invite(deckID, ownerID string, role deck.Role) (deck.Invite, error) {
+	if _, err := s.authorized(deckID, ownerID, deck.RoleOwner); err != nil {
+		return deck.Invite{}, err
+	}
+	if s.invites == nil || s.members == nil {
+		return deck.Invite{}, deck.ErrForbidden
+	}
+	if !role.Valid() {
+		return deck.Invite{}, deck.ErrInvalidRole
+	}
+
+	token, err := newInviteToken()
+	if err != nil {
+		return deck.Invite{}, err
+	}
+	now := s.now()
+	return s.invites.Save(deck.Invite{
+		Token:     token,
+		DeckID:    deckID,
+		Role:      role,
+		CreatedBy: ownerID,
+		CreatedAt: now,
+		ExpiresAt: now.Add(InviteTTL),
+	})
+}
+
+// AcceptInvite makes userID a member of the invite's deck and uses the invite up.
+// A member who already holds a higher role keeps it.
+func (s *Service) AcceptInvite(token, userID string) (deck.Deck, deck.Member, error) {
+	if s.invites == nil || s.members == nil {
+		return deck.Deck{}, deck.Member{}, deck.ErrInviteNotFound
+	}
+	invite, err := s.invites.FindByToken(token)
+	if err != nil {
+		return deck.Deck{}, deck.Member{}, err
+	}
+	if invite.Expired(s.now()) {
+		_ = s.invites.Delete(token)
+		return deck.Deck{}, deck.Member{}, deck.ErrInviteExpired
+	}
+	d, err := s.decks.FindByID(invite.DeckID)
+	if err != nil {
+		return deck.Deck{}, deck.Member{}, err
+	}
+
+	members, err := s.members.FindByDeck(d.ID)
+	if err != nil {
+		return deck.Deck{}, deck.Member{}, err
+	}
+	role := invite.Role
+	if current := d.RoleOf(userID, members); current.Permits(role) && current != deck.RoleOwner {
+		role = current
+	}
+	member, err := s.addMember(d, userID, role)
+	if err != nil {
+		return deck.Deck{}, deck.Member{}, err
+	}
+	if err := s.invites.Delete(token); err != nil && err != deck.ErrInviteNotFound {
+		return deck.Deck{}, deck.Member{}, err
+	}
+	return d, member, nil
+}
+
+func (s *Service) addMember(d deck.Deck, userID string, role deck.Role) (deck.Member, error) {
+	if userID == d.OwnerID {
+		return deck.Member{}, deck.ErrOwnerMember
+	}
+	m := deck.Member{
+		DeckID:    d.ID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: s.now(),
+	}
+	if err := m.Validate(); err != nil {
+		return deck.Member{}, err
+	}
+	return s.members.Save(m)
+}
+
+func newInviteToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,119 @@
+package deckapp
+
+import (
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/deck"
+)
+
+func TestSharedDeckRoles(t *testing.T) {
+	service, cards := newDeckService()
+
+	d, err := service.CreateDeck("German", "", "teacher", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	if _, err := service.Share(d.ID, "teacher", "viewer", deck.RoleViewer); err != nil {
+		t.Fatalf("share with viewer failed: %v", err)
+	}
+	if _, err := service.Share(d.ID, "teacher", "editor", deck.RoleEditor); err != nil {
+		t.Fatalf("share with editor failed: %v", err)
+	}
+
+	// Viewers read the deck but cannot change it.
+	if _, _, err := service.Export(d.ID, "viewer"); err != nil {
+		t.Fatalf("viewer export failed: %v", err)
+	}
+	if _, err := service.UpdateDeck(d.ID, "viewer", "Renamed", ""); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden for viewer update, got %v", err)
+	}
+	if _, err := cards.CreateCardInDeck("Hund", "dog", "viewer", d.ID); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden for viewer card, got %v", err)
+	}
+
+	// Editors add cards, which belong to the deck owner, and rename the deck.
+	created, err := cards.CreateCardInDeck("Hund", "dog", "editor", d.ID)
+	if err != nil {
+		t.Fatalf("editor card failed: %v", err)
+	}
+	if created.OwnerID != "teacher" {
+		t.Fatalf("expected the card to belong to the deck owner, got %q", created.OwnerID)
+	}
+	if _, err := service.UpdateDeck(d.ID, "editor", "German A1", ""); err != nil {
+		t.Fatalf("editor update failed: %v", err)
+	}
+
+	// Only the owner moves, shares and deletes.
+	if _, err := service.Share(d.ID, "editor", "someone", deck.RoleViewer); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden for editor share, got %v", err)
+	}
+	if err := service.DeleteDeck(d.ID, "editor"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden for editor delete, got %v", err)
+	}
+	if _, err := service.GetDeck(d.ID, "stranger"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner for a stranger, got %v", err)
+	}
+	if _, err := service.Share(d.ID, "teacher", "teacher", deck.RoleViewer); err != deck.ErrOwnerMember {
+		t.Fatalf("expected ErrOwnerMember, got %v", err)
+	}
+	if _, err := service.Share(d.ID, "teacher", "someone", deck.RoleOwner); err != deck.ErrInvalidRole {
+		t.Fatalf("expected ErrInvalidRole, got %v", err)
+	}
+
+	shared, err := service.SharedDecks("editor")
+	if err != nil || len(shared) != 1 || shared[0].Role != deck.RoleEditor || shared[0].Deck.Name != "German A1" {
+		t.Fatalf("unexpected shared decks: %+v (%v)", shared, err)
+	}
+
+	// Members may leave; the owner may revoke anyone.
+	if err := service.Revoke(d.ID, "viewer", "editor"); err != deck.ErrForbidden {
+		t.Fatalf("expected ErrForbidden when a viewer revokes another member, got %v", err)
+	}
+	if err := service.Revoke(d.ID, "viewer", "viewer"); err != nil {
+		t.Fatalf("viewer leaving failed: %v", err)
+	}
+	if err := service.Revoke(d.ID, "teacher", "editor"); err != nil {
+		t.Fatalf("owner revoke failed: %v", err)
+	}
+	if _, err := service.GetDeck(d.ID, "editor"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected a revoked editor to lose access, got %v", err)
+	}
+}
+
+func TestDeckInvites(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	service, _ := newDeckService(WithClock(func() time.Time { return now }))
+
+	d, _ := service.CreateDeck("German", "", "teacher", "")
+	if _, err := service.Invite(d.ID, "student", deck.RoleViewer); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner for a stranger's invite, got %v", err)
+	}
+
+	invite, err := service.Invite(d.ID, "teacher", deck.RoleEditor)
+	if err != nil {
+		t.Fatalf("invite failed: %v", err)
+	}
+	accepted, member, err := service.AcceptInvite(invite.Token, "student")
+	if err != nil {
+		t.Fatalf("accept failed: %v", err)
+	}
+	if accepted.ID != d.ID || member.UserID != "student" || member.Role != deck.RoleEditor {
+		t.Fatalf("unexpected membership: %+v", member)
+	}
+	if _, _, err := service.AcceptInvite(invite.Token, "another"); err != deck.ErrInviteNotFound {
+		t.Fatalf("expected the invite to be used up, got %v", err)
+	}
+
+	// A lower-role invite does not downgrade an existing member.
+	viewerInvite, _ := service.Invite(d.ID, "teacher", deck.RoleViewer)
+	if _, member, _ := service.AcceptInvite(viewerInvite.Token, "student"); member.Role != deck.RoleEditor {
+		t.Fatalf("expected the editor role to be kept, got %q", member.Role)
+	}
+
+	expiring, _ := service.Invite(d.ID, "teacher", deck.RoleViewer)
+	now = now.Add(InviteTTL)
+	if _, _, err := service.AcceptInvite(expiring.Token, "late"); err != deck.ErrInviteExpired {
+		t.Fatalf("expected ErrInviteExpired, got %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package filterapp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/filter"
+)
+
+const dateLayout = "2006-01-02"
+
+// Query is a parsed filter query. Terms are separated by spaces and all must hold:
+//
+//	tag:grammar -tag:hard     cards carrying grammar but not hard
+//	deck:"German::A1"         cards in the deck (by id or path) or its subdecks
+//	is:due -is:suspended      review states: due, new, suspended, leech
+//	added:2024-05-01 added:7d cards created on or after the date, or within the last 7 days
+//	hund                      words that must appear in the card content
+//
+// Double quotes keep spaces inside a term.
+type Query struct {
+	Deck       string
+	Tags       card.TagFilter
+	Words      []string
+	States     []card.State
+	NotStates  []card.State
+	AddedAfter time.Time
+}
+
+// Parse reads a filter query, resolving relative dates against now.
+func Parse(text string, now time.Time) (Query, error) {
+	terms, err := splitTerms(text)
+	if err != nil {
+		return Query{}, err
+	}
+	if len(terms) == 0 {
+		return Query{}, filter.ErrEmptyQuery
+	}
+
+	var q Query
+	for _, term := range terms {
+		if err := q.add(term, now); err != nil {
+			return Query{}, err
+		}
+	}
+	return q, nil
+}
+
+func (q *Query) add(term string, now time.Time) error {
+	raw := term
+	negated := strings.HasPrefix(term, "-") && len(term) > 1
+	if negated {
+		term = term[1:]
+	}
+
+	key, value, ok := strings.Cut(term, ":")
+	if !ok || key == "" || strings.IndexFunc(key, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 {
+		if negated {
+			return fmt.Errorf("%w: words cannot be excluded: %q", filter.ErrInvalidValue, raw)
+		}
+		q.Words = append(q.Words, card.Tokenize(term)...)
+		return nil
+	}
+
+	switch strings.ToLower(key) {
+	case "tag":
+		tag, err := card.NormalizeTag(value)
+		if err != nil {
+			return fmt.Errorf("%w: %q", filter.ErrInvalidValue, raw)
+		}
+		if negated {
+			q.Tags.Exclude = append(q.Tags.Exclude, tag)
+		} else {
+			q.Tags.Include = append(q.Tags.Include, tag)
+		}
+	case "deck":
+		value = strings.TrimSpace(value)
+		if negated || value == "" || q.Deck != "" {
+			return fmt.Errorf("%w: only one deck can be selected: %q", filter.ErrInvalidValue, raw)
+		}
+		q.Deck = value
+	case "is":
+		state := card.State(strings.ToLower(value))
+		if !state.Valid() {
+			return fmt.Errorf("%w: %q", filter.ErrInvalidValue, raw)
+		}
+		if negated {
+			q.NotStates = append(q.NotStates, state)
+		} else {
+			q.States = append(q.States, state)
+		}
+	case "added":
+		if negated {
+			return fmt.Errorf("%w: %q", filter.ErrInvalidValue, raw)
+		}
+		after, err := parseAdded(value, now)
+		if err != nil {
+			return fmt.Errorf("%w: %q", filter.ErrInvalidValue, raw)
+		}
+		if after.After(q.AddedAfter) {
+			q.AddedAfter = after
+		}
+	default:
+		return fmt.Errorf("%w: %q", filter.ErrUnknownField, key)
+	}
+	return nil
+}
+
+// parseAdded reads either a date or a number of days back from now.
+func parseAdded(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return time.Time{}, filter.ErrInvalidValue
+		}
+		return now.AddDate(0, 0, -n), nil
+	}
+	return time.ParseInLocation(dateLayout, value, now.Location())
+}
+
+// splitTerms splits the query on spaces outside double quotes and drops the quotes.
+func splitTerms(text string) ([]string, error) {
+	var (
+		terms   []string
+		current strings.Builder
+		quoted  bool
+	)
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if current.Len() > 0 {
+				terms = append(terms, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if quoted {
+		return nil, filter.ErrUnclosedQuote
+	}
+	if current.Len() > 0 {
+		terms = append(terms, current.String())
+	}
+	return terms, nil
+}
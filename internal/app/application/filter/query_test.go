@@ -0,0 +1,64 @@
+package filterapp
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/filter"
+)
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	q, err := Parse(`tag:Grammar -tag:hard deck:"German::A1" is:due -is:suspended added:7d Hund`, now)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+
+	want := Query{
+		Deck:       "German::A1",
+		Tags:       card.TagFilter{Include: []string{"grammar"}, Exclude: []string{"hard"}},
+		Words:      []string{"hund"},
+		States:     []card.State{card.StateDue},
+		NotStates:  []card.State{card.StateSuspended},
+		AddedAfter: now.AddDate(0, 0, -7),
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Fatalf("unexpected query:\n got %+v\nwant %+v", q, want)
+	}
+}
+
+func TestParseQueryDate(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	q, err := Parse("added:2024-05-01", now)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if !q.AddedAfter.Equal(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %v", q.AddedAfter)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	now := time.Now().UTC()
+
+	cases := map[string]error{
+		"   ":                  filter.ErrEmptyQuery,
+		"color:red":            filter.ErrUnknownField,
+		"is:forgotten":         filter.ErrInvalidValue,
+		"added:yesterday":      filter.ErrInvalidValue,
+		"-hund":                filter.ErrInvalidValue,
+		"deck:German deck:Art": filter.ErrInvalidValue,
+		`deck:"German`:         filter.ErrUnclosedQuote,
+		"tag:":                 filter.ErrInvalidValue,
+	}
+	for query, want := range cases {
+		if _, err := Parse(query, now); !errors.Is(err, want) {
+			t.Errorf("Parse(%q): expected %v, got %v", query, want, err)
+		}
+	}
+}
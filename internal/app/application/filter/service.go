@@ -0,0 +1,182 @@
+package filterapp
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/filter"
+	"flash2fy/internal/app/ports"
+)
+
+// Service orchestrates filtered deck use-cases. A filtered deck only stores its query;
+// the matching cards are looked up every time, so cards never move in or out of it.
+type Service struct {
+	filters ports.FilterRepository
+	cards   ports.CardRepository
+	decks   ports.DeckRepository
+	now     func() time.Time
+}
+
+// Option customises optional Service collaborators.
+type Option func(*Service)
+
+// WithDecks lets queries select cards by deck.
+func WithDecks(decks ports.DeckRepository) Option {
+	return func(s *Service) {
+		s.decks = decks
+	}
+}
+
+// WithClock overrides the time source, mainly for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(filters ports.FilterRepository, cards ports.CardRepository, opts ...Option) *Service {
+	s := &Service{
+		filters: filters,
+		cards:   cards,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateFilter saves a named query of the owner after checking that it parses.
+func (s *Service) CreateFilter(name, query, ownerID string) (filter.Filter, error) {
+	now := s.now()
+	f := filter.Filter{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Query:     strings.TrimSpace(query),
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := f.Validate(); err != nil {
+		return filter.Filter{}, err
+	}
+	if _, err := s.criteria(ownerID, f.Query); err != nil {
+		return filter.Filter{}, err
+	}
+
+	return s.filters.Save(f)
+}
+
+func (s *Service) GetFilter(id string) (filter.Filter, error) {
+	return s.filters.FindByID(id)
+}
+
+// ListFilters returns the owner's filtered decks.
+func (s *Service) ListFilters(ownerID string) ([]filter.Filter, error) {
+	return s.filters.FindByOwner(ownerID)
+}
+
+func (s *Service) UpdateFilter(id, name, query string) (filter.Filter, error) {
+	existing, err := s.filters.FindByID(id)
+	if err != nil {
+		return filter.Filter{}, err
+	}
+
+	existing.Name = name
+	existing.Query = strings.TrimSpace(query)
+	existing.UpdatedAt = s.now()
+
+	if err := existing.Validate(); err != nil {
+		return filter.Filter{}, err
+	}
+	if _, err := s.criteria(existing.OwnerID, existing.Query); err != nil {
+		return filter.Filter{}, err
+	}
+
+	return s.filters.Update(existing)
+}
+
+func (s *Service) DeleteFilter(id string) error {
+	return s.filters.Delete(id)
+}
+
+// Cards returns the cards currently matching the filtered deck.
+func (s *Service) Cards(id string) ([]card.Card, error) {
+	f, err := s.filters.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.Preview(f.OwnerID, f.Query)
+}
+
+// FilteredCards returns the cards matching the filtered deck after checking that it belongs to ownerID.
+func (s *Service) FilteredCards(ownerID, filterID string) ([]card.Card, error) {
+	f, err := s.filters.FindByID(filterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Accepts(ownerID); err != nil {
+		return nil, err
+	}
+	return s.Preview(ownerID, f.Query)
+}
+
+// Preview returns the owner's cards matching a query without saving it.
+func (s *Service) Preview(ownerID, query string) ([]card.Card, error) {
+	criteria, err := s.criteria(ownerID, query)
+	if err != nil {
+		return nil, err
+	}
+	return s.cards.FindMatching(criteria)
+}
+
+// criteria parses the query and resolves its deck, by id or path, to the ids of its subtree.
+func (s *Service) criteria(ownerID, query string) (card.Criteria, error) {
+	now := s.now()
+	q, err := Parse(query, now)
+	if err != nil {
+		return card.Criteria{}, err
+	}
+
+	criteria := card.Criteria{
+		OwnerID:    ownerID,
+		Tags:       q.Tags,
+		Words:      q.Words,
+		States:     q.States,
+		NotStates:  q.NotStates,
+		AddedAfter: q.AddedAfter,
+		Now:        now,
+	}
+	if q.Deck != "" {
+		if s.decks == nil {
+			return card.Criteria{}, deck.ErrNotFound
+		}
+		decks, err := s.decks.FindByOwner(ownerID)
+		if err != nil {
+			return card.Criteria{}, err
+		}
+		tree := deck.NewTree(decks)
+		id, ok := findDeck(tree, decks, q.Deck)
+		if !ok {
+			return card.Criteria{}, deck.ErrNotFound
+		}
+		criteria.DeckIDs = tree.Subtree(id)
+	}
+	return criteria, nil
+}
+
+func findDeck(tree deck.Tree, decks []deck.Deck, ref string) (string, bool) {
+	if _, ok := tree.Deck(ref); ok {
+		return ref, true
+	}
+	for _, d := range decks {
+		if strings.EqualFold(tree.Path(d.ID), ref) {
+			return d.ID, true
+		}
+	}
+	return "", false
+}
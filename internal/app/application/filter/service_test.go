@@ -0,0 +1,128 @@
+package filterapp
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	filterstorage "flash2fy/internal/adapters/storage/filter"
+	cardapp "flash2fy/internal/app/application/card"
+	deckapp "flash2fy/internal/app/application/deck"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/filter"
+)
+
+type fixture struct {
+	filters *Service
+	cards   *cardapp.Service
+	decks   *deckapp.Service
+}
+
+func newFixture() fixture {
+	cards := cardstorage.NewMemoryRepository()
+	decks := deckstorage.NewMemoryRepository()
+	return fixture{
+		filters: NewService(filterstorage.NewMemoryRepository(), cards, WithDecks(decks)),
+		cards:   cardapp.NewService(cards, cardapp.WithDecks(decks)),
+		decks:   deckapp.NewService(decks, cards),
+	}
+}
+
+func ids(cards []card.Card) map[string]bool {
+	set := make(map[string]bool, len(cards))
+	for _, c := range cards {
+		set[c.ID] = true
+	}
+	return set
+}
+
+func TestFilterCardsByDeckPathAndTags(t *testing.T) {
+	f := newFixture()
+
+	german, _ := f.decks.CreateDeck("German", "", "user-1", "")
+	a1, _ := f.decks.CreateDeck("A1", "", "user-1", german.ID)
+	hund, _ := f.cards.CreateCardInDeck("der Hund", "dog", "user-1", a1.ID, "noun")
+	f.cards.CreateCardInDeck("laufen", "to run", "user-1", a1.ID, "verb")
+	f.cards.CreateCard("die Katze", "cat", "user-1", "noun")
+	f.cards.CreateCardInDeck("der Baum", "tree", "user-2", "", "noun")
+
+	saved, err := f.filters.CreateFilter("Nouns", `deck:"german::a1" tag:noun`, "user-1")
+	if err != nil {
+		t.Fatalf("create filter failed: %v", err)
+	}
+
+	cards, err := f.filters.Cards(saved.ID)
+	if err != nil {
+		t.Fatalf("evaluate filter failed: %v", err)
+	}
+	if len(cards) != 1 || cards[0].ID != hund.ID {
+		t.Fatalf("expected only the A1 noun, got %+v", cards)
+	}
+
+	// The deck is resolved again on every use, so new subdecks are picked up.
+	a2, _ := f.decks.CreateDeck("A2", "", "user-1", german.ID)
+	tisch, _ := f.cards.CreateCardInDeck("der Tisch", "table", "user-1", a2.ID, "noun")
+	if _, err := f.filters.UpdateFilter(saved.ID, "Nouns", "deck:German tag:noun"); err != nil {
+		t.Fatalf("update filter failed: %v", err)
+	}
+	cards, _ = f.filters.Cards(saved.ID)
+	if got := ids(cards); len(got) != 2 || !got[hund.ID] || !got[tisch.ID] {
+		t.Fatalf("expected the nouns of the whole German subtree, got %+v", cards)
+	}
+}
+
+func TestFilterCardsByStateWordsAndDate(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	cards := cardstorage.NewMemoryRepository()
+	filters := NewService(filterstorage.NewMemoryRepository(), cards, WithClock(clock))
+	cardService := cardapp.NewService(cards, cardapp.WithClock(clock))
+
+	if _, err := cardService.CreateCard("der Hund", "dog", "user-1"); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	now = now.AddDate(0, 0, 10)
+	recent, _ := cardService.CreateCard("der Hund bellt", "the dog barks", "user-1")
+	suspended, _ := cardService.CreateCard("der Hund schläft", "the dog sleeps", "user-1")
+	if _, err := cardService.SuspendCard(suspended.ID, "user-1"); err != nil {
+		t.Fatalf("suspend failed: %v", err)
+	}
+
+	matched, err := filters.Preview("user-1", "hund added:3d -is:suspended is:new")
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if len(matched) != 1 || matched[0].ID != recent.ID {
+		t.Fatalf("expected only the recent card, got %+v", matched)
+	}
+}
+
+func TestFilterOwnership(t *testing.T) {
+	f := newFixture()
+
+	saved, err := f.filters.CreateFilter("Due", "is:due", "user-1")
+	if err != nil {
+		t.Fatalf("create filter failed: %v", err)
+	}
+	if _, err := f.filters.FilteredCards("user-2", saved.ID); err != filter.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner, got %v", err)
+	}
+
+	if _, err := f.filters.CreateFilter("Missing", "deck:Spanish", "user-1"); err != deck.ErrNotFound {
+		t.Fatalf("expected deck.ErrNotFound, got %v", err)
+	}
+	if _, err := f.filters.CreateFilter("Broken", "is:forgotten", "user-1"); !errors.Is(err, filter.ErrInvalidValue) {
+		t.Fatalf("expected ErrInvalidValue, got %v", err)
+	}
+
+	if err := f.filters.DeleteFilter(saved.ID); err != nil {
+		t.Fatalf("delete filter failed: %v", err)
+	}
+	if _, err := f.filters.GetFilter(saved.ID); err != filter.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package formulaapp
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"sync"
+
+	"flash2fy/internal/app/domain/formula"
+	"flash2fy/internal/app/domain/media"
+	"flash2fy/internal/app/ports"
+)
+
+// CachePrefix starts the cache key of every rendering.
+const CachePrefix = "formula-"
+
+// DefaultCacheLimit is the number of bytes of renderings kept in the cache unless WithCacheLimit says otherwise.
+const DefaultCacheLimit = 64 << 20
+
+// Service renders formulas of card content to images. Renderings are cached under the hash of
+// the formula, so a formula shared by many cards or reviewed again is only drawn once. The cache
+// is bounded: once it outgrows its limit, the renderings used least recently are deleted.
+type Service struct {
+	renderer ports.FormulaRenderer
+	cache    ports.MediaStorage
+	limit    int64
+
+	mu      sync.Mutex
+	order   *list.List // cached renderings, most recently used first
+	entries map[string]*list.Element
+	size    int64
+}
+
+// entry is a rendering kept in the cache.
+type entry struct {
+	key  string
+	size int64
+}
+
+// Option customises optional Service settings.
+type Option func(*Service)
+
+// WithCacheLimit bounds the bytes of renderings kept in the cache.
+func WithCacheLimit(bytes int64) Option {
+	return func(s *Service) {
+		if bytes > 0 {
+			s.limit = bytes
+		}
+	}
+}
+
+func NewService(renderer ports.FormulaRenderer, cache ports.MediaStorage, opts ...Option) *Service {
+	s := &Service{
+		renderer: renderer,
+		cache:    cache,
+		limit:    DefaultCacheLimit,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Image returns the formula drawn as a PNG image.
+func (s *Service) Image(f formula.Formula) ([]byte, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
+
+	key := CachePrefix + f.Key() + ".png"
+	cached, err := s.cache.Open(key)
+	switch err {
+	case nil:
+		defer cached.Close()
+		image, err := io.ReadAll(cached)
+		if err != nil {
+			return nil, fmt.Errorf("read cached formula: %w", err)
+		}
+		return image, s.remember(key, int64(len(image)))
+	case media.ErrNotFound:
+	default:
+		return nil, fmt.Errorf("open cached formula: %w", err)
+	}
+
+	image, err := s.renderer.Render(f)
+	if err != nil {
+		return nil, fmt.Errorf("render formula: %w", err)
+	}
+	n, err := s.cache.Put(key, bytes.NewReader(image))
+	if err != nil {
+		return nil, fmt.Errorf("cache formula: %w", err)
+	}
+	return image, s.remember(key, n)
+}
+
+// remember marks the rendering under key as the most recently used and evicts the least recently
+// used ones while the cache is over its limit. The rendering just used is never evicted.
+func (s *Service) remember(key string, size int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[key]; ok {
+		s.size -= el.Value.(*entry).size
+		el.Value.(*entry).size = size
+		s.order.MoveToFront(el)
+	} else {
+		s.entries[key] = s.order.PushFront(&entry{key: key, size: size})
+	}
+	s.size += size
+
+	for s.size > s.limit && s.order.Len() > 1 {
+		oldest := s.order.Back()
+		e := oldest.Value.(*entry)
+		if err := s.cache.Delete(e.key); err != nil && err != media.ErrNotFound {
+			return fmt.Errorf("evict cached formula: %w", err)
+		}
+		s.order.Remove(oldest)
+		delete(s.entries, e.key)
+		s.size -= e.size
+	}
+	return nil
+}
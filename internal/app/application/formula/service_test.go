@@ -0,0 +1,102 @@
+package formulaapp
+
+import (
+	"errors"
+	"testing"
+
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	"flash2fy/internal/app/domain/formula"
+	"flash2fy/internal/app/domain/media"
+)
+
+type countingRenderer struct {
+	calls int
+	err   error
+}
+
+func (r *countingRenderer) Render(f formula.Formula) ([]byte, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return []byte("png:" + f.Source), nil
+}
+
+func TestImageIsCachedByFormula(t *testing.T) {
+	renderer := &countingRenderer{}
+	service := NewService(renderer, mediastorage.NewMemoryStorage())
+
+	first, err := service.Image(formula.Formula{Source: `x^2`})
+	if err != nil {
+		t.Fatalf("image failed: %v", err)
+	}
+	second, err := service.Image(formula.Formula{Source: `x^2`})
+	if err != nil {
+		t.Fatalf("cached image failed: %v", err)
+	}
+	if string(first) != "png:x^2" || string(second) != string(first) {
+		t.Fatalf("unexpected images %q and %q", first, second)
+	}
+	if renderer.calls != 1 {
+		t.Fatalf("expected one rendering, got %d", renderer.calls)
+	}
+
+	if _, err := service.Image(formula.Formula{Source: `x^2`, Display: true}); err != nil {
+		t.Fatalf("display image failed: %v", err)
+	}
+	if renderer.calls != 2 {
+		t.Fatalf("expected display formulas to be rendered separately, got %d renderings", renderer.calls)
+	}
+}
+
+func TestImageRejectsInvalidFormula(t *testing.T) {
+	renderer := &countingRenderer{}
+	service := NewService(renderer, mediastorage.NewMemoryStorage())
+
+	if _, err := service.Image(formula.Formula{Source: `\frac{1}`}); err != formula.ErrMissingArgument {
+		t.Fatalf("expected ErrMissingArgument, got %v", err)
+	}
+	if renderer.calls != 0 {
+		t.Fatalf("expected invalid formulas not to be rendered")
+	}
+}
+
+func TestImageDoesNotCacheFailures(t *testing.T) {
+	renderer := &countingRenderer{err: errors.New("boom")}
+	service := NewService(renderer, mediastorage.NewMemoryStorage())
+
+	if _, err := service.Image(formula.Formula{Source: `y`}); err == nil {
+		t.Fatalf("expected the renderer error")
+	}
+	renderer.err = nil
+	if image, err := service.Image(formula.Formula{Source: `y`}); err != nil || string(image) != "png:y" {
+		t.Fatalf("expected a fresh rendering, got %q, %v", image, err)
+	}
+}
+
+func TestImageCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	renderer := &countingRenderer{}
+	cache := mediastorage.NewMemoryStorage()
+	service := NewService(renderer, cache, WithCacheLimit(12))
+
+	// Each rendering is "png:" and a one-letter source, 5 bytes, so two fit in the cache.
+	service.Image(formula.Formula{Source: `a`})
+	service.Image(formula.Formula{Source: `b`})
+	service.Image(formula.Formula{Source: `a`})
+	service.Image(formula.Formula{Source: `c`})
+	if renderer.calls != 3 {
+		t.Fatalf("expected three renderings, got %d", renderer.calls)
+	}
+
+	service.Image(formula.Formula{Source: `a`})
+	if renderer.calls != 3 {
+		t.Fatalf("expected the recently used formula kept, got %d renderings", renderer.calls)
+	}
+	service.Image(formula.Formula{Source: `b`})
+	if renderer.calls != 4 {
+		t.Fatalf("expected the least recently used formula evicted, got %d renderings", renderer.calls)
+	}
+	if _, err := cache.Open("formula-" + formula.Formula{Source: `c`}.Key() + ".png"); err != media.ErrNotFound {
+		t.Fatalf("expected the evicted rendering deleted from storage, got %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package noteapp
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/note"
+	"flash2fy/internal/app/domain/notetype"
+	"flash2fy/internal/app/ports"
+)
+
+// WithNoteTypes lets users define note types with their own fields and templates and write
+// notes with them.
+func WithNoteTypes(noteTypes ports.NoteTypeRepository) Option {
+	return func(s *Service) {
+		s.noteTypes = noteTypes
+	}
+}
+
+// CreateNoteType stores a note type after checking its fields and that its templates render.
+func (s *Service) CreateNoteType(name, ownerID string, fields []notetype.Field, frontTemplate, backTemplate string) (notetype.NoteType, error) {
+	if s.noteTypes == nil {
+		return notetype.NoteType{}, notetype.ErrNotFound
+	}
+
+	now := s.now()
+	t := notetype.NoteType{
+		ID:            uuid.NewString(),
+		OwnerID:       ownerID,
+		Name:          strings.TrimSpace(name),
+		Fields:        fields,
+		FrontTemplate: frontTemplate,
+		BackTemplate:  backTemplate,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := t.Validate(); err != nil {
+		return notetype.NoteType{}, err
+	}
+	if err := checkTemplates(t); err != nil {
+		return notetype.NoteType{}, err
+	}
+	return s.noteTypes.Save(t)
+}
+
+// GetNoteType returns a note type by ID.
+func (s *Service) GetNoteType(id string) (notetype.NoteType, error) {
+	if s.noteTypes == nil {
+		return notetype.NoteType{}, notetype.ErrNotFound
+	}
+	return s.noteTypes.FindByID(id)
+}
+
+// ListNoteTypes returns the owner's note types.
+func (s *Service) ListNoteTypes(ownerID string) ([]notetype.NoteType, error) {
+	if s.noteTypes == nil {
+		return nil, nil
+	}
+	return s.noteTypes.FindByOwner(ownerID)
+}
+
+// UpdateNoteType edits a note type and re-renders the notes written with it, whose cards keep
+// their review state. Nothing changes when any of those notes would no longer be valid, such
+// as one lacking a field that became required.
+func (s *Service) UpdateNoteType(id, name string, fields []notetype.Field, frontTemplate, backTemplate string) (notetype.NoteType, error) {
+	existing, err := s.GetNoteType(id)
+	if err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	existing.Name = strings.TrimSpace(name)
+	existing.Fields = fields
+	existing.FrontTemplate = frontTemplate
+	existing.BackTemplate = backTemplate
+	existing.UpdatedAt = s.now()
+	if err := existing.Validate(); err != nil {
+		return notetype.NoteType{}, err
+	}
+	if err := checkTemplates(existing); err != nil {
+		return notetype.NoteType{}, err
+	}
+
+	notes, err := s.notes.FindByNoteType(id)
+	if err != nil {
+		return notetype.NoteType{}, err
+	}
+	rendered := make([]note.Note, 0, len(notes))
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f.Name] = true
+	}
+	for _, n := range notes {
+		// Values of fields the type no longer has are dropped.
+		values := make(map[string]string, len(n.Fields))
+		for name, value := range n.Fields {
+			if known[name] {
+				values[name] = value
+			}
+		}
+		n, err := applyNoteType(existing, n, values)
+		if err != nil {
+			return notetype.NoteType{}, err
+		}
+		rendered = append(rendered, n)
+	}
+
+	updated, err := s.noteTypes.Update(existing)
+	if err != nil {
+		return notetype.NoteType{}, err
+	}
+	for _, n := range rendered {
+		if _, _, err := s.regenerate(n); err != nil {
+			return notetype.NoteType{}, err
+		}
+	}
+	return updated, nil
+}
+
+// DeleteNoteType removes a note type no note is written with.
+func (s *Service) DeleteNoteType(id string) error {
+	if _, err := s.GetNoteType(id); err != nil {
+		return err
+	}
+	notes, err := s.notes.FindByNoteType(id)
+	if err != nil {
+		return err
+	}
+	if len(notes) > 0 {
+		return notetype.ErrInUse
+	}
+	return s.noteTypes.Delete(id)
+}
+
+// PreviewNoteType renders the templates of a note type, saved or not, with sample values so
+// they can be tried out while being written. Required fields may be left empty.
+func (s *Service) PreviewNoteType(t notetype.NoteType, values map[string]string) (string, string, error) {
+	// Drafts have no owner yet.
+	if err := t.Validate(); err != nil && err != notetype.ErrEmptyOwner {
+		return "", "", err
+	}
+	return renderNoteType(t, values)
+}
+
+// CreateTypedNote writes a note with a note type: the values fill its fields and its templates
+// render the front and back of a basic note, reversed too when reverse is set.
+// ownerID must own the note type and may place the note in a deck as with CreateNoteInDeck.
+func (s *Service) CreateTypedNote(noteTypeID string, values map[string]string, ownerID, deckID string, reverse bool) (note.Note, []card.Card, error) {
+	t, err := s.GetNoteType(noteTypeID)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	if err := t.Accepts(ownerID); err != nil {
+		return note.Note{}, nil, err
+	}
+	noteOwner, err := s.deckOwner(deckID, ownerID)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	n, err := applyNoteType(t, note.Note{OwnerID: noteOwner, NoteTypeID: t.ID, Reverse: reverse}, values)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	return s.create(n, deckID)
+}
+
+// UpdateNoteFields edits the field values of a note written with a note type and regenerates
+// its cards as UpdateNote does.
+func (s *Service) UpdateNoteFields(id string, values map[string]string, reverse bool) (note.Note, []card.Card, error) {
+	existing, err := s.notes.FindByID(id)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	if existing.NoteTypeID == "" {
+		return note.Note{}, nil, note.ErrUntypedNote
+	}
+	t, err := s.GetNoteType(existing.NoteTypeID)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	existing.Reverse = reverse
+	existing, err = applyNoteType(t, existing, values)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	return s.regenerate(existing)
+}
+
+// applyNoteType checks values against the note type and renders them into the note, which is
+// then checked to generate valid cards.
+func applyNoteType(t notetype.NoteType, n note.Note, values map[string]string) (note.Note, error) {
+	if err := t.CheckValues(values); err != nil {
+		return note.Note{}, err
+	}
+	front, back, err := renderNoteType(t, values)
+	if err != nil {
+		return note.Note{}, err
+	}
+
+	n.Type = card.TypeBasic
+	n.Fields = make(map[string]string, len(values))
+	for name, value := range values {
+		n.Fields[name] = value
+	}
+	n.Front, n.Back = front, back
+	if err := n.Validate(); err != nil {
+		return note.Note{}, err
+	}
+	return n, nil
+}
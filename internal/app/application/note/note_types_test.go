@@ -0,0 +1,244 @@
+package noteapp
+
+import (
+	"strings"
+	"testing"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	notestorage "flash2fy/internal/adapters/storage/note"
+	notetypestorage "flash2fy/internal/adapters/storage/notetype"
+	cardapp "flash2fy/internal/app/application/card"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/note"
+	"flash2fy/internal/app/domain/notetype"
+)
+
+var vocabularyFields = []notetype.Field{
+	{Name: "word", Required: true},
+	{Name: "meaning", Required: true},
+	{Name: "example"},
+}
+
+func newTypedNoteService() (*Service, *cardstorage.MemoryRepository) {
+	cards := cardstorage.NewMemoryRepository()
+	return NewService(notestorage.NewMemoryRepository(), cards,
+		WithNoteTypes(notetypestorage.NewMemoryRepository())), cards
+}
+
+func createVocabulary(t *testing.T, service *Service) notetype.NoteType {
+	t.Helper()
+	nt, err := service.CreateNoteType("Vocabulary", "user-1", vocabularyFields,
+		"{{.word}}", "{{.meaning}}{{if .example}}\n_{{.example}}_{{end}}")
+	if err != nil {
+		t.Fatalf("create note type failed: %v", err)
+	}
+	return nt
+}
+
+func TestCreateTypedNoteRendersTemplates(t *testing.T) {
+	service, _ := newTypedNoteService()
+	nt := createVocabulary(t, service)
+
+	n, cards, err := service.CreateTypedNote(nt.ID, map[string]string{
+		"word": "Hund", "meaning": "dog", "example": "Der Hund bellt.",
+	}, "user-1", "", true)
+	if err != nil {
+		t.Fatalf("create typed note failed: %v", err)
+	}
+	if n.NoteTypeID != nt.ID || n.Fields["word"] != "Hund" {
+		t.Fatalf("expected note to keep its type and fields, got %+v", n)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("expected forward and reverse cards, got %d", len(cards))
+	}
+	if cards[0].Front != "Hund" || cards[0].Back != "dog\n_Der Hund bellt._" {
+		t.Fatalf("unexpected forward card: %+v", cards[0])
+	}
+
+	_, cards, err = service.CreateTypedNote(nt.ID, map[string]string{"word": "Katze", "meaning": "cat"}, "user-1", "", false)
+	if err != nil {
+		t.Fatalf("create typed note without optional field failed: %v", err)
+	}
+	if cards[0].Back != "cat" {
+		t.Fatalf("expected optional example to be left out, got %q", cards[0].Back)
+	}
+}
+
+func TestCreateTypedNoteValidation(t *testing.T) {
+	service, cards := newTypedNoteService()
+	nt := createVocabulary(t, service)
+
+	tests := []struct {
+		noteTypeID string
+		values     map[string]string
+		ownerID    string
+		want       error
+	}{
+		{nt.ID, map[string]string{"word": "Hund"}, "user-1", notetype.ErrMissingField},
+		{nt.ID, map[string]string{"word": "Hund", "meaning": "  "}, "user-1", notetype.ErrMissingField},
+		{nt.ID, map[string]string{"word": "Hund", "meaning": "dog", "gender": "m"}, "user-1", notetype.ErrUnknownField},
+		{nt.ID, map[string]string{"word": "Hund", "meaning": "dog"}, "user-2", notetype.ErrForeignOwner},
+		{"missing", map[string]string{"word": "Hund", "meaning": "dog"}, "user-1", notetype.ErrNotFound},
+	}
+	for _, tc := range tests {
+		if _, _, err := service.CreateTypedNote(tc.noteTypeID, tc.values, tc.ownerID, "", false); err != tc.want {
+			t.Fatalf("%v: expected %v, got %v", tc.values, tc.want, err)
+		}
+	}
+	if stored, _ := cards.FindAll(); len(stored) != 0 {
+		t.Fatalf("expected no cards stored, got %d", len(stored))
+	}
+}
+
+func TestCreateNoteTypeValidation(t *testing.T) {
+	service, _ := newTypedNoteService()
+
+	tests := []struct {
+		name   string
+		fields []notetype.Field
+		front  string
+		back   string
+		want   error
+	}{
+		{"", vocabularyFields, "{{.word}}", "", notetype.ErrEmptyName},
+		{"Vocabulary", nil, "{{.word}}", "", notetype.ErrNoFields},
+		{"Vocabulary", []notetype.Field{{Name: "1word"}}, "x", "", notetype.ErrInvalidFieldName},
+		{"Vocabulary", []notetype.Field{{Name: "word"}, {Name: "word"}}, "{{.word}}", "", notetype.ErrDuplicateField},
+		{"Vocabulary", vocabularyFields, " ", "", notetype.ErrEmptyTemplate},
+		{"Vocabulary", vocabularyFields, "{{.word", "", notetype.ErrInvalidTemplate},
+		{"Vocabulary", vocabularyFields, "{{.gender}}", "", notetype.ErrInvalidTemplate},
+		{"Vocabulary", vocabularyFields, "{{range .word}}x{{end}}", "", notetype.ErrInvalidTemplate},
+		{"Vocabulary", vocabularyFields, `{{define "x"}}y{{end}}{{.word}}`, "", notetype.ErrInvalidTemplate},
+		{"Vocabulary", vocabularyFields, `{{printf "%0999999d" 1}}`, "", notetype.ErrInvalidTemplate},
+		{"Vocabulary", vocabularyFields, "{{.word}}", "{{call .meaning}}", notetype.ErrInvalidTemplate},
+		{"Vocabulary", vocabularyFields, "{{.word}}" + strings.Repeat("x", notetype.MaxRendered), "", notetype.ErrTemplateTooLong},
+	}
+	for _, tc := range tests {
+		if _, err := service.CreateNoteType(tc.name, "user-1", tc.fields, tc.front, tc.back); err != tc.want {
+			t.Fatalf("%q/%q: expected %v, got %v", tc.front, tc.back, tc.want, err)
+		}
+	}
+}
+
+func TestUpdateNoteTypeRerendersNotes(t *testing.T) {
+	cards := cardstorage.NewMemoryRepository()
+	service := NewService(notestorage.NewMemoryRepository(), cards,
+		WithNoteTypes(notetypestorage.NewMemoryRepository()))
+	grader := cardapp.NewService(cards)
+	nt := createVocabulary(t, service)
+
+	n, created, err := service.CreateTypedNote(nt.ID, map[string]string{
+		"word": "Hund", "meaning": "dog", "example": "Der Hund bellt.",
+	}, "user-1", "", false)
+	if err != nil {
+		t.Fatalf("create typed note failed: %v", err)
+	}
+	graded, err := grader.GradeCard(created[0].ID, card.RatingGood, 0, "user-1")
+	if err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+
+	fields := []notetype.Field{{Name: "word", Required: true}, {Name: "meaning", Required: true}}
+	if _, err := service.UpdateNoteType(nt.ID, "Vocabulary", fields, "Translate: {{.word}}", "{{.meaning}}"); err != nil {
+		t.Fatalf("update note type failed: %v", err)
+	}
+
+	updated, current, err := service.GetNote(n.ID)
+	if err != nil {
+		t.Fatalf("get note failed: %v", err)
+	}
+	if _, ok := updated.Fields["example"]; ok {
+		t.Fatalf("expected dropped field to be removed from the note, got %v", updated.Fields)
+	}
+	if len(current) != 1 || current[0].ID != created[0].ID {
+		t.Fatalf("expected the card to be kept, got %+v", current)
+	}
+	if current[0].Front != "Translate: Hund" || current[0].Back != "dog" {
+		t.Fatalf("expected card to be re-rendered, got %+v", current[0])
+	}
+	if current[0].Review.Repetitions != graded.Review.Repetitions || !current[0].Review.DueAt.Equal(graded.Review.DueAt) {
+		t.Fatalf("expected review state to be kept, got %+v", current[0])
+	}
+
+	fields = append(fields, notetype.Field{Name: "gender", Required: true})
+	if _, err := service.UpdateNoteType(nt.ID, "Vocabulary", fields, "{{.word}}", "{{.meaning}}"); err != notetype.ErrMissingField {
+		t.Fatalf("expected new required field to be rejected, got %v", err)
+	}
+	if unchanged, _ := service.GetNoteType(nt.ID); len(unchanged.Fields) != 2 {
+		t.Fatalf("expected note type to be left unchanged, got %+v", unchanged)
+	}
+}
+
+func TestUpdateNoteFields(t *testing.T) {
+	service, _ := newTypedNoteService()
+	nt := createVocabulary(t, service)
+
+	n, _, err := service.CreateTypedNote(nt.ID, map[string]string{"word": "Hund", "meaning": "dog"}, "user-1", "", false)
+	if err != nil {
+		t.Fatalf("create typed note failed: %v", err)
+	}
+	if _, _, err := service.UpdateNote(n.ID, "Hund", "dog", false); err != note.ErrTypedNote {
+		t.Fatalf("expected typed note to be edited through its fields, got %v", err)
+	}
+
+	_, cards, err := service.UpdateNoteFields(n.ID, map[string]string{"word": "Hund", "meaning": "hound"}, true)
+	if err != nil {
+		t.Fatalf("update note fields failed: %v", err)
+	}
+	if len(cards) != 2 || cards[0].Back != "hound" || cards[1].Front != "hound" {
+		t.Fatalf("expected re-rendered forward and reverse cards, got %+v", cards)
+	}
+
+	untyped, _, err := service.CreateNote(card.TypeBasic, "Katze", "cat", "user-1", false)
+	if err != nil {
+		t.Fatalf("create note failed: %v", err)
+	}
+	if _, _, err := service.UpdateNoteFields(untyped.ID, map[string]string{"word": "Katze"}, false); err != note.ErrUntypedNote {
+		t.Fatalf("expected untyped note to be rejected, got %v", err)
+	}
+}
+
+func TestDeleteNoteTypeInUse(t *testing.T) {
+	service, _ := newTypedNoteService()
+	nt := createVocabulary(t, service)
+
+	n, _, err := service.CreateTypedNote(nt.ID, map[string]string{"word": "Hund", "meaning": "dog"}, "user-1", "", false)
+	if err != nil {
+		t.Fatalf("create typed note failed: %v", err)
+	}
+	if err := service.DeleteNoteType(nt.ID); err != notetype.ErrInUse {
+		t.Fatalf("expected note type in use, got %v", err)
+	}
+	if err := service.DeleteNote(n.ID); err != nil {
+		t.Fatalf("delete note failed: %v", err)
+	}
+	if err := service.DeleteNoteType(nt.ID); err != nil {
+		t.Fatalf("delete note type failed: %v", err)
+	}
+	if _, err := service.GetNoteType(nt.ID); err != notetype.ErrNotFound {
+		t.Fatalf("expected note type to be gone, got %v", err)
+	}
+}
+
+func TestPreviewNoteType(t *testing.T) {
+	service, _ := newTypedNoteService()
+
+	draft := notetype.NoteType{
+		Name:          "Vocabulary",
+		Fields:        vocabularyFields,
+		FrontTemplate: "{{.word}}",
+		BackTemplate:  "{{.meaning}}{{if .example}} ({{.example}}){{end}}",
+	}
+	front, back, err := service.PreviewNoteType(draft, map[string]string{"word": "Hund"})
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if front != "Hund" || back != "" {
+		t.Fatalf("unexpected preview %q/%q", front, back)
+	}
+
+	draft.FrontTemplate = "{{template \"x\"}}"
+	if _, _, err := service.PreviewNoteType(draft, nil); err != notetype.ErrInvalidTemplate {
+		t.Fatalf("expected invalid template, got %v", err)
+	}
+}
@@ -0,0 +1,291 @@
+package noteapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/note"
+	"flash2fy/internal/app/ports"
+)
+
+// Service orchestrates note use-cases and keeps each note's generated cards in sync.
+type Service struct {
+	notes     ports.NoteRepository
+	cards     ports.CardRepository
+	decks     ports.DeckRepository
+	members   ports.DeckMemberRepository
+	noteTypes ports.NoteTypeRepository
+	purger    CardPurger
+	now       func() time.Time
+}
+
+// CardPurger removes generated cards for good together with their attachments, revisions and
+// whatever adapters keep about them.
+type CardPurger interface {
+	PurgeNoteCard(id string) error
+}
+
+// Option customises optional Service collaborators.
+type Option func(*Service)
+
+// WithDecks lets the cards of new notes be placed in decks stored in the given repository.
+func WithDecks(decks ports.DeckRepository) Option {
+	return func(s *Service) {
+		s.decks = decks
+	}
+}
+
+// WithDeckMembers lets the editors a deck is shared with add notes to it.
+func WithDeckMembers(members ports.DeckMemberRepository) Option {
+	return func(s *Service) {
+		s.members = members
+	}
+}
+
+// WithCardPurger removes the cards a note no longer generates through purger. Without it they are
+// only deleted from the card repository.
+func WithCardPurger(purger CardPurger) Option {
+	return func(s *Service) {
+		s.purger = purger
+	}
+}
+
+// WithClock overrides the time source, mainly for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(notes ports.NoteRepository, cards ports.CardRepository, opts ...Option) *Service {
+	s := &Service{
+		notes: notes,
+		cards: cards,
+		now:   func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CreateNote stores the note and the cards it generates, each with its own fresh schedule.
+func (s *Service) CreateNote(noteType card.Type, front, back, ownerID string, reverse bool) (note.Note, []card.Card, error) {
+	return s.CreateNoteInDeck(noteType, front, back, ownerID, "", reverse)
+}
+
+// CreateNoteInDeck is CreateNote placing the generated cards in a deck unless deckID is empty.
+// ownerID may also be an editor of a shared deck, in which case the note belongs to the deck owner.
+func (s *Service) CreateNoteInDeck(noteType card.Type, front, back, ownerID, deckID string, reverse bool) (note.Note, []card.Card, error) {
+	ownerID, err := s.deckOwner(deckID, ownerID)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	return s.create(note.Note{
+		OwnerID: ownerID,
+		Type:    noteType,
+		Front:   front,
+		Back:    back,
+		Reverse: reverse,
+	}, deckID)
+}
+
+// create stores a new note and the cards it generates in the deck.
+func (s *Service) create(n note.Note, deckID string) (note.Note, []card.Card, error) {
+	now := s.now()
+	n.ID = uuid.NewString()
+	n.CreatedAt = now
+	n.UpdatedAt = now
+	generated, err := n.Cards()
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	saved, err := s.notes.Save(n)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	cards := make([]card.Card, 0, len(generated))
+	for _, g := range generated {
+		g.DeckID = deckID
+		c, err := s.cards.Save(s.newCard(g, now))
+		if err != nil {
+			return note.Note{}, nil, err
+		}
+		cards = append(cards, c)
+	}
+	return saved, cards, nil
+}
+
+// GetNote returns the note together with its generated cards.
+func (s *Service) GetNote(id string) (note.Note, []card.Card, error) {
+	n, err := s.notes.FindByID(id)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	cards, err := s.cards.FindByNote(id)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	return n, cards, nil
+}
+
+// ListNotes returns the owner's notes.
+func (s *Service) ListNotes(ownerID string) ([]note.Note, error) {
+	return s.notes.FindByOwner(ownerID)
+}
+
+// UpdateNote edits the note and regenerates its cards. Cards that still exist keep
+// their review state; cards the note no longer generates, such as a dropped reverse
+// card or cloze deletion, are deleted, and new ones start fresh with the deck and tags of their siblings.
+// Notes written with a note type are edited through UpdateNoteFields instead.
+func (s *Service) UpdateNote(id, front, back string, reverse bool) (note.Note, []card.Card, error) {
+	existing, err := s.notes.FindByID(id)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	if existing.NoteTypeID != "" {
+		return note.Note{}, nil, note.ErrTypedNote
+	}
+
+	existing.Front = front
+	existing.Back = back
+	existing.Reverse = reverse
+	return s.regenerate(existing)
+}
+
+// regenerate saves the edited note and brings its cards in line with it, as UpdateNote describes.
+func (s *Service) regenerate(existing note.Note) (note.Note, []card.Card, error) {
+	now := s.now()
+	existing.UpdatedAt = now
+
+	generated, err := existing.Cards()
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+	current, err := s.cards.FindByNote(existing.ID)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	updated, err := s.notes.Update(existing)
+	if err != nil {
+		return note.Note{}, nil, err
+	}
+
+	cards := make([]card.Card, 0, len(generated))
+	kept := make(map[string]bool, len(current))
+	for _, g := range generated {
+		match, ok := findSlot(current, g)
+		if !ok {
+			if len(current) > 0 {
+				g.DeckID = current[0].DeckID
+				g.Tags = current[0].Tags
+			}
+			c, err := s.cards.Save(s.newCard(g, now))
+			if err != nil {
+				return note.Note{}, nil, err
+			}
+			cards = append(cards, c)
+			continue
+		}
+
+		kept[match.ID] = true
+		match.Front, match.Back = g.Front, g.Back
+		match.UpdatedAt = now
+		c, err := s.cards.Update(match)
+		if err != nil {
+			return note.Note{}, nil, err
+		}
+		cards = append(cards, c)
+	}
+
+	for _, c := range current {
+		if kept[c.ID] {
+			continue
+		}
+		if err := s.removeCard(c.ID); err != nil {
+			return note.Note{}, nil, err
+		}
+	}
+
+	return updated, cards, nil
+}
+
+// DeleteNote removes the note and every card generated from it.
+func (s *Service) DeleteNote(id string) error {
+	cards, err := s.cards.FindByNote(id)
+	if err != nil {
+		return err
+	}
+	if err := s.notes.Delete(id); err != nil {
+		return err
+	}
+
+	for _, c := range cards {
+		if err := s.removeCard(c.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeCard deletes a generated card for good; cards already gone are skipped.
+func (s *Service) removeCard(id string) error {
+	remove := s.cards.Delete
+	if s.purger != nil {
+		remove = s.purger.PurgeNoteCard
+	}
+	if err := remove(id); err != nil && err != card.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+func (s *Service) newCard(generated card.Card, now time.Time) card.Card {
+	generated.ID = uuid.NewString()
+	generated.CreatedAt = now
+	generated.UpdatedAt = now
+	generated.Review = card.NewReviewState(now)
+	return generated
+}
+
+// deckOwner returns who owns the notes actorID creates in the deck: actorID outside any deck,
+// and the deck owner once actorID is known to own or edit the deck.
+func (s *Service) deckOwner(deckID, actorID string) (string, error) {
+	if deckID == "" {
+		return actorID, nil
+	}
+	if s.decks == nil {
+		return "", deck.ErrNotFound
+	}
+	d, err := s.decks.FindByID(deckID)
+	if err != nil {
+		return "", err
+	}
+	var members []deck.Member
+	if s.members != nil && d.OwnerID != actorID {
+		if members, err = s.members.FindByDeck(deckID); err != nil {
+			return "", err
+		}
+	}
+	if err := d.Authorize(actorID, members, deck.RoleEditor); err != nil {
+		return "", err
+	}
+	return d.OwnerID, nil
+}
+
+func findSlot(cards []card.Card, generated card.Card) (card.Card, bool) {
+	for _, c := range cards {
+		if c.SameSlot(generated) {
+			return c, true
+		}
+	}
+	return card.Card{}, false
+}
@@ -0,0 +1,190 @@
+package noteapp
+
+import (
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	notestorage "flash2fy/internal/adapters/storage/note"
+	cardapp "flash2fy/internal/app/application/card"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/note"
+)
+
+func newNoteService() (*Service, *cardstorage.MemoryRepository) {
+	cards := cardstorage.NewMemoryRepository()
+	return NewService(notestorage.NewMemoryRepository(), cards), cards
+}
+
+func TestCreateNoteWithReverseCard(t *testing.T) {
+	service, _ := newNoteService()
+
+	n, cards, err := service.CreateNote(card.TypeBasic, "Hund", "dog", "user-1", true)
+	if err != nil {
+		t.Fatalf("create note failed: %v", err)
+	}
+	if len(cards) != 2 {
+		t.Fatalf("expected forward and reverse cards, got %d", len(cards))
+	}
+	if cards[0].Front != "Hund" || cards[0].Back != "dog" || cards[0].Reversed {
+		t.Fatalf("unexpected forward card: %+v", cards[0])
+	}
+	if cards[1].Front != "dog" || cards[1].Back != "Hund" || !cards[1].Reversed {
+		t.Fatalf("unexpected reverse card: %+v", cards[1])
+	}
+	for _, c := range cards {
+		if c.NoteID != n.ID || c.OwnerID != "user-1" {
+			t.Fatalf("expected card linked to note and owner, got %+v", c)
+		}
+	}
+}
+
+func TestCreateNoteValidation(t *testing.T) {
+	service, cards := newNoteService()
+
+	tests := []struct {
+		noteType card.Type
+		front    string
+		back     string
+		reverse  bool
+		want     error
+	}{
+		{card.TypeBasic, "", "back", false, card.ErrEmptyFront},
+		{card.TypeBasic, "front", "", true, note.ErrEmptyBack},
+		{card.TypeCloze, "{{c1::x}}", "", true, note.ErrReverseCloze},
+		{card.TypeCloze, "no deletions", "", false, card.ErrClozeMissing},
+		{"image", "front", "", false, card.ErrUnknownType},
+	}
+	for _, tc := range tests {
+		if _, _, err := service.CreateNote(tc.noteType, tc.front, tc.back, "user-1", tc.reverse); err != tc.want {
+			t.Fatalf("%q/%q: expected %v, got %v", tc.front, tc.back, tc.want, err)
+		}
+	}
+	if stored, _ := cards.FindAll(); len(stored) != 0 {
+		t.Fatalf("expected no cards stored, got %d", len(stored))
+	}
+}
+
+func TestUpdateNoteKeepsSchedulingPerCard(t *testing.T) {
+	cards := cardstorage.NewMemoryRepository()
+	service := NewService(notestorage.NewMemoryRepository(), cards)
+	grader := cardapp.NewService(cards)
+
+	n, created, err := service.CreateNote(card.TypeBasic, "Hund", "dog", "user-1", true)
+	if err != nil {
+		t.Fatalf("create note failed: %v", err)
+	}
+	graded, err := grader.GradeCard(created[0].ID, card.RatingGood, 0, "user-1")
+	if err != nil {
+		t.Fatalf("grade failed: %v", err)
+	}
+
+	_, updated, err := service.UpdateNote(n.ID, "der Hund", "the dog", true)
+	if err != nil {
+		t.Fatalf("update note failed: %v", err)
+	}
+	if len(updated) != 2 || updated[0].ID != created[0].ID || updated[1].ID != created[1].ID {
+		t.Fatalf("expected the same two cards, got %+v", updated)
+	}
+	if updated[0].Front != "der Hund" || updated[1].Front != "the dog" {
+		t.Fatalf("expected both cards to follow the note, got %q and %q", updated[0].Front, updated[1].Front)
+	}
+	if updated[0].Review.Repetitions != graded.Review.Repetitions || updated[1].Review.Repetitions != 0 {
+		t.Fatalf("expected independent scheduling to survive the edit, got %+v and %+v", updated[0].Review, updated[1].Review)
+	}
+
+	if _, err := grader.UpdateCard(updated[0].ID, "x", "y", "user-1"); err != card.ErrManagedByNote {
+		t.Fatalf("expected ErrManagedByNote when editing a generated card, got %v", err)
+	}
+
+	_, withoutReverse, err := service.UpdateNote(n.ID, "der Hund", "the dog", false)
+	if err != nil {
+		t.Fatalf("update note failed: %v", err)
+	}
+	if len(withoutReverse) != 1 {
+		t.Fatalf("expected reverse card dropped, got %d cards", len(withoutReverse))
+	}
+	if _, err := cards.FindByID(created[1].ID); err != card.ErrNotFound {
+		t.Fatalf("expected reverse card deleted, got %v", err)
+	}
+}
+
+func TestUpdateClozeNoteSyncsDeletions(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cards := cardstorage.NewMemoryRepository()
+	service := NewService(notestorage.NewMemoryRepository(), cards, WithClock(func() time.Time { return now }))
+
+	n, created, err := service.CreateNote(card.TypeCloze, "{{c1::Paris}} is in {{c2::France}}", "", "user-1", false)
+	if err != nil {
+		t.Fatalf("create note failed: %v", err)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected 2 cloze cards, got %d", len(created))
+	}
+
+	now = now.Add(time.Hour)
+	_, updated, err := service.UpdateNote(n.ID, "{{c1::Paris}} is the capital of {{c3::France}}", "", false)
+	if err != nil {
+		t.Fatalf("update note failed: %v", err)
+	}
+	if len(updated) != 2 || updated[0].ID != created[0].ID || updated[1].ClozeIndex != 3 {
+		t.Fatalf("expected c1 kept and c3 added, got %+v", updated)
+	}
+	if _, err := cards.FindByID(created[1].ID); err != card.ErrNotFound {
+		t.Fatalf("expected c2 card deleted, got %v", err)
+	}
+}
+
+func TestDeleteNoteRemovesCards(t *testing.T) {
+	service, cards := newNoteService()
+
+	n, _, err := service.CreateNote(card.TypeBasic, "Hund", "dog", "user-1", true)
+	if err != nil {
+		t.Fatalf("create note failed: %v", err)
+	}
+	if err := service.DeleteNote(n.ID); err != nil {
+		t.Fatalf("delete note failed: %v", err)
+	}
+	if stored, _ := cards.FindAll(); len(stored) != 0 {
+		t.Fatalf("expected generated cards deleted, got %d", len(stored))
+	}
+	if _, _, err := service.GetNote(n.ID); err != note.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// purgeRecorder records the cards the card service purges.
+type purgeRecorder []string
+
+func (r *purgeRecorder) CardPurged(cardID string) error {
+	*r = append(*r, cardID)
+	return nil
+}
+
+func TestRemovedCardsArePurgedThroughCardService(t *testing.T) {
+	cards := cardstorage.NewMemoryRepository()
+	purged := &purgeRecorder{}
+	service := NewService(notestorage.NewMemoryRepository(), cards,
+		WithCardPurger(cardapp.NewService(cards, cardapp.WithPurgeHook(purged))))
+
+	n, created, err := service.CreateNote(card.TypeBasic, "Hund", "dog", "user-1", true)
+	if err != nil {
+		t.Fatalf("create note failed: %v", err)
+	}
+	if _, _, err := service.UpdateNote(n.ID, "Hund", "dog", false); err != nil {
+		t.Fatalf("update note failed: %v", err)
+	}
+	if len(*purged) != 1 || (*purged)[0] != created[1].ID {
+		t.Fatalf("expected the reverse card purged, got %v", *purged)
+	}
+
+	if err := service.DeleteNote(n.ID); err != nil {
+		t.Fatalf("delete note failed: %v", err)
+	}
+	if len(*purged) != 2 || (*purged)[1] != created[0].ID {
+		t.Fatalf("expected the forward card purged with the note, got %v", *purged)
+	}
+	if trash, _ := cards.FindTrashed("user-1"); len(trash) != 0 {
+		t.Fatalf("expected note cards to skip the trash, got %+v", trash)
+	}
+}
@@ -0,0 +1,134 @@
+package noteapp
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"flash2fy/internal/app/domain/notetype"
+)
+
+// templateFuncs are the only functions templates may call. Functions such as printf, whose
+// output can grow far beyond the template, are left out.
+var templateFuncs = map[string]bool{
+	"and": true, "or": true, "not": true, "eq": true, "ne": true, "len": true, "index": true,
+}
+
+// errRenderedTooLong stops the execution of a template whose output exceeds notetype.MaxRendered.
+var errRenderedTooLong = errors.New("rendered template too long")
+
+// renderNoteType renders the front and back templates of the note type with the field values.
+// Every field of the type is available as {{.name}}, empty when not filled in.
+func renderNoteType(t notetype.NoteType, values map[string]string) (string, string, error) {
+	data := t.Values(values)
+	front, err := renderTemplate(t, "front", t.FrontTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	back, err := renderTemplate(t, "back", t.BackTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	return front, back, nil
+}
+
+// checkTemplates ensures the templates of the note type parse, only use the allowed actions and
+// render within bounds when every field is filled in.
+func checkTemplates(t notetype.NoteType) error {
+	sample := make(map[string]string, len(t.Fields))
+	for _, f := range t.Fields {
+		sample[f.Name] = f.Name
+	}
+	_, _, err := renderNoteType(t, sample)
+	return err
+}
+
+func renderTemplate(t notetype.NoteType, name, text string, data map[string]string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil || len(tmpl.Templates()) > 1 {
+		return "", notetype.ErrInvalidTemplate
+	}
+	fields := make(map[string]bool, len(t.Fields))
+	for _, f := range t.Fields {
+		fields[f.Name] = true
+	}
+	if !allowedNode(tmpl.Tree.Root, fields) {
+		return "", notetype.ErrInvalidTemplate
+	}
+
+	out := &limitedWriter{max: notetype.MaxRendered}
+	if err := tmpl.Execute(out, data); err != nil {
+		if errors.Is(err, errRenderedTooLong) {
+			return "", notetype.ErrTemplateTooLong
+		}
+		return "", notetype.ErrInvalidTemplate
+	}
+	return out.String(), nil
+}
+
+// allowedNode reports whether a template only prints, compares and tests the fields of its note
+// type: range, template and block, and functions outside templateFuncs are rejected.
+func allowedNode(node parse.Node, fields map[string]bool) bool {
+	switch n := node.(type) {
+	case nil:
+		return true
+	case *parse.ListNode:
+		if n == nil {
+			return true
+		}
+		for _, child := range n.Nodes {
+			if !allowedNode(child, fields) {
+				return false
+			}
+		}
+		return true
+	case *parse.TextNode, *parse.CommentNode, *parse.DotNode, *parse.StringNode,
+		*parse.NumberNode, *parse.BoolNode, *parse.NilNode, *parse.VariableNode:
+		return true
+	case *parse.ActionNode:
+		return allowedNode(n.Pipe, fields)
+	case *parse.IfNode:
+		return allowedNode(n.Pipe, fields) && allowedNode(n.List, fields) && allowedNode(n.ElseList, fields)
+	case *parse.WithNode:
+		return allowedNode(n.Pipe, fields) && allowedNode(n.List, fields) && allowedNode(n.ElseList, fields)
+	case *parse.PipeNode:
+		if n == nil {
+			return true
+		}
+		for _, cmd := range n.Cmds {
+			if !allowedNode(cmd, fields) {
+				return false
+			}
+		}
+		return true
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if !allowedNode(arg, fields) {
+				return false
+			}
+		}
+		return true
+	case *parse.IdentifierNode:
+		return templateFuncs[n.Ident]
+	case *parse.FieldNode:
+		return len(n.Ident) == 1 && fields[n.Ident[0]]
+	}
+	return false
+}
+
+// limitedWriter collects output up to max bytes and fails beyond.
+type limitedWriter struct {
+	strings.Builder
+	max int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.Len()+len(p) > w.max {
+		return 0, errRenderedTooLong
+	}
+	return w.Builder.Write(p)
+}
@@ -0,0 +1,370 @@
+package studyapp
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/filter"
+	"flash2fy/internal/app/domain/study"
+	"flash2fy/internal/app/domain/user"
+	"flash2fy/internal/app/ports"
+)
+
+// DefaultLimits apply to users that did not configure their own daily limits.
+var DefaultLimits = study.Limits{NewCards: 20, Reviews: 200}
+
+// requeueGap is how many cards a failed card waits before it is shown again in the same session.
+const requeueGap = 3
+
+// CardGrader captures the card use-case that reschedules a card after an answer.
+type CardGrader interface {
+	GradeCard(id string, rating card.Rating, answerTime time.Duration, actorID string) (card.Card, error)
+}
+
+// FilterEvaluator captures the filtered deck use-case that returns the cards matching a saved query.
+type FilterEvaluator interface {
+	FilteredCards(ownerID, filterID string) ([]card.Card, error)
+}
+
+// Service orchestrates study sessions: it queues due reviews first, then new cards,
+// within each user's daily limits, and re-queues failed cards as learning steps.
+type Service struct {
+	cards    ports.CardRepository
+	grader   CardGrader
+	sessions ports.SessionRepository
+	users    ports.UserRepository
+	decks    ports.DeckRepository
+	members  ports.DeckMemberRepository
+	filters  FilterEvaluator
+	limits   study.Limits
+	now      func() time.Time
+}
+
+// Option customises optional Service settings.
+type Option func(*Service)
+
+// WithDefaultLimits overrides DefaultLimits for users without their own limits.
+func WithDefaultLimits(limits study.Limits) Option {
+	return func(s *Service) {
+		s.limits = limits
+	}
+}
+
+// WithDecks lets sessions be limited to the cards of one deck.
+func WithDecks(decks ports.DeckRepository) Option {
+	return func(s *Service) {
+		s.decks = decks
+	}
+}
+
+// WithDeckMembers lets users study the decks shared with them.
+func WithDeckMembers(members ports.DeckMemberRepository) Option {
+	return func(s *Service) {
+		s.members = members
+	}
+}
+
+// WithFilters lets sessions be limited to the cards of a filtered deck.
+func WithFilters(filters FilterEvaluator) Option {
+	return func(s *Service) {
+		s.filters = filters
+	}
+}
+
+// WithClock overrides the time source, mainly for deterministic tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(cards ports.CardRepository, grader CardGrader, sessions ports.SessionRepository, users ports.UserRepository, opts ...Option) *Service {
+	s := &Service{
+		cards:    cards,
+		grader:   grader,
+		sessions: sessions,
+		users:    users,
+		limits:   DefaultLimits,
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start opens a session for the user with the cards still allowed today.
+func (s *Service) Start(userID string) (study.Session, error) {
+	return s.start(study.Session{UserID: userID}, userID, nil)
+}
+
+// StartDeck opens a session over the due cards of a deck the user may view and of those of
+// its subdecks the user may view too; sharing a deck does not share its subdecks.
+// The daily limits are shared with every other session of the day.
+func (s *Service) StartDeck(userID, deckID string) (study.Session, error) {
+	if s.decks == nil {
+		return study.Session{}, deck.ErrNotFound
+	}
+	d, err := s.decks.FindByID(deckID)
+	if err != nil {
+		return study.Session{}, err
+	}
+	if err := s.authorize(d, userID); err != nil {
+		return study.Session{}, err
+	}
+	decks, err := s.decks.FindByOwner(d.OwnerID)
+	if err != nil {
+		return study.Session{}, err
+	}
+
+	tree := deck.NewTree(decks)
+	subtree := make(map[string]bool)
+	for _, id := range tree.Subtree(deckID) {
+		sub, ok := tree.Deck(id)
+		if !ok {
+			continue
+		}
+		if err := s.authorize(sub, userID); err == nil {
+			subtree[id] = true
+		} else if err != deck.ErrForeignOwner {
+			return study.Session{}, err
+		}
+	}
+	return s.start(study.Session{UserID: userID, DeckID: deckID}, d.OwnerID, func(c card.Card) bool {
+		return subtree[c.DeckID]
+	})
+}
+
+// authorize checks that the user may view the deck, as its owner or one of its members.
+func (s *Service) authorize(d deck.Deck, userID string) error {
+	var members []deck.Member
+	if s.members != nil && d.OwnerID != userID {
+		var err error
+		if members, err = s.members.FindByDeck(d.ID); err != nil {
+			return err
+		}
+	}
+	return d.Authorize(userID, members, deck.RoleViewer)
+}
+
+// StartFilter opens a session over the due cards matching one of the user's filtered decks.
+// The cards stay in their own decks; the query is evaluated once, when the session starts.
+func (s *Service) StartFilter(userID, filterID string) (study.Session, error) {
+	if s.filters == nil {
+		return study.Session{}, filter.ErrNotFound
+	}
+	matched, err := s.filters.FilteredCards(userID, filterID)
+	if err != nil {
+		return study.Session{}, err
+	}
+
+	ids := make(map[string]bool, len(matched))
+	for _, c := range matched {
+		ids[c.ID] = true
+	}
+	return s.start(study.Session{UserID: userID, FilterID: filterID}, userID, func(c card.Card) bool {
+		return ids[c.ID]
+	})
+}
+
+// start opens the session with the due cards of ownerID accepted by include, or all of them when include is nil.
+func (s *Service) start(session study.Session, ownerID string, include func(card.Card) bool) (study.Session, error) {
+	now := s.now()
+
+	remaining, err := s.remainingToday(session.UserID, now)
+	if err != nil {
+		return study.Session{}, err
+	}
+
+	due, err := s.cards.FindDue(ownerID, now)
+	if err != nil {
+		return study.Session{}, err
+	}
+
+	var reviews, fresh []study.Item
+	for _, c := range due {
+		if include != nil && !include(c) {
+			continue
+		}
+		item := study.Item{CardID: c.ID, New: c.Review.IsNew()}
+		switch {
+		case item.New && len(fresh) < remaining.NewCards:
+			fresh = append(fresh, item)
+		case !item.New && len(reviews) < remaining.Reviews:
+			reviews = append(reviews, item)
+		}
+	}
+
+	session.ID = uuid.NewString()
+	session.Queue = append(reviews, fresh...)
+	session.StartedAt = now
+	if err := session.Validate(); err != nil {
+		return study.Session{}, err
+	}
+
+	return s.sessions.Save(session)
+}
+
+// Get returns the session with the given id.
+func (s *Service) Get(sessionID string) (study.Session, error) {
+	return s.sessions.FindByID(sessionID)
+}
+
+// Next returns the card the user should answer next and starts timing the answer.
+// Cards suspended, buried or deleted since the session started are dropped from the queue.
+func (s *Service) Next(sessionID string) (card.Card, error) {
+	session, err := s.sessions.FindByID(sessionID)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	now := s.now()
+	changed := false
+	for {
+		current, err := session.Current()
+		if err != nil {
+			if changed {
+				if _, err := s.sessions.Update(session); err != nil {
+					return card.Card{}, err
+				}
+			}
+			return card.Card{}, err
+		}
+
+		c, err := s.cards.FindByID(current.CardID)
+		if err != nil && err != card.ErrNotFound {
+			return card.Card{}, err
+		}
+		if err == card.ErrNotFound || !c.Available(now) {
+			session.Queue = session.Queue[1:]
+			session.ShownAt = time.Time{}
+			changed = true
+			continue
+		}
+
+		if session.ShownAt.IsZero() {
+			session.ShownAt = now
+			changed = true
+		}
+		if changed {
+			if _, err := s.sessions.Update(session); err != nil {
+				return card.Card{}, err
+			}
+		}
+		return c, nil
+	}
+}
+
+// Answer grades the current card of the session. Cards graded Again are re-queued
+// a few cards later so the user repeats them before the session ends, unless they were suspended as leeches.
+func (s *Service) Answer(sessionID, cardID string, rating card.Rating) (card.Card, error) {
+	if !rating.Valid() {
+		return card.Card{}, card.ErrInvalidRating
+	}
+
+	session, err := s.sessions.FindByID(sessionID)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	current, err := session.Current()
+	if err != nil {
+		return card.Card{}, err
+	}
+	if current.CardID != cardID {
+		return card.Card{}, study.ErrNotCurrentCard
+	}
+
+	var answerTime time.Duration
+	if !session.ShownAt.IsZero() {
+		answerTime = s.now().Sub(session.ShownAt)
+	}
+
+	graded, err := s.grader.GradeCard(cardID, rating, answerTime, session.UserID)
+	if err != nil {
+		return card.Card{}, err
+	}
+
+	if current.Answers == 0 {
+		if current.New {
+			session.NewStudied++
+		} else {
+			session.Reviewed++
+		}
+	}
+	current.Answers++
+
+	session.Queue = session.Queue[1:]
+	session.ShownAt = time.Time{}
+	if rating == card.RatingAgain && graded.Available(s.now()) {
+		at := requeueGap
+		if at > len(session.Queue) {
+			at = len(session.Queue)
+		}
+		session.Queue = append(session.Queue[:at], append([]study.Item{current}, session.Queue[at:]...)...)
+		session.Requeued++
+	}
+
+	if _, err := s.sessions.Update(session); err != nil {
+		return card.Card{}, err
+	}
+
+	return graded, nil
+}
+
+// Finish closes the session and returns its final summary.
+func (s *Service) Finish(sessionID string) (study.Session, error) {
+	session, err := s.sessions.FindByID(sessionID)
+	if err != nil {
+		return study.Session{}, err
+	}
+	if session.Finished() {
+		return study.Session{}, study.ErrSessionFinished
+	}
+
+	session.FinishedAt = s.now()
+	return s.sessions.Update(session)
+}
+
+// remainingToday returns how many new cards and reviews the user may still study on the day of now.
+func (s *Service) remainingToday(userID string, now time.Time) (study.Limits, error) {
+	limits, err := s.limitsFor(userID)
+	if err != nil {
+		return study.Limits{}, err
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today, err := s.sessions.FindByUserSince(userID, dayStart)
+	if err != nil {
+		return study.Limits{}, err
+	}
+	for _, session := range today {
+		limits.NewCards -= session.NewStudied
+		limits.Reviews -= session.Reviewed
+	}
+
+	return limits, nil
+}
+
+func (s *Service) limitsFor(userID string) (study.Limits, error) {
+	limits := s.limits
+
+	u, err := s.users.FindByID(userID)
+	if err == user.ErrNotFound {
+		return limits, nil
+	}
+	if err != nil {
+		return study.Limits{}, err
+	}
+
+	if u.DailyNewLimit > 0 {
+		limits.NewCards = u.DailyNewLimit
+	}
+	if u.DailyReviewLimit > 0 {
+		limits.Reviews = u.DailyReviewLimit
+	}
+	return limits, nil
+}
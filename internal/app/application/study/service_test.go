@@ -0,0 +1,418 @@
+package studyapp
+
+import (
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	filterstorage "flash2fy/internal/adapters/storage/filter"
+	reviewstorage "flash2fy/internal/adapters/storage/review"
+	studystorage "flash2fy/internal/adapters/storage/study"
+	userstorage "flash2fy/internal/adapters/storage/user"
+	cardapp "flash2fy/internal/app/application/card"
+	filterapp "flash2fy/internal/app/application/filter"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/deck"
+	"flash2fy/internal/app/domain/filter"
+	"flash2fy/internal/app/domain/study"
+	"flash2fy/internal/app/domain/user"
+)
+
+type studyTestDeps struct {
+	cards   *cardstorage.MemoryRepository
+	grader  *cardapp.Service
+	users   *userstorage.MemoryRepository
+	decks   *deckstorage.MemoryRepository
+	members *deckstorage.MemoryMemberRepository
+	filters *filterapp.Service
+	service *Service
+	clock   *time.Time
+}
+
+func newStudyTestDeps(limits study.Limits) studyTestDeps {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	clock := &now
+	nowFn := func() time.Time { return *clock }
+
+	cards := cardstorage.NewMemoryRepository()
+	users := userstorage.NewMemoryRepository()
+	decks := deckstorage.NewMemoryRepository()
+	members := deckstorage.NewMemoryMemberRepository()
+	grader := cardapp.NewService(cards,
+		cardapp.WithUsers(users),
+		cardapp.WithDecks(decks),
+		cardapp.WithDeckMembers(members),
+		cardapp.WithReviewLog(reviewstorage.NewMemoryRepository()),
+		cardapp.WithClock(nowFn),
+	)
+	filters := filterapp.NewService(filterstorage.NewMemoryRepository(), cards,
+		filterapp.WithDecks(decks),
+		filterapp.WithClock(nowFn),
+	)
+	service := NewService(cards, grader, studystorage.NewMemoryRepository(), users,
+		WithDefaultLimits(limits),
+		WithDecks(decks),
+		WithDeckMembers(members),
+		WithFilters(filters),
+		WithClock(nowFn),
+	)
+
+	return studyTestDeps{cards: cards, grader: grader, users: users, decks: decks, members: members, filters: filters, service: service, clock: clock}
+}
+
+func (d studyTestDeps) addCard(t *testing.T, id string, review card.ReviewState) {
+	t.Helper()
+	if _, err := d.cards.Save(card.Card{ID: id, Front: id, OwnerID: "user-1", Review: review}); err != nil {
+		t.Fatalf("save card %s: %v", id, err)
+	}
+}
+
+func (d studyTestDeps) addDeckCard(t *testing.T, id, deckID string, review card.ReviewState) {
+	t.Helper()
+	if _, err := d.cards.Save(card.Card{ID: id, Front: id, OwnerID: "user-1", DeckID: deckID, Review: review}); err != nil {
+		t.Fatalf("save card %s: %v", id, err)
+	}
+}
+
+func queueIDs(s study.Session) []string {
+	ids := make([]string, 0, len(s.Queue))
+	for _, item := range s.Queue {
+		ids = append(ids, item.CardID)
+	}
+	return ids
+}
+
+func TestStartQueuesReviewsBeforeNewCards(t *testing.T) {
+	deps := newStudyTestDeps(study.Limits{NewCards: 1, Reviews: 10})
+	now := *deps.clock
+
+	deps.addCard(t, "new-1", card.NewReviewState(now.Add(-2*time.Hour)))
+	deps.addCard(t, "new-2", card.NewReviewState(now.Add(-time.Hour)))
+	deps.addCard(t, "review-1", card.ReviewState{EaseFactor: 2.5, Interval: 3, DueAt: now.Add(-time.Minute), ReviewedAt: now.AddDate(0, 0, -3)})
+	deps.addCard(t, "future", card.ReviewState{EaseFactor: 2.5, Interval: 3, DueAt: now.Add(time.Hour), ReviewedAt: now})
+
+	session, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	got := queueIDs(session)
+	want := []string{"review-1", "new-1"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("expected queue %v, got %v", want, got)
+	}
+}
+
+func TestStartDeckQueuesDeckAndSubdeckCards(t *testing.T) {
+	deps := newStudyTestDeps(study.Limits{NewCards: 10, Reviews: 10})
+	now := *deps.clock
+
+	for _, d := range []deck.Deck{
+		{ID: "german", Name: "German", OwnerID: "user-1"},
+		{ID: "verbs", Name: "Verbs", OwnerID: "user-1", ParentID: "german"},
+		{ID: "foreign", Name: "French", OwnerID: "user-2"},
+	} {
+		if _, err := deps.decks.Save(d); err != nil {
+			t.Fatalf("save deck: %v", err)
+		}
+	}
+	deps.addDeckCard(t, "hund", "german", card.NewReviewState(now.Add(-2*time.Hour)))
+	deps.addDeckCard(t, "gehen", "verbs", card.NewReviewState(now.Add(-time.Hour)))
+	deps.addCard(t, "loose", card.NewReviewState(now.Add(-time.Hour)))
+
+	session, err := deps.service.StartDeck("user-1", "german")
+	if err != nil {
+		t.Fatalf("start deck failed: %v", err)
+	}
+	if got := queueIDs(session); len(got) != 2 || got[0] != "hund" || got[1] != "gehen" || session.DeckID != "german" {
+		t.Fatalf("expected the deck and subdeck cards queued, got %v (deck %q)", got, session.DeckID)
+	}
+
+	session, err = deps.service.StartDeck("user-1", "verbs")
+	if err != nil {
+		t.Fatalf("start subdeck failed: %v", err)
+	}
+	if got := queueIDs(session); len(got) != 1 || got[0] != "gehen" {
+		t.Fatalf("expected only the subdeck card queued, got %v", got)
+	}
+
+	if _, err := deps.service.StartDeck("user-1", "foreign"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner, got %v", err)
+	}
+	if _, err := deps.service.StartDeck("user-1", "missing"); err != deck.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStartDeckSharedWithViewer(t *testing.T) {
+	deps := newStudyTestDeps(study.Limits{NewCards: 10, Reviews: 10})
+	now := *deps.clock
+
+	for _, d := range []deck.Deck{
+		{ID: "german", Name: "German", OwnerID: "user-1"},
+		{ID: "verbs", Name: "Verbs", OwnerID: "user-1", ParentID: "german"},
+	} {
+		if _, err := deps.decks.Save(d); err != nil {
+			t.Fatalf("save deck: %v", err)
+		}
+	}
+	if _, err := deps.members.Save(deck.Member{DeckID: "german", UserID: "user-2", Role: deck.RoleViewer, CreatedAt: now}); err != nil {
+		t.Fatalf("share deck: %v", err)
+	}
+	deps.addDeckCard(t, "hund", "german", card.NewReviewState(now.Add(-2*time.Hour)))
+	deps.addDeckCard(t, "gehen", "verbs", card.NewReviewState(now.Add(-time.Hour)))
+
+	session, err := deps.service.StartDeck("user-2", "german")
+	if err != nil {
+		t.Fatalf("start shared deck failed: %v", err)
+	}
+	if got := queueIDs(session); len(got) != 1 || got[0] != "hund" || session.UserID != "user-2" {
+		t.Fatalf("expected only the shared deck's card queued for the viewer, got %v", got)
+	}
+	if _, err := deps.service.Answer(session.ID, "hund", card.RatingGood); err != nil {
+		t.Fatalf("expected the viewer to study the shared card, got %v", err)
+	}
+
+	if _, err := deps.service.StartDeck("user-2", "verbs"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected the unshared subdeck refused, got %v", err)
+	}
+	if _, err := deps.service.StartDeck("user-3", "german"); err != deck.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner for a stranger, got %v", err)
+	}
+}
+
+func TestStartFilterQueuesMatchingCards(t *testing.T) {
+	deps := newStudyTestDeps(study.Limits{NewCards: 10, Reviews: 10})
+	now := *deps.clock
+
+	if _, err := deps.decks.Save(deck.Deck{ID: "german", Name: "German", OwnerID: "user-1"}); err != nil {
+		t.Fatalf("save deck: %v", err)
+	}
+	deps.addDeckCard(t, "hund", "german", card.NewReviewState(now.Add(-time.Hour)))
+	deps.addCard(t, "katze", card.NewReviewState(now.Add(-time.Hour)))
+	if _, err := deps.grader.SetTags("katze", []string{"noun"}, "user-1"); err != nil {
+		t.Fatalf("tag card: %v", err)
+	}
+	if _, err := deps.grader.SetTags("hund", []string{"noun"}, "user-1"); err != nil {
+		t.Fatalf("tag card: %v", err)
+	}
+	deps.addCard(t, "laufen", card.NewReviewState(now.Add(-time.Hour)))
+
+	nouns, err := deps.filters.CreateFilter("Nouns", "tag:noun", "user-1")
+	if err != nil {
+		t.Fatalf("create filter: %v", err)
+	}
+
+	session, err := deps.service.StartFilter("user-1", nouns.ID)
+	if err != nil {
+		t.Fatalf("start filter failed: %v", err)
+	}
+	if got := queueIDs(session); len(got) != 2 || session.FilterID != nouns.ID {
+		t.Fatalf("expected both nouns queued, got %v (filter %q)", got, session.FilterID)
+	}
+	if c, _ := deps.cards.FindByID("hund"); c.DeckID != "german" {
+		t.Fatalf("expected the card to stay in its deck, got %q", c.DeckID)
+	}
+
+	if _, err := deps.service.StartFilter("user-2", nouns.ID); err != filter.ErrForeignOwner {
+		t.Fatalf("expected ErrForeignOwner, got %v", err)
+	}
+	if _, err := deps.service.StartFilter("user-1", "missing"); err != filter.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestAnswerRequeuesFailedCards(t *testing.T) {
+	deps := newStudyTestDeps(study.Limits{NewCards: 10, Reviews: 10})
+	now := *deps.clock
+	for i, id := range []string{"a", "b"} {
+		deps.addCard(t, id, card.NewReviewState(now.Add(time.Duration(i-10)*time.Minute)))
+	}
+
+	session, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	next, err := deps.service.Next(session.ID)
+	if err != nil || next.ID != "a" {
+		t.Fatalf("expected card a first, got %q (%v)", next.ID, err)
+	}
+	if _, err := deps.service.Answer(session.ID, "b", card.RatingGood); err != study.ErrNotCurrentCard {
+		t.Fatalf("expected ErrNotCurrentCard, got %v", err)
+	}
+
+	if _, err := deps.service.Answer(session.ID, "a", card.RatingAgain); err != nil {
+		t.Fatalf("answer failed: %v", err)
+	}
+	session, _ = deps.service.Get(session.ID)
+	if got := queueIDs(session); len(got) != 2 || got[0] != "b" || got[1] != "a" {
+		t.Fatalf("expected failed card re-queued after b, got %v", got)
+	}
+
+	for _, id := range []string{"b", "a"} {
+		if _, err := deps.service.Answer(session.ID, id, card.RatingGood); err != nil {
+			t.Fatalf("answer %s failed: %v", id, err)
+		}
+	}
+	if _, err := deps.service.Next(session.ID); err != study.ErrQueueEmpty {
+		t.Fatalf("expected ErrQueueEmpty, got %v", err)
+	}
+
+	finished, err := deps.service.Finish(session.ID)
+	if err != nil {
+		t.Fatalf("finish failed: %v", err)
+	}
+	if finished.NewStudied != 2 || finished.Reviewed != 0 || finished.Requeued != 1 {
+		t.Fatalf("unexpected session summary: %+v", finished)
+	}
+	if !finished.FinishedAt.Equal(now) {
+		t.Fatalf("expected finish time from clock, got %v", finished.FinishedAt)
+	}
+	if _, err := deps.service.Finish(session.ID); err != study.ErrSessionFinished {
+		t.Fatalf("expected ErrSessionFinished, got %v", err)
+	}
+	if _, err := deps.service.Next(session.ID); err != study.ErrSessionFinished {
+		t.Fatalf("expected ErrSessionFinished from next, got %v", err)
+	}
+}
+
+func TestStartRespectsDailyLimits(t *testing.T) {
+	deps := newStudyTestDeps(study.Limits{NewCards: 5, Reviews: 5})
+	now := *deps.clock
+	if _, err := deps.users.Save(user.User{ID: "user-1", Nickname: "learner", DailyNewLimit: 2}); err != nil {
+		t.Fatalf("save user: %v", err)
+	}
+	for i, id := range []string{"a", "b", "c"} {
+		deps.addCard(t, id, card.NewReviewState(now.Add(time.Duration(i-10)*time.Minute)))
+	}
+
+	first, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if len(first.Queue) != 2 {
+		t.Fatalf("expected user limit of 2 new cards, got %v", queueIDs(first))
+	}
+	if _, err := deps.service.Answer(first.ID, "a", card.RatingGood); err != nil {
+		t.Fatalf("answer failed: %v", err)
+	}
+
+	second, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("second start failed: %v", err)
+	}
+	if len(second.Queue) != 1 {
+		t.Fatalf("expected one new card left today, got %v", queueIDs(second))
+	}
+
+	*deps.clock = now.AddDate(0, 0, 1)
+	tomorrow, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("next day start failed: %v", err)
+	}
+	if got := queueIDs(tomorrow); len(got) != 3 || got[0] != "a" {
+		t.Fatalf("expected a's review followed by two new cards the next day, got %v", got)
+	}
+}
+
+func TestAnswerRecordsAnswerTime(t *testing.T) {
+	deps := newStudyTestDeps(DefaultLimits)
+	now := *deps.clock
+	deps.addCard(t, "a", card.NewReviewState(now))
+
+	session, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if _, err := deps.service.Next(session.ID); err != nil {
+		t.Fatalf("next failed: %v", err)
+	}
+
+	*deps.clock = now.Add(7 * time.Second)
+	if _, err := deps.service.Next(session.ID); err != nil {
+		t.Fatalf("repeated next failed: %v", err)
+	}
+	if _, err := deps.service.Answer(session.ID, "a", card.RatingGood); err != nil {
+		t.Fatalf("answer failed: %v", err)
+	}
+
+	logs, _, err := deps.grader.CardReviews("a", "user-1")
+	if err != nil {
+		t.Fatalf("card reviews failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0].AnswerTime != 7*time.Second {
+		t.Fatalf("expected answer time measured from first display, got %+v", logs)
+	}
+}
+
+func TestSessionNotFound(t *testing.T) {
+	deps := newStudyTestDeps(DefaultLimits)
+
+	if _, err := deps.service.Next("missing"); err != study.ErrSessionNotFound {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+	if _, err := deps.service.Start(""); err != study.ErrEmptyUser {
+		t.Fatalf("expected ErrEmptyUser, got %v", err)
+	}
+}
+
+func TestNextSkipsCardsSuspendedMidSession(t *testing.T) {
+	deps := newStudyTestDeps(DefaultLimits)
+	deps.addCard(t, "a", card.NewReviewState(*deps.clock))
+	deps.addCard(t, "b", card.NewReviewState(*deps.clock))
+
+	session, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if _, err := deps.grader.SuspendCard("a", "user-1"); err != nil {
+		t.Fatalf("suspend failed: %v", err)
+	}
+
+	next, err := deps.service.Next(session.ID)
+	if err != nil {
+		t.Fatalf("next failed: %v", err)
+	}
+	if next.ID != "b" {
+		t.Fatalf("expected suspended card skipped, got %s", next.ID)
+	}
+	stored, _ := deps.service.Get(session.ID)
+	if got := queueIDs(stored); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("expected queue [b], got %v", got)
+	}
+}
+
+func TestNextSkipsCardsDeletedMidSession(t *testing.T) {
+	deps := newStudyTestDeps(DefaultLimits)
+	deps.addCard(t, "a", card.NewReviewState(*deps.clock))
+	deps.addCard(t, "b", card.NewReviewState(*deps.clock))
+
+	session, err := deps.service.Start("user-1")
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if err := deps.grader.DeleteCard("a", "user-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	next, err := deps.service.Next(session.ID)
+	if err != nil {
+		t.Fatalf("next failed: %v", err)
+	}
+	if next.ID != "b" {
+		t.Fatalf("expected the card in the trash skipped, got %s", next.ID)
+	}
+	stored, _ := deps.service.Get(session.ID)
+	if got := queueIDs(stored); len(got) != 1 || got[0] != "b" {
+		t.Fatalf("expected queue [b], got %v", got)
+	}
+
+	if err := deps.grader.DeleteCard("b", "user-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := deps.service.Next(session.ID); err != study.ErrQueueEmpty {
+		t.Fatalf("expected an empty queue once every card is deleted, got %v", err)
+	}
+}
@@ -20,8 +20,9 @@ func NewService(repo coreports.UserRepository) *Service {
 
 func (s *Service) CreateUser(nickname string) (user.User, error) {
 	newUser := user.User{
-		ID:       uuid.NewString(),
-		Nickname: strings.TrimSpace(nickname),
+		ID:        uuid.NewString(),
+		Nickname:  strings.TrimSpace(nickname),
+		Scheduler: user.DefaultScheduler,
 	}
 	if err := newUser.Validate(); err != nil {
 		return user.User{}, err
@@ -52,6 +53,37 @@ func (s *Service) UpdateUser(id, nickname string) (user.User, error) {
 	return s.repo.Update(existing)
 }
 
+// SetScheduler switches the spaced-repetition algorithm used for the user's reviews.
+func (s *Service) SetScheduler(id string, scheduler user.Scheduler) (user.User, error) {
+	if !scheduler.Valid() {
+		return user.User{}, user.ErrUnknownScheduler
+	}
+
+	existing, err := s.repo.FindByID(id)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	existing.Scheduler = scheduler
+	return s.repo.Update(existing)
+}
+
+// SetDailyLimits caps how many new cards and reviews the user studies per day; zero restores the default.
+func (s *Service) SetDailyLimits(id string, newCards, reviews int) (user.User, error) {
+	existing, err := s.repo.FindByID(id)
+	if err != nil {
+		return user.User{}, err
+	}
+
+	existing.DailyNewLimit = newCards
+	existing.DailyReviewLimit = reviews
+	if err := existing.Validate(); err != nil {
+		return user.User{}, err
+	}
+
+	return s.repo.Update(existing)
+}
+
 func (s *Service) DeleteUser(id string) error {
 	return s.repo.Delete(id)
 }
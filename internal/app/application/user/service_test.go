@@ -66,3 +66,53 @@ func TestDeleteUser(t *testing.T) {
 		t.Fatalf("expected ErrNotFound, got %v", err)
 	}
 }
+
+func TestSetScheduler(t *testing.T) {
+	repo := userstorage.NewMemoryRepository()
+	service := NewService(repo)
+
+	created, err := service.CreateUser("nickname")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if created.Scheduler != user.DefaultScheduler {
+		t.Fatalf("expected default scheduler %q, got %q", user.DefaultScheduler, created.Scheduler)
+	}
+
+	updated, err := service.SetScheduler(created.ID, user.SchedulerFSRS)
+	if err != nil {
+		t.Fatalf("set scheduler failed: %v", err)
+	}
+	if updated.Scheduler != user.SchedulerFSRS {
+		t.Fatalf("expected fsrs scheduler, got %q", updated.Scheduler)
+	}
+
+	if _, err := service.SetScheduler(created.ID, "anki"); err != user.ErrUnknownScheduler {
+		t.Fatalf("expected ErrUnknownScheduler, got %v", err)
+	}
+	if _, err := service.SetScheduler("missing", user.SchedulerSM2); err != user.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSetDailyLimits(t *testing.T) {
+	repo := userstorage.NewMemoryRepository()
+	service := NewService(repo)
+
+	created, err := service.CreateUser("nickname")
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+
+	updated, err := service.SetDailyLimits(created.ID, 5, 50)
+	if err != nil {
+		t.Fatalf("set limits failed: %v", err)
+	}
+	if updated.DailyNewLimit != 5 || updated.DailyReviewLimit != 50 {
+		t.Fatalf("unexpected limits: %+v", updated)
+	}
+
+	if _, err := service.SetDailyLimits(created.ID, -1, 0); err != user.ErrNegativeLimit {
+		t.Fatalf("expected ErrNegativeLimit, got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+package card
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrClozeUnclosed      = errors.New("cloze marker is not closed with }}")
+	ErrClozeMalformed     = errors.New("cloze marker must look like {{c1::text}} or {{c1::text::hint}}")
+	ErrClozeEmpty         = errors.New("cloze deletion must not be empty")
+	ErrClozeMissing       = errors.New("cloze card needs at least one {{c1::text}} deletion")
+	ErrClozeIndexNotFound = errors.New("cloze card index does not match any deletion in the text")
+)
+
+const (
+	clozeOpen  = "{{"
+	clozeClose = "}}"
+	clozeMask  = "[...]"
+)
+
+// Cloze is one {{cN::text::hint}} deletion found in a cloze text.
+// Start and End delimit the whole marker in the source text.
+type Cloze struct {
+	Index int
+	Text  string
+	Hint  string
+	Start int
+	End   int
+}
+
+// ParseCloze extracts the cloze deletions of text in order of appearance.
+func ParseCloze(text string) ([]Cloze, error) {
+	var deletions []Cloze
+	offset := 0
+	for {
+		open := strings.Index(text[offset:], clozeOpen)
+		if open < 0 {
+			break
+		}
+		start := offset + open
+		body := start + len(clozeOpen)
+
+		closing := strings.Index(text[body:], clozeClose)
+		if closing < 0 {
+			return nil, ErrClozeUnclosed
+		}
+		end := body + closing + len(clozeClose)
+
+		deletion, err := parseClozeMarker(text[body : body+closing])
+		if err != nil {
+			return nil, err
+		}
+		deletion.Start, deletion.End = start, end
+		deletions = append(deletions, deletion)
+		offset = end
+	}
+	return deletions, nil
+}
+
+func parseClozeMarker(marker string) (Cloze, error) {
+	if strings.Contains(marker, clozeOpen) {
+		return Cloze{}, ErrClozeMalformed
+	}
+	parts := strings.SplitN(marker, "::", 3)
+	if len(parts) < 2 || !strings.HasPrefix(parts[0], "c") {
+		return Cloze{}, ErrClozeMalformed
+	}
+	index, err := strconv.Atoi(parts[0][1:])
+	if err != nil || index < 1 {
+		return Cloze{}, ErrClozeMalformed
+	}
+	if strings.TrimSpace(parts[1]) == "" {
+		return Cloze{}, ErrClozeEmpty
+	}
+
+	deletion := Cloze{Index: index, Text: parts[1]}
+	if len(parts) == 3 {
+		deletion.Hint = strings.TrimSpace(parts[2])
+	}
+	return deletion, nil
+}
+
+// ClozeIndexes returns the distinct cloze indexes of text in ascending order.
+func ClozeIndexes(text string) ([]int, error) {
+	deletions, err := ParseCloze(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(deletions) == 0 {
+		return nil, ErrClozeMissing
+	}
+
+	seen := make(map[int]bool)
+	var indexes []int
+	for _, d := range deletions {
+		if !seen[d.Index] {
+			seen[d.Index] = true
+			indexes = append(indexes, d.Index)
+		}
+	}
+	sort.Ints(indexes)
+	return indexes, nil
+}
+
+// RenderCloze replaces the deletions of the given index with a mask, or its hint,
+// and shows every other deletion as plain text. With reveal set the deletions of
+// index are shown in brackets instead.
+func RenderCloze(text string, index int, reveal bool) (string, error) {
+	deletions, err := ParseCloze(text)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, d := range deletions {
+		b.WriteString(text[last:d.Start])
+		switch {
+		case d.Index != index:
+			b.WriteString(d.Text)
+		case reveal:
+			b.WriteString("[" + d.Text + "]")
+		case d.Hint != "":
+			b.WriteString("[" + d.Hint + "]")
+		default:
+			b.WriteString(clozeMask)
+		}
+		last = d.End
+	}
+	b.WriteString(text[last:])
+	return b.String(), nil
+}
+
+func (c *Card) validateCloze() error {
+	indexes, err := ClozeIndexes(c.Front)
+	if err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index == c.ClozeIndex {
+			return nil
+		}
+	}
+	return ErrClozeIndexNotFound
+}
@@ -0,0 +1,97 @@
+package card
+
+import "time"
+
+// State is a review state a card can be in.
+type State string
+
+const (
+	// StateDue marks cards reviewed before and due for review now.
+	StateDue State = "due"
+	// StateNew marks cards never reviewed.
+	StateNew State = "new"
+	// StateSuspended marks suspended cards.
+	StateSuspended State = "suspended"
+	// StateLeech marks cards flagged as leeches.
+	StateLeech State = "leech"
+)
+
+// Valid reports whether s is one of the known states.
+func (s State) Valid() bool {
+	switch s {
+	case StateDue, StateNew, StateSuspended, StateLeech:
+		return true
+	}
+	return false
+}
+
+// InState reports whether the card is in the state at now.
+func (c Card) InState(state State, now time.Time) bool {
+	switch state {
+	case StateDue:
+		return !c.Review.IsNew() && c.Review.IsDue(now) && c.Available(now)
+	case StateNew:
+		return c.Review.IsNew()
+	case StateSuspended:
+		return c.Suspended
+	case StateLeech:
+		return c.Leech
+	}
+	return false
+}
+
+// Criteria selects cards of one owner. Every set field narrows the selection:
+// DeckIDs, when not nil, lists the decks the card may sit in; Words must all appear
+// in the card content; the card must be in every state of States and in none of
+// NotStates at Now; AddedAfter keeps cards created at or after it.
+type Criteria struct {
+	OwnerID    string
+	DeckIDs    []string
+	Tags       TagFilter
+	Words      []string
+	States     []State
+	NotStates  []State
+	AddedAfter time.Time
+	Now        time.Time
+}
+
+// Matches reports whether the card meets the criteria.
+func (cr Criteria) Matches(c Card) bool {
+	if c.OwnerID != cr.OwnerID || !cr.Tags.Matches(c) {
+		return false
+	}
+	if cr.DeckIDs != nil && !contains(cr.DeckIDs, c.DeckID) {
+		return false
+	}
+	if !cr.AddedAfter.IsZero() && c.CreatedAt.Before(cr.AddedAfter) {
+		return false
+	}
+	for _, state := range cr.States {
+		if !c.InState(state, cr.Now) {
+			return false
+		}
+	}
+	for _, state := range cr.NotStates {
+		if c.InState(state, cr.Now) {
+			return false
+		}
+	}
+	if len(cr.Words) > 0 {
+		words := Tokenize(c.SearchText())
+		for _, word := range cr.Words {
+			if !contains(words, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,345 @@
+package card
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"flash2fy/internal/app/domain/formula"
+)
+
+var (
+	ErrUnclosedMarkup = errors.New("card markdown has an unclosed **, *, _, ~~ or ` marker")
+	ErrUnsafeLink     = errors.New("card markdown links must use http or https")
+	ErrUnclosedMath   = errors.New("card markdown has a $$ formula that is not closed with $$")
+)
+
+// SpanKind tells how a piece of Markdown card content is displayed.
+type SpanKind string
+
+const (
+	SpanText   SpanKind = "text"
+	SpanBold   SpanKind = "bold"
+	SpanItalic SpanKind = "italic"
+	SpanStrike SpanKind = "strike"
+	SpanCode   SpanKind = "code"
+	SpanLink   SpanKind = "link"
+	SpanBreak  SpanKind = "break"
+	SpanMath   SpanKind = "math"
+	// SpanDisplayMath is a formula set on its own line.
+	SpanDisplayMath SpanKind = "display-math"
+)
+
+// Span is a node of parsed card content. Text, code and math spans carry Text, the LaTeX source
+// of math spans, links their URL, and bold, italic, strike and link spans their nested Children.
+type Span struct {
+	Kind     SpanKind
+	Text     string
+	URL      string
+	Children []Span
+}
+
+// ParseMarkdown parses card content written in the restricted Markdown cards support:
+// **bold**, *italic* or _italic_, ~~strike~~, `code`, [label](https://…) links, line breaks,
+// and LaTeX formulas, $...$ inline and $$...$$ on their own line.
+// A backslash escapes the next punctuation character. Markers only open before a non-space
+// and close after one, and never inside a word, so "snake_case" and "2*3" stay plain text.
+// Likewise a $ only opens a formula when a closing $ follows a non-space and is not followed
+// by a digit, so "$5 and $10" stays plain text.
+// Unclosed markers, links to anything but http or https and unsupported LaTeX are rejected.
+func ParseMarkdown(text string) ([]Span, error) {
+	p := &markdownParser{src: text}
+	spans, err := p.parse("")
+	if err != nil {
+		return nil, err
+	}
+	return spans, nil
+}
+
+// ValidateMarkdown reports whether text is valid card Markdown.
+func ValidateMarkdown(text string) error {
+	_, err := ParseMarkdown(text)
+	return err
+}
+
+// Formulas returns the formulas of card content in order of appearance; none when the content
+// is not valid Markdown.
+func Formulas(text string) []formula.Formula {
+	spans, err := ParseMarkdown(text)
+	if err != nil {
+		return nil
+	}
+	var formulas []formula.Formula
+	collectFormulas(spans, &formulas)
+	return formulas
+}
+
+func collectFormulas(spans []Span, formulas *[]formula.Formula) {
+	for _, s := range spans {
+		switch s.Kind {
+		case SpanMath, SpanDisplayMath:
+			*formulas = append(*formulas, formula.Formula{Source: s.Text, Display: s.Kind == SpanDisplayMath})
+		default:
+			collectFormulas(s.Children, formulas)
+		}
+	}
+}
+
+// PlainText returns the text of spans without any markup.
+func PlainText(spans []Span) string {
+	var b strings.Builder
+	writePlain(&b, spans)
+	return b.String()
+}
+
+func writePlain(b *strings.Builder, spans []Span) {
+	for _, s := range spans {
+		switch s.Kind {
+		case SpanText, SpanCode, SpanMath, SpanDisplayMath:
+			b.WriteString(s.Text)
+		case SpanBreak:
+			b.WriteByte('\n')
+		default:
+			writePlain(b, s.Children)
+		}
+	}
+}
+
+var emphasis = []struct {
+	marker string
+	kind   SpanKind
+}{
+	{"**", SpanBold},
+	{"~~", SpanStrike},
+	{"*", SpanItalic},
+	{"_", SpanItalic},
+}
+
+type markdownParser struct {
+	src string
+	pos int
+}
+
+// parse reads spans until closer, or the end of the input when closer is empty.
+func (p *markdownParser) parse(closer string) ([]Span, error) {
+	var (
+		spans []Span
+		text  strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			spans = append(spans, Span{Kind: SpanText, Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		if closer != "" && p.closes(closer) {
+			p.pos += len(closer)
+			flush()
+			return spans, nil
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.src) && isASCIIPunct(p.src[p.pos+1]):
+			text.WriteByte(p.src[p.pos+1])
+			p.pos += 2
+			continue
+		case c == '\n':
+			flush()
+			spans = append(spans, Span{Kind: SpanBreak})
+			p.pos++
+			continue
+		case c == '`':
+			end := strings.IndexByte(p.src[p.pos+1:], '`')
+			if end < 0 {
+				return nil, ErrUnclosedMarkup
+			}
+			flush()
+			spans = append(spans, Span{Kind: SpanCode, Text: p.src[p.pos+1 : p.pos+1+end]})
+			p.pos += end + 2
+			continue
+		case c == '$':
+			math, ok, err := p.math()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				flush()
+				spans = append(spans, math)
+				continue
+			}
+		case c == '[':
+			link, ok, err := p.link()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				flush()
+				spans = append(spans, link)
+				continue
+			}
+		}
+
+		if kind, marker, ok := p.opens(); ok {
+			p.pos += len(marker)
+			children, err := p.parse(marker)
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			spans = append(spans, Span{Kind: kind, Children: children})
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		text.WriteRune(r)
+		p.pos += size
+	}
+
+	if closer != "" {
+		return nil, ErrUnclosedMarkup
+	}
+	flush()
+	return spans, nil
+}
+
+// opens reports the emphasis marker starting at the current position, if it may open a span.
+func (p *markdownParser) opens() (SpanKind, string, bool) {
+	for _, e := range emphasis {
+		if !strings.HasPrefix(p.src[p.pos:], e.marker) {
+			continue
+		}
+		after, _ := utf8.DecodeRuneInString(p.src[p.pos+len(e.marker):])
+		if p.pos+len(e.marker) >= len(p.src) || unicode.IsSpace(after) || isWord(p.before(p.pos)) {
+			return "", "", false
+		}
+		return e.kind, e.marker, true
+	}
+	return "", "", false
+}
+
+// closes reports whether closer at the current position ends the open span.
+func (p *markdownParser) closes(closer string) bool {
+	if !strings.HasPrefix(p.src[p.pos:], closer) {
+		return false
+	}
+	if unicode.IsSpace(p.before(p.pos)) {
+		return false
+	}
+	after, _ := utf8.DecodeRuneInString(p.src[p.pos+len(closer):])
+	return !isWord(after)
+}
+
+// link parses [label](url) at the current position. ok is false when the text is not a link.
+func (p *markdownParser) link() (Span, bool, error) {
+	rest := p.src[p.pos:]
+	labelEnd := strings.Index(rest, "](")
+	if labelEnd < 0 || strings.ContainsAny(rest[1:labelEnd], "[\n") {
+		return Span{}, false, nil
+	}
+	urlEnd := strings.IndexByte(rest[labelEnd+2:], ')')
+	if urlEnd < 0 {
+		return Span{}, false, nil
+	}
+	url := rest[labelEnd+2 : labelEnd+2+urlEnd]
+	if url == "" || strings.ContainsAny(url, " \n") {
+		return Span{}, false, nil
+	}
+	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
+		return Span{}, false, ErrUnsafeLink
+	}
+
+	children, err := ParseMarkdown(rest[1:labelEnd])
+	if err != nil {
+		return Span{}, false, err
+	}
+	p.pos += labelEnd + 2 + urlEnd + 1
+	return Span{Kind: SpanLink, URL: url, Children: children}, true, nil
+}
+
+// math parses a $$...$$ or $...$ formula at the current position. ok is false when the $ does
+// not open a formula.
+func (p *markdownParser) math() (Span, bool, error) {
+	rest := p.src[p.pos:]
+	if strings.HasPrefix(rest, "$$") {
+		end := closingDollar(rest[2:], true)
+		if end < 0 {
+			return Span{}, false, ErrUnclosedMath
+		}
+		source := strings.TrimSpace(rest[2 : 2+end])
+		if err := (formula.Formula{Source: source, Display: true}).Validate(); err != nil {
+			return Span{}, false, err
+		}
+		p.pos += 2 + end + 2
+		return Span{Kind: SpanDisplayMath, Text: source}, true, nil
+	}
+
+	after, _ := utf8.DecodeRuneInString(rest[1:])
+	if len(rest) == 1 || unicode.IsSpace(after) {
+		return Span{}, false, nil
+	}
+	end := closingDollar(rest[1:], false)
+	if end < 0 {
+		return Span{}, false, nil
+	}
+	source := rest[1 : 1+end]
+	if err := (formula.Formula{Source: source}).Validate(); err != nil {
+		return Span{}, false, err
+	}
+	p.pos += 1 + end + 1
+	return Span{Kind: SpanMath, Text: source}, true, nil
+}
+
+// closingDollar returns the offset in text of the $$ closing display math, or of the $ closing
+// inline math: one after a non-space, not followed by a digit, on the same line. Escaped
+// dollars are skipped. It returns -1 when there is none.
+func closingDollar(text string, display bool) int {
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '\n':
+			if !display {
+				return -1
+			}
+		case '$':
+			if display {
+				if strings.HasPrefix(text[i:], "$$") {
+					return i
+				}
+				continue
+			}
+			before, _ := utf8.DecodeLastRuneInString(text[:i])
+			next := byte(0)
+			if i+1 < len(text) {
+				next = text[i+1]
+			}
+			if i > 0 && !unicode.IsSpace(before) && !(next >= '0' && next <= '9') && next != '$' {
+				return i
+			}
+			if next == '$' {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+func (p *markdownParser) before(pos int) rune {
+	if pos == 0 {
+		return ' '
+	}
+	r, _ := utf8.DecodeLastRuneInString(p.src[:pos])
+	return r
+}
+
+func isWord(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isASCIIPunct(c byte) bool {
+	return c < utf8.RuneSelf && (unicode.IsPunct(rune(c)) || unicode.IsSymbol(rune(c)))
+}
@@ -7,24 +7,131 @@ import (
 )
 
 var (
-	ErrEmptyFront = errors.New("card front must not be empty")
-	ErrNotFound   = errors.New("card not found")
+	ErrEmptyFront    = errors.New("card front must not be empty")
+	ErrNotFound      = errors.New("card not found")
+	ErrInvalidRating = errors.New("card rating must be one of again, hard, good or easy")
+	ErrUnknownType   = errors.New("card type must be basic or cloze")
+	ErrManagedByNote = errors.New("card is generated from a note; edit the note instead")
+	ErrTrashExpired  = errors.New("card was purged from the trash")
+	ErrForeignOwner  = errors.New("card belongs to another user")
 )
 
-// Card represents a flashcard with front and back content.
+// TrashRetention is how long a deleted card stays in the trash before it is purged for good.
+const TrashRetention = 30 * 24 * time.Hour
+
+// Type tells how a card turns its fields into a question and an answer.
+type Type string
+
+const (
+	// TypeBasic shows Front as the question and Back as the answer.
+	TypeBasic Type = "basic"
+	// TypeCloze keeps a text with {{cN::...}} deletions in Front and asks for the deletions
+	// numbered ClozeIndex; Back holds optional extra notes shown with the answer.
+	TypeCloze Type = "cloze"
+)
+
+// LeechTag is added to a card when it is flagged as a leech.
+const LeechTag = "leech"
+
+// Card represents a flashcard with front and back content. An empty Type means TypeBasic.
+// Suspended cards stay out of review queues until unsuspended; buried cards until BuriedUntil.
+// Leech marks cards suspended automatically after failing too often.
+// Cards generated from a note carry its NoteID; Reversed marks the back → front card of a basic note.
+// DeckID is empty for cards outside any deck. Tags are normalized, sorted and unique.
+// Deleted cards keep DeletedAt while they sit in the trash.
 type Card struct {
-	ID        string
-	Front     string
-	Back      string
-	OwnerID   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID          string
+	Front       string
+	Back        string
+	OwnerID     string
+	DeckID      string
+	Tags        []string
+	NoteID      string
+	Type        Type
+	Reversed    bool
+	ClozeIndex  int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Review      ReviewState
+	Suspended   bool
+	Leech       bool
+	BuriedUntil time.Time
+	DeletedAt   time.Time
 }
 
-// Validate ensures the card has the required fields.
+// Validate ensures the card has the required fields and that its front and back are valid Markdown.
 func (c *Card) Validate() error {
 	if strings.TrimSpace(c.Front) == "" {
 		return ErrEmptyFront
 	}
-	return nil
+	if err := ValidateMarkdown(c.Front); err != nil {
+		return err
+	}
+	if err := ValidateMarkdown(c.Back); err != nil {
+		return err
+	}
+	switch c.Type {
+	case "", TypeBasic:
+		return nil
+	case TypeCloze:
+		return c.validateCloze()
+	default:
+		return ErrUnknownType
+	}
+}
+
+// Kind returns the card type, treating cards without one as basic.
+func (c Card) Kind() Type {
+	if c.Type == "" {
+		return TypeBasic
+	}
+	return c.Type
+}
+
+// Question returns the text shown before the answer is revealed.
+func (c Card) Question() string {
+	if c.Type != TypeCloze {
+		return c.Front
+	}
+	question, err := RenderCloze(c.Front, c.ClozeIndex, false)
+	if err != nil {
+		return c.Front
+	}
+	return question
+}
+
+// Answer returns the text shown once the answer is revealed.
+func (c Card) Answer() string {
+	if c.Type != TypeCloze {
+		return c.Back
+	}
+	answer, err := RenderCloze(c.Front, c.ClozeIndex, true)
+	if err != nil {
+		answer = c.Front
+	}
+	if strings.TrimSpace(c.Back) != "" {
+		answer += "\n\n" + c.Back
+	}
+	return answer
+}
+
+// Available reports whether the card may be queued for review at now.
+func (c Card) Available(now time.Time) bool {
+	return !c.Suspended && !c.BuriedUntil.After(now)
+}
+
+// Trashed reports whether the card was deleted and sits in the trash.
+func (c Card) Trashed() bool {
+	return !c.DeletedAt.IsZero()
+}
+
+// PurgeAt returns when a card in the trash is purged for good.
+func (c Card) PurgeAt() time.Time {
+	return c.DeletedAt.Add(TrashRetention)
+}
+
+// SameSlot reports whether c and other are the same card of a note: the same type,
+// direction and cloze index.
+func (c Card) SameSlot(other Card) bool {
+	return c.Kind() == other.Kind() && c.Reversed == other.Reversed && c.ClozeIndex == other.ClozeIndex
 }
@@ -0,0 +1,97 @@
+package card
+
+import (
+	"strings"
+	"time"
+)
+
+// Rating grades how well a card was recalled during a review.
+type Rating int
+
+const (
+	RatingAgain Rating = iota + 1
+	RatingHard
+	RatingGood
+	RatingEasy
+)
+
+// DefaultEaseFactor is the SM-2 ease assigned to cards that were never reviewed.
+const DefaultEaseFactor = 2.5
+
+var ratingNames = map[Rating]string{
+	RatingAgain: "again",
+	RatingHard:  "hard",
+	RatingGood:  "good",
+	RatingEasy:  "easy",
+}
+
+// ParseRating converts a case-insensitive rating name such as "good" into a Rating.
+func ParseRating(value string) (Rating, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for rating, name := range ratingNames {
+		if name == normalized {
+			return rating, nil
+		}
+	}
+	return 0, ErrInvalidRating
+}
+
+// Valid reports whether r is one of the supported ratings.
+func (r Rating) Valid() bool {
+	_, ok := ratingNames[r]
+	return ok
+}
+
+func (r Rating) String() string {
+	if name, ok := ratingNames[r]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ReviewState captures the spaced-repetition progress of a card.
+// EaseFactor is maintained by SM-2, Stability and Difficulty by FSRS and Box by the Leitner system.
+type ReviewState struct {
+	EaseFactor  float64
+	Stability   float64
+	Difficulty  float64
+	Box         int
+	Interval    int // days until the next review
+	Repetitions int
+	Lapses      int // times the card was forgotten after being learned
+	DueAt       time.Time
+	ReviewedAt  time.Time
+}
+
+// NewReviewState returns the state of a card that has never been studied and is due at now.
+func NewReviewState(now time.Time) ReviewState {
+	return ReviewState{
+		EaseFactor: DefaultEaseFactor,
+		DueAt:      now,
+	}
+}
+
+// IsNew reports whether the card has never been reviewed.
+func (s ReviewState) IsNew() bool {
+	return s.ReviewedAt.IsZero()
+}
+
+// LeitnerBox returns the Leitner box of the card; cards that never entered a box sit in the first one.
+func (s ReviewState) LeitnerBox() int {
+	if s.Box < 1 {
+		return 1
+	}
+	return s.Box
+}
+
+// LeitnerBox summarises how many of a user's cards sit in a Leitner box.
+type LeitnerBox struct {
+	Box     int
+	Cadence int // days between reviews of the cards in this box
+	Cards   int
+}
+
+// IsDue reports whether the card should be reviewed at now.
+func (s ReviewState) IsDue(now time.Time) bool {
+	return !s.DueAt.After(now)
+}
@@ -0,0 +1,85 @@
+package card
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+var ErrEmptyQuery = errors.New("search query must contain at least one word")
+
+// HighlightStart and HighlightEnd surround the matched words of a search snippet.
+const (
+	HighlightStart = "**"
+	HighlightEnd   = "**"
+)
+
+// SearchHit is a card matching a search query, with its relevance and a snippet
+// of its content in which the matched words are highlighted.
+type SearchHit struct {
+	Card    Card
+	Rank    float64
+	Snippet string
+}
+
+// SearchText returns the content of the card that searches look into.
+func (c Card) SearchText() string {
+	if strings.TrimSpace(c.Back) == "" {
+		return c.Front
+	}
+	return c.Front + " " + c.Back
+}
+
+// Tokenize splits text into lowercase words made of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Highlight returns up to maxWords words of text around the first word matching
+// one of the terms, with every matching word highlighted.
+func Highlight(text string, terms []string, maxWords int) string {
+	wanted := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		wanted[term] = true
+	}
+	matches := func(word string) bool {
+		for _, token := range Tokenize(word) {
+			if wanted[token] {
+				return true
+			}
+		}
+		return false
+	}
+
+	words := strings.Fields(text)
+	start := 0
+	for i, word := range words {
+		if matches(word) {
+			start = max(0, i-3)
+			break
+		}
+	}
+	end := len(words)
+	if maxWords > 0 {
+		end = min(end, start+maxWords)
+	}
+
+	snippet := make([]string, 0, end-start)
+	for _, word := range words[start:end] {
+		if matches(word) {
+			word = HighlightStart + word + HighlightEnd
+		}
+		snippet = append(snippet, word)
+	}
+
+	result := strings.Join(snippet, " ")
+	if start > 0 {
+		result = "… " + result
+	}
+	if end < len(words) {
+		result += " …"
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package card
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidTag = errors.New("card tags must be non-empty words without spaces")
+
+// NormalizeTag lowercases the tag and drops a leading '#', so "#Grammar" and "grammar" are the same tag.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	if tag == "" || strings.HasPrefix(tag, "-") || strings.IndexFunc(tag, unicode.IsSpace) >= 0 {
+		return "", ErrInvalidTag
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes every tag and returns them sorted without duplicates.
+func NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		t, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[t] {
+			seen[t] = true
+			normalized = append(normalized, t)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// HasTag reports whether the card carries the normalized tag.
+func (c Card) HasTag(tag string) bool {
+	for _, t := range c.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// TagFilter selects cards carrying every Include tag and none of the Exclude tags.
+type TagFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseTagFilter reads tags such as "grammar" to include and "-hard" to exclude.
+func ParseTagFilter(values []string) (TagFilter, error) {
+	var filter TagFilter
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		exclude := strings.HasPrefix(value, "-")
+		tag, err := NormalizeTag(strings.TrimPrefix(value, "-"))
+		if err != nil {
+			return TagFilter{}, err
+		}
+		if exclude {
+			filter.Exclude = append(filter.Exclude, tag)
+		} else {
+			filter.Include = append(filter.Include, tag)
+		}
+	}
+	return filter, nil
+}
+
+// Empty reports whether the filter lets every card through.
+func (f TagFilter) Empty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// Matches reports whether the card passes the filter.
+func (f TagFilter) Matches(c Card) bool {
+	for _, tag := range f.Include {
+		if !c.HasTag(tag) {
+			return false
+		}
+	}
+	for _, tag := range f.Exclude {
+		if c.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package catalog
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyDeck       = errors.New("catalog listing deck must not be empty")
+	ErrEmptyTitle      = errors.New("catalog listing title must not be empty")
+	ErrInvalidLanguage = errors.New("catalog listing language must be a two-letter ISO 639-1 code")
+	ErrNotFound        = errors.New("catalog listing not found")
+)
+
+// Listing publishes a deck in the public catalog, where anyone may browse and clone it.
+// Language is the lowercase ISO 639-1 code of the deck content, such as "de".
+type Listing struct {
+	DeckID      string
+	OwnerID     string
+	Title       string
+	Language    string
+	Description string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate ensures the listing has the required fields.
+func (l *Listing) Validate() error {
+	if l.DeckID == "" {
+		return ErrEmptyDeck
+	}
+	if strings.TrimSpace(l.Title) == "" {
+		return ErrEmptyTitle
+	}
+	if !ValidLanguage(l.Language) {
+		return ErrInvalidLanguage
+	}
+	return nil
+}
+
+// NormalizeLanguage trims and lowercases a language code.
+func NormalizeLanguage(language string) string {
+	return strings.ToLower(strings.TrimSpace(language))
+}
+
+// ValidLanguage reports whether language is a normalized two-letter code.
+func ValidLanguage(language string) bool {
+	if len(language) != 2 {
+		return false
+	}
+	for _, r := range language {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// SearchText returns the listing text matched by catalog searches.
+func (l Listing) SearchText() string {
+	return l.Title + " " + l.Description
+}
@@ -0,0 +1,84 @@
+package catalog
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrEmptySubscription    = errors.New("subscription deck and source must not be empty")
+	ErrEmptySubscriber      = errors.New("subscription user must not be empty")
+	ErrEmptyLink            = errors.New("card link must name the deck, the card and the source card")
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	ErrSourceGone           = errors.New("subscribed source deck no longer exists")
+	ErrLinkNotFound         = errors.New("card is not linked to the source deck")
+	ErrNoConflict           = errors.New("card has no pending upstream conflict")
+	ErrInvalidResolution    = errors.New("conflict resolution must be local or upstream")
+)
+
+// Subscription keeps a user's copy of a published deck, DeckID, in step with its source deck.
+// SyncedAt is the last time upstream changes were pulled into the copy.
+type Subscription struct {
+	DeckID    string
+	SourceID  string
+	UserID    string
+	CreatedAt time.Time
+	SyncedAt  time.Time
+}
+
+// Validate ensures the subscription has the required fields.
+func (s *Subscription) Validate() error {
+	if s.DeckID == "" || s.SourceID == "" {
+		return ErrEmptySubscription
+	}
+	if s.UserID == "" {
+		return ErrEmptySubscriber
+	}
+	return nil
+}
+
+// Link pairs a card of a subscribed copy with the source card it was copied from.
+// Front and Back hold the source content last applied to the copy, so a sync can tell
+// upstream edits from local ones. A card edited on both sides is left as is and marked
+// Conflict, with the pending source content in UpstreamFront and UpstreamBack.
+type Link struct {
+	DeckID        string
+	CardID        string
+	SourceCardID  string
+	Front         string
+	Back          string
+	Conflict      bool
+	UpstreamFront string
+	UpstreamBack  string
+}
+
+// Validate ensures the link has the required fields.
+func (l *Link) Validate() error {
+	if l.DeckID == "" || l.CardID == "" || l.SourceCardID == "" {
+		return ErrEmptyLink
+	}
+	return nil
+}
+
+// Resolution tells which side of a conflict a subscriber keeps.
+type Resolution string
+
+const (
+	// KeepLocal keeps the subscriber's edit and stops reporting the pending upstream edit.
+	KeepLocal Resolution = "local"
+	// KeepUpstream replaces the subscriber's edit with the upstream content.
+	KeepUpstream Resolution = "upstream"
+)
+
+// Valid reports whether r is a known resolution.
+func (r Resolution) Valid() bool {
+	return r == KeepLocal || r == KeepUpstream
+}
+
+// SyncReport counts the changes a sync applied to a subscribed copy.
+type SyncReport struct {
+	Added     int
+	Updated   int
+	Removed   int
+	Conflicts int
+}
@@ -0,0 +1,100 @@
+package deck
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidRole    = errors.New("deck role must be viewer or editor")
+	ErrEmptyMember    = errors.New("deck member must not be empty")
+	ErrOwnerMember    = errors.New("deck owner cannot be added as a member")
+	ErrForbidden      = errors.New("deck role does not allow this action")
+	ErrMemberNotFound = errors.New("deck member not found")
+	ErrInviteNotFound = errors.New("deck invite not found")
+	ErrInviteExpired  = errors.New("deck invite has expired")
+)
+
+// Role is what a user may do with a deck. Each role includes the ones below it.
+type Role string
+
+const (
+	// RoleViewer may read the deck and its cards.
+	RoleViewer Role = "viewer"
+	// RoleEditor may also add cards to the deck and rename it.
+	RoleEditor Role = "editor"
+	// RoleOwner may also move, share and delete the deck. Only the deck owner holds it.
+	RoleOwner Role = "owner"
+)
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// Valid reports whether the role can be granted to a member.
+func (r Role) Valid() bool {
+	return r == RoleViewer || r == RoleEditor
+}
+
+// Permits reports whether the role includes required.
+func (r Role) Permits(required Role) bool {
+	return roleRanks[r] > 0 && roleRanks[r] >= roleRanks[required]
+}
+
+// Member grants a user other than the owner a role on a deck. Sharing a deck does not share its subdecks.
+type Member struct {
+	DeckID    string
+	UserID    string
+	Role      Role
+	CreatedAt time.Time
+}
+
+// Validate ensures the membership has the required fields.
+func (m *Member) Validate() error {
+	if m.DeckID == "" || m.UserID == "" {
+		return ErrEmptyMember
+	}
+	if !m.Role.Valid() {
+		return ErrInvalidRole
+	}
+	return nil
+}
+
+// Invite is a single-use token that makes whoever accepts it a member of the deck with Role.
+type Invite struct {
+	Token     string
+	DeckID    string
+	Role      Role
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the invite can no longer be accepted at now.
+func (i Invite) Expired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// RoleOf returns the role userID holds on the deck given its members, or "" when the user has none.
+func (d Deck) RoleOf(userID string, members []Member) Role {
+	if userID != "" && d.OwnerID == userID {
+		return RoleOwner
+	}
+	for _, m := range members {
+		if m.DeckID == d.ID && m.UserID == userID {
+			return m.Role
+		}
+	}
+	return ""
+}
+
+// Authorize ensures userID holds at least the required role on the deck. Users without any
+// role get ErrForeignOwner; members whose role is too low get ErrForbidden.
+func (d Deck) Authorize(userID string, members []Member, required Role) error {
+	role := d.RoleOf(userID, members)
+	if role == "" {
+		return ErrForeignOwner
+	}
+	if !role.Permits(required) {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package deck
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyName     = errors.New("deck name must not be empty")
+	ErrNameSeparator = errors.New(`deck name must not contain "::"`)
+	ErrEmptyOwner    = errors.New("deck owner must not be empty")
+	ErrNotFound      = errors.New("deck not found")
+	ErrForeignOwner  = errors.New("deck belongs to another owner")
+	ErrCycle         = errors.New("deck cannot be moved under itself or one of its subdecks")
+)
+
+// PathSeparator joins the names of nested decks, as in "Spanish::Verbs::Irregular".
+const PathSeparator = "::"
+
+// Deck groups a user's cards so they can be listed, studied and exported together.
+// A deck with a ParentID is a subdeck of another deck of the same owner. A deck cloned
+// from the public catalog keeps the id of the deck it was copied from in SourceID.
+type Deck struct {
+	ID          string
+	Name        string
+	Description string
+	OwnerID     string
+	ParentID    string
+	SourceID    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Validate ensures the deck has the required fields.
+func (d *Deck) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return ErrEmptyName
+	}
+	if strings.Contains(d.Name, PathSeparator) {
+		return ErrNameSeparator
+	}
+	if d.OwnerID == "" {
+		return ErrEmptyOwner
+	}
+	if d.ParentID != "" && d.ParentID == d.ID {
+		return ErrCycle
+	}
+	return nil
+}
+
+// Accepts reports whether a card of ownerID may be placed in the deck.
+func (d Deck) Accepts(ownerID string) error {
+	if d.OwnerID != ownerID {
+		return ErrForeignOwner
+	}
+	return nil
+}
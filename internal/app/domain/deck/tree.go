@@ -0,0 +1,96 @@
+package deck
+
+import (
+	"sort"
+	"strings"
+)
+
+// Tree indexes the decks of one owner by parent so hierarchies can be walked.
+// Decks whose parent is missing from the tree are treated as top-level decks.
+type Tree struct {
+	decks    map[string]Deck
+	children map[string][]Deck
+}
+
+// NewTree builds the hierarchy of the given decks.
+func NewTree(decks []Deck) Tree {
+	t := Tree{
+		decks:    make(map[string]Deck, len(decks)),
+		children: make(map[string][]Deck),
+	}
+	for _, d := range decks {
+		t.decks[d.ID] = d
+	}
+	for _, d := range decks {
+		parent := d.ParentID
+		if _, ok := t.decks[parent]; !ok {
+			parent = ""
+		}
+		t.children[parent] = append(t.children[parent], d)
+	}
+	for _, children := range t.children {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i].Name < children[j].Name
+		})
+	}
+	return t
+}
+
+// Deck returns the deck with the given id.
+func (t Tree) Deck(id string) (Deck, bool) {
+	d, ok := t.decks[id]
+	return d, ok
+}
+
+// Children returns the direct subdecks of the deck, sorted by name; an empty id returns the top-level decks.
+func (t Tree) Children(id string) []Deck {
+	return t.children[id]
+}
+
+// Subtree returns the ids of the deck and of all of its descendants.
+func (t Tree) Subtree(id string) []string {
+	if _, ok := t.decks[id]; !ok {
+		return nil
+	}
+
+	ids := []string{id}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range t.children[ids[i]] {
+			ids = append(ids, child.ID)
+		}
+	}
+	return ids
+}
+
+// Path returns the names from the top-level deck down to the deck, joined by PathSeparator.
+func (t Tree) Path(id string) string {
+	var names []string
+	for d, ok := t.decks[id]; ok && len(names) <= len(t.decks); d, ok = t.decks[d.ParentID] {
+		names = append([]string{d.Name}, names...)
+	}
+	return strings.Join(names, PathSeparator)
+}
+
+// CheckParent ensures the deck may become a subdeck of parentID: the parent must
+// exist, belong to the same owner and lie outside the deck's own subtree.
+// An empty parentID makes the deck top-level and is always allowed.
+func (t Tree) CheckParent(id, parentID string) error {
+	if parentID == "" {
+		return nil
+	}
+	parent, ok := t.decks[parentID]
+	if !ok {
+		return ErrNotFound
+	}
+	if d, ok := t.decks[id]; ok {
+		if err := parent.Accepts(d.OwnerID); err != nil {
+			return err
+		}
+	}
+	for _, descendant := range t.Subtree(id) {
+		if descendant == parentID {
+			return ErrCycle
+		}
+	}
+	return nil
+}
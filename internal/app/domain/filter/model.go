@@ -0,0 +1,51 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyName     = errors.New("filtered deck name must not be empty")
+	ErrEmptyOwner    = errors.New("filtered deck owner must not be empty")
+	ErrEmptyQuery    = errors.New("filtered deck query must not be empty")
+	ErrNotFound      = errors.New("filtered deck not found")
+	ErrForeignOwner  = errors.New("filtered deck belongs to another owner")
+	ErrUnknownField  = errors.New("unknown filter field")
+	ErrInvalidValue  = errors.New("invalid filter value")
+	ErrUnclosedQuote = errors.New("filter query has an unclosed quote")
+)
+
+// Filter is a saved query over a user's cards that can be listed and studied like a deck
+// without moving any card. Query is kept as written and evaluated each time it is used.
+type Filter struct {
+	ID        string
+	Name      string
+	Query     string
+	OwnerID   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate ensures the filtered deck has the required fields.
+func (f *Filter) Validate() error {
+	if strings.TrimSpace(f.Name) == "" {
+		return ErrEmptyName
+	}
+	if strings.TrimSpace(f.Query) == "" {
+		return ErrEmptyQuery
+	}
+	if f.OwnerID == "" {
+		return ErrEmptyOwner
+	}
+	return nil
+}
+
+// Accepts reports whether ownerID may use the filtered deck.
+func (f Filter) Accepts(ownerID string) error {
+	if f.OwnerID != ownerID {
+		return ErrForeignOwner
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package formula
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+var (
+	ErrEmpty           = errors.New("formula must not be empty")
+	ErrTooLong         = errors.New("formula must not exceed 1000 characters")
+	ErrTooDeep         = errors.New("formula is nested too deeply")
+	ErrUnbalanced      = errors.New("formula has unbalanced braces or \\left and \\right")
+	ErrUnsupported     = errors.New("formula uses a LaTeX command or character that is not supported")
+	ErrMissingArgument = errors.New("formula command or script is missing its argument")
+	ErrMisplacedScript = errors.New("formula has a ^ or _ without a base, or a double ^ or _")
+)
+
+const (
+	// MaxLength bounds the source of a formula, in bytes.
+	MaxLength = 1000
+	// maxDepth bounds how deeply groups, fractions and scripts may nest.
+	maxDepth = 32
+)
+
+// Formula is a LaTeX math segment of card content: $...$ inline or $$...$$ on its own line.
+type Formula struct {
+	Source  string
+	Display bool
+}
+
+// Key identifies the formula and the way it is displayed, e.g. to cache its rendering.
+func (f Formula) Key() string {
+	mode := "inline:"
+	if f.Display {
+		mode = "display:"
+	}
+	sum := sha256.Sum256([]byte(mode + f.Source))
+	return hex.EncodeToString(sum[:])
+}
+
+// Parse parses the formula into a tree ready to be rendered.
+func (f Formula) Parse() (Node, error) {
+	return Parse(f.Source)
+}
+
+// Validate reports whether the formula uses only the supported LaTeX.
+func (f Formula) Validate() error {
+	_, err := f.Parse()
+	return err
+}
+
+// NodeKind tells how a node of a parsed formula is laid out.
+type NodeKind string
+
+const (
+	NodeRow      NodeKind = "row"      // Children side by side
+	NodeIdent    NodeKind = "ident"    // a variable, shown in italics when it is a single letter
+	NodeNumber   NodeKind = "number"   // digits and a decimal point
+	NodeOperator NodeKind = "operator" // a symbol such as +, = or (
+	NodeFunction NodeKind = "function" // an upright name such as sin or lim
+	NodeText     NodeKind = "text"     // upright text from \text{...}
+	NodeSpace    NodeKind = "space"    // Width em of horizontal space
+	NodeFraction NodeKind = "fraction" // Children[0] over Children[1]
+	NodeRoot     NodeKind = "root"     // the root of Children[0], of degree Children[1] when present
+	NodeScripts  NodeKind = "scripts"  // Children[0] with the optional Sub and Sup scripts
+	NodeLargeOp  NodeKind = "largeop"  // a big operator such as ∑ or ∫ whose scripts act as limits
+	NodeAccent   NodeKind = "accent"   // Children[0] with the mark Text above it
+	NodeFenced   NodeKind = "fenced"   // Children[1] between the delimiters Children[0] and Children[2]
+)
+
+// Node is an element of a parsed formula. Text holds the characters of leaf nodes and the mark
+// of accents; the meaning of Children depends on Kind.
+type Node struct {
+	Kind     NodeKind
+	Text     string
+	Width    float64
+	Children []Node
+	Sub      *Node
+	Sup      *Node
+}
+
+// Accent marks.
+const (
+	AccentVector = "→"
+	AccentHat    = "^"
+	AccentBar    = "¯"
+	AccentDot    = "˙"
+	AccentTilde  = "~"
+)
+
+// Limits reports whether displayed formulas set the scripts of n under and over it, as for
+// sums and lim, rather than beside it as for integrals.
+func (n Node) Limits() bool {
+	switch n.Kind {
+	case NodeLargeOp:
+		return n.Text != "∫" && n.Text != "∬" && n.Text != "∮"
+	case NodeFunction:
+		return limitFunctions[n.Text]
+	}
+	return false
+}
+
+var limitFunctions = map[string]bool{
+	"lim": true, "max": true, "min": true, "sup": true, "inf": true, "det": true, "gcd": true,
+}
@@ -0,0 +1,432 @@
+package formula
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parse parses a formula written in the LaTeX math subset cards support: letters, digits and
+// operators; Greek letters and common symbols such as \alpha, \leq, \infty or \to; functions
+// such as \sin and \lim; \frac, \sqrt, ^ and _ scripts, \sum, \prod and \int with limits;
+// \vec, \hat, \bar, \overline, \dot and \tilde accents; \mathbb{R}; \text{...}; \left and
+// \right delimiters and the spacing commands \, \: \; \! \quad and \qquad.
+func Parse(source string) (Node, error) {
+	if strings.TrimSpace(source) == "" {
+		return Node{}, ErrEmpty
+	}
+	if len(source) > MaxLength {
+		return Node{}, ErrTooLong
+	}
+
+	p := &parser{src: source}
+	row, err := p.row(endInput)
+	if err != nil {
+		return Node{}, err
+	}
+	return row, nil
+}
+
+type terminator int
+
+const (
+	endInput terminator = iota
+	endGroup            // a closing brace
+	endRight            // a \right delimiter
+)
+
+type parser struct {
+	src   string
+	pos   int
+	depth int
+}
+
+// row parses nodes until the terminator, which it consumes, apart from \right.
+func (p *parser) row(end terminator) (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return Node{}, ErrTooDeep
+	}
+
+	row := Node{Kind: NodeRow}
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.src) {
+			if end != endInput {
+				return Node{}, ErrUnbalanced
+			}
+			return row, nil
+		}
+
+		c := p.src[p.pos]
+		switch {
+		case c == '}':
+			if end != endGroup {
+				return Node{}, ErrUnbalanced
+			}
+			p.pos++
+			return row, nil
+		case c == '^' || c == '_':
+			p.pos++
+			if err := p.script(&row, c == '^'); err != nil {
+				return Node{}, err
+			}
+			continue
+		case strings.HasPrefix(p.src[p.pos:], `\right`) && !p.letterAt(p.pos+len(`\right`)):
+			if end != endRight {
+				return Node{}, ErrUnbalanced
+			}
+			return row, nil
+		}
+
+		node, err := p.atom()
+		if err != nil {
+			return Node{}, err
+		}
+		row.Children = append(row.Children, node)
+	}
+}
+
+// script attaches the next atom as superscript or subscript of the last node of row.
+func (p *parser) script(row *Node, sup bool) error {
+	if len(row.Children) == 0 {
+		return ErrMisplacedScript
+	}
+	last := &row.Children[len(row.Children)-1]
+	if last.Kind != NodeScripts {
+		*last = Node{Kind: NodeScripts, Children: []Node{*last}}
+	}
+	if (sup && last.Sup != nil) || (!sup && last.Sub != nil) {
+		return ErrMisplacedScript
+	}
+
+	arg, err := p.argument()
+	if err != nil {
+		return err
+	}
+	if sup {
+		last.Sup = &arg
+	} else {
+		last.Sub = &arg
+	}
+	return nil
+}
+
+// argument parses the argument of a command or script: a group in braces or a single atom.
+// As in LaTeX, x^23 raises only the 2.
+func (p *parser) argument() (Node, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return Node{}, ErrMissingArgument
+	}
+	switch c := p.src[p.pos]; {
+	case c == '{':
+		p.pos++
+		return p.row(endGroup)
+	case c == '}' || c == '^' || c == '_':
+		return Node{}, ErrMissingArgument
+	case c >= '0' && c <= '9':
+		p.pos++
+		return Node{Kind: NodeNumber, Text: string(c)}, nil
+	}
+	return p.atom()
+}
+
+// atom parses a group, command or single character.
+func (p *parser) atom() (Node, error) {
+	c := p.src[p.pos]
+	switch {
+	case c == '{':
+		p.pos++
+		return p.row(endGroup)
+	case c == '\\':
+		return p.command()
+	case c >= '0' && c <= '9' || c == '.' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1]):
+		start := p.pos
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		return Node{Kind: NodeNumber, Text: p.src[start:p.pos]}, nil
+	case c == '~':
+		p.pos++
+		return Node{Kind: NodeSpace, Width: spaceWidths[" "]}, nil
+	case c == '&' || c == '#' || c == '%' || c == '$':
+		return Node{}, ErrUnsupported
+	}
+
+	r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+	p.pos += size
+	if r == utf8.RuneError && size == 1 {
+		return Node{}, ErrUnsupported
+	}
+	if symbol, ok := asciiOperators[r]; ok {
+		return Node{Kind: NodeOperator, Text: symbol}, nil
+	}
+	if unicode.IsLetter(r) {
+		return Node{Kind: NodeIdent, Text: string(r)}, nil
+	}
+	if unicode.IsDigit(r) {
+		return Node{Kind: NodeNumber, Text: string(r)}, nil
+	}
+	if unicode.IsControl(r) {
+		return Node{}, ErrUnsupported
+	}
+	return Node{Kind: NodeOperator, Text: string(r)}, nil
+}
+
+// command parses a backslash command at the current position.
+func (p *parser) command() (Node, error) {
+	p.pos++ // the backslash
+	if p.pos >= len(p.src) {
+		return Node{}, ErrUnsupported
+	}
+	start := p.pos
+	for p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		// A single non-letter such as \, or \{.
+		p.pos++
+		name := p.src[start:p.pos]
+		if width, ok := spaceWidths[name]; ok {
+			return Node{Kind: NodeSpace, Width: width}, nil
+		}
+		if symbol, ok := escapedSymbols[name]; ok {
+			return Node{Kind: NodeOperator, Text: symbol}, nil
+		}
+		return Node{}, ErrUnsupported
+	}
+	name := p.src[start:p.pos]
+
+	if symbol, ok := letters[name]; ok {
+		return Node{Kind: NodeIdent, Text: symbol}, nil
+	}
+	if symbol, ok := symbols[name]; ok {
+		return Node{Kind: NodeOperator, Text: symbol}, nil
+	}
+	if symbol, ok := largeOperators[name]; ok {
+		return Node{Kind: NodeLargeOp, Text: symbol}, nil
+	}
+	if functions[name] {
+		return Node{Kind: NodeFunction, Text: name}, nil
+	}
+	if width, ok := spaceWidths[name]; ok {
+		return Node{Kind: NodeSpace, Width: width}, nil
+	}
+	if mark, ok := accents[name]; ok {
+		body, err := p.argument()
+		if err != nil {
+			return Node{}, err
+		}
+		return Node{Kind: NodeAccent, Text: mark, Children: []Node{body}}, nil
+	}
+
+	switch name {
+	case "frac", "dfrac", "tfrac":
+		num, err := p.argument()
+		if err != nil {
+			return Node{}, err
+		}
+		den, err := p.argument()
+		if err != nil {
+			return Node{}, err
+		}
+		return Node{Kind: NodeFraction, Children: []Node{num, den}}, nil
+	case "sqrt":
+		return p.root()
+	case "text", "textrm", "mathrm", "operatorname":
+		text, err := p.rawArgument()
+		if err != nil {
+			return Node{}, err
+		}
+		if name == "text" || name == "textrm" {
+			return Node{Kind: NodeText, Text: text}, nil
+		}
+		return Node{Kind: NodeFunction, Text: strings.TrimSpace(text)}, nil
+	case "mathbb":
+		text, err := p.rawArgument()
+		if err != nil {
+			return Node{}, err
+		}
+		symbol, ok := doubleStruck[strings.TrimSpace(text)]
+		if !ok {
+			return Node{}, ErrUnsupported
+		}
+		return Node{Kind: NodeIdent, Text: symbol}, nil
+	case "left":
+		return p.fenced()
+	}
+	return Node{}, ErrUnsupported
+}
+
+// root parses \sqrt with an optional [degree].
+func (p *parser) root() (Node, error) {
+	p.skipSpaces()
+	var degree *Node
+	if p.pos < len(p.src) && p.src[p.pos] == '[' {
+		end := strings.IndexByte(p.src[p.pos:], ']')
+		if end < 0 {
+			return Node{}, ErrUnbalanced
+		}
+		inner, err := Parse(p.src[p.pos+1 : p.pos+end])
+		if err != nil {
+			return Node{}, err
+		}
+		degree = &inner
+		p.pos += end + 1
+	}
+
+	radicand, err := p.argument()
+	if err != nil {
+		return Node{}, err
+	}
+	node := Node{Kind: NodeRoot, Children: []Node{radicand}}
+	if degree != nil {
+		node.Children = append(node.Children, *degree)
+	}
+	return node, nil
+}
+
+// fenced parses \left<delimiter> ... \right<delimiter>.
+func (p *parser) fenced() (Node, error) {
+	open, err := p.delimiter()
+	if err != nil {
+		return Node{}, err
+	}
+	body, err := p.row(endRight)
+	if err != nil {
+		return Node{}, err
+	}
+	p.pos += len(`\right`)
+	closing, err := p.delimiter()
+	if err != nil {
+		return Node{}, err
+	}
+	return Node{Kind: NodeFenced, Children: []Node{open, body, closing}}, nil
+}
+
+// delimiter parses the delimiter after \left or \right; "." stands for none.
+func (p *parser) delimiter() (Node, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) {
+		return Node{}, ErrMissingArgument
+	}
+	for _, d := range delimiters {
+		if strings.HasPrefix(p.src[p.pos:], d.name) && !(d.name[0] == '\\' && p.letterAt(p.pos+len(d.name))) {
+			p.pos += len(d.name)
+			return Node{Kind: NodeOperator, Text: d.symbol}, nil
+		}
+	}
+	return Node{}, ErrUnsupported
+}
+
+// rawArgument returns the text of a braced argument without parsing it.
+func (p *parser) rawArgument() (string, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.src) || p.src[p.pos] != '{' {
+		return "", ErrMissingArgument
+	}
+	depth := 0
+	for i := p.pos; i < len(p.src); i++ {
+		switch p.src[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				text := p.src[p.pos+1 : i]
+				p.pos = i + 1
+				return text, nil
+			}
+		}
+	}
+	return "", ErrUnbalanced
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n' || p.src[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *parser) letterAt(pos int) bool {
+	return pos < len(p.src) && isLetter(p.src[pos])
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// asciiOperators maps characters typed directly to the symbols they stand for.
+var asciiOperators = map[rune]string{
+	'+': "+", '-': "−", '*': "∗", '/': "/", '=': "=", '<': "<", '>': ">",
+	'(': "(", ')': ")", '[': "[", ']': "]", '|': "|", ',': ",", ';': ";",
+	':': ":", '!': "!", '?': "?", '\'': "′", '.': ".",
+}
+
+var escapedSymbols = map[string]string{
+	"{": "{", "}": "}", "%": "%", "$": "$", "#": "#", "&": "&", "_": "_", "|": "‖",
+}
+
+// spaceWidths holds the width in em of the spacing commands, keyed without the backslash.
+var spaceWidths = map[string]float64{
+	",": 3.0 / 18, ":": 4.0 / 18, ";": 5.0 / 18, "!": -3.0 / 18, " ": 0.25,
+	"quad": 1, "qquad": 2,
+}
+
+var letters = map[string]string{
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ", "varepsilon": "ε",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "pi": "π", "rho": "ρ", "sigma": "σ",
+	"tau": "τ", "upsilon": "υ", "phi": "ϕ", "varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	"infty": "∞", "partial": "∂", "nabla": "∇", "hbar": "ℏ", "ell": "ℓ",
+}
+
+var symbols = map[string]string{
+	"cdot": "⋅", "times": "×", "div": "÷", "pm": "±", "mp": "∓", "ast": "∗", "circ": "∘",
+	"leq": "≤", "le": "≤", "geq": "≥", "ge": "≥", "neq": "≠", "ne": "≠",
+	"approx": "≈", "equiv": "≡", "sim": "∼", "propto": "∝",
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "gets": "←", "leftrightarrow": "↔",
+	"Rightarrow": "⇒", "implies": "⇒", "Leftrightarrow": "⇔", "iff": "⇔",
+	"in": "∈", "notin": "∉", "subset": "⊂", "subseteq": "⊆", "cup": "∪", "cap": "∩",
+	"forall": "∀", "exists": "∃", "ldots": "…", "dots": "…", "cdots": "⋯",
+	"langle": "⟨", "rangle": "⟩", "lbrace": "{", "rbrace": "}", "vert": "|", "Vert": "‖",
+	"prime": "′",
+}
+
+var largeOperators = map[string]string{
+	"sum": "∑", "prod": "∏", "int": "∫", "iint": "∬", "oint": "∮",
+}
+
+var functions = map[string]bool{
+	"sin": true, "cos": true, "tan": true, "cot": true, "sec": true, "csc": true,
+	"arcsin": true, "arccos": true, "arctan": true, "sinh": true, "cosh": true, "tanh": true,
+	"log": true, "ln": true, "lg": true, "exp": true, "lim": true, "max": true, "min": true,
+	"sup": true, "inf": true, "det": true, "gcd": true, "deg": true, "dim": true, "ker": true,
+}
+
+var accents = map[string]string{
+	"vec": AccentVector, "hat": AccentHat, "widehat": AccentHat, "bar": AccentBar,
+	"overline": AccentBar, "dot": AccentDot, "tilde": AccentTilde, "widetilde": AccentTilde,
+}
+
+var doubleStruck = map[string]string{
+	"R": "ℝ", "N": "ℕ", "Z": "ℤ", "Q": "ℚ", "C": "ℂ",
+}
+
+// delimiters lists what may follow \left and \right, longest names first.
+var delimiters = []struct {
+	name   string
+	symbol string
+}{
+	{`\langle`, "⟨"}, {`\rangle`, "⟩"}, {`\lbrace`, "{"}, {`\rbrace`, "}"},
+	{`\Vert`, "‖"}, {`\vert`, "|"}, {`\{`, "{"}, {`\}`, "}"}, {`\|`, "‖"},
+	{"(", "("}, {")", ")"}, {"[", "["}, {"]", "]"}, {"|", "|"}, {".", ""},
+}
@@ -0,0 +1,133 @@
+package media
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("media not found")
+	ErrEmptyCard       = errors.New("media must belong to a card")
+	ErrEmpty           = errors.New("media file must not be empty")
+	ErrTooLarge        = errors.New("media file exceeds the size limit: 5 MiB for images, 20 MiB for audio")
+	ErrUnsupportedType = errors.New("media must be a JPEG, PNG, GIF or WebP image or an OGG, MP3 or M4A audio file")
+	ErrInvalidSide     = errors.New("media side must be front or back")
+	ErrUnavailable     = errors.New("media storage is not configured")
+)
+
+// MaxImageSize and MaxAudioSize are the largest files of each kind accepted, in bytes;
+// MaxSize is the largest file of any kind.
+const (
+	MaxImageSize int64 = 5 << 20
+	MaxAudioSize int64 = 20 << 20
+	MaxSize            = MaxAudioSize
+)
+
+// Kind groups content types that are displayed the same way.
+type Kind string
+
+const (
+	KindImage Kind = "image"
+	KindAudio Kind = "audio"
+)
+
+// Side tells on which side of its card an attachment is shown.
+type Side string
+
+const (
+	SideFront Side = "front"
+	SideBack  Side = "back"
+)
+
+// Valid reports whether s is a known card side.
+func (s Side) Valid() bool {
+	return s == SideFront || s == SideBack
+}
+
+// types lists the accepted content types and their kinds.
+var types = map[string]Kind{
+	"image/jpeg": KindImage,
+	"image/png":  KindImage,
+	"image/gif":  KindImage,
+	"image/webp": KindImage,
+	"audio/ogg":  KindAudio,
+	"audio/mpeg": KindAudio,
+	"audio/mp4":  KindAudio,
+}
+
+// aliases maps other names in use for the accepted content types to the canonical ones.
+var aliases = map[string]string{
+	"application/ogg": "audio/ogg",
+	"audio/opus":      "audio/ogg",
+	"audio/mp3":       "audio/mpeg",
+	"audio/x-m4a":     "audio/mp4",
+	"audio/m4a":       "audio/mp4",
+}
+
+// CanonicalType drops the parameters of contentType and resolves known aliases,
+// so "audio/ogg; codecs=opus" and "application/ogg" both become "audio/ogg".
+func CanonicalType(contentType string) string {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if canonical, ok := aliases[contentType]; ok {
+		return canonical
+	}
+	return contentType
+}
+
+// Supported reports whether files of contentType may be attached to cards.
+func Supported(contentType string) bool {
+	_, ok := types[contentType]
+	return ok
+}
+
+// KindOf returns the kind of an accepted content type.
+func KindOf(contentType string) Kind {
+	return types[contentType]
+}
+
+// Limit returns the largest file accepted of the content type, in bytes.
+func Limit(contentType string) int64 {
+	if KindOf(contentType) == KindAudio {
+		return MaxAudioSize
+	}
+	return MaxImageSize
+}
+
+// Attachment references a media file shown on one side of a card. The file itself is kept by
+// the media storage under the attachment ID; Filename is the name it was uploaded with.
+type Attachment struct {
+	ID          string
+	CardID      string
+	Side        Side
+	ContentType string
+	Filename    string
+	Size        int64
+	CreatedAt   time.Time
+}
+
+// Validate ensures the attachment references a supported file within the size limit.
+func (a *Attachment) Validate() error {
+	if a.CardID == "" {
+		return ErrEmptyCard
+	}
+	if !a.Side.Valid() {
+		return ErrInvalidSide
+	}
+	if !Supported(a.ContentType) {
+		return ErrUnsupportedType
+	}
+	if a.Size <= 0 {
+		return ErrEmpty
+	}
+	if a.Size > Limit(a.ContentType) {
+		return ErrTooLarge
+	}
+	return nil
+}
+
+// Kind returns whether the attachment is an image or audio.
+func (a Attachment) Kind() Kind {
+	return KindOf(a.ContentType)
+}
@@ -0,0 +1,106 @@
+package note
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+var (
+	ErrNotFound     = errors.New("note not found")
+	ErrEmptyBack    = errors.New("note back must not be empty when a reverse card is requested")
+	ErrReverseCloze = errors.New("cloze notes cannot generate reverse cards")
+	ErrTypedNote    = errors.New("notes written with a note type are edited through their fields")
+	ErrUntypedNote  = errors.New("only notes written with a note type have fields")
+)
+
+// Note is the fact a user writes down; the cards studied are generated from it.
+// A basic note yields a forward card (front → back) and, with Reverse, a reversed one (back → front).
+// A cloze note keeps the {{cN::...}} text in Front and extra notes in Back and yields one card per cloze index.
+// A note written with a note type keeps its field values in Fields, and Front and Back hold the
+// basic card content rendered from them by the note type's templates.
+type Note struct {
+	ID         string
+	OwnerID    string
+	Type       card.Type
+	NoteTypeID string
+	Fields     map[string]string
+	Front      string
+	Back       string
+	Reverse    bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Validate ensures the note can generate its cards.
+func (n *Note) Validate() error {
+	if strings.TrimSpace(n.Front) == "" {
+		return card.ErrEmptyFront
+	}
+	if err := card.ValidateMarkdown(n.Front); err != nil {
+		return err
+	}
+	if err := card.ValidateMarkdown(n.Back); err != nil {
+		return err
+	}
+
+	switch n.Kind() {
+	case card.TypeBasic:
+		if n.Reverse && strings.TrimSpace(n.Back) == "" {
+			return ErrEmptyBack
+		}
+	case card.TypeCloze:
+		if n.Reverse {
+			return ErrReverseCloze
+		}
+		if _, err := card.ClozeIndexes(n.Front); err != nil {
+			return err
+		}
+	default:
+		return card.ErrUnknownType
+	}
+	return nil
+}
+
+// Kind returns the note type, treating notes without one as basic.
+func (n Note) Kind() card.Type {
+	if n.Type == "" {
+		return card.TypeBasic
+	}
+	return n.Type
+}
+
+// Cards returns the content of the cards the note generates, in a stable order.
+// IDs, timestamps and review state are left for the caller to fill in.
+func (n Note) Cards() ([]card.Card, error) {
+	if err := n.Validate(); err != nil {
+		return nil, err
+	}
+
+	base := card.Card{NoteID: n.ID, OwnerID: n.OwnerID, Type: n.Kind()}
+	if n.Kind() == card.TypeCloze {
+		indexes, err := card.ClozeIndexes(n.Front)
+		if err != nil {
+			return nil, err
+		}
+		cards := make([]card.Card, 0, len(indexes))
+		for _, index := range indexes {
+			c := base
+			c.Front, c.Back, c.ClozeIndex = n.Front, n.Back, index
+			cards = append(cards, c)
+		}
+		return cards, nil
+	}
+
+	forward := base
+	forward.Front, forward.Back = n.Front, n.Back
+	cards := []card.Card{forward}
+	if n.Reverse {
+		reversed := base
+		reversed.Front, reversed.Back, reversed.Reversed = n.Back, n.Front, true
+		cards = append(cards, reversed)
+	}
+	return cards, nil
+}
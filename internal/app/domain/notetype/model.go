@@ -0,0 +1,133 @@
+package notetype
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound         = errors.New("note type not found")
+	ErrEmptyName        = errors.New("note type name must not be empty")
+	ErrEmptyOwner       = errors.New("note type owner must not be empty")
+	ErrNoFields         = errors.New("note type needs at least one field")
+	ErrTooManyFields    = errors.New("note type must not have more than 32 fields")
+	ErrInvalidFieldName = errors.New("note type field names must start with a letter and contain only letters, digits and underscores")
+	ErrDuplicateField   = errors.New("note type field names must be unique")
+	ErrEmptyTemplate    = errors.New("note type front template must not be empty")
+	ErrInvalidTemplate  = errors.New("note type template does not parse, uses range or template, or refers to a field the type does not have")
+	ErrTemplateTooLong  = errors.New("note type template renders more than 10000 characters")
+	ErrMissingField     = errors.New("note is missing a required field of its note type")
+	ErrUnknownField     = errors.New("note has a field its note type does not define")
+	ErrInUse            = errors.New("note type is used by notes")
+	ErrForeignOwner     = errors.New("note type belongs to another owner")
+)
+
+const (
+	// MaxFields bounds how many fields a note type defines.
+	MaxFields = 32
+	// MaxRendered bounds the length in bytes of a rendered template.
+	MaxRendered = 10000
+)
+
+// Field is a named input of a note type, such as "word" or "meaning" of a vocabulary note.
+type Field struct {
+	Name     string
+	Required bool
+}
+
+// NoteType defines the fields of the notes written with it and the Go text/template
+// templates that turn those fields into the front and back of the cards they generate,
+// e.g. a front of "{{.word}}" and a back of "{{.meaning}}{{if .example}}\n_{{.example}}_{{end}}".
+type NoteType struct {
+	ID            string
+	OwnerID       string
+	Name          string
+	Fields        []Field
+	FrontTemplate string
+	BackTemplate  string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Validate ensures the note type has a name, well-formed unique fields and a front template.
+// Whether the templates parse is checked where they are rendered.
+func (t *NoteType) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrEmptyName
+	}
+	if t.OwnerID == "" {
+		return ErrEmptyOwner
+	}
+	if len(t.Fields) == 0 {
+		return ErrNoFields
+	}
+	if len(t.Fields) > MaxFields {
+		return ErrTooManyFields
+	}
+	seen := make(map[string]bool, len(t.Fields))
+	for _, f := range t.Fields {
+		if !validFieldName(f.Name) {
+			return ErrInvalidFieldName
+		}
+		if seen[f.Name] {
+			return ErrDuplicateField
+		}
+		seen[f.Name] = true
+	}
+	if strings.TrimSpace(t.FrontTemplate) == "" {
+		return ErrEmptyTemplate
+	}
+	return nil
+}
+
+// Accepts reports whether ownerID may use the note type.
+func (t NoteType) Accepts(ownerID string) error {
+	if t.OwnerID != ownerID {
+		return ErrForeignOwner
+	}
+	return nil
+}
+
+// CheckValues ensures values has every required field filled in and no field the type lacks.
+func (t NoteType) CheckValues(values map[string]string) error {
+	known := make(map[string]bool, len(t.Fields))
+	for _, f := range t.Fields {
+		known[f.Name] = true
+		if f.Required && strings.TrimSpace(values[f.Name]) == "" {
+			return ErrMissingField
+		}
+	}
+	for name := range values {
+		if !known[name] {
+			return ErrUnknownField
+		}
+	}
+	return nil
+}
+
+// Values returns values with every field of the type present, empty when not filled in, so
+// templates can test optional fields with {{if .name}}.
+func (t NoteType) Values(values map[string]string) map[string]string {
+	complete := make(map[string]string, len(t.Fields))
+	for _, f := range t.Fields {
+		complete[f.Name] = values[f.Name]
+	}
+	return complete
+}
+
+// validFieldName reports whether name can be referred to as {{.name}} in a template.
+func validFieldName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && (r >= '0' && r <= '9' || r == '_'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+package review
+
+import (
+	"errors"
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+var (
+	ErrEmptyCardID = errors.New("review log card id must not be empty")
+	ErrDuplicate   = errors.New("review log already recorded")
+)
+
+// Log is an immutable record of a single card review.
+type Log struct {
+	ID               string
+	CardID           string
+	UserID           string
+	Rating           card.Rating
+	PreviousInterval int // days scheduled before the review
+	NextInterval     int // days scheduled after the review
+	ElapsedDays      int // days since the previous review
+	AnswerTime       time.Duration
+	ReviewedAt       time.Time
+}
+
+// Validate ensures the log has the required fields.
+func (l *Log) Validate() error {
+	if l.CardID == "" {
+		return ErrEmptyCardID
+	}
+	if !l.Rating.Valid() {
+		return card.ErrInvalidRating
+	}
+	return nil
+}
+
+// Lapse reports whether the review forgot a card that had already graduated to a review interval.
+func (l Log) Lapse() bool {
+	return l.Rating == card.RatingAgain && l.PreviousInterval > 0
+}
+
+// Stats aggregates the review history of a card.
+type Stats struct {
+	Reviews           int
+	Lapses            int
+	AverageAnswerTime time.Duration
+	Retention         float64 // share of recalled cards among reviews of already learned cards
+}
+
+// Summarize computes Stats over logs.
+func Summarize(logs []Log) Stats {
+	var (
+		stats    Stats
+		answered time.Duration
+		timed    int
+		matured  int
+		recalled int
+	)
+	for _, l := range logs {
+		stats.Reviews++
+		if l.AnswerTime > 0 {
+			answered += l.AnswerTime
+			timed++
+		}
+		if l.PreviousInterval > 0 {
+			matured++
+			if l.Rating != card.RatingAgain {
+				recalled++
+			}
+		}
+		if l.Lapse() {
+			stats.Lapses++
+		}
+	}
+
+	if timed > 0 {
+		stats.AverageAnswerTime = answered / time.Duration(timed)
+	}
+	if matured > 0 {
+		stats.Retention = float64(recalled) / float64(matured)
+	}
+	return stats
+}
@@ -0,0 +1,65 @@
+package revision
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound       = errors.New("card revision not found")
+	ErrEmptyCardID    = errors.New("card revision card id must not be empty")
+	ErrInvalidNumber  = errors.New("card revision number must be positive")
+	ErrDuplicate      = errors.New("card revision already recorded")
+	ErrInvalidChannel = errors.New("card revision channel must be http or telegram")
+)
+
+// Channel is the entry point an edit was made through.
+type Channel string
+
+const (
+	ChannelHTTP     Channel = "http"
+	ChannelTelegram Channel = "telegram"
+)
+
+// Valid reports whether c is a known channel or empty, for content whose channel is unknown
+// such as the content a card had before its first recorded edit.
+func (c Channel) Valid() bool {
+	switch c {
+	case "", ChannelHTTP, ChannelTelegram:
+		return true
+	}
+	return false
+}
+
+// Editor names who edits a card and through which channel.
+type Editor struct {
+	UserID  string
+	Channel Channel
+}
+
+// Revision is an immutable snapshot of the content of a card. Revisions of a card are
+// numbered from 1 in the order they were made; revision 1 holds the content the card had
+// before its first edit.
+type Revision struct {
+	CardID    string
+	Number    int
+	Front     string
+	Back      string
+	EditorID  string
+	Channel   Channel
+	CreatedAt time.Time
+}
+
+// Validate ensures the revision has the required fields.
+func (r *Revision) Validate() error {
+	if r.CardID == "" {
+		return ErrEmptyCardID
+	}
+	if r.Number < 1 {
+		return ErrInvalidNumber
+	}
+	if !r.Channel.Valid() {
+		return ErrInvalidChannel
+	}
+	return nil
+}
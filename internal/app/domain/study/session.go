@@ -0,0 +1,74 @@
+package study
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSessionNotFound = errors.New("study session not found")
+	ErrSessionFinished = errors.New("study session already finished")
+	ErrQueueEmpty      = errors.New("study session has no cards left")
+	ErrNotCurrentCard  = errors.New("card is not the current card of the study session")
+	ErrEmptyUser       = errors.New("study session user id must not be empty")
+)
+
+// Limits caps how many cards a user studies per day.
+type Limits struct {
+	NewCards int
+	Reviews  int
+}
+
+// Item is one card waiting in a session queue.
+type Item struct {
+	CardID  string
+	New     bool
+	Answers int
+}
+
+// Session is a single study run over a user's due cards, or only those of DeckID or of
+// the filtered deck FilterID when set.
+// The head of Queue is the card currently shown to the user; ShownAt records when it was first shown.
+type Session struct {
+	ID         string
+	UserID     string
+	DeckID     string
+	FilterID   string
+	Queue      []Item
+	ShownAt    time.Time
+	NewStudied int
+	Reviewed   int
+	Requeued   int
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Validate ensures the session has the required fields.
+func (s *Session) Validate() error {
+	if s.UserID == "" {
+		return ErrEmptyUser
+	}
+	return nil
+}
+
+// Finished reports whether the session was closed.
+func (s Session) Finished() bool {
+	return !s.FinishedAt.IsZero()
+}
+
+// Current returns the item at the head of the queue.
+func (s Session) Current() (Item, error) {
+	if s.Finished() {
+		return Item{}, ErrSessionFinished
+	}
+	if len(s.Queue) == 0 {
+		return Item{}, ErrQueueEmpty
+	}
+	return s.Queue[0], nil
+}
+
+// Clone returns a copy of the session that does not share its queue.
+func (s Session) Clone() Session {
+	s.Queue = append([]Item(nil), s.Queue...)
+	return s
+}
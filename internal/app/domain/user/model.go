@@ -6,15 +6,41 @@ import (
 )
 
 var (
-	ErrEmptyID       = errors.New("user id must not be empty")
-	ErrEmptyNickname = errors.New("user nickname must not be empty")
-	ErrNotFound      = errors.New("user not found")
+	ErrEmptyID          = errors.New("user id must not be empty")
+	ErrEmptyNickname    = errors.New("user nickname must not be empty")
+	ErrNotFound         = errors.New("user not found")
+	ErrUnknownScheduler = errors.New("user scheduler is not supported")
+	ErrNegativeLimit    = errors.New("user daily limits must not be negative")
 )
 
+// Scheduler names the spaced-repetition algorithm a user studies with.
+type Scheduler string
+
+const (
+	SchedulerSM2     Scheduler = "sm2"
+	SchedulerFSRS    Scheduler = "fsrs"
+	SchedulerLeitner Scheduler = "leitner"
+
+	DefaultScheduler = SchedulerSM2
+)
+
+// Valid reports whether s names a supported scheduler.
+func (s Scheduler) Valid() bool {
+	switch s {
+	case SchedulerSM2, SchedulerFSRS, SchedulerLeitner:
+		return true
+	}
+	return false
+}
+
 // User represents an application user.
+// Zero daily limits mean the study service defaults apply.
 type User struct {
-	ID       string
-	Nickname string
+	ID               string
+	Nickname         string
+	Scheduler        Scheduler
+	DailyNewLimit    int
+	DailyReviewLimit int
 }
 
 // Validate ensures required fields are present.
@@ -25,5 +51,19 @@ func (u *User) Validate() error {
 	if strings.TrimSpace(u.Nickname) == "" {
 		return ErrEmptyNickname
 	}
+	if u.Scheduler != "" && !u.Scheduler.Valid() {
+		return ErrUnknownScheduler
+	}
+	if u.DailyNewLimit < 0 || u.DailyReviewLimit < 0 {
+		return ErrNegativeLimit
+	}
 	return nil
 }
+
+// PreferredScheduler returns the user's scheduler, falling back to DefaultScheduler when unset.
+func (u User) PreferredScheduler() Scheduler {
+	if u.Scheduler == "" {
+		return DefaultScheduler
+	}
+	return u.Scheduler
+}
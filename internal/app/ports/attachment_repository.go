@@ -0,0 +1,11 @@
+package ports
+
+import "flash2fy/internal/app/domain/media"
+
+// AttachmentRepository defines the persistence behavior for the media attachments of cards.
+type AttachmentRepository interface {
+	Save(media.Attachment) (media.Attachment, error)
+	FindByID(id string) (media.Attachment, error)
+	FindByCard(cardID string) ([]media.Attachment, error)
+	Delete(id string) error
+}
@@ -0,0 +1,7 @@
+package ports
+
+// CardPurgeHook is told about every card purged for good, whichever entry point purged it, so
+// adapters can forget what they keep about the card.
+type CardPurgeHook interface {
+	CardPurged(cardID string) error
+}
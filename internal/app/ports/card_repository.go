@@ -1,12 +1,27 @@
 package ports
 
-import "flash2fy/internal/app/domain/card"
+import (
+	"time"
 
-// CardRepository defines the persistence behavior for cards.
+	"flash2fy/internal/app/domain/card"
+)
+
+// CardRepository defines the persistence behavior for cards. Cards in the trash are left out
+// of every lookup but the FindTrashed ones; Update moves cards in and out of the trash.
 type CardRepository interface {
 	Save(card.Card) (card.Card, error)
 	FindByID(id string) (card.Card, error)
 	FindAll() ([]card.Card, error)
+	FindByOwner(ownerID string) ([]card.Card, error)
+	FindDue(ownerID string, now time.Time) ([]card.Card, error)
+	FindByNote(noteID string) ([]card.Card, error)
+	FindByDeck(deckID string) ([]card.Card, error)
+	FindTagged(filter card.TagFilter) ([]card.Card, error)
+	Search(ownerID, query string, limit int) ([]card.SearchHit, error)
+	FindMatching(criteria card.Criteria) ([]card.Card, error)
+	FindTrashed(ownerID string) ([]card.Card, error)
+	FindTrashedByID(id string) (card.Card, error)
+	FindTrashedBefore(t time.Time) ([]card.Card, error)
 	Update(card.Card) (card.Card, error)
 	Delete(id string) error
 }
@@ -0,0 +1,10 @@
+package ports
+
+import "flash2fy/internal/app/domain/deck"
+
+// DeckInviteRepository defines the persistence behavior for pending deck invites.
+type DeckInviteRepository interface {
+	Save(deck.Invite) (deck.Invite, error)
+	FindByToken(token string) (deck.Invite, error)
+	Delete(token string) error
+}
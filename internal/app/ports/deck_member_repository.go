@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/deck"
+
+// DeckMemberRepository defines the persistence behavior for deck memberships.
+// Save replaces the role of an existing member.
+type DeckMemberRepository interface {
+	Save(deck.Member) (deck.Member, error)
+	FindByDeck(deckID string) ([]deck.Member, error)
+	FindByUser(userID string) ([]deck.Member, error)
+	Delete(deckID, userID string) error
+}
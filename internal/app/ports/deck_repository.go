@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/deck"
+
+// DeckRepository defines the persistence behavior for decks.
+type DeckRepository interface {
+	Save(deck.Deck) (deck.Deck, error)
+	FindByID(id string) (deck.Deck, error)
+	FindByOwner(ownerID string) ([]deck.Deck, error)
+	Update(deck.Deck) (deck.Deck, error)
+	Delete(id string) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/filter"
+
+// FilterRepository defines the persistence behavior for saved filtered decks.
+type FilterRepository interface {
+	Save(filter.Filter) (filter.Filter, error)
+	FindByID(id string) (filter.Filter, error)
+	FindByOwner(ownerID string) ([]filter.Filter, error)
+	Update(filter.Filter) (filter.Filter, error)
+	Delete(id string) error
+}
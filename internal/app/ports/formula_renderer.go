@@ -0,0 +1,8 @@
+package ports
+
+import "flash2fy/internal/app/domain/formula"
+
+// FormulaRenderer draws a formula as a PNG image.
+type FormulaRenderer interface {
+	Render(f formula.Formula) ([]byte, error)
+}
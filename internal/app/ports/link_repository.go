@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/catalog"
+
+// LinkRepository defines the persistence behavior for the card links of subscribed decks.
+// Save replaces the link of a source card that is already linked.
+type LinkRepository interface {
+	Save(catalog.Link) (catalog.Link, error)
+	FindByDeck(deckID string) ([]catalog.Link, error)
+	Delete(deckID, sourceCardID string) error
+	DeleteByDeck(deckID string) error
+}
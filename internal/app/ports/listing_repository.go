@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/catalog"
+
+// ListingRepository defines the persistence behavior for the public deck catalog.
+// Save replaces the listing of a deck that is already published.
+type ListingRepository interface {
+	Save(catalog.Listing) (catalog.Listing, error)
+	FindByDeck(deckID string) (catalog.Listing, error)
+	Search(query, language string) ([]catalog.Listing, error)
+	Delete(deckID string) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "io"
+
+// MediaStorage keeps the content of media files under opaque keys.
+// Put returns the number of bytes stored; Open and Delete return media.ErrNotFound for unknown keys.
+type MediaStorage interface {
+	Put(key string, content io.Reader) (int64, error)
+	Open(key string) (io.ReadSeekCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,13 @@
+package ports
+
+import "flash2fy/internal/app/domain/note"
+
+// NoteRepository defines the persistence behavior for notes.
+type NoteRepository interface {
+	Save(note.Note) (note.Note, error)
+	FindByID(id string) (note.Note, error)
+	FindByOwner(ownerID string) ([]note.Note, error)
+	FindByNoteType(noteTypeID string) ([]note.Note, error)
+	Update(note.Note) (note.Note, error)
+	Delete(id string) error
+}
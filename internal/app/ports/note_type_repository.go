@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/notetype"
+
+// NoteTypeRepository defines the persistence behavior for user-defined note types.
+type NoteTypeRepository interface {
+	Save(notetype.NoteType) (notetype.NoteType, error)
+	FindByID(id string) (notetype.NoteType, error)
+	FindByOwner(ownerID string) ([]notetype.NoteType, error)
+	Update(notetype.NoteType) (notetype.NoteType, error)
+	Delete(id string) error
+}
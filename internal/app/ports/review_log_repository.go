@@ -0,0 +1,10 @@
+package ports
+
+import "flash2fy/internal/app/domain/review"
+
+// ReviewLogRepository defines the persistence behavior for review logs.
+// Logs are append-only: once saved they are never updated or deleted.
+type ReviewLogRepository interface {
+	Save(review.Log) (review.Log, error)
+	FindByCard(cardID string) ([]review.Log, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "flash2fy/internal/app/domain/revision"
+
+// RevisionRepository defines the persistence behavior for card revisions.
+// Revisions are append-only: they are only deleted together with their card.
+type RevisionRepository interface {
+	Save(revision.Revision) (revision.Revision, error)
+	FindByCard(cardID string) ([]revision.Revision, error)
+	FindByNumber(cardID string, number int) (revision.Revision, error)
+	DeleteByCard(cardID string) error
+}
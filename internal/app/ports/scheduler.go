@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"time"
+
+	"flash2fy/internal/app/domain/card"
+)
+
+// Scheduler computes the next review state of a card after it has been graded.
+type Scheduler interface {
+	Schedule(state card.ReviewState, rating card.Rating, now time.Time) card.ReviewState
+}
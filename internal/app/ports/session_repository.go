@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"time"
+
+	"flash2fy/internal/app/domain/study"
+)
+
+// SessionRepository defines the persistence behavior for study sessions.
+type SessionRepository interface {
+	Save(study.Session) (study.Session, error)
+	FindByID(id string) (study.Session, error)
+	FindByUserSince(userID string, since time.Time) ([]study.Session, error)
+	Update(study.Session) (study.Session, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "flash2fy/internal/app/domain/catalog"
+
+// SubscriptionRepository defines the persistence behavior for deck subscriptions, keyed by the subscribed copy.
+type SubscriptionRepository interface {
+	Save(catalog.Subscription) (catalog.Subscription, error)
+	FindByDeck(deckID string) (catalog.Subscription, error)
+	FindByUser(userID string) ([]catalog.Subscription, error)
+	Delete(deckID string) error
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/subosito/gotenv"
 )
@@ -19,15 +22,47 @@ type (
 
 	Telegram struct {
 		BotToken      string
+		BotUsername   string
 		WebhookURL    string
 		WebhookSecret string
 		WebhookPath   string
 	}
 
+	Study struct {
+		LeitnerCadence   []int
+		DailyNewLimit    int
+		DailyReviewLimit int
+		LeechThreshold   int
+	}
+
+	Reminders struct {
+		Timezone string
+		Interval time.Duration
+	}
+
+	Media struct {
+		Dir string
+	}
+
+	Formulas struct {
+		CacheDir   string
+		CacheLimit int64 // bytes
+		RateLimit  int   // image requests per client and minute
+	}
+
+	Trash struct {
+		PurgeInterval time.Duration
+	}
+
 	Config struct {
-		Server   Server
-		Database Database
-		Telegram Telegram
+		Server    Server
+		Database  Database
+		Telegram  Telegram
+		Study     Study
+		Reminders Reminders
+		Media     Media
+		Formulas  Formulas
+		Trash     Trash
 	}
 )
 
@@ -37,6 +72,56 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	cadence, err := parseCadence(getEnv("LEITNER_CADENCE", "1,2,4,8,16"))
+	if err != nil {
+		return nil, fmt.Errorf("parse LEITNER_CADENCE: %w", err)
+	}
+
+	dailyNew, err := getEnvInt("STUDY_DAILY_NEW_LIMIT", 20)
+	if err != nil {
+		return nil, err
+	}
+	dailyReviews, err := getEnvInt("STUDY_DAILY_REVIEW_LIMIT", 200)
+	if err != nil {
+		return nil, err
+	}
+
+	leechThreshold, err := getEnvInt("LEECH_THRESHOLD", 8)
+	if err != nil {
+		return nil, err
+	}
+
+	formulaCacheMB, err := getEnvInt("FORMULA_CACHE_LIMIT_MB", 64)
+	if err != nil {
+		return nil, err
+	}
+	if formulaCacheMB <= 0 {
+		return nil, errors.New("FORMULA_CACHE_LIMIT_MB must be positive")
+	}
+	formulaRate, err := getEnvInt("FORMULA_RATE_LIMIT", 60)
+	if err != nil {
+		return nil, err
+	}
+	if formulaRate <= 0 {
+		return nil, errors.New("FORMULA_RATE_LIMIT must be positive")
+	}
+
+	reminderInterval, err := time.ParseDuration(getEnv("REMINDER_CHECK_INTERVAL", "1m"))
+	if err != nil {
+		return nil, fmt.Errorf("parse REMINDER_CHECK_INTERVAL: %w", err)
+	}
+	if reminderInterval <= 0 {
+		return nil, errors.New("REMINDER_CHECK_INTERVAL must be positive")
+	}
+
+	trashInterval, err := time.ParseDuration(getEnv("TRASH_PURGE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("parse TRASH_PURGE_INTERVAL: %w", err)
+	}
+	if trashInterval <= 0 {
+		return nil, errors.New("TRASH_PURGE_INTERVAL must be positive")
+	}
+
 	cfg := &Config{
 		Server: Server{
 			Addr: getEnv("SERVER_ADDR", ":8080"),
@@ -46,10 +131,32 @@ func Load() (*Config, error) {
 		},
 		Telegram: Telegram{
 			BotToken:      getEnv("TELEGRAM_BOT_TOKEN", ""),
+			BotUsername:   strings.TrimPrefix(getEnv("TELEGRAM_BOT_USERNAME", ""), "@"),
 			WebhookURL:    getEnv("TELEGRAM_WEBHOOK_URL", ""),
 			WebhookSecret: getEnv("TELEGRAM_WEBHOOK_SECRET", ""),
 			WebhookPath:   getEnv("TELEGRAM_WEBHOOK_PATH", "/telegram/webhook"),
 		},
+		Study: Study{
+			LeitnerCadence:   cadence,
+			DailyNewLimit:    dailyNew,
+			DailyReviewLimit: dailyReviews,
+			LeechThreshold:   leechThreshold,
+		},
+		Reminders: Reminders{
+			Timezone: getEnv("REMINDER_TIMEZONE", "UTC"),
+			Interval: reminderInterval,
+		},
+		Media: Media{
+			Dir: getEnv("MEDIA_DIR", "./data/media"),
+		},
+		Formulas: Formulas{
+			CacheDir:   getEnv("FORMULA_CACHE_DIR", "./data/formulas"),
+			CacheLimit: int64(formulaCacheMB) << 20,
+			RateLimit:  formulaRate,
+		},
+		Trash: Trash{
+			PurgeInterval: trashInterval,
+		},
 	}
 
 	return cfg, nil
@@ -75,3 +182,32 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) (int, error) {
+	val, ok := os.LookupEnv(key)
+	if !ok {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", key, err)
+	}
+	return n, nil
+}
+
+// parseCadence reads a comma-separated list of positive day counts such as "1,2,4,8,16".
+func parseCadence(value string) ([]int, error) {
+	parts := strings.Split(value, ",")
+	cadence := make([]int, 0, len(parts))
+	for _, part := range parts {
+		days, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if days <= 0 {
+			return nil, fmt.Errorf("box interval must be positive, got %d", days)
+		}
+		cadence = append(cadence, days)
+	}
+	return cadence, nil
+}
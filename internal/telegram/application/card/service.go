@@ -1,20 +1,44 @@
 package cardapp
 
 import (
+	"io"
+
 	"github.com/google/uuid"
 
 	appcard "flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/media"
+	"flash2fy/internal/app/domain/revision"
 	telegrmdomain "flash2fy/internal/telegram/domain"
 	telegrmports "flash2fy/internal/telegram/ports"
 )
 
 // AppCardService captures the upstream application contract used by Telegram.
 type AppCardService interface {
-	CreateCard(front, back, ownerID string) (appcard.Card, error)
-	GetCard(id string) (appcard.Card, error)
-	DeleteCard(id string) error
+	CreateCard(front, back, ownerID string, tags ...string) (appcard.Card, error)
+	GetCard(id, actorID string) (appcard.Card, error)
+	EditCard(id, front, back string, editor revision.Editor) (appcard.Card, error)
+	DeleteCard(id, actorID string) error
+	Trash(ownerID string) ([]appcard.Card, error)
+	RestoreCard(id, actorID string) (appcard.Card, error)
+	PurgeCard(id, actorID string) error
+	LeitnerBoxes(ownerID string) ([]appcard.LeitnerBox, error)
+	SuspendCard(id, actorID string) (appcard.Card, error)
+	UnsuspendCard(id, actorID string) (appcard.Card, error)
+	Search(ownerID, query string, limit int) ([]appcard.SearchHit, error)
+	AttachMedia(cardID, actorID string, side media.Side, filename, contentType string, r io.Reader) (media.Attachment, error)
+	Media(cardID, actorID string) ([]media.Attachment, error)
+	OpenMedia(cardID, id, actorID string) (media.Attachment, io.ReadSeekCloser, error)
 }
 
+// Telegram re-encodes every photo sent to a chat as JPEG.
+const (
+	photoFilename    = "photo.jpg"
+	photoContentType = "image/jpeg"
+)
+
+// searchLimit keeps search results short enough for a chat message.
+const searchLimit = 10
+
 // Service orchestrates Telegram card workflows.
 type Service struct {
 	appCards AppCardService
@@ -25,8 +49,8 @@ func NewService(appCards AppCardService, ctxRepo telegrmports.CardRepository) *S
 	return &Service{appCards: appCards, ctxRepo: ctxRepo}
 }
 
-func (s *Service) CreateCard(front, back string, owner telegrmdomain.User, chatID int64) (appcard.Card, error) {
-	created, err := s.appCards.CreateCard(front, back, owner.CoreUserID)
+func (s *Service) CreateCard(front, back string, owner telegrmdomain.User, chatID int64, tags ...string) (appcard.Card, error) {
+	created, err := s.appCards.CreateCard(front, back, owner.CoreUserID, tags...)
 	if err != nil {
 		return appcard.Card{}, err
 	}
@@ -48,13 +72,165 @@ func (s *Service) CreateCard(front, back string, owner telegrmdomain.User, chatI
 	return created, nil
 }
 
-func (s *Service) GetCard(id string) (appcard.Card, error) {
-	return s.appCards.GetCard(id)
+// CreatePhotoCard creates a card with the photo read from r on its front. The card is removed
+// again when the photo cannot be attached, so a failed upload leaves no half-made card behind.
+func (s *Service) CreatePhotoCard(front string, photo io.Reader, owner telegrmdomain.User, chatID int64, tags ...string) (appcard.Card, error) {
+	created, err := s.CreateCard(front, "", owner, chatID, tags...)
+	if err != nil {
+		return appcard.Card{}, err
+	}
+
+	if _, err := s.appCards.AttachMedia(created.ID, owner.CoreUserID, media.SideFront, photoFilename, photoContentType, photo); err != nil {
+		s.discard(created.ID, owner)
+		return appcard.Card{}, err
+	}
+	return created, nil
+}
+
+// AttachAudio attaches a pronunciation to the back of one of the owner's cards.
+// Cards of other users are reported as not found.
+func (s *Service) AttachAudio(cardID string, owner telegrmdomain.User, filename, contentType string, audio io.Reader) (media.Attachment, error) {
+	if err := s.checkOwner(cardID, owner); err != nil {
+		return media.Attachment{}, err
+	}
+	return s.appCards.AttachMedia(cardID, owner.CoreUserID, media.SideBack, filename, contentType, audio)
+}
+
+// EditCard replaces the content of one of the owner's cards, recording the edit as made
+// through Telegram. Cards of other users are reported as not found.
+func (s *Service) EditCard(cardID string, owner telegrmdomain.User, front, back string) (appcard.Card, error) {
+	if err := s.checkOwner(cardID, owner); err != nil {
+		return appcard.Card{}, err
+	}
+	return s.appCards.EditCard(cardID, front, back, revision.Editor{UserID: owner.CoreUserID, Channel: revision.ChannelTelegram})
+}
+
+// Media returns the attachments shown on one side of a card the owner may view, oldest first.
+// Cards of other users are reported as not found.
+func (s *Service) Media(cardID string, owner telegrmdomain.User, side media.Side) ([]media.Attachment, error) {
+	attachments, err := s.appCards.Media(cardID, owner.CoreUserID)
+	if err != nil {
+		return nil, notFoundIfForeign(err)
+	}
+	onSide := make([]media.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		if a.Side == side {
+			onSide = append(onSide, a)
+		}
+	}
+	return onSide, nil
+}
+
+// OpenMedia returns the content of an attachment of a card the owner may view, which the caller
+// must close. Cards of other users are reported as not found.
+func (s *Service) OpenMedia(cardID string, owner telegrmdomain.User, id string) (io.ReadSeekCloser, error) {
+	_, content, err := s.appCards.OpenMedia(cardID, id, owner.CoreUserID)
+	return content, notFoundIfForeign(err)
+}
+
+// GetCard returns one of the owner's cards. Cards of other users are reported as not found.
+func (s *Service) GetCard(id string, owner telegrmdomain.User) (appcard.Card, error) {
+	c, err := s.appCards.GetCard(id, owner.CoreUserID)
+	if err != nil {
+		return appcard.Card{}, notFoundIfForeign(err)
+	}
+	if c.OwnerID != owner.CoreUserID {
+		return appcard.Card{}, appcard.ErrNotFound
+	}
+	return c, nil
+}
+
+// DeleteCard moves one of the owner's cards to the trash. Its projection is kept so that
+// the card returns to its chat when restored. Cards of other users are reported as not found.
+func (s *Service) DeleteCard(cardID string, owner telegrmdomain.User) error {
+	if err := s.checkOwner(cardID, owner); err != nil {
+		return err
+	}
+	return s.appCards.DeleteCard(cardID, owner.CoreUserID)
+}
+
+// Trash returns the owner's deleted cards, the most recently deleted first.
+func (s *Service) Trash(owner telegrmdomain.User) ([]appcard.Card, error) {
+	return s.appCards.Trash(owner.CoreUserID)
+}
+
+// RestoreCard takes one of the owner's cards out of the trash. Cards of other users are reported as not found.
+func (s *Service) RestoreCard(cardID string, owner telegrmdomain.User) (appcard.Card, error) {
+	trash, err := s.appCards.Trash(owner.CoreUserID)
+	if err != nil {
+		return appcard.Card{}, err
+	}
+	for _, c := range trash {
+		if c.ID == cardID {
+			return s.appCards.RestoreCard(cardID, owner.CoreUserID)
+		}
+	}
+	return appcard.Card{}, appcard.ErrNotFound
 }
 
-func (s *Service) DeleteCard(id string) error {
-	if err := s.appCards.DeleteCard(id); err != nil {
+// discard removes a card that was never handed to the user, skipping the trash.
+func (s *Service) discard(cardID string, owner telegrmdomain.User) {
+	if err := s.appCards.DeleteCard(cardID, owner.CoreUserID); err != nil {
+		return
+	}
+	_ = s.appCards.PurgeCard(cardID, owner.CoreUserID)
+}
+
+// ProjectionPurger drops the projection of every card the core purges, so purging through
+// HTTP, the bot or the trash worker leaves no chat pointing at a card that is gone.
+type ProjectionPurger struct {
+	ctxRepo telegrmports.CardRepository
+}
+
+func NewProjectionPurger(ctxRepo telegrmports.CardRepository) *ProjectionPurger {
+	return &ProjectionPurger{ctxRepo: ctxRepo}
+}
+
+// CardPurged deletes the projection of the card, if it has one.
+func (p *ProjectionPurger) CardPurged(cardID string) error {
+	if err := p.ctxRepo.DeleteByCoreID(cardID); err != nil && err != telegrmdomain.ErrCardNotFound {
 		return err
 	}
-	return s.ctxRepo.DeleteByCoreID(id)
+	return nil
+}
+
+// LeitnerBoxes reports how the owner's cards are spread across Leitner boxes.
+func (s *Service) LeitnerBoxes(owner telegrmdomain.User) ([]appcard.LeitnerBox, error) {
+	return s.appCards.LeitnerBoxes(owner.CoreUserID)
+}
+
+// SuspendCard keeps one of the owner's cards out of reviews until it is unsuspended.
+// Cards of other users are reported as not found.
+func (s *Service) SuspendCard(cardID string, owner telegrmdomain.User) (appcard.Card, error) {
+	if err := s.checkOwner(cardID, owner); err != nil {
+		return appcard.Card{}, err
+	}
+	return s.appCards.SuspendCard(cardID, owner.CoreUserID)
+}
+
+// UnsuspendCard returns one of the owner's cards to reviews. Cards of other users are reported as not found.
+func (s *Service) UnsuspendCard(cardID string, owner telegrmdomain.User) (appcard.Card, error) {
+	if err := s.checkOwner(cardID, owner); err != nil {
+		return appcard.Card{}, err
+	}
+	return s.appCards.UnsuspendCard(cardID, owner.CoreUserID)
+}
+
+// checkOwner reports cards that do not belong to owner as not found.
+func (s *Service) checkOwner(cardID string, owner telegrmdomain.User) error {
+	_, err := s.GetCard(cardID, owner)
+	return err
+}
+
+// notFoundIfForeign hides the cards the core refuses to show the user behind appcard.ErrNotFound.
+func notFoundIfForeign(err error) error {
+	if err == appcard.ErrForeignOwner {
+		return appcard.ErrNotFound
+	}
+	return err
+}
+
+// Search finds the owner's cards containing every word of the query, the most relevant first.
+func (s *Service) Search(owner telegrmdomain.User, query string) ([]appcard.SearchHit, error) {
+	return s.appCards.Search(owner.CoreUserID, query, searchLimit)
 }
@@ -1,12 +1,18 @@
 package cardapp
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	cardstorage "flash2fy/internal/adapters/storage/card"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	revisionstorage "flash2fy/internal/adapters/storage/revision"
 	telecardstorage "flash2fy/internal/adapters/storage/telegram/card"
 	appcardapp "flash2fy/internal/app/application/card"
 	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/media"
+	"flash2fy/internal/app/domain/revision"
 	telegrmdomain "flash2fy/internal/telegram/domain"
 )
 
@@ -55,13 +61,172 @@ func TestDeleteCard(t *testing.T) {
 		t.Fatalf("create card failed: %v", err)
 	}
 
-	if err := service.DeleteCard(created.ID); err != nil {
+	if err := service.DeleteCard(created.ID, telegrmdomain.User{CoreUserID: "core-user-2"}); err != card.ErrNotFound {
+		t.Fatalf("expected another user's card reported as not found, got %v", err)
+	}
+	if err := service.DeleteCard(created.ID, owner); err != nil {
 		t.Fatalf("delete failed: %v", err)
 	}
 	if _, err := appRepo.FindByID(created.ID); err != card.ErrNotFound {
-		t.Fatalf("expected core card removed, got %v", err)
+		t.Fatalf("expected core card moved to the trash, got %v", err)
+	}
+	if _, err := ctxRepo.FindByCoreID(created.ID); err != nil {
+		t.Fatalf("expected context projection kept while in the trash, got %v", err)
+	}
+
+	if _, err := service.RestoreCard(created.ID, telegrmdomain.User{CoreUserID: "core-user-2"}); err != card.ErrNotFound {
+		t.Fatalf("expected another user's trash left alone, got %v", err)
+	}
+	restored, err := service.RestoreCard(created.ID, owner)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+	if restored.Trashed() {
+		t.Fatalf("expected the card out of the trash, got %+v", restored)
+	}
+	if projection, err := ctxRepo.FindByCoreID(created.ID); err != nil || projection.ChatID != 1234 {
+		t.Fatalf("expected the projection to follow the restored card, got %+v, %v", projection, err)
+	}
+}
+
+func TestPurgeTrashDropsProjections(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	ctxRepo := telecardstorage.NewMemoryRepository()
+	appService := appcardapp.NewService(cardstorage.NewMemoryRepository(),
+		appcardapp.WithClock(func() time.Time { return now }),
+		appcardapp.WithPurgeHook(NewProjectionPurger(ctxRepo)))
+	service := NewService(appService, ctxRepo)
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+
+	created, _ := service.CreateCard("Front", "Back", owner, 1234)
+	if err := service.DeleteCard(created.ID, owner); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	// The trash worker purges through the core service.
+	if purged, err := appService.PurgeTrash(); err != nil || len(purged) != 0 {
+		t.Fatalf("expected nothing purged before the retention period, got %+v, %v", purged, err)
+	}
+
+	now = now.Add(card.TrashRetention + time.Minute)
+	if purged, err := appService.PurgeTrash(); err != nil || len(purged) != 1 {
+		t.Fatalf("expected the expired card purged, got %+v, %v", purged, err)
+	}
+	if trash, _ := service.Trash(owner); len(trash) != 0 {
+		t.Fatalf("expected the trash emptied, got %+v", trash)
 	}
 	if _, err := ctxRepo.FindByCoreID(created.ID); err == nil {
-		t.Fatalf("expected context projection removed")
+		t.Fatalf("expected context projection purged with the card")
+	}
+}
+
+func TestPurgeCardDropsProjection(t *testing.T) {
+	ctxRepo := telecardstorage.NewMemoryRepository()
+	appService := appcardapp.NewService(cardstorage.NewMemoryRepository(),
+		appcardapp.WithPurgeHook(NewProjectionPurger(ctxRepo)))
+	service := NewService(appService, ctxRepo)
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+
+	created, _ := service.CreateCard("Front", "Back", owner, 1234)
+	if err := service.DeleteCard(created.ID, owner); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	// The card is purged through the core service, as DELETE /v1/cards/trash/{id} does.
+	if err := appService.PurgeCard(created.ID, owner.CoreUserID); err != nil {
+		t.Fatalf("purge failed: %v", err)
+	}
+	if _, err := ctxRepo.FindByCoreID(created.ID); err != telegrmdomain.ErrCardNotFound {
+		t.Fatalf("expected the projection purged with the card, got %v", err)
+	}
+}
+
+func TestCreatePhotoCard(t *testing.T) {
+	appRepo := cardstorage.NewMemoryRepository()
+	attachments := mediastorage.NewMemoryRepository()
+	appService := appcardapp.NewService(appRepo, appcardapp.WithMedia(attachments, mediastorage.NewMemoryStorage()))
+	ctxRepo := telecardstorage.NewMemoryRepository()
+	service := NewService(appService, ctxRepo)
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+
+	created, err := service.CreatePhotoCard("Which bone?", strings.NewReader("jpeg-bytes"), owner, 1234, "anatomy")
+	if err != nil {
+		t.Fatalf("create photo card failed: %v", err)
+	}
+	listed, _ := attachments.FindByCard(created.ID)
+	if len(listed) != 1 || listed[0].Side != media.SideFront || listed[0].ContentType != "image/jpeg" {
+		t.Fatalf("expected the photo on the front, got %+v", listed)
+	}
+
+	if _, err := service.CreatePhotoCard("Empty photo", strings.NewReader(""), owner, 1234); err != media.ErrEmpty {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+	cards, _ := appRepo.FindAll()
+	if len(cards) != 1 {
+		t.Fatalf("expected the card of the failed photo to be removed, got %d cards", len(cards))
+	}
+}
+
+func TestAttachAudioToOwnCard(t *testing.T) {
+	appRepo := cardstorage.NewMemoryRepository()
+	appService := appcardapp.NewService(appRepo, appcardapp.WithMedia(mediastorage.NewMemoryRepository(), mediastorage.NewMemoryStorage()))
+	service := NewService(appService, telecardstorage.NewMemoryRepository())
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+	stranger := telegrmdomain.User{ID: "tg-user-2", CoreUserID: "core-user-2", TelegramID: 43}
+
+	created, _ := service.CreateCard("der Hund", "the dog", owner, 1234)
+	if _, err := service.AttachAudio(created.ID, stranger, "voice.ogg", "audio/ogg", strings.NewReader("ogg")); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for another user's card, got %v", err)
+	}
+
+	attachment, err := service.AttachAudio(created.ID, owner, "voice.ogg", "audio/ogg; codecs=opus", strings.NewReader("ogg"))
+	if err != nil {
+		t.Fatalf("attach audio failed: %v", err)
+	}
+	if attachment.Side != media.SideBack || attachment.ContentType != "audio/ogg" {
+		t.Fatalf("expected the audio on the back, got %+v", attachment)
+	}
+
+	if front, _ := service.Media(created.ID, owner, media.SideFront); len(front) != 0 {
+		t.Fatalf("expected nothing on the front, got %+v", front)
+	}
+	if _, err := service.Media(created.ID, stranger, media.SideBack); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound listing another user's media, got %v", err)
+	}
+	if _, err := service.OpenMedia(created.ID, stranger, attachment.ID); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound opening another user's media, got %v", err)
+	}
+	back, _ := service.Media(created.ID, owner, media.SideBack)
+	if len(back) != 1 || back[0].ID != attachment.ID {
+		t.Fatalf("expected the audio on the back, got %+v", back)
+	}
+	content, err := service.OpenMedia(created.ID, owner, attachment.ID)
+	if err != nil {
+		t.Fatalf("open media failed: %v", err)
+	}
+	content.Close()
+}
+
+func TestEditOwnCard(t *testing.T) {
+	appRepo := cardstorage.NewMemoryRepository()
+	appService := appcardapp.NewService(appRepo, appcardapp.WithRevisions(revisionstorage.NewMemoryRepository()))
+	service := NewService(appService, telecardstorage.NewMemoryRepository())
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+	stranger := telegrmdomain.User{ID: "tg-user-2", CoreUserID: "core-user-2", TelegramID: 43}
+
+	created, _ := service.CreateCard("Hund", "", owner, 1234)
+	if _, err := service.EditCard(created.ID, stranger, "x", "y"); err != card.ErrNotFound {
+		t.Fatalf("expected ErrNotFound for another user's card, got %v", err)
+	}
+
+	edited, err := service.EditCard(created.ID, owner, "der Hund", "the dog")
+	if err != nil {
+		t.Fatalf("edit card failed: %v", err)
+	}
+	if edited.Front != "der Hund" || edited.Back != "the dog" {
+		t.Fatalf("unexpected edited card: %+v", edited)
+	}
+
+	revisions, _ := appService.Revisions(created.ID, owner.CoreUserID)
+	if len(revisions) != 2 || revisions[1].Channel != revision.ChannelTelegram || revisions[1].EditorID != owner.CoreUserID {
+		t.Fatalf("expected the edit recorded as made through Telegram, got %+v", revisions)
 	}
 }
@@ -0,0 +1,56 @@
+package catalogapp
+
+import (
+	appcatalogapp "flash2fy/internal/app/application/catalog"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/catalog"
+	"flash2fy/internal/app/domain/deck"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+// BrowseLimit caps how many catalog entries are listed in a single chat message.
+const BrowseLimit = 10
+
+// AppCatalogService captures the upstream catalog contract used by Telegram.
+type AppCatalogService interface {
+	Browse(query, language string) ([]appcatalogapp.Entry, error)
+	Clone(deckID, userID string) (deck.Deck, []card.Card, error)
+	SyncAll(userID string) (catalog.SyncReport, error)
+}
+
+// Service lets Telegram users browse the public deck catalog and clone decks from it.
+type Service struct {
+	catalog AppCatalogService
+}
+
+func NewService(catalog AppCatalogService) *Service {
+	return &Service{catalog: catalog}
+}
+
+// Browse returns up to BrowseLimit catalog entries matching every word of query, in any language.
+func (s *Service) Browse(query string) ([]appcatalogapp.Entry, error) {
+	entries, err := s.catalog.Browse(query, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > BrowseLimit {
+		entries = entries[:BrowseLimit]
+	}
+	return entries, nil
+}
+
+// Clone copies a published deck into the collection of the core user behind the Telegram account.
+func (s *Service) Clone(owner telegrmdomain.User, deckID string) (deck.Deck, []card.Card, error) {
+	if owner.CoreUserID == "" {
+		return deck.Deck{}, nil, telegrmdomain.ErrEmptyCoreID
+	}
+	return s.catalog.Clone(deckID, owner.CoreUserID)
+}
+
+// Sync pulls upstream changes into every deck the core user behind the Telegram account subscribes to.
+func (s *Service) Sync(owner telegrmdomain.User) (catalog.SyncReport, error) {
+	if owner.CoreUserID == "" {
+		return catalog.SyncReport{}, telegrmdomain.ErrEmptyCoreID
+	}
+	return s.catalog.SyncAll(owner.CoreUserID)
+}
@@ -0,0 +1,85 @@
+package catalogapp
+
+import (
+	"fmt"
+	"testing"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	catalogstorage "flash2fy/internal/adapters/storage/catalog"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	appcardapp "flash2fy/internal/app/application/card"
+	appcatalogapp "flash2fy/internal/app/application/catalog"
+	appdeckapp "flash2fy/internal/app/application/deck"
+	"flash2fy/internal/app/domain/catalog"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+func TestBrowseAndClone(t *testing.T) {
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	appCatalog := appcatalogapp.NewService(catalogstorage.NewMemoryRepository(), decks, cards)
+	appDecks := appdeckapp.NewService(decks, cards)
+	service := NewService(appCatalog)
+
+	var first string
+	for i := 0; i < BrowseLimit+2; i++ {
+		d, err := appDecks.CreateDeck(fmt.Sprintf("Deck %d", i), "", "teacher", "")
+		if err != nil {
+			t.Fatalf("create deck failed: %v", err)
+		}
+		if _, err := appCatalog.Publish(d.ID, "teacher", d.Name, "en", "Vocabulary"); err != nil {
+			t.Fatalf("publish failed: %v", err)
+		}
+		if first == "" {
+			first = d.ID
+		}
+	}
+
+	entries, err := service.Browse("vocabulary")
+	if err != nil {
+		t.Fatalf("browse failed: %v", err)
+	}
+	if len(entries) != BrowseLimit {
+		t.Fatalf("expected %d entries, got %d", BrowseLimit, len(entries))
+	}
+
+	if _, _, err := service.Clone(telegrmdomain.User{ID: "ctx-1"}, first); err != telegrmdomain.ErrEmptyCoreID {
+		t.Fatalf("expected ErrEmptyCoreID, got %v", err)
+	}
+	student := telegrmdomain.User{ID: "ctx-1", CoreUserID: "student", TelegramID: 42}
+	clone, _, err := service.Clone(student, first)
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	if clone.OwnerID != "student" || clone.SourceID != first {
+		t.Fatalf("unexpected clone: %+v", clone)
+	}
+	if _, _, err := service.Clone(student, "unknown"); err != catalog.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestSync(t *testing.T) {
+	decks := deckstorage.NewMemoryRepository()
+	cards := cardstorage.NewMemoryRepository()
+	appCatalog := appcatalogapp.NewService(catalogstorage.NewMemoryRepository(), decks, cards,
+		appcatalogapp.WithSubscriptions(catalogstorage.NewMemorySubscriptionRepository(), catalogstorage.NewMemoryLinkRepository()),
+	)
+	appCards := appcardapp.NewService(cards, appcardapp.WithDecks(decks))
+	service := NewService(appCatalog)
+
+	d, _ := appdeckapp.NewService(decks, cards).CreateDeck("German", "", "teacher", "")
+	appCatalog.Publish(d.ID, "teacher", "German", "de", "")
+	if _, _, err := appCatalog.Subscribe(d.ID, "student"); err != nil {
+		t.Fatalf("subscribe failed: %v", err)
+	}
+	appCards.CreateCardInDeck("der Hund", "dog", "teacher", d.ID)
+
+	report, err := service.Sync(telegrmdomain.User{ID: "ctx-1", CoreUserID: "student", TelegramID: 42})
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if report.Added != 1 {
+		t.Fatalf("expected the new card to be pulled, got %+v", report)
+	}
+}
@@ -0,0 +1,28 @@
+package deckapp
+
+import (
+	"flash2fy/internal/app/domain/deck"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+// AppDeckService captures the upstream deck-sharing contract used by Telegram.
+type AppDeckService interface {
+	AcceptInvite(token, userID string) (deck.Deck, deck.Member, error)
+}
+
+// Service lets Telegram users join shared decks through invite deep links.
+type Service struct {
+	decks AppDeckService
+}
+
+func NewService(decks AppDeckService) *Service {
+	return &Service{decks: decks}
+}
+
+// AcceptInvite makes the core user behind the Telegram account a member of the invite's deck.
+func (s *Service) AcceptInvite(owner telegrmdomain.User, token string) (deck.Deck, deck.Member, error) {
+	if owner.CoreUserID == "" {
+		return deck.Deck{}, deck.Member{}, telegrmdomain.ErrEmptyCoreID
+	}
+	return s.decks.AcceptInvite(token, owner.CoreUserID)
+}
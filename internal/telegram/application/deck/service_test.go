@@ -0,0 +1,40 @@
+package deckapp
+
+import (
+	"testing"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	deckstorage "flash2fy/internal/adapters/storage/deck"
+	appdeckapp "flash2fy/internal/app/application/deck"
+	"flash2fy/internal/app/domain/deck"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+func TestAcceptInvite(t *testing.T) {
+	appService := appdeckapp.NewService(deckstorage.NewMemoryRepository(), cardstorage.NewMemoryRepository(),
+		appdeckapp.WithSharing(deckstorage.NewMemoryMemberRepository(), deckstorage.NewMemoryInviteRepository()),
+	)
+	service := NewService(appService)
+
+	d, err := appService.CreateDeck("German", "", "teacher", "")
+	if err != nil {
+		t.Fatalf("create deck failed: %v", err)
+	}
+	invite, err := appService.Invite(d.ID, "teacher", deck.RoleViewer)
+	if err != nil {
+		t.Fatalf("invite failed: %v", err)
+	}
+
+	student := telegrmdomain.User{ID: "ctx-1", CoreUserID: "student", TelegramID: 42}
+	joined, member, err := service.AcceptInvite(student, invite.Token)
+	if err != nil {
+		t.Fatalf("accept failed: %v", err)
+	}
+	if joined.Name != "German" || member.UserID != "student" || member.Role != deck.RoleViewer {
+		t.Fatalf("unexpected membership: %+v in %+v", member, joined)
+	}
+
+	if _, _, err := service.AcceptInvite(student, "unknown"); err != deck.ErrInviteNotFound {
+		t.Fatalf("expected ErrInviteNotFound, got %v", err)
+	}
+}
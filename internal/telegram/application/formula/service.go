@@ -0,0 +1,42 @@
+package formulaapp
+
+import (
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/formula"
+)
+
+// MaxImages caps how many formula images are sent for one side of a card.
+const MaxImages = 5
+
+// AppFormulaService captures the upstream formula rendering contract used by Telegram.
+type AppFormulaService interface {
+	Image(f formula.Formula) ([]byte, error)
+}
+
+// Service renders the formulas of card content as images, since Telegram messages cannot
+// display LaTeX.
+type Service struct {
+	formulas AppFormulaService
+}
+
+func NewService(formulas AppFormulaService) *Service {
+	return &Service{formulas: formulas}
+}
+
+// Images returns PNG images of the first MaxImages formulas of card content, in order.
+func (s *Service) Images(text string) ([][]byte, error) {
+	formulas := card.Formulas(text)
+	if len(formulas) > MaxImages {
+		formulas = formulas[:MaxImages]
+	}
+
+	images := make([][]byte, 0, len(formulas))
+	for _, f := range formulas {
+		image, err := s.formulas.Image(f)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
@@ -0,0 +1,41 @@
+package formulaapp
+
+import (
+	"strings"
+	"testing"
+
+	formularender "flash2fy/internal/adapters/render/formula"
+	mediastorage "flash2fy/internal/adapters/storage/media"
+	appformulaapp "flash2fy/internal/app/application/formula"
+)
+
+func TestImagesRendersFormulasOfText(t *testing.T) {
+	renderer, err := formularender.NewRenderer()
+	if err != nil {
+		t.Fatalf("new renderer failed: %v", err)
+	}
+	service := NewService(appformulaapp.NewService(renderer, mediastorage.NewMemoryStorage()))
+
+	images, err := service.Images("Area: $\\pi r^2$, costs $5\n$$\\int_0^1 x\\,dx$$")
+	if err != nil {
+		t.Fatalf("images failed: %v", err)
+	}
+	if len(images) != 2 {
+		t.Fatalf("expected two images, got %d", len(images))
+	}
+	for _, image := range images {
+		if !strings.HasPrefix(string(image), "\x89PNG") {
+			t.Fatalf("expected PNG images")
+		}
+	}
+
+	none, err := service.Images("no formulas here")
+	if err != nil || len(none) != 0 {
+		t.Fatalf("expected no images, got %d, %v", len(none), err)
+	}
+
+	many, err := service.Images(strings.Repeat("$x$ ", MaxImages+3))
+	if err != nil || len(many) != MaxImages {
+		t.Fatalf("expected %d images, got %d, %v", MaxImages, len(many), err)
+	}
+}
@@ -0,0 +1,191 @@
+package reminderapp
+
+import (
+	"context"
+	"log"
+	"time"
+
+	appcard "flash2fy/internal/app/domain/card"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+	telegrmports "flash2fy/internal/telegram/ports"
+)
+
+// DefaultHour is the local hour at which new reminders fire.
+const DefaultHour = 9
+
+// AppCardService captures the upstream card contract used to count due cards.
+type AppCardService interface {
+	DueCards(ownerID string) ([]appcard.Card, error)
+}
+
+// Sender delivers a reminder about due cards to a Telegram chat.
+type Sender interface {
+	SendReminder(ctx context.Context, chatID int64, due int) error
+}
+
+// Service keeps per-user reminder schedules and pushes daily study reminders.
+type Service struct {
+	cards     AppCardService
+	reminders telegrmports.ReminderRepository
+	chats     telegrmports.CardRepository
+	timezone  string
+	now       func() time.Time
+}
+
+// Option customises the reminder service.
+type Option func(*Service)
+
+// WithDefaultTimezone sets the timezone assigned to new reminders.
+func WithDefaultTimezone(timezone string) Option {
+	return func(s *Service) {
+		s.timezone = timezone
+	}
+}
+
+// WithClock overrides the time source, mainly for tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Service) {
+		s.now = now
+	}
+}
+
+func NewService(cards AppCardService, reminders telegrmports.ReminderRepository, chats telegrmports.CardRepository, opts ...Option) *Service {
+	s := &Service{
+		cards:     cards,
+		reminders: reminders,
+		chats:     chats,
+		timezone:  "UTC",
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Ensure returns the owner's reminder, creating an enabled default one on first contact.
+// A new reminder counts as handled at creation so it first fires at the next scheduled time.
+func (s *Service) Ensure(owner telegrmdomain.User) (telegrmdomain.Reminder, error) {
+	reminder, err := s.reminders.FindByTelegramID(owner.TelegramID)
+	if err == nil {
+		return reminder, nil
+	}
+	if err != telegrmdomain.ErrReminderNotFound {
+		return telegrmdomain.Reminder{}, err
+	}
+
+	return s.reminders.Save(telegrmdomain.Reminder{
+		TelegramID: owner.TelegramID,
+		CoreUserID: owner.CoreUserID,
+		Hour:       DefaultHour,
+		Timezone:   s.timezone,
+		Enabled:    true,
+		LastSentAt: s.now(),
+	})
+}
+
+// SetEnabled opts the owner in to or out of daily reminders.
+func (s *Service) SetEnabled(owner telegrmdomain.User, enabled bool) (telegrmdomain.Reminder, error) {
+	reminder, err := s.Ensure(owner)
+	if err != nil {
+		return telegrmdomain.Reminder{}, err
+	}
+
+	reminder.Enabled = enabled
+	return s.reminders.Save(reminder)
+}
+
+// SetTime moves the owner's reminder to hour:minute in the given timezone and enables it.
+// An empty timezone keeps the current one.
+func (s *Service) SetTime(owner telegrmdomain.User, hour, minute int, timezone string) (telegrmdomain.Reminder, error) {
+	reminder, err := s.Ensure(owner)
+	if err != nil {
+		return telegrmdomain.Reminder{}, err
+	}
+
+	reminder.Hour = hour
+	reminder.Minute = minute
+	if timezone != "" {
+		reminder.Timezone = timezone
+	}
+	reminder.Enabled = true
+	return s.reminders.Save(reminder)
+}
+
+// SendDue pushes every reminder whose time has come and marks it handled for the day.
+// Users with nothing due are skipped silently. A reminder counts as handled once any of the
+// user's chats received it, so a single failing chat does not repeat it in the others.
+func (s *Service) SendDue(ctx context.Context, sender Sender) error {
+	reminders, err := s.reminders.FindEnabled()
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	for _, reminder := range reminders {
+		if !reminder.Due(now) {
+			continue
+		}
+		if err := s.send(ctx, sender, reminder); err != nil {
+			log.Printf("reminders: failed notifying telegram user %d: %v", reminder.TelegramID, err)
+			continue
+		}
+
+		reminder.LastSentAt = now
+		if _, err := s.reminders.Save(reminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run calls SendDue every interval until ctx is cancelled.
+func (s *Service) Run(ctx context.Context, sender Sender, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx, sender); err != nil {
+			log.Printf("reminders: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) send(ctx context.Context, sender Sender, reminder telegrmdomain.Reminder) error {
+	due, err := s.cards.DueCards(reminder.CoreUserID)
+	if err != nil {
+		return err
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	chats, err := s.chats.ChatIDsByOwner(reminder.TelegramID)
+	if err != nil {
+		return err
+	}
+	if len(chats) == 0 {
+		// A private chat with the bot shares the user's Telegram ID.
+		chats = []int64{reminder.TelegramID}
+	}
+
+	var lastErr error
+	delivered := 0
+	for _, chatID := range chats {
+		if err := sender.SendReminder(ctx, chatID, len(due)); err != nil {
+			log.Printf("reminders: failed notifying telegram chat %d: %v", chatID, err)
+			lastErr = err
+			continue
+		}
+		delivered++
+	}
+	if delivered == 0 {
+		return lastErr
+	}
+	return nil
+}
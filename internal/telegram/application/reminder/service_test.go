@@ -0,0 +1,221 @@
+package reminderapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	telecardstorage "flash2fy/internal/adapters/storage/telegram/card"
+	telereminderstorage "flash2fy/internal/adapters/storage/telegram/reminder"
+	appcardapp "flash2fy/internal/app/application/card"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+type stubSender struct {
+	sent    map[int64]int
+	failing map[int64]bool
+}
+
+func (s *stubSender) SendReminder(_ context.Context, chatID int64, due int) error {
+	if s.failing[chatID] {
+		return errors.New("chat unreachable")
+	}
+	if s.sent == nil {
+		s.sent = make(map[int64]int)
+	}
+	s.sent[chatID] = due
+	return nil
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newReminderService(t *testing.T, clock *fakeClock) (*Service, *appcardapp.Service, *telecardstorage.MemoryRepository, telegrmdomain.User) {
+	t.Helper()
+
+	appCards := appcardapp.NewService(cardstorage.NewMemoryRepository(), appcardapp.WithClock(clock.Now))
+	chats := telecardstorage.NewMemoryRepository()
+	service := NewService(appCards, telereminderstorage.NewMemoryRepository(), chats, WithClock(clock.Now))
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+	return service, appCards, chats, owner
+}
+
+func TestSendDueFiresOncePerDay(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)}
+	service, appCards, chats, owner := newReminderService(t, clock)
+
+	created, err := appCards.CreateCard("Front", "Back", owner.CoreUserID)
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := chats.Save(telegrmdomain.Card{ID: "tg-card", CoreCardID: created.ID, OwnerTelegramID: owner.TelegramID, ChatID: 500}); err != nil {
+		t.Fatalf("save projection failed: %v", err)
+	}
+	if _, err := service.Ensure(owner); err != nil {
+		t.Fatalf("ensure failed: %v", err)
+	}
+
+	sender := &stubSender{}
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("expected no reminder before 09:00, got %v", sender.sent)
+	}
+
+	clock.now = clock.now.Add(2 * time.Hour)
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if sender.sent[500] != 1 {
+		t.Fatalf("expected reminder with 1 due card in chat 500, got %v", sender.sent)
+	}
+
+	sender.sent = nil
+	clock.now = clock.now.Add(time.Hour)
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("expected a single reminder per day, got %v", sender.sent)
+	}
+
+	clock.now = clock.now.Add(24 * time.Hour)
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if sender.sent[500] != 1 {
+		t.Fatalf("expected reminder on the next day, got %v", sender.sent)
+	}
+}
+
+func TestSendDueRespectsOptOutAndTimezone(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC)}
+	service, appCards, _, owner := newReminderService(t, clock)
+
+	if _, err := appCards.CreateCard("Front", "Back", owner.CoreUserID); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := service.SetTime(owner, 8, 30, "Asia/Tokyo"); err != nil {
+		t.Fatalf("set time failed: %v", err)
+	}
+	if _, err := service.SetEnabled(owner, false); err != nil {
+		t.Fatalf("opt out failed: %v", err)
+	}
+
+	sender := &stubSender{}
+	clock.now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC) // 09:00 in Tokyo
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("expected no reminder after opting out, got %v", sender.sent)
+	}
+
+	if _, err := service.SetEnabled(owner, true); err != nil {
+		t.Fatalf("opt in failed: %v", err)
+	}
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if sender.sent[owner.TelegramID] != 1 {
+		t.Fatalf("expected reminder in the private chat, got %v", sender.sent)
+	}
+}
+
+func TestSendDueSkipsUsersWithNothingDue(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)}
+	service, _, _, owner := newReminderService(t, clock)
+
+	if _, err := service.Ensure(owner); err != nil {
+		t.Fatalf("ensure failed: %v", err)
+	}
+
+	sender := &stubSender{}
+	clock.now = clock.now.Add(3 * time.Hour)
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("expected no reminder without due cards, got %v", sender.sent)
+	}
+}
+
+func TestSetTimeRejectsUnknownTimezone(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 3, 1, 7, 0, 0, 0, time.UTC)}
+	service, _, _, owner := newReminderService(t, clock)
+
+	if _, err := service.SetTime(owner, 8, 0, "Mars/Olympus"); err != telegrmdomain.ErrUnknownTimezone {
+		t.Fatalf("expected unknown timezone error, got %v", err)
+	}
+}
+
+func TestSendDueMarksReminderSentWhenAnyChatReceivesIt(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)}
+	service, appCards, chats, owner := newReminderService(t, clock)
+
+	created, err := appCards.CreateCard("Front", "Back", owner.CoreUserID)
+	if err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	for i, chatID := range []int64{500, 501} {
+		projection := telegrmdomain.Card{ID: fmt.Sprintf("tg-card-%d", i), CoreCardID: created.ID, OwnerTelegramID: owner.TelegramID, ChatID: chatID}
+		if _, err := chats.Save(projection); err != nil {
+			t.Fatalf("save projection failed: %v", err)
+		}
+	}
+	if _, err := service.Ensure(owner); err != nil {
+		t.Fatalf("ensure failed: %v", err)
+	}
+	clock.now = clock.now.Add(24 * time.Hour)
+
+	sender := &stubSender{failing: map[int64]bool{501: true}}
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if sender.sent[500] != 1 {
+		t.Fatalf("expected the reachable chat notified, got %v", sender.sent)
+	}
+
+	sender.sent = nil
+	clock.now = clock.now.Add(time.Hour)
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatalf("expected no repeat after a partial delivery, got %v", sender.sent)
+	}
+}
+
+func TestSendDueRetriesWhenEveryChatFails(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)}
+	service, appCards, _, owner := newReminderService(t, clock)
+
+	if _, err := appCards.CreateCard("Front", "Back", owner.CoreUserID); err != nil {
+		t.Fatalf("create card failed: %v", err)
+	}
+	if _, err := service.Ensure(owner); err != nil {
+		t.Fatalf("ensure failed: %v", err)
+	}
+	clock.now = clock.now.Add(24 * time.Hour)
+
+	sender := &stubSender{failing: map[int64]bool{owner.TelegramID: true}}
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+
+	sender.failing = nil
+	clock.now = clock.now.Add(time.Minute)
+	if err := service.SendDue(context.Background(), sender); err != nil {
+		t.Fatalf("send due failed: %v", err)
+	}
+	if sender.sent[owner.TelegramID] != 1 {
+		t.Fatalf("expected the failed reminder retried, got %v", sender.sent)
+	}
+}
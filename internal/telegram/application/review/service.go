@@ -0,0 +1,105 @@
+package reviewapp
+
+import (
+	appcard "flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/study"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+	telegrmports "flash2fy/internal/telegram/ports"
+)
+
+// AppStudyService captures the upstream study-session contract used by Telegram.
+type AppStudyService interface {
+	Start(userID string) (study.Session, error)
+	Next(sessionID string) (appcard.Card, error)
+	Answer(sessionID, cardID string, rating appcard.Rating) (appcard.Card, error)
+	Finish(sessionID string) (study.Session, error)
+}
+
+// Service runs study sessions inside Telegram chats, one active session per chat.
+type Service struct {
+	sessions AppStudyService
+	ctxRepo  telegrmports.ReviewRepository
+}
+
+func NewService(sessions AppStudyService, ctxRepo telegrmports.ReviewRepository) *Service {
+	return &Service{sessions: sessions, ctxRepo: ctxRepo}
+}
+
+// Start opens a new study session in the chat, replacing any unfinished one, and returns its first card.
+// It returns study.ErrQueueEmpty when nothing is due.
+func (s *Service) Start(owner telegrmdomain.User, chatID int64) (appcard.Card, error) {
+	if existing, err := s.ctxRepo.FindByChatID(chatID); err == nil {
+		_, _ = s.sessions.Finish(existing.SessionID)
+		_ = s.ctxRepo.DeleteByChatID(chatID)
+	}
+
+	session, err := s.sessions.Start(owner.CoreUserID)
+	if err != nil {
+		return appcard.Card{}, err
+	}
+
+	ctxSession := telegrmdomain.ReviewSession{
+		ChatID:          chatID,
+		OwnerTelegramID: owner.TelegramID,
+		SessionID:       session.ID,
+	}
+	if _, err := s.ctxRepo.Save(ctxSession); err != nil {
+		return appcard.Card{}, err
+	}
+
+	next, err := s.sessions.Next(session.ID)
+	if err == study.ErrQueueEmpty {
+		_, _ = s.Finish(chatID)
+	}
+	return next, err
+}
+
+// Current returns the card currently shown in the chat's session to its owner.
+func (s *Service) Current(chatID int64, owner telegrmdomain.User) (appcard.Card, error) {
+	ctxSession, err := s.ownSession(chatID, owner)
+	if err != nil {
+		return appcard.Card{}, err
+	}
+	return s.sessions.Next(ctxSession.SessionID)
+}
+
+// Answer grades the card in the chat's session and returns the next card.
+// It returns study.ErrQueueEmpty once the session has no cards left; call Finish to close it.
+// Only the user who started the session may answer; others get ErrReviewForeign.
+func (s *Service) Answer(chatID int64, owner telegrmdomain.User, cardID string, rating appcard.Rating) (appcard.Card, error) {
+	ctxSession, err := s.ownSession(chatID, owner)
+	if err != nil {
+		return appcard.Card{}, err
+	}
+
+	if _, err := s.sessions.Answer(ctxSession.SessionID, cardID, rating); err != nil {
+		return appcard.Card{}, err
+	}
+	return s.sessions.Next(ctxSession.SessionID)
+}
+
+// ownSession returns the chat's session when it was started by owner.
+func (s *Service) ownSession(chatID int64, owner telegrmdomain.User) (telegrmdomain.ReviewSession, error) {
+	ctxSession, err := s.ctxRepo.FindByChatID(chatID)
+	if err != nil {
+		return telegrmdomain.ReviewSession{}, err
+	}
+	if ctxSession.OwnerTelegramID != owner.TelegramID {
+		return telegrmdomain.ReviewSession{}, telegrmdomain.ErrReviewForeign
+	}
+	return ctxSession, nil
+}
+
+// Finish closes the chat's session and returns its summary.
+func (s *Service) Finish(chatID int64) (study.Session, error) {
+	ctxSession, err := s.ctxRepo.FindByChatID(chatID)
+	if err != nil {
+		return study.Session{}, err
+	}
+
+	summary, err := s.sessions.Finish(ctxSession.SessionID)
+	if err != nil {
+		return study.Session{}, err
+	}
+	return summary, s.ctxRepo.DeleteByChatID(chatID)
+}
@@ -0,0 +1,108 @@
+package reviewapp
+
+import (
+	"testing"
+
+	cardstorage "flash2fy/internal/adapters/storage/card"
+	studystorage "flash2fy/internal/adapters/storage/study"
+	telereviewstorage "flash2fy/internal/adapters/storage/telegram/review"
+	userstorage "flash2fy/internal/adapters/storage/user"
+	appcardapp "flash2fy/internal/app/application/card"
+	appstudyapp "flash2fy/internal/app/application/study"
+	"flash2fy/internal/app/domain/card"
+	"flash2fy/internal/app/domain/study"
+	telegrmdomain "flash2fy/internal/telegram/domain"
+)
+
+func newReviewService(t *testing.T, fronts ...string) (*Service, *telereviewstorage.MemoryRepository, telegrmdomain.User) {
+	t.Helper()
+
+	owner := telegrmdomain.User{ID: "tg-user-1", CoreUserID: "core-user-1", TelegramID: 42}
+	cardRepo := cardstorage.NewMemoryRepository()
+	cardService := appcardapp.NewService(cardRepo)
+	for _, front := range fronts {
+		if _, err := cardService.CreateCard(front, "back of "+front, owner.CoreUserID); err != nil {
+			t.Fatalf("create card failed: %v", err)
+		}
+	}
+
+	studyService := appstudyapp.NewService(cardRepo, cardService, studystorage.NewMemoryRepository(), userstorage.NewMemoryRepository())
+	ctxRepo := telereviewstorage.NewMemoryRepository()
+	return NewService(studyService, ctxRepo), ctxRepo, owner
+}
+
+func TestStartWithoutCards(t *testing.T) {
+	service, ctxRepo, owner := newReviewService(t)
+
+	if _, err := service.Start(owner, 7); err != study.ErrQueueEmpty {
+		t.Fatalf("expected empty queue, got %v", err)
+	}
+	if _, err := ctxRepo.FindByChatID(7); err != telegrmdomain.ErrReviewNotFound {
+		t.Fatalf("expected no active session for chat, got %v", err)
+	}
+}
+
+func TestReviewFlow(t *testing.T) {
+	service, ctxRepo, owner := newReviewService(t, "one", "two")
+
+	first, err := service.Start(owner, 7)
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	current, err := service.Current(7, owner)
+	if err != nil || current.ID != first.ID {
+		t.Fatalf("expected current card %s, got %s (%v)", first.ID, current.ID, err)
+	}
+
+	second, err := service.Answer(7, owner, first.ID, card.RatingGood)
+	if err != nil {
+		t.Fatalf("answer failed: %v", err)
+	}
+	if second.ID == first.ID {
+		t.Fatalf("expected a different card after answering")
+	}
+
+	if _, err := service.Answer(7, owner, second.ID, card.RatingEasy); err != study.ErrQueueEmpty {
+		t.Fatalf("expected empty queue after last card, got %v", err)
+	}
+
+	summary, err := service.Finish(7)
+	if err != nil {
+		t.Fatalf("finish failed: %v", err)
+	}
+	if summary.NewStudied != 2 {
+		t.Fatalf("expected 2 new cards studied, got %d", summary.NewStudied)
+	}
+	if _, err := ctxRepo.FindByChatID(7); err != telegrmdomain.ErrReviewNotFound {
+		t.Fatalf("expected chat session to be cleared, got %v", err)
+	}
+}
+
+func TestAnswerWithoutSession(t *testing.T) {
+	service, _, owner := newReviewService(t)
+
+	if _, err := service.Answer(7, owner, "missing", card.RatingGood); err != telegrmdomain.ErrReviewNotFound {
+		t.Fatalf("expected missing session error, got %v", err)
+	}
+}
+
+func TestReviewRejectsOtherUsers(t *testing.T) {
+	service, _, owner := newReviewService(t, "one", "two")
+	stranger := telegrmdomain.User{ID: "tg-user-2", CoreUserID: "core-user-2", TelegramID: 99}
+
+	first, err := service.Start(owner, 7)
+	if err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	if _, err := service.Current(7, stranger); err != telegrmdomain.ErrReviewForeign {
+		t.Fatalf("expected ErrReviewForeign reading another user's session, got %v", err)
+	}
+	if _, err := service.Answer(7, stranger, first.ID, card.RatingEasy); err != telegrmdomain.ErrReviewForeign {
+		t.Fatalf("expected ErrReviewForeign answering another user's session, got %v", err)
+	}
+
+	current, err := service.Current(7, owner)
+	if err != nil || current.ID != first.ID {
+		t.Fatalf("expected the owner's session untouched, got %+v, %v", current, err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 type AppUserService interface {
 	CreateUser(nickname string) (appuser.User, error)
 	GetUser(id string) (appuser.User, error)
+	SetScheduler(id string, scheduler appuser.Scheduler) (appuser.User, error)
 	DeleteUser(id string) error
 }
 
@@ -80,6 +81,11 @@ func (s *Service) EnsureUser(telegramID int64, name, username string) (appuser.U
 	return createdCore, savedCtx, nil
 }
 
+// SetScheduler switches the spaced-repetition algorithm of the core user linked to the Telegram account.
+func (s *Service) SetScheduler(coreID string, scheduler appuser.Scheduler) (appuser.User, error) {
+	return s.appUsers.SetScheduler(coreID, scheduler)
+}
+
 func (s *Service) DeleteUser(coreID string) error {
 	if err := s.appUsers.DeleteUser(coreID); err != nil {
 		return err
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrEmptyReminderUser   = errors.New("telegram reminder core user id must not be empty")
+	ErrInvalidReminderTime = errors.New("telegram reminder time must be HH:MM")
+	ErrUnknownTimezone     = errors.New("telegram reminder timezone is unknown")
+	ErrReminderNotFound    = errors.New("telegram reminder not found")
+)
+
+// Reminder is a user's daily study reminder, fired once per day at Hour:Minute in Timezone.
+// LastSentAt records the last time the reminder was handled so restarts never send it twice.
+type Reminder struct {
+	TelegramID int64
+	CoreUserID string
+	Hour       int
+	Minute     int
+	Timezone   string
+	Enabled    bool
+	LastSentAt time.Time
+}
+
+func (r *Reminder) Validate() error {
+	if r.TelegramID == 0 {
+		return ErrEmptyTelegramID
+	}
+	if r.CoreUserID == "" {
+		return ErrEmptyReminderUser
+	}
+	if r.Hour < 0 || r.Hour > 23 || r.Minute < 0 || r.Minute > 59 {
+		return ErrInvalidReminderTime
+	}
+	if _, err := r.Location(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Location resolves the reminder timezone, treating an empty value as UTC.
+func (r Reminder) Location() (*time.Location, error) {
+	if r.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return nil, ErrUnknownTimezone
+	}
+	return loc, nil
+}
+
+// Clock formats the reminder time as HH:MM.
+func (r Reminder) Clock() string {
+	return fmt.Sprintf("%02d:%02d", r.Hour, r.Minute)
+}
+
+// Due reports whether today's reminder time has passed in the user's timezone
+// and the reminder has not been handled since.
+func (r Reminder) Due(now time.Time) bool {
+	if !r.Enabled {
+		return false
+	}
+	loc, err := r.Location()
+	if err != nil {
+		return false
+	}
+
+	local := now.In(loc)
+	scheduled := time.Date(local.Year(), local.Month(), local.Day(), r.Hour, r.Minute, 0, 0, loc)
+	if local.Before(scheduled) {
+		return false
+	}
+	return r.LastSentAt.Before(scheduled)
+}
+
+// ParseReminderClock parses an HH:MM time of day.
+func ParseReminderClock(value string) (hour, minute int, err error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, 0, ErrInvalidReminderTime
+	}
+	return parsed.Hour(), parsed.Minute(), nil
+}
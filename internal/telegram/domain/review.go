@@ -0,0 +1,30 @@
+package domain
+
+import "errors"
+
+var (
+	ErrEmptyReviewChat    = errors.New("telegram review chat id must not be empty")
+	ErrEmptyReviewSession = errors.New("telegram review session id must not be empty")
+	ErrReviewNotFound     = errors.New("telegram review session not found")
+	ErrReviewForeign      = errors.New("telegram review session belongs to another user")
+)
+
+// ReviewSession links a Telegram chat to the study session being reviewed in it.
+type ReviewSession struct {
+	ChatID          int64
+	OwnerTelegramID int64
+	SessionID       string
+}
+
+func (r *ReviewSession) Validate() error {
+	if r.ChatID == 0 {
+		return ErrEmptyReviewChat
+	}
+	if r.SessionID == "" {
+		return ErrEmptyReviewSession
+	}
+	if r.OwnerTelegramID == 0 {
+		return ErrEmptyOwner
+	}
+	return nil
+}
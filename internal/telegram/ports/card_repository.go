@@ -6,4 +6,5 @@ type CardRepository interface {
 	Save(telegrmdomain.Card) (telegrmdomain.Card, error)
 	FindByCoreID(coreID string) (telegrmdomain.Card, error)
 	DeleteByCoreID(coreID string) error
+	ChatIDsByOwner(ownerTelegramID int64) ([]int64, error)
 }
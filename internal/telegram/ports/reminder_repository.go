@@ -0,0 +1,9 @@
+package ports
+
+import telegrmdomain "flash2fy/internal/telegram/domain"
+
+type ReminderRepository interface {
+	Save(telegrmdomain.Reminder) (telegrmdomain.Reminder, error)
+	FindByTelegramID(id int64) (telegrmdomain.Reminder, error)
+	FindEnabled() ([]telegrmdomain.Reminder, error)
+}
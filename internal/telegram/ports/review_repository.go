@@ -0,0 +1,9 @@
+package ports
+
+import telegrmdomain "flash2fy/internal/telegram/domain"
+
+type ReviewRepository interface {
+	Save(telegrmdomain.ReviewSession) (telegrmdomain.ReviewSession, error)
+	FindByChatID(chatID int64) (telegrmdomain.ReviewSession, error)
+	DeleteByChatID(chatID int64) error
+}